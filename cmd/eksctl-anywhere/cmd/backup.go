@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backup resources",
+	Long:  "Use eksctl anywhere backup to back up cluster data, such as etcd",
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+}
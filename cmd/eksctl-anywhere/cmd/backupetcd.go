@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	ubuntuSSHUser          = "capv"
+	defaultSSHKeyFileName  = "eks-a-id_rsa"
+	defaultBackupRetention = 10
+)
+
+type etcdBackupOptions struct {
+	clusterOptions
+	wConfig    string
+	location   string
+	s3Endpoint string
+	s3Region   string
+	sshUser    string
+	sshKey     string
+	retention  int
+	snapshot   string
+}
+
+func (eo *etcdBackupOptions) kubeConfig(clusterName string) string {
+	if eo.wConfig == "" {
+		return filepath.Join(clusterName, fmt.Sprintf(kubeconfigPattern, clusterName))
+	}
+	return eo.wConfig
+}
+
+func (eo *etcdBackupOptions) bindFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&eo.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	cmd.Flags().StringVarP(&eo.wConfig, "w-config", "w", "", "Kubeconfig file of the workload cluster")
+	cmd.Flags().StringVar(&eo.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	cmd.Flags().StringVar(&eo.location, "location", "", "Local directory or s3://bucket/prefix url where the snapshots are stored")
+	cmd.Flags().StringVar(&eo.s3Endpoint, "s3-endpoint", "", "Endpoint of an S3 compatible server, like MinIO, for s3:// locations")
+	cmd.Flags().StringVar(&eo.s3Region, "s3-region", "", "Region of the bucket for s3:// locations")
+	cmd.Flags().StringVar(&eo.sshUser, "ssh-user", "", "User to ssh into the control plane and etcd machines (default the user of their machine configs)")
+	cmd.Flags().StringVar(&eo.sshKey, "ssh-key", "", "Private key to ssh into the control plane and etcd machines (default <cluster-name>/eks-a-id_rsa)")
+	for _, flag := range []string{"filename", "location"} {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func preRunEtcdBackup(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+// setup validates the cluster config and builds the etcd backup manager and the snapshot store
+func (eo *etcdBackupOptions) setup(ctx context.Context) (*cluster.Spec, *types.Cluster, *etcdbackup.Manager, etcdbackup.Store, error) {
+	clusterConfig, err := commonValidation(ctx, eo.fileName)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("common validations failed due to: %v", err)
+	}
+	if !validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, eo.wConfig, kubeconfigPattern) {
+		return nil, nil, nil, nil, fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
+	}
+
+	clusterSpec, err := newClusterSpec(eo.clusterOptions)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	store, err := etcdbackup.NewStore(eo.location, etcdbackup.S3Config{Endpoint: eo.s3Endpoint, Region: eo.s3Region})
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(eo.mountDirs()...).
+		WithKubectl().
+		WithDocker().
+		Build(ctx)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	var runner etcdbackup.NodeRunner
+	if clusterSpec.Spec.DatacenterRef.Kind == v1alpha1.DockerDatacenterKind {
+		runner = etcdbackup.NewDockerRunner(deps.DockerClient)
+	} else {
+		sshKey := eo.sshKey
+		if sshKey == "" {
+			sshKey = filepath.Join(clusterSpec.Name, defaultSSHKeyFileName)
+		}
+		machineConfigs, err := etcdMachineConfigs(clusterSpec, eo.fileName)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		sshUser := eo.sshUser
+		if sshUser == "" {
+			if sshUser, err = machineConfigsSSHUser(machineConfigs); err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
+		if runner, err = etcdbackup.NewSSHRunner(sshUser, sshKey); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: eo.kubeConfig(clusterSpec.Name),
+	}
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	return clusterSpec, managementCluster, etcdbackup.NewManager(deps.Kubectl, runner), store, nil
+}
+
+// etcdMachineConfig holds the fields of the control plane and etcd machine configs the etcd commands depend on
+type etcdMachineConfig struct {
+	users    []v1alpha1.UserConfiguration
+	osFamily v1alpha1.OSFamily
+}
+
+// etcdMachineConfigs returns the machine configs of the control plane and etcd machines, keyed by name.
+// The snapshot scripts run kubeadm's static pods with sudo, crictl and ctr, so only Ubuntu machines are supported
+func etcdMachineConfigs(clusterSpec *cluster.Spec, fileName string) (map[string]etcdMachineConfig, error) {
+	refs := []*v1alpha1.Ref{clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef}
+	if clusterSpec.Spec.ExternalEtcdConfiguration != nil {
+		refs = append(refs, clusterSpec.Spec.ExternalEtcdConfiguration.MachineGroupRef)
+	}
+
+	all := map[string]etcdMachineConfig{}
+	switch clusterSpec.Spec.DatacenterRef.Kind {
+	case v1alpha1.VSphereDatacenterKind:
+		machineConfigs, err := v1alpha1.GetVSphereMachineConfigs(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get machine configs from file: %v", err)
+		}
+		for name, machineConfig := range machineConfigs {
+			all[name] = etcdMachineConfig{users: machineConfig.Spec.Users, osFamily: machineConfig.Spec.OSFamily}
+		}
+	case v1alpha1.TinkerbellDatacenterKind:
+		machineConfigs, err := v1alpha1.GetTinkerbellMachineConfigs(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get machine configs from file: %v", err)
+		}
+		for name, machineConfig := range machineConfigs {
+			all[name] = etcdMachineConfig{users: machineConfig.Spec.Users, osFamily: machineConfig.Spec.OSFamily}
+		}
+	}
+
+	machineConfigs := map[string]etcdMachineConfig{}
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+		machineConfig, ok := all[ref.Name]
+		if !ok {
+			return nil, fmt.Errorf("machine config %s not found", ref.Name)
+		}
+		if machineConfig.osFamily == v1alpha1.Bottlerocket {
+			return nil, fmt.Errorf("machine config %s uses osFamily %s, etcd backup and restore only support %s machines", ref.Name, v1alpha1.Bottlerocket, v1alpha1.Ubuntu)
+		}
+		machineConfigs[ref.Name] = machineConfig
+	}
+
+	return machineConfigs, nil
+}
+
+// machineConfigsSSHUser returns the ssh user of the control plane and etcd machine configs, which must be the same
+func machineConfigsSSHUser(machineConfigs map[string]etcdMachineConfig) (string, error) {
+	user := ""
+	for _, machineConfig := range machineConfigs {
+		u := sshUser(machineConfig.users)
+		if user != "" && u != user {
+			return "", fmt.Errorf("control plane and etcd machine configs have different ssh users %s and %s, set the ssh user with --ssh-user", user, u)
+		}
+		user = u
+	}
+	if user == "" {
+		return "", errors.New("unable to find the ssh user of the machine configs, set it with --ssh-user")
+	}
+
+	return user, nil
+}
+
+// sshUser returns the first user of a machine config, defaulting it the same way the providers do for Ubuntu
+func sshUser(users []v1alpha1.UserConfiguration) string {
+	if len(users) > 0 && users[0].Name != "" {
+		return users[0].Name
+	}
+	return ubuntuSSHUser
+}
+
+var ebo = &etcdBackupOptions{}
+
+var backupEtcdCmd = &cobra.Command{
+	Use:          "etcd",
+	Short:        "Take an etcd snapshot of a cluster",
+	Long:         "This command takes a snapshot of the cluster etcd, from the control plane or the external etcd machines, and saves it in a local directory or an S3 compatible bucket",
+	PreRunE:      preRunEtcdBackup,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ebo.backupEtcd(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to back up etcd: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	backupCmd.AddCommand(backupEtcdCmd)
+	ebo.bindFlags(backupEtcdCmd)
+	backupEtcdCmd.Flags().IntVar(&ebo.retention, "retention", defaultBackupRetention, "Number of snapshots of the cluster to keep in the location, 0 keeps all of them")
+}
+
+func (eo *etcdBackupOptions) backupEtcd(ctx context.Context) error {
+	clusterSpec, managementCluster, manager, store, err := eo.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Taking etcd snapshot", "cluster", clusterSpec.Name)
+	name, err := manager.Backup(ctx, managementCluster, clusterSpec, store, eo.retention)
+	if err != nil {
+		return err
+	}
+	logger.MarkSuccess("Etcd snapshot saved", "snapshot", name, "location", eo.location)
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/managementbackup"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type managementBackupOptions struct {
+	clusterOptions
+	archive string
+}
+
+func preRunManagementBackup(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+// setup validates the cluster config and builds the management backup manager. The objects are staged
+// in the cluster folder, which is mounted in the executables container
+func (mo *managementBackupOptions) setup(ctx context.Context) (*types.Cluster, *managementbackup.Manager, error) {
+	clusterConfig, err := commonValidation(ctx, mo.fileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("common validations failed due to: %v", err)
+	}
+
+	kubeconfig := mo.managementKubeconfig
+	if kubeconfig == "" {
+		if !validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, "", kubeconfigPattern) {
+			return nil, nil, fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
+		}
+		kubeconfig = filepath.Join(clusterConfig.Name, fmt.Sprintf(kubeconfigPattern, clusterConfig.Name))
+	}
+
+	clusterSpec, err := newClusterSpec(mo.clusterOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(mo.mountDirs()...).
+		WithKubectl().
+		WithClusterctl().
+		Build(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: kubeconfig,
+	}
+	workDir := filepath.Join(clusterSpec.Name, filewriter.DefaultTmpFolder)
+
+	return cluster, managementbackup.NewManager(deps.Clusterctl, deps.Kubectl, workDir), nil
+}
+
+var mbo = &managementBackupOptions{}
+
+var backupManagementCmd = &cobra.Command{
+	Use:          "management",
+	Short:        "Back up the EKS-A and CAPI objects of a management cluster",
+	Long:         "This command saves the EKS-A and CAPI objects of a management cluster, together with the eksa-system secrets, in an archive that can be restored with restore management",
+	PreRunE:      preRunManagementBackup,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := mbo.backupManagement(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to back up management cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	backupCmd.AddCommand(backupManagementCmd)
+	backupManagementCmd.Flags().StringVarP(&mbo.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration of the management cluster")
+	backupManagementCmd.Flags().StringVar(&mbo.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file (default <cluster-name>/<cluster-name>-eks-a-cluster.kubeconfig)")
+	backupManagementCmd.Flags().StringVar(&mbo.archive, "archive", "", "Path of the backup archive (default <cluster-name>-management-<timestamp>.tar.gz)")
+	if err := backupManagementCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (mo *managementBackupOptions) backupManagement(ctx context.Context) error {
+	cluster, manager, err := mo.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	archive := mo.archive
+	if archive == "" {
+		archive = fmt.Sprintf("%s-management-%s.tar.gz", cluster.Name, time.Now().Format("20060102150405"))
+	}
+
+	logger.Info("Backing up management cluster", "cluster", cluster.Name)
+	if err = manager.Backup(ctx, cluster, archive); err != nil {
+		return err
+	}
+	logger.MarkSuccess("Management cluster backup saved", "archive", archive)
+
+	return nil
+}
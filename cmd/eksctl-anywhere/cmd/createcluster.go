@@ -22,6 +22,8 @@ type createClusterOptions struct {
 	forceClean       bool
 	skipIpCheck      bool
 	hardwareFileName string
+	resume           bool
+	dryRun           bool
 }
 
 var cc = &createClusterOptions{}
@@ -50,6 +52,8 @@ func init() {
 		createClusterCmd.Flags().StringVarP(&cc.hardwareFileName, "hardwarefile", "w", "", "Filename that contains datacenter hardware information")
 	}
 	createClusterCmd.Flags().BoolVar(&cc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
+	createClusterCmd.Flags().BoolVar(&cc.resume, "resume", false, "Resume a previously failed create from the last completed task")
+	createClusterCmd.Flags().BoolVar(&cc.dryRun, "dry-run", false, "Run the setup and validations and write the generated manifests to the cluster folder, without creating the cluster")
 	createClusterCmd.Flags().BoolVar(&cc.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
 	createClusterCmd.Flags().StringVar(&cc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	createClusterCmd.Flags().StringVar(&cc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
@@ -70,11 +74,18 @@ func preRunCreateCluster(cmd *cobra.Command, args []string) error {
 }
 
 func (cc *createClusterOptions) validate(ctx context.Context) error {
+	if cc.resume && cc.forceClean {
+		return fmt.Errorf("--resume and --force-cleanup can't be used together")
+	}
+	if cc.dryRun && (cc.resume || cc.forceClean) {
+		return fmt.Errorf("--dry-run can't be used with --resume or --force-cleanup")
+	}
 	clusterConfig, err := commonValidation(ctx, cc.fileName)
 	if err != nil {
 		return err
 	}
-	if validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, "", kubeconfigPattern) {
+	// a resumed create might have already written the kubeconfig of the new cluster
+	if !cc.resume && validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, "", kubeconfigPattern) {
 		return fmt.Errorf("old cluster config file exists under %s, please use a different clusterName to proceed", clusterConfig.Name)
 	}
 	return nil
@@ -114,13 +125,16 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command) error {
 		}
 	}
 
+	events := newTaskEvents(deps.Writer, clusterSpec.Name, "create")
+	defer events.printSummary()
+
 	createCluster := workflows.NewCreate(
 		deps.Bootstrapper,
 		deps.Provider,
 		deps.ClusterManager,
 		deps.FluxAddonClient,
 		deps.Writer,
-	)
+	).WithTaskEventSinks(events.sinks()...)
 
 	var cluster *types.Cluster
 	if clusterSpec.ManagementCluster == nil {
@@ -147,6 +161,12 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command) error {
 	}
 	createValidations := createvalidations.New(validationOpts)
 
-	err = createCluster.Run(ctx, clusterSpec, createValidations, cc.forceClean)
+	if cc.dryRun {
+		dryRun := workflows.NewDryRun(deps.Provider, deps.ClusterManager, deps.FluxAddonClient, deps.Writer).WithTaskEventSinks(events.sinks()...)
+		err = dryRun.RunCreate(ctx, clusterSpec, createValidations)
+		return err
+	}
+
+	err = createCluster.Run(ctx, clusterSpec, createValidations, cc.forceClean, cc.resume)
 	return err
 }
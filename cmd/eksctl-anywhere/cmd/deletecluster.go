@@ -100,12 +100,15 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 	}
 	defer cleanup(ctx, deps, &err)
 
+	events := newTaskEvents(deps.Writer, clusterSpec.Name, "delete")
+	defer events.printSummary()
+
 	deleteCluster := workflows.NewDelete(
 		deps.Bootstrapper,
 		deps.Provider,
 		deps.ClusterManager,
 		deps.FluxAddonClient,
-	)
+	).WithTaskEventSinks(events.sinks()...)
 
 	var cluster *types.Cluster
 	if clusterSpec.ManagementCluster == nil {
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var getCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get resources",
+	Long:  "Use eksctl anywhere get to display information about cluster resources, such as certificates",
+}
+
+func init() {
+	rootCmd.AddCommand(getCmd)
+}
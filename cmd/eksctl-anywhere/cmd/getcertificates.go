@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type getCertificatesOptions struct {
+	clusterOptions
+	wConfig string
+	output  string
+}
+
+func (gco *getCertificatesOptions) kubeConfig(clusterName string) string {
+	if gco.wConfig == "" {
+		return filepath.Join(clusterName, fmt.Sprintf(kubeconfigPattern, clusterName))
+	}
+	return gco.wConfig
+}
+
+var gco = &getCertificatesOptions{}
+
+var getCertificatesCmd = &cobra.Command{
+	Use:          "certificates",
+	Short:        "Display the expiration of the cluster certificates",
+	Long:         "This command reports the expiration date of the API server, etcd, front-proxy, kubelet and aws-iam-authenticator certificates of a cluster",
+	PreRunE:      preRunGetCertificates,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gco.getCertificates(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to get certificates: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunGetCertificates(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	getCmd.AddCommand(getCertificatesCmd)
+	getCertificatesCmd.Flags().StringVarP(&gco.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	getCertificatesCmd.Flags().StringVarP(&gco.wConfig, "w-config", "w", "", "Kubeconfig file of the workload cluster")
+	getCertificatesCmd.Flags().StringVar(&gco.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	getCertificatesCmd.Flags().StringVarP(&gco.output, "output", "o", certificates.TableOutput, "Output format: table, json or yaml")
+	err := getCertificatesCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (gco *getCertificatesOptions) getCertificates(ctx context.Context) error {
+	if err := certificates.ValidateOutputFormat(gco.output); err != nil {
+		return err
+	}
+	clusterConfig, err := commonValidation(ctx, gco.fileName)
+	if err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+	if !validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, gco.wConfig, kubeconfigPattern) {
+		return fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
+	}
+
+	clusterSpec, err := newClusterSpec(gco.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(gco.mountDirs()...).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: gco.kubeConfig(clusterSpec.Name),
+	}
+	managementCluster := workloadCluster
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	report, err := certificates.NewInspector(deps.Kubectl).Certificates(ctx, managementCluster, workloadCluster, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	return report.Print(os.Stdout, gco.output)
+}
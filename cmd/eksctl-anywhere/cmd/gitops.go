@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var gitopsCmd = &cobra.Command{
+	Use:   "gitops",
+	Short: "Manage the GitOps repository",
+	Long:  "Use eksctl anywhere gitops to manage the content of the GitOps repository of a cluster, such as add-ons",
+}
+
+func init() {
+	rootCmd.AddCommand(gitopsCmd)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type addAddonOptions struct {
+	clusterOptions
+}
+
+var aao = &addAddonOptions{}
+
+var gitopsAddAddonCmd = &cobra.Command{
+	Use:   "add-addon <path>",
+	Short: "Add add-on manifests to the GitOps repository of a cluster",
+	Long: "This command copies the manifests in path, a yaml file or a directory, to the add-ons folder of the cluster " +
+		"in its GitOps repository and commits them, so Flux installs them in the cluster",
+	Args:         cobra.ExactArgs(1),
+	PreRunE:      preRunGitOpsAddAddon,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := aao.addAddon(cmd.Context(), args[0]); err != nil {
+			return fmt.Errorf("failed to add add-on: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunGitOpsAddAddon(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	gitopsCmd.AddCommand(gitopsAddAddonCmd)
+	gitopsAddAddonCmd.Flags().StringVarP(&aao.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	gitopsAddAddonCmd.Flags().StringVar(&aao.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	if err := gitopsAddAddonCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (ao *addAddonOptions) addAddon(ctx context.Context, manifestsPath string) error {
+	if _, err := commonValidation(ctx, ao.fileName); err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+
+	clusterSpec, err := newClusterSpec(ao.clusterOptions)
+	if err != nil {
+		return err
+	}
+	if clusterSpec.GitOpsConfig == nil {
+		return fmt.Errorf("cluster %s doesn't have a GitOps configuration", clusterSpec.Name)
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(ao.mountDirs()...).
+		WithFluxAddonClient(ctx, clusterSpec.Cluster, clusterSpec.GitOpsConfig).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	logger.Info("Adding add-on manifests to the GitOps repository", "path", manifestsPath)
+	if err = deps.FluxAddonClient.AddAddon(ctx, clusterSpec, manifestsPath); err != nil {
+		return err
+	}
+	logger.MarkSuccess("Add-on manifests committed, Flux will install them in the cluster")
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type gitOpsStatusOptions struct {
+	clusterOptions
+	wConfig string
+}
+
+func (gso *gitOpsStatusOptions) kubeConfig(clusterName string) string {
+	if gso.wConfig == "" {
+		return filepath.Join(clusterName, fmt.Sprintf(kubeconfigPattern, clusterName))
+	}
+	return gso.wConfig
+}
+
+var gso = &gitOpsStatusOptions{}
+
+var gitopsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Display the drift between the GitOps repository and the cluster",
+	Long: "This command compares the EKS-A objects of a cluster in its GitOps repository with the ones in the " +
+		"management cluster and reports the fields that differ, along with the readiness and last applied revision " +
+		"of the Flux resources that sync them",
+	PreRunE:      preRunGitOpsStatus,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gso.gitOpsStatus(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to get GitOps status: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunGitOpsStatus(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	gitopsCmd.AddCommand(gitopsStatusCmd)
+	gitopsStatusCmd.Flags().StringVarP(&gso.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	gitopsStatusCmd.Flags().StringVarP(&gso.wConfig, "w-config", "w", "", "Kubeconfig file of the workload cluster")
+	gitopsStatusCmd.Flags().StringVar(&gso.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	gitopsStatusCmd.Flags().StringVar(&gso.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	if err := gitopsStatusCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (gso *gitOpsStatusOptions) gitOpsStatus(ctx context.Context) error {
+	clusterConfig, err := commonValidation(ctx, gso.fileName)
+	if err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+	if !validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, gso.wConfig, kubeconfigPattern) {
+		return fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
+	}
+
+	clusterSpec, err := newClusterSpec(gso.clusterOptions)
+	if err != nil {
+		return err
+	}
+	if clusterSpec.GitOpsConfig == nil {
+		return fmt.Errorf("cluster %s doesn't have a GitOps configuration", clusterSpec.Name)
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(gso.mountDirs()...).
+		WithFluxAddonClient(ctx, clusterSpec.Cluster, clusterSpec.GitOpsConfig).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: gso.kubeConfig(clusterSpec.Name),
+	}
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	status, err := deps.FluxAddonClient.Status(ctx, managementCluster, clusterSpec)
+	if err != nil {
+		return err
+	}
+	if err = status.Print(os.Stdout); err != nil {
+		return err
+	}
+
+	if status.Drifted() {
+		logger.MarkWarning("The cluster has drifted from the GitOps repository")
+	}
+	return nil
+}
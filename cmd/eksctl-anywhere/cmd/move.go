@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var moveCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Move resources",
+	Long:  "Use eksctl anywhere move to move resources, such as the management of a workload cluster, to a different cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(moveCmd)
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+)
+
+type moveClusterOptions struct {
+	clusterOptions
+	toManagementKubeconfig string
+}
+
+func (mo *moveClusterOptions) mountDirs() []string {
+	return append(mo.clusterOptions.mountDirs(), filepath.Dir(mo.toManagementKubeconfig))
+}
+
+var mco = &moveClusterOptions{}
+
+var moveClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Short:        "Move a workload cluster to a different management cluster",
+	Long:         "This command moves the CAPI and EKS-A objects of a workload cluster from its management cluster to a different management cluster",
+	PreRunE:      preRunMoveCluster,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := mco.moveCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to move cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunMoveCluster(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	moveCmd.AddCommand(moveClusterCmd)
+	moveClusterCmd.Flags().StringVarP(&mco.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration of the workload cluster")
+	moveClusterCmd.Flags().StringVar(&mco.managementKubeconfig, "kubeconfig", "", "Kubeconfig file of the current management cluster")
+	moveClusterCmd.Flags().StringVar(&mco.toManagementKubeconfig, "to-management", "", "Kubeconfig file of the management cluster the workload cluster is moved to")
+	moveClusterCmd.Flags().StringVar(&mco.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	for _, flag := range []string{"filename", "kubeconfig", "to-management"} {
+		if err := moveClusterCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (mo *moveClusterOptions) moveCluster(ctx context.Context) error {
+	if _, err := commonValidation(ctx, mo.fileName); err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+
+	clusterSpec, err := newClusterSpec(mo.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	target, err := cluster.LoadManagement(mo.toManagementKubeconfig)
+	if err != nil {
+		return fmt.Errorf("unable to get target management cluster from kubeconfig: %v", err)
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(mo.mountDirs()...).
+		WithClusterManager(clusterSpec.Cluster).
+		WithProvider(mo.fileName, clusterSpec.Cluster, cc.skipIpCheck, "").
+		WithFluxAddonClient(ctx, clusterSpec.Cluster, clusterSpec.GitOpsConfig).
+		WithWriter().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup(ctx, deps, &err)
+
+	events := newTaskEvents(deps.Writer, clusterSpec.Name, "move")
+	defer events.printSummary()
+
+	moveCluster := workflows.NewMove(deps.Provider, deps.ClusterManager, deps.FluxAddonClient).
+		WithTaskEventSinks(events.sinks()...)
+
+	// The config of clusters managed with GitOps is committed to the Git repository of the target management cluster
+	if clusterSpec.GitOpsConfig != nil {
+		targetSpec, err := deps.ClusterManager.GetCurrentClusterSpec(ctx, target, target.Name)
+		if err != nil {
+			return fmt.Errorf("error getting target management cluster spec: %v", err)
+		}
+		if targetSpec.GitOpsConfig != nil {
+			targetDeps, err := dependencies.ForSpec(ctx, targetSpec).WithExecutableMountDirs(mo.mountDirs()...).
+				WithFluxAddonClient(ctx, targetSpec.Cluster, targetSpec.GitOpsConfig).
+				Build(ctx)
+			if err != nil {
+				return err
+			}
+			defer close(ctx, targetDeps)
+			moveCluster.WithTargetAddonManager(targetDeps.FluxAddonClient)
+		}
+	}
+
+	err = moveCluster.Run(ctx, clusterSpec, clusterSpec.ManagementCluster, target)
+	return err
+}
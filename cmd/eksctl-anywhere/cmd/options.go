@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/git/providers/generic"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
 
@@ -19,12 +21,16 @@ func (c clusterOptions) mountDirs() []string {
 	if c.managementKubeconfig != "" {
 		dirs = append(dirs, filepath.Dir(c.managementKubeconfig))
 	}
+	// flux reads the private key of a generic git repository from inside the executables container
+	if privateKeyFile := os.Getenv(generic.EksaGitPrivateKeyEnv); privateKeyFile != "" {
+		dirs = append(dirs, filepath.Dir(privateKeyFile))
+	}
 
 	return dirs
 }
 
-func newClusterSpec(options clusterOptions) (*cluster.Spec, error) {
-	var specOpts []cluster.SpecOpt
+func newClusterSpec(options clusterOptions, opts ...cluster.SpecOpt) (*cluster.Spec, error) {
+	specOpts := opts
 	if options.bundlesOverride != "" {
 		specOpts = append(specOpts, cluster.WithOverrideBundlesManifest(options.bundlesOverride))
 	}
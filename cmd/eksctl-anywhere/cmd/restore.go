@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore resources",
+	Long:  "Use eksctl anywhere restore to restore cluster data from a backup, such as etcd or the management cluster objects",
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+var ero = &etcdBackupOptions{}
+
+var restoreEtcdCmd = &cobra.Command{
+	Use:          "etcd",
+	Short:        "Restore the etcd data of a cluster from a snapshot",
+	Long:         "This command restores the etcd data of a cluster from a snapshot taken with backup etcd, reusing the existing control plane and etcd machines",
+	PreRunE:      preRunEtcdBackup,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := ero.restoreEtcd(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to restore etcd: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreEtcdCmd)
+	ero.bindFlags(restoreEtcdCmd)
+	restoreEtcdCmd.Flags().StringVar(&ero.snapshot, "snapshot", "", "Name of the snapshot to restore, defaults to the newest snapshot of the cluster in the location")
+}
+
+func (eo *etcdBackupOptions) restoreEtcd(ctx context.Context) error {
+	clusterSpec, managementCluster, manager, store, err := eo.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Restoring etcd", "cluster", clusterSpec.Name)
+	if err = manager.Restore(ctx, managementCluster, clusterSpec, store, eo.snapshot); err != nil {
+		return err
+	}
+	logger.MarkSuccess("Etcd restored, the control plane might take a few minutes to be ready")
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+var mro = &managementBackupOptions{}
+
+var restoreManagementCmd = &cobra.Command{
+	Use:          "management",
+	Short:        "Restore the EKS-A and CAPI objects of a management cluster from a backup",
+	Long:         "This command creates the objects saved with backup management in a bootstrap or management cluster. Reconciliation stays paused until all the objects are verified",
+	PreRunE:      preRunManagementBackup,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := mro.restoreManagement(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to restore management cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreManagementCmd)
+	restoreManagementCmd.Flags().StringVarP(&mro.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration of the backed up management cluster")
+	restoreManagementCmd.Flags().StringVar(&mro.managementKubeconfig, "kubeconfig", "", "Kubeconfig file of the cluster where the objects are restored")
+	restoreManagementCmd.Flags().StringVar(&mro.archive, "archive", "", "Path of the backup archive")
+	for _, flag := range []string{"filename", "kubeconfig", "archive"} {
+		if err := restoreManagementCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (mo *managementBackupOptions) restoreManagement(ctx context.Context) error {
+	cluster, manager, err := mo.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Restoring management cluster", "archive", mo.archive)
+	metadata, err := manager.Restore(ctx, cluster, mo.archive)
+	if err != nil {
+		return err
+	}
+	logger.MarkSuccess("Management cluster restored", "cluster", metadata.ManagementCluster, "backup created at", metadata.CreatedAt)
+
+	return nil
+}
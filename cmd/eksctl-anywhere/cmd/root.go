@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
@@ -42,3 +43,11 @@ func initLogger() error {
 func Execute() error {
 	return rootCmd.ExecuteContext(context.Background())
 }
+
+// ExitCode returns the exit code for an error returned by Execute
+func ExitCode(err error) int {
+	if errors.Is(err, errUpgradeAvailable) {
+		return upgradeAvailableExitCode
+	}
+	return -1
+}
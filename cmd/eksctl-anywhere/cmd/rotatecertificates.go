@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type rotateCertificatesOptions struct {
+	clusterOptions
+	wConfig string
+}
+
+func (rco *rotateCertificatesOptions) kubeConfig(clusterName string) string {
+	if rco.wConfig == "" {
+		return filepath.Join(clusterName, fmt.Sprintf(kubeconfigPattern, clusterName))
+	}
+	return rco.wConfig
+}
+
+var rco = &rotateCertificatesOptions{}
+
+var rotateCertificatesCmd = &cobra.Command{
+	Use:          "certificates",
+	Short:        "Renew the cluster certificates",
+	Long:         "This command renews the certificates of the control plane and external etcd nodes by rolling them out, and regenerates the aws-iam-authenticator certificate",
+	PreRunE:      preRunRotateCertificates,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rco.rotateCertificates(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to rotate certificates: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunRotateCertificates(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	rotateCmd.AddCommand(rotateCertificatesCmd)
+	rotateCertificatesCmd.Flags().StringVarP(&rco.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	rotateCertificatesCmd.Flags().StringVarP(&rco.wConfig, "w-config", "w", "", "Kubeconfig file of the workload cluster")
+	rotateCertificatesCmd.Flags().StringVar(&rco.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	rotateCertificatesCmd.Flags().StringVar(&rco.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	err := rotateCertificatesCmd.MarkFlagRequired("filename")
+	if err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (rco *rotateCertificatesOptions) rotateCertificates(ctx context.Context) error {
+	clusterConfig, err := commonValidation(ctx, rco.fileName)
+	if err != nil {
+		return fmt.Errorf("common validations failed due to: %v", err)
+	}
+	if !validations.KubeConfigExists(clusterConfig.Name, clusterConfig.Name, rco.wConfig, kubeconfigPattern) {
+		return fmt.Errorf("KubeConfig doesn't exists for cluster %s", clusterConfig.Name)
+	}
+
+	clusterSpec, err := newClusterSpec(rco.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(rco.mountDirs()...).
+		WithClusterManager(clusterSpec.Cluster).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	managementCluster := &types.Cluster{
+		Name:           clusterSpec.Name,
+		KubeconfigFile: rco.kubeConfig(clusterSpec.Name),
+	}
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	logger.Info("Rotating certificates", "cluster", clusterSpec.Name)
+	if err = deps.ClusterManager.RotateCertificates(ctx, managementCluster, clusterSpec); err != nil {
+		return err
+	}
+	logger.MarkSuccess("Certificates rotated")
+
+	return nil
+}
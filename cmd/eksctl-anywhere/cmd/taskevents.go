@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/task"
+)
+
+// taskEvents records the events of a command workflow tasks in a JSON lines file in the cluster folder
+// and keeps their timings to print a summary once the command finishes
+type taskEvents struct {
+	file    *task.JSONLinesFileSink
+	summary *task.TimingSummary
+}
+
+func newTaskEvents(writer filewriter.FileWriter, clusterName, command string) *taskEvents {
+	return &taskEvents{
+		file:    task.NewJSONLinesFileSink(filepath.Join(writer.Dir(), fmt.Sprintf("%s-%s-task-events.jsonl", clusterName, command))),
+		summary: task.NewTimingSummary(),
+	}
+}
+
+func (t *taskEvents) sinks() []task.EventSink {
+	return []task.EventSink{t.file, t.summary}
+}
+
+func (t *taskEvents) printSummary() {
+	fmt.Println()
+	if err := t.summary.Print(os.Stdout); err != nil {
+		logger.V(4).Info("Failed printing task summary", "error", err)
+	}
+	logger.V(0).Info("Task events written", "file", t.file.Path())
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
@@ -23,6 +26,11 @@ type upgradeClusterOptions struct {
 	wConfig          string
 	forceClean       bool
 	hardwareFileName string
+	resume           bool
+	output           string
+	targetVersion    string
+	failOnDisruption bool
+	dryRun           bool
 }
 
 func (uc *upgradeClusterOptions) kubeConfig(clusterName string) string {
@@ -63,8 +71,12 @@ func init() {
 	upgradeClusterCmd.Flags().StringVarP(&uc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
 	upgradeClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file to use when upgrading a workload cluster")
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
+	upgradeClusterCmd.Flags().BoolVar(&uc.resume, "resume", false, "Resume a previously failed upgrade from the last completed task")
 	upgradeClusterCmd.Flags().StringVar(&uc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	upgradeClusterCmd.Flags().StringVar(&uc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	upgradeClusterCmd.Flags().StringVar(&uc.targetVersion, "target-version", "", "Kubernetes version to upgrade to, one minor version at a time. Overrides the version in the cluster config")
+	upgradeClusterCmd.Flags().BoolVar(&uc.failOnDisruption, "fail-on-workload-disruption-risks", false, "Fail the preflight validations when workloads would block or be disrupted by the node drains, instead of warning")
+	upgradeClusterCmd.Flags().BoolVar(&uc.dryRun, "dry-run", false, "Run the setup and validations and write the generated manifests to the cluster folder, without upgrading the cluster")
 	err := upgradeClusterCmd.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -72,14 +84,107 @@ func init() {
 }
 
 func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
+	if uc.resume && uc.forceClean {
+		return fmt.Errorf("--resume and --force-cleanup can't be used together")
+	}
+	if uc.dryRun && (uc.resume || uc.forceClean) {
+		return fmt.Errorf("--dry-run can't be used with --resume or --force-cleanup")
+	}
+	if uc.dryRun && uc.targetVersion != "" {
+		return fmt.Errorf("--dry-run and --target-version can't be used together")
+	}
 	if _, err := uc.commonValidations(ctx); err != nil {
 		return fmt.Errorf("common validations failed due to: %v", err)
 	}
+
+	if uc.targetVersion != "" {
+		return uc.upgradeToTargetVersion(ctx)
+	}
+
 	clusterSpec, err := newClusterSpec(uc.clusterOptions)
 	if err != nil {
 		return err
 	}
 
+	return uc.runUpgrade(ctx, clusterSpec, uc.forceClean, uc.resume, false)
+}
+
+// upgradeToTargetVersion upgrades the cluster one minor version at a time until it reaches the target version.
+// Each hop is a full upgrade, so its preflight validations check the health of the cluster after the previous hop.
+// The machine templates in the cluster config are built for its kubernetes version, so the hops to any other
+// version use the default templates of that version instead
+func (uc *upgradeClusterOptions) upgradeToTargetVersion(ctx context.Context) error {
+	clusterSpec, err := newClusterSpec(uc.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	currentVersion, err := uc.currentKubernetesVersion(ctx, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	hops, err := upgradevalidations.UpgradePath(clusterSpec.Bundles, currentVersion, v1alpha1.KubernetesVersion(uc.targetVersion))
+	if err != nil {
+		return err
+	}
+	if len(hops) == 0 {
+		logger.Info("Cluster is already at the target kubernetes version", "version", uc.targetVersion)
+		return nil
+	}
+	logger.Info("Upgrade plan", "currentVersion", currentVersion, "hops", hops)
+
+	for i, hop := range hops {
+		logger.Info(fmt.Sprintf("Upgrading cluster to kubernetes version %s (%d/%d)", hop, i+1, len(hops)))
+		hopSpec, err := newClusterSpec(uc.clusterOptions, cluster.WithKubernetesVersion(hop))
+		if err != nil {
+			return err
+		}
+
+		// only the first hop continues a previous run, the plan starts from the last completed hop
+		defaultTemplates := hop != clusterSpec.Spec.KubernetesVersion
+		if err = uc.runUpgrade(ctx, hopSpec, uc.forceClean && i == 0, uc.resume && i == 0, defaultTemplates); err != nil {
+			return fmt.Errorf("upgrade stopped at kubernetes version %s, run the command again to continue: %v", hop, err)
+		}
+	}
+
+	return nil
+}
+
+// currentKubernetesVersion returns the version of the EKS-A cluster object, which is only updated once the upgrade
+// of all the nodes is complete
+func (uc *upgradeClusterOptions) currentKubernetesVersion(ctx context.Context, clusterSpec *cluster.Spec) (v1alpha1.KubernetesVersion, error) {
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(uc.mountDirs()...).
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer close(ctx, deps)
+
+	eksaCluster, err := deps.Kubectl.GetEksaCluster(ctx, uc.managementCluster(clusterSpec), clusterSpec.Name)
+	if err != nil {
+		return "", fmt.Errorf("error getting current kubernetes version: %v", err)
+	}
+
+	return eksaCluster.Spec.KubernetesVersion, nil
+}
+
+func (uc *upgradeClusterOptions) managementCluster(clusterSpec *cluster.Spec) *types.Cluster {
+	if clusterSpec.ManagementCluster == nil {
+		return &types.Cluster{
+			Name:           clusterSpec.Name,
+			KubeconfigFile: uc.kubeConfig(clusterSpec.Name),
+		}
+	}
+
+	return &types.Cluster{
+		Name:           clusterSpec.ManagementCluster.Name,
+		KubeconfigFile: clusterSpec.ManagementCluster.KubeconfigFile,
+	}
+}
+
+func (uc *upgradeClusterOptions) runUpgrade(ctx context.Context, clusterSpec *cluster.Spec, forceClean, resume, defaultTemplates bool) error {
 	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(cc.mountDirs()...).
 		WithBootstrapper().
 		WithClusterManager(clusterSpec.Cluster).
@@ -94,6 +199,13 @@ func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
 	}
 	defer cleanup(ctx, deps, &err)
 
+	if defaultTemplates {
+		clearTemplates(deps.Provider)
+	}
+
+	events := newTaskEvents(deps.Writer, clusterSpec.Name, "upgrade")
+	defer events.printSummary()
+
 	upgradeCluster := workflows.NewUpgrade(
 		deps.Bootstrapper,
 		deps.Provider,
@@ -101,39 +213,46 @@ func (uc *upgradeClusterOptions) upgradeCluster(ctx context.Context) error {
 		deps.ClusterManager,
 		deps.FluxAddonClient,
 		deps.Writer,
-	)
+	).WithTaskEventSinks(events.sinks()...)
 
 	workloadCluster := &types.Cluster{
 		Name:           clusterSpec.Name,
 		KubeconfigFile: uc.kubeConfig(clusterSpec.Name),
 	}
 
-	var cluster *types.Cluster
-	if clusterSpec.ManagementCluster == nil {
-		cluster = &types.Cluster{
-			Name:           clusterSpec.Name,
-			KubeconfigFile: uc.kubeConfig(clusterSpec.Name),
-		}
-	} else {
-		cluster = &types.Cluster{
-			Name:           clusterSpec.ManagementCluster.Name,
-			KubeconfigFile: clusterSpec.ManagementCluster.KubeconfigFile,
-		}
-	}
+	managementCluster := uc.managementCluster(clusterSpec)
 
 	validationOpts := &validations.Opts{
-		Kubectl:           deps.Kubectl,
-		Spec:              clusterSpec,
-		WorkloadCluster:   workloadCluster,
-		ManagementCluster: cluster,
-		Provider:          deps.Provider,
+		Kubectl:                       deps.Kubectl,
+		Spec:                          clusterSpec,
+		WorkloadCluster:               workloadCluster,
+		ManagementCluster:             managementCluster,
+		Provider:                      deps.Provider,
+		FailOnWorkloadDisruptionRisks: uc.failOnDisruption,
 	}
 	upgradeValidations := upgradevalidations.New(validationOpts)
 
-	err = upgradeCluster.Run(ctx, clusterSpec, cluster, upgradeValidations, uc.forceClean)
+	if uc.dryRun {
+		dryRun := workflows.NewDryRun(deps.Provider, deps.ClusterManager, deps.FluxAddonClient, deps.Writer).WithTaskEventSinks(events.sinks()...)
+		err = dryRun.RunUpgrade(ctx, clusterSpec, workloadCluster, upgradeValidations)
+		return err
+	}
+
+	err = upgradeCluster.Run(ctx, clusterSpec, managementCluster, upgradeValidations, forceClean, resume)
 	return err
 }
 
+// clearTemplates unsets the templates of the machine configs, so the provider sets them to the default
+// templates of the kubernetes version of the spec
+func clearTemplates(provider providers.Provider) {
+	for _, machineConfig := range provider.MachineConfigs() {
+		if vsphereMachineConfig, ok := machineConfig.(*v1alpha1.VSphereMachineConfig); ok && vsphereMachineConfig.Spec.Template != "" {
+			logger.V(3).Info("Using default template instead of the one in the cluster config", "machineConfig", vsphereMachineConfig.Name, "template", vsphereMachineConfig.Spec.Template)
+			vsphereMachineConfig.Spec.Template = ""
+		}
+	}
+}
+
 func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster *v1alpha1.Cluster, err error) {
 	clusterConfig, err := commonValidation(ctx, uc.fileName)
 	if err != nil {
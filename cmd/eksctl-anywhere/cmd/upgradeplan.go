@@ -2,23 +2,27 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
-	fluxupgrader "github.com/aws/eks-anywhere/pkg/addonmanager/addonclients"
-	capiupgrader "github.com/aws/eks-anywhere/pkg/clusterapi"
-	eksaupgrader "github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/upgradeplan"
 )
 
+// errUpgradeAvailable makes upgrade plan exit with upgradeAvailableExitCode, so pipelines can tell
+// an available upgrade apart from a failure
+var errUpgradeAvailable = errors.New("upgrade available")
+
+const upgradeAvailableExitCode = 2
+
 var upgradePlanCmd = &cobra.Command{
 	Use:          "plan",
 	Short:        "Provides new release versions for the next cluster upgrade",
@@ -27,6 +31,11 @@ var upgradePlanCmd = &cobra.Command{
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := uc.upgradePlanCluster(cmd.Context()); err != nil {
+			if errors.Is(err, errUpgradeAvailable) {
+				// It's not a failure, so cobra mustn't print it after the plan, which can be json or yaml
+				cmd.SilenceErrors = true
+				return err
+			}
 			return fmt.Errorf("failed to display upgrade plan: %v", err)
 		}
 		return nil
@@ -46,6 +55,7 @@ func preRunUpgradePlanCluster(cmd *cobra.Command, args []string) error {
 func init() {
 	upgradeCmd.AddCommand(upgradePlanCmd)
 	upgradePlanCmd.Flags().StringVarP(&uc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	upgradePlanCmd.Flags().StringVarP(&uc.output, "output", "o", upgradeplan.TableOutput, "Output format: table, json or yaml")
 	upgradePlanCmd.Flags().StringVar(&uc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	err := upgradePlanCmd.MarkFlagRequired("filename")
 	if err != nil {
@@ -54,6 +64,9 @@ func init() {
 }
 
 func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
+	if err := upgradeplan.ValidateOutputFormat(uc.output); err != nil {
+		return err
+	}
 	if _, err := uc.commonValidations(ctx); err != nil {
 		return fmt.Errorf("common validations failed due to: %v", err)
 	}
@@ -83,21 +96,17 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 		return err
 	}
 
-	componentChangeDiffs := eksaupgrader.EksaChangeDiff(currentSpec, newClusterSpec)
-	componentChangeDiffs.Append(fluxupgrader.FluxChangeDiff(currentSpec, newClusterSpec))
-	componentChangeDiffs.Append(capiupgrader.CapiChangeDiff(currentSpec, newClusterSpec, deps.Provider))
-
-	if componentChangeDiffs == nil {
-		fmt.Println("All the components are up to date with the latest versions")
-		return nil
+	plan, err := upgradeplan.Build(ctx, workloadCluster, deps.Provider, currentSpec, newClusterSpec)
+	if err != nil {
+		return err
 	}
-	w := tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
-	fmt.Fprintln(w, "NAME\tCURRENT VERSION\tNEXT VERSION")
-	for i := range componentChangeDiffs.ComponentReports {
-		fmt.Fprintf(w, "%s\t%s\t%s\n", componentChangeDiffs.ComponentReports[i].ComponentName, componentChangeDiffs.ComponentReports[i].OldVersion, componentChangeDiffs.ComponentReports[i].NewVersion)
+
+	if err = plan.Print(os.Stdout, uc.output); err != nil {
+		return err
 	}
-	if err := w.Flush(); err != nil {
-		fmt.Printf("Error %v", err)
+
+	if plan.UpgradeAvailable {
+		return errUpgradeAvailable
 	}
 
 	return nil
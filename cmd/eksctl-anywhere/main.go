@@ -26,8 +26,8 @@ func main() {
 			os.Exit(-1)
 		}
 	}
-	if cmd.Execute() == nil {
-		os.Exit(0)
+	if err := cmd.Execute(); err != nil {
+		os.Exit(cmd.ExitCode(err))
 	}
-	os.Exit(-1)
+	os.Exit(0)
 }
@@ -4,43 +4,111 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1alpha3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/aws/eks-anywhere/controllers/controllers/clusters"
+	"github.com/aws/eks-anywhere/controllers/controllers/resource"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 )
 
 // ClusterReconciler reconciles a Cluster object
 type ClusterReconciler struct {
-	client client.Client
-	log    logr.Logger
+	client          client.Client
+	log             logr.Logger
+	resourceFetcher resource.ResourceFetcher
 }
 
 func NewClusterReconciler(client client.Client, log logr.Logger, scheme *runtime.Scheme) *ClusterReconciler {
 	return &ClusterReconciler{
-		client: client,
-		log:    log,
+		client:          client,
+		log:             log,
+		resourceFetcher: resource.NewCAPIResourceFetcher(client, log),
 	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	capiObjectToCluster := handler.EnqueueRequestsFromMapFunc(r.capiObjectToCluster)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&anywherev1.Cluster{}).
+		Watches(&source.Kind{Type: &clusterv1.Cluster{}}, capiObjectToCluster).
+		Watches(&source.Kind{Type: &controlplanev1.KubeadmControlPlane{}}, capiObjectToCluster).
+		Watches(&source.Kind{Type: &clusterv1.MachineDeployment{}}, capiObjectToCluster).
+		Watches(&source.Kind{Type: &etcdv1.EtcdadmCluster{}}, capiObjectToCluster).
 		Complete(r)
 }
 
-// TODO: add here kubebuilder permissions as neeeded
+// capiObjectToCluster maps a CAPI object to the eks-a Cluster with the same name as the CAPI cluster it belongs to.
+func (r *ClusterReconciler) capiObjectToCluster(o client.Object) []reconcile.Request {
+	clusterName := capiClusterName(o)
+	if clusterName == "" {
+		return nil
+	}
+
+	clusters := &anywherev1.ClusterList{}
+	if err := r.client.List(context.Background(), clusters); err != nil {
+		r.log.Error(err, "Failed to list clusters", "capiObject", o.GetName())
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, c := range clusters.Items {
+		if c.Name == clusterName {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: c.Namespace, Name: c.Name}})
+		}
+	}
+
+	return requests
+}
+
+func capiClusterName(o client.Object) string {
+	if _, ok := o.(*clusterv1.Cluster); ok {
+		return o.GetName()
+	}
+
+	if name, ok := o.GetLabels()[clusterv1.ClusterLabelName]; ok {
+		return name
+	}
+
+	for _, ref := range o.GetOwnerReferences() {
+		if ref.Kind == "Cluster" && ref.APIVersion == clusterv1.GroupVersion.String() {
+			return ref.Name
+		}
+	}
+
+	return ""
+}
+
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=gitopsconfigs;oidcconfigs,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;create;patch
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;create;patch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;create;patch
+// The controller must hold the permissions of the cluster autoscaler ClusterRole to be allowed to create it
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments;machinedeployments/scale;machines;machinesets,verbs=get;list;update;watch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=*,verbs=get;list;watch
+
 func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := r.log.WithValues("cluster", req.NamespacedName)
 
 	// Fetch the Cluster object
 	cluster := &anywherev1.Cluster{}
 	if err := r.client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
 		return ctrl.Result{}, err
 	}
 
@@ -52,7 +120,11 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 
 	defer func() {
 		// Always attempt to patch the object and status after each reconciliation.
-		if err := patchHelper.Patch(ctx, cluster); err != nil {
+		patchOpts := []patch.Option{}
+		if reterr == nil && cluster.DeletionTimestamp.IsZero() {
+			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
+		}
+		if err := patchHelper.Patch(ctx, cluster, patchOpts...); err != nil {
 			reterr = kerrors.NewAggregate([]error{reterr, err})
 		}
 	}()
@@ -67,11 +139,19 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 		return ctrl.Result{}, nil
 	}
 
+	if err := clusters.UpdateClusterStatus(ctx, r.resourceFetcher, cluster); err != nil {
+		log.Error(err, "Failed to update Cluster status")
+		return ctrl.Result{}, err
+	}
+
 	if cluster.IsSelfManaged() {
 		log.Info("Ignoring self managed cluster")
 		return ctrl.Result{}, nil
 	}
 
+	// Make sure the CAPI cluster gets torn down before the cluster is removed
+	controllerutil.AddFinalizer(cluster, anywherev1.ClusterFinalizerName)
+
 	result, err := r.reconcile(ctx, cluster, log)
 	if err != nil {
 		log.Error(err, "Failed to reconcile Cluster")
@@ -80,7 +160,7 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 }
 
 func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *anywherev1.Cluster, log logr.Logger) (ctrl.Result, error) {
-	clusterProviderReconciler, err := clusters.BuildProviderReconciler(cluster.Spec.DatacenterRef.Kind)
+	clusterProviderReconciler, err := clusters.BuildProviderReconciler(cluster.Spec.DatacenterRef.Kind, r.client, log)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -93,5 +173,10 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *anywherev1.C
 }
 
 func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *anywherev1.Cluster, log logr.Logger) (ctrl.Result, error) {
-	return ctrl.Result{}, nil
+	result, err := clusters.ReconcileDelete(ctx, r.client, log, cluster)
+	if err != nil {
+		log.Error(err, "Failed to delete Cluster")
+		return ctrl.Result{}, err
+	}
+	return result.ToCtrlResult(), nil
 }
@@ -0,0 +1,103 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/reconciler"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// deleteRequeueAfter is how often to check if the CAPI cluster and its machines are gone
+const deleteRequeueAfter = 10 * time.Second
+
+// ReconcileDelete tears down the CAPI cluster backing an eks-a Cluster that is being deleted and,
+// once all its machines are gone, deletes the eks-a objects the cluster references and removes the finalizer.
+// Clusters paused by the CLI are deleted by the CLI itself, so for those the finalizer is just removed.
+func ReconcileDelete(ctx context.Context, c client.Client, log logr.Logger, cs *anywherev1.Cluster) (reconciler.Result, error) {
+	if !controllerutil.ContainsFinalizer(cs, anywherev1.ClusterFinalizerName) {
+		return reconciler.Result{}, nil
+	}
+
+	if cs.IsReconcilePaused() || cs.IsSelfManaged() {
+		log.Info("Releasing cluster without cleaning up its resources")
+		controllerutil.RemoveFinalizer(cs, anywherev1.ClusterFinalizerName)
+		return reconciler.Result{}, nil
+	}
+
+	capiCluster := &clusterv1.Cluster{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: cs.Name}, capiCluster)
+	if err == nil {
+		if capiCluster.DeletionTimestamp.IsZero() {
+			log.Info("Deleting CAPI cluster", "name", capiCluster.Name)
+			if err = c.Delete(ctx, capiCluster); err != nil && !apierrors.IsNotFound(err) {
+				return reconciler.Result{}, fmt.Errorf("failed deleting CAPI cluster: %v", err)
+			}
+		}
+		log.Info("Waiting for CAPI cluster to be deleted", "name", capiCluster.Name)
+		return requeueAfter(deleteRequeueAfter), nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return reconciler.Result{}, err
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err = c.List(ctx, machines, client.InNamespace(constants.EksaSystemNamespace), client.MatchingLabels{clusterv1.ClusterLabelName: cs.Name}); err != nil {
+		return reconciler.Result{}, err
+	}
+	if len(machines.Items) > 0 {
+		log.Info("Waiting for machines to be deleted", "machines", len(machines.Items))
+		return requeueAfter(deleteRequeueAfter), nil
+	}
+
+	if err = deleteReferencedObjects(ctx, c, log, cs); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	controllerutil.RemoveFinalizer(cs, anywherev1.ClusterFinalizerName)
+	return reconciler.Result{}, nil
+}
+
+// deleteReferencedObjects deletes the GitOps, identity provider, datacenter and machine configs of a cluster,
+// same as the CLI does when deleting a managed cluster.
+func deleteReferencedObjects(ctx context.Context, c client.Client, log logr.Logger, cs *anywherev1.Cluster) error {
+	refs := make([]anywherev1.Ref, 0, len(cs.Spec.IdentityProviderRefs)+4)
+	if cs.Spec.GitOpsRef != nil {
+		refs = append(refs, *cs.Spec.GitOpsRef)
+	}
+	refs = append(refs, cs.Spec.IdentityProviderRefs...)
+	refs = append(refs, cs.Spec.DatacenterRef)
+	refs = append(refs, cs.MachineConfigRefs()...)
+
+	for _, ref := range refs {
+		if ref.Kind == "" || ref.Name == "" {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(anywherev1.GroupVersion.WithKind(ref.Kind))
+		obj.SetName(ref.Name)
+		obj.SetNamespace(cs.Namespace)
+
+		log.Info("Deleting object", "kind", ref.Kind, "name", ref.Name)
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed deleting %s %s: %v", ref.Kind, ref.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func requeueAfter(d time.Duration) reconciler.Result {
+	return reconciler.Result{Result: &ctrl.Result{RequeueAfter: d}}
+}
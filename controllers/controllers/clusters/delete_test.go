@@ -0,0 +1,141 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/clusters"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+type deleteTest struct {
+	*WithT
+	ctx     context.Context
+	cluster *anywherev1.Cluster
+}
+
+func newDeleteTest(t *testing.T) *deleteTest {
+	return &deleteTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "workload",
+				Namespace:  "default",
+				Finalizers: []string{anywherev1.ClusterFinalizerName},
+			},
+			Spec: anywherev1.ClusterSpec{
+				ManagementCluster: anywherev1.ManagementCluster{Name: "management"},
+				DatacenterRef:     anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: "datacenter"},
+				ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+					MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "cp-machines"},
+				},
+				WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+					{MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "worker-machines"}},
+				},
+				IdentityProviderRefs: []anywherev1.Ref{{Kind: anywherev1.OIDCConfigKind, Name: "oidc"}},
+				GitOpsRef:            &anywherev1.Ref{Kind: anywherev1.GitOpsConfigKind, Name: "gitops"},
+			},
+		},
+	}
+}
+
+func (tt *deleteTest) client(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	tt.Expect(anywherev1.AddToScheme(scheme)).To(Succeed())
+	tt.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func (tt *deleteTest) referencedObjects() []client.Object {
+	return []client.Object{
+		&anywherev1.VSphereDatacenterConfig{ObjectMeta: metav1.ObjectMeta{Name: "datacenter", Namespace: "default"}},
+		&anywherev1.VSphereMachineConfig{ObjectMeta: metav1.ObjectMeta{Name: "cp-machines", Namespace: "default"}},
+		&anywherev1.VSphereMachineConfig{ObjectMeta: metav1.ObjectMeta{Name: "worker-machines", Namespace: "default"}},
+		&anywherev1.OIDCConfig{ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: "default"}},
+		&anywherev1.GitOpsConfig{ObjectMeta: metav1.ObjectMeta{Name: "gitops", Namespace: "default"}},
+	}
+}
+
+func capiClusterToDelete() *clusterv1.Cluster {
+	return &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "eksa-system"}}
+}
+
+func TestReconcileDeleteNoFinalizer(t *testing.T) {
+	tt := newDeleteTest(t)
+	tt.cluster.Finalizers = nil
+	c := tt.client(capiClusterToDelete())
+
+	result, err := clusters.ReconcileDelete(tt.ctx, c, logr.Discard(), tt.cluster)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(result.Result).To(BeNil())
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(capiClusterToDelete()), &clusterv1.Cluster{})).To(Succeed())
+}
+
+func TestReconcileDeletePausedCluster(t *testing.T) {
+	tt := newDeleteTest(t)
+	tt.cluster.PauseReconcile()
+	c := tt.client(capiClusterToDelete())
+
+	result, err := clusters.ReconcileDelete(tt.ctx, c, logr.Discard(), tt.cluster)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(result.Result).To(BeNil())
+	tt.Expect(tt.cluster.Finalizers).To(BeEmpty())
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(capiClusterToDelete()), &clusterv1.Cluster{})).To(Succeed())
+}
+
+func TestReconcileDeleteCAPIClusterExists(t *testing.T) {
+	tt := newDeleteTest(t)
+	c := tt.client(capiClusterToDelete())
+
+	result, err := clusters.ReconcileDelete(tt.ctx, c, logr.Discard(), tt.cluster)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(result.Result).NotTo(BeNil())
+	tt.Expect(result.Result.RequeueAfter).NotTo(BeZero())
+	tt.Expect(tt.cluster.Finalizers).To(ConsistOf(anywherev1.ClusterFinalizerName))
+
+	err = c.Get(tt.ctx, client.ObjectKeyFromObject(capiClusterToDelete()), &clusterv1.Cluster{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "CAPI cluster should have been deleted")
+}
+
+func TestReconcileDeleteMachinesExist(t *testing.T) {
+	tt := newDeleteTest(t)
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workload-md-0-abcde",
+			Namespace: "eksa-system",
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "workload"},
+		},
+	}
+	c := tt.client(append(tt.referencedObjects(), machine)...)
+
+	result, err := clusters.ReconcileDelete(tt.ctx, c, logr.Discard(), tt.cluster)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(result.Result).NotTo(BeNil())
+	tt.Expect(tt.cluster.Finalizers).To(ConsistOf(anywherev1.ClusterFinalizerName))
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "datacenter", Namespace: "default"}, &anywherev1.VSphereDatacenterConfig{})).To(Succeed())
+}
+
+func TestReconcileDeleteComplete(t *testing.T) {
+	tt := newDeleteTest(t)
+	c := tt.client(tt.referencedObjects()...)
+
+	result, err := clusters.ReconcileDelete(tt.ctx, c, logr.Discard(), tt.cluster)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(result.Result).To(BeNil())
+	tt.Expect(tt.cluster.Finalizers).To(BeEmpty())
+
+	for _, obj := range tt.referencedObjects() {
+		err = c.Get(tt.ctx, client.ObjectKeyFromObject(obj), obj)
+		tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s should have been deleted", obj.GetName())
+	}
+}
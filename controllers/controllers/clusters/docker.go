@@ -3,18 +3,136 @@ package clusters
 import (
 	"context"
 
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	"github.com/aws/eks-anywhere/controllers/controllers/reconciler"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
+)
+
+const (
+	dockerMachineTemplateKind       = "DockerMachineTemplate"
+	dockerMachineTemplateAPIVersion = "infrastructure.cluster.x-k8s.io/v1alpha3"
 )
 
 type DockerReconciler struct {
 	*providerClusterReconciler
 }
 
-func NewDockerReconciler() *DockerReconciler {
-	return &DockerReconciler{providerClusterReconciler: &providerClusterReconciler{}}
+func NewDockerReconciler(client client.Client, log logr.Logger) *DockerReconciler {
+	return &DockerReconciler{providerClusterReconciler: newProviderClusterReconciler(client, log)}
 }
 
-func (v *DockerReconciler) Reconcile(ctx context.Context, cluster *anywherev1.Cluster) (reconciler.Result, error) {
+func (d *DockerReconciler) Reconcile(ctx context.Context, cs *anywherev1.Cluster) (reconciler.Result, error) {
+	spec, err := d.buildSpec(ctx, cs)
+	if err != nil {
+		return reconciler.Result{}, err
+	}
+
+	objs, err := d.generateCAPIObjects(ctx, cs, spec)
+	if err != nil {
+		return reconciler.Result{}, err
+	}
+
+	d.log.Info("Applying CAPI objects", "cluster", cs.Name, "objects", len(objs))
+	if err = reconciler.ReconcileObjects(ctx, d.client, objs); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	if err = d.removeOldWorkerNodeGroups(ctx, cs, spec); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	if err = d.reconcileClusterAutoscaler(ctx, spec); err != nil {
+		return reconciler.Result{}, err
+	}
+
 	return reconciler.Result{}, nil
 }
+
+func (d *DockerReconciler) generateCAPIObjects(ctx context.Context, cs *anywherev1.Cluster, spec *cluster.Spec) ([]client.Object, error) {
+	templateBuilder := docker.NewDockerTemplateBuilder(d.now)
+	clusterName := spec.ObjectMeta.Name
+	// The kind node image changes with both the kubernetes version and the bundle
+	// so it's enough to decide if the machines need to be rolled out
+	reusable := d.templateWithImage(ctx, spec.VersionsBundle.EksD.KindNode.VersionedImage())
+
+	existingCP, existingEtcd, err := d.existingTemplateNames(ctx, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	existingMDs, err := d.existingMachineDeployments(ctx, cs, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlaneTemplateName, err := machineTemplateName(existingCP, reusable, func() string { return templateBuilder.CPMachineTemplateName(clusterName) })
+	if err != nil {
+		return nil, err
+	}
+
+	workloadTemplateNames := make(map[string]string, len(spec.Spec.WorkerNodeGroupConfigurations))
+	workerReplicas := make(map[string]int, len(spec.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range spec.Spec.WorkerNodeGroupConfigurations {
+		workerNodeGroupName := workerNodeGroup.Name
+		workloadTemplateNames[workerNodeGroupName], err = machineTemplateName(existingWorkerTemplateName(existingMDs, workerNodeGroupName), reusable, func() string {
+			return templateBuilder.WorkerMachineTemplateName(clusterName, workerNodeGroupName)
+		})
+		if err != nil {
+			return nil, err
+		}
+		workerReplicas[workerNodeGroupName] = clusterapi.WorkerReplicas(workerNodeGroup, existingMDs[workerNodeGroupName])
+	}
+
+	var etcdTemplateName string
+	if cs.Spec.ExternalEtcdConfiguration != nil {
+		etcdTemplateName, err = machineTemplateName(existingEtcd, reusable, func() string { return templateBuilder.EtcdMachineTemplateName(clusterName) })
+		if err != nil {
+			return nil, err
+		}
+		if existingEtcd != "" && etcdTemplateName != existingEtcd {
+			if err = d.markEtcdUpgrading(ctx, cs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cpOpt := func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = controlPlaneTemplateName
+		values["etcdTemplateName"] = etcdTemplateName
+	}
+	workersOpt := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		values["workloadTemplateName"] = workloadTemplateNames[workerNodeGroupName]
+		values["worker_replicas"] = workerReplicas[workerNodeGroupName]
+	})
+
+	return d.generateCAPIObjectsForWorkers(ctx, templateBuilder, spec, existingMDs, cpOpt, workersOpt)
+}
+
+// templateWithImage returns a check for DockerMachineTemplates that are already using the given node image.
+func (d *DockerReconciler) templateWithImage(ctx context.Context, image string) func(name string) (bool, error) {
+	return func(name string) (bool, error) {
+		template, err := d.fetcher.Fetch(ctx, name, constants.EksaSystemNamespace, dockerMachineTemplateKind, dockerMachineTemplateAPIVersion)
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		currentImage, _, err := unstructured.NestedString(template.Object, "spec", "template", "spec", "customImage")
+		if err != nil {
+			return false, err
+		}
+
+		return currentImage == image, nil
+	}
+}
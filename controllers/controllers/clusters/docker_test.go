@@ -0,0 +1,314 @@
+package clusters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1alpha3"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	kubeadmv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/resource/mocks"
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type dockerTest struct {
+	*WithT
+	ctx        context.Context
+	fetcher    *mocks.MockResourceFetcher
+	reconciler *DockerReconciler
+	spec       *cluster.Spec
+}
+
+func newDockerTest(t *testing.T) *dockerTest {
+	ctrl := gomock.NewController(t)
+	fetcher := mocks.NewMockResourceFetcher(ctrl)
+	return &dockerTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		fetcher: fetcher,
+		reconciler: &DockerReconciler{
+			providerClusterReconciler: &providerClusterReconciler{
+				log:     logr.Discard(),
+				fetcher: fetcher,
+				now:     func() time.Time { return time.Unix(0, 1e6) },
+			},
+		},
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Name = "test-cluster"
+			s.Namespace = "default"
+			s.Spec.ControlPlaneConfiguration.Count = 1
+			s.Spec.WorkerNodeGroupConfigurations[0].Count = 1
+			s.VersionsBundle.KubeDistro.Kubernetes.Tag = "v1.21.2-eks-1-21-4"
+			s.VersionsBundle.EksD.KindNode = releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/kind-node:v1.21.2"}
+		}),
+	}
+}
+
+func (tt *dockerTest) expectNoCAPIObjects() {
+	notFound := apierrors.NewNotFound(schema.GroupResource{}, "")
+	tt.fetcher.EXPECT().ControlPlane(tt.ctx, tt.spec.Cluster).Return(nil, notFound)
+	tt.fetcher.EXPECT().MachineDeployments(tt.ctx, tt.spec.Cluster).Return(nil, nil)
+}
+
+func (tt *dockerTest) expectExistingCAPIObjects() {
+	tt.fetcher.EXPECT().ControlPlane(tt.ctx, tt.spec.Cluster).Return(&controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			InfrastructureTemplate: corev1.ObjectReference{Name: "test-cluster-control-plane-template-original"},
+		},
+	}, nil)
+	tt.fetcher.EXPECT().MachineDeployments(tt.ctx, tt.spec.Cluster).Return([]*clusterv1.MachineDeployment{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-md-0"},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Bootstrap: clusterv1.Bootstrap{
+							ConfigRef: &corev1.ObjectReference{Name: "test-cluster-md-0-template-original"},
+						},
+						InfrastructureRef: corev1.ObjectReference{Name: "test-cluster-md-0-original"},
+					},
+				},
+			},
+		},
+	}, nil)
+}
+
+// expectKubeadmConfigTemplate returns the workers KubeadmConfigTemplate rendered for spec as the existing template with the given name
+func (tt *dockerTest) expectKubeadmConfigTemplate(name string, spec *cluster.Spec) {
+	objs, err := generateCAPIObjects(docker.NewDockerTemplateBuilder(tt.reconciler.now), spec, func(map[string]interface{}) {}, func(map[string]interface{}) {})
+	tt.Expect(err).To(Succeed())
+	var template *unstructured.Unstructured
+	for _, o := range objs {
+		if o.GetObjectKind().GroupVersionKind().Kind == kubeadmConfigTemplateKind {
+			template = o.(*unstructured.Unstructured)
+		}
+	}
+	tt.Expect(template).NotTo(BeNil())
+	tt.fetcher.EXPECT().Fetch(tt.ctx, name, "eksa-system", kubeadmConfigTemplateKind, kubeadmConfigTemplateAPIVersion).Return(template, nil)
+}
+
+func (tt *dockerTest) expectMachineTemplate(name, image string) {
+	template := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	_ = unstructured.SetNestedField(template.Object, image, "spec", "template", "spec", "customImage")
+	tt.fetcher.EXPECT().Fetch(tt.ctx, name, "eksa-system", dockerMachineTemplateKind, dockerMachineTemplateAPIVersion).Return(template, nil)
+}
+
+func kubeadmConfigTemplateNames(objs []client.Object) []string {
+	names := []string{}
+	for _, o := range objs {
+		if o.GetObjectKind().GroupVersionKind().Kind == kubeadmConfigTemplateKind {
+			names = append(names, o.GetName())
+		}
+	}
+	return names
+}
+
+func machineTemplateNames(objs []client.Object) []string {
+	names := []string{}
+	for _, o := range objs {
+		if o.GetObjectKind().GroupVersionKind().Kind == dockerMachineTemplateKind {
+			names = append(names, o.GetName())
+		}
+	}
+	return names
+}
+
+func TestDockerGenerateCAPIObjectsCreate(t *testing.T) {
+	tt := newDockerTest(t)
+	tt.expectNoCAPIObjects()
+
+	objs, err := tt.reconciler.generateCAPIObjects(tt.ctx, tt.spec.Cluster, tt.spec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(machineTemplateNames(objs)).To(ConsistOf(
+		"test-cluster-control-plane-template-1",
+		"test-cluster-md-0-1",
+	))
+}
+
+func TestDockerGenerateCAPIObjectsNoChanges(t *testing.T) {
+	tt := newDockerTest(t)
+	tt.expectExistingCAPIObjects()
+	tt.expectMachineTemplate("test-cluster-control-plane-template-original", "public.ecr.aws/eks-anywhere/kind-node:v1.21.2")
+	tt.expectMachineTemplate("test-cluster-md-0-original", "public.ecr.aws/eks-anywhere/kind-node:v1.21.2")
+	tt.expectKubeadmConfigTemplate("test-cluster-md-0-template-original", tt.spec)
+
+	objs, err := tt.reconciler.generateCAPIObjects(tt.ctx, tt.spec.Cluster, tt.spec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(machineTemplateNames(objs)).To(ConsistOf(
+		"test-cluster-control-plane-template-original",
+		"test-cluster-md-0-original",
+	))
+	tt.Expect(kubeadmConfigTemplateNames(objs)).To(ConsistOf("test-cluster-md-0-template-original"))
+}
+
+func TestDockerGenerateCAPIObjectsNewImage(t *testing.T) {
+	tt := newDockerTest(t)
+	tt.expectExistingCAPIObjects()
+	tt.expectMachineTemplate("test-cluster-control-plane-template-original", "public.ecr.aws/eks-anywhere/kind-node:v1.20.7")
+	tt.expectMachineTemplate("test-cluster-md-0-original", "public.ecr.aws/eks-anywhere/kind-node:v1.20.7")
+	tt.expectKubeadmConfigTemplate("test-cluster-md-0-template-original", tt.spec)
+
+	objs, err := tt.reconciler.generateCAPIObjects(tt.ctx, tt.spec.Cluster, tt.spec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(machineTemplateNames(objs)).To(ConsistOf(
+		"test-cluster-control-plane-template-1",
+		"test-cluster-md-0-1",
+	))
+}
+
+func TestDockerGenerateCAPIObjectsExternalEtcdUpgrade(t *testing.T) {
+	tt := newDockerTest(t)
+	tt.spec.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+	etcd := &etcdv1.EtcdadmCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-etcd", Namespace: "eksa-system"},
+		Spec: etcdv1.EtcdadmClusterSpec{
+			InfrastructureTemplate: corev1.ObjectReference{Name: "test-cluster-etcd-template-original"},
+		},
+	}
+	scheme := runtime.NewScheme()
+	tt.Expect(etcdv1.AddToScheme(scheme)).To(Succeed())
+	tt.reconciler.client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(etcd.DeepCopy()).Build()
+
+	tt.expectExistingCAPIObjects()
+	tt.fetcher.EXPECT().Etcd(tt.ctx, tt.spec.Cluster).Return(etcd.DeepCopy(), nil).Times(2)
+	tt.expectMachineTemplate("test-cluster-control-plane-template-original", "public.ecr.aws/eks-anywhere/kind-node:v1.21.2")
+	tt.expectMachineTemplate("test-cluster-md-0-original", "public.ecr.aws/eks-anywhere/kind-node:v1.21.2")
+	tt.expectMachineTemplate("test-cluster-etcd-template-original", "public.ecr.aws/eks-anywhere/kind-node:v1.20.7")
+	tt.expectKubeadmConfigTemplate("test-cluster-md-0-template-original", tt.spec)
+
+	objs, err := tt.reconciler.generateCAPIObjects(tt.ctx, tt.spec.Cluster, tt.spec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(machineTemplateNames(objs)).To(ConsistOf(
+		"test-cluster-control-plane-template-original",
+		"test-cluster-md-0-original",
+		"test-cluster-etcd-template-1",
+	))
+
+	updatedEtcd := &etcdv1.EtcdadmCluster{}
+	tt.Expect(tt.reconciler.client.Get(tt.ctx, client.ObjectKeyFromObject(etcd), updatedEtcd)).To(Succeed())
+	tt.Expect(updatedEtcd.Annotations).To(HaveKeyWithValue(etcdv1.UpgradeInProgressAnnotation, "true"))
+}
+
+func TestDockerGenerateCAPIObjectsAutoscalingKeepsReplicas(t *testing.T) {
+	tt := newDockerTest(t)
+	tt.spec.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &anywherev1.AutoScalingConfiguration{MinCount: 1, MaxCount: 5}
+	replicas := int32(3)
+	existingMD := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-md-0"},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Replicas: &replicas,
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					InfrastructureRef: corev1.ObjectReference{Name: "test-cluster-md-0-original"},
+				},
+			},
+		},
+	}
+	tt.fetcher.EXPECT().ControlPlane(tt.ctx, tt.spec.Cluster).Return(&controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			InfrastructureTemplate: corev1.ObjectReference{Name: "test-cluster-control-plane-template-original"},
+		},
+	}, nil)
+	tt.fetcher.EXPECT().MachineDeployments(tt.ctx, tt.spec.Cluster).Return([]*clusterv1.MachineDeployment{existingMD}, nil)
+	tt.expectMachineTemplate("test-cluster-control-plane-template-original", "public.ecr.aws/eks-anywhere/kind-node:v1.21.2")
+	tt.expectMachineTemplate("test-cluster-md-0-original", "public.ecr.aws/eks-anywhere/kind-node:v1.21.2")
+	tt.expectKubeadmConfigTemplate("test-cluster-md-0", tt.spec)
+
+	objs, err := tt.reconciler.generateCAPIObjects(tt.ctx, tt.spec.Cluster, tt.spec)
+	tt.Expect(err).To(Succeed())
+
+	var md *unstructured.Unstructured
+	for _, o := range objs {
+		if o.GetObjectKind().GroupVersionKind().Kind == "MachineDeployment" {
+			md = o.(*unstructured.Unstructured)
+		}
+	}
+	tt.Expect(md).NotTo(BeNil())
+	gotReplicas, _, _ := unstructured.NestedFieldNoCopy(md.Object, "spec", "replicas")
+	tt.Expect(gotReplicas).To(BeNumerically("==", 3))
+	tt.Expect(md.GetAnnotations()).To(HaveKeyWithValue("cluster.x-k8s.io/cluster-api-autoscaler-node-group-min-size", "1"))
+	tt.Expect(md.GetAnnotations()).To(HaveKeyWithValue("cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size", "5"))
+}
+
+func TestDockerGenerateCAPIObjectsWorkerNodeGroupLabelsChanged(t *testing.T) {
+	tt := newDockerTest(t)
+	existingSpec := tt.spec.DeepCopy()
+	existingSpec.Spec.WorkerNodeGroupConfigurations[0].Labels = map[string]string{"role": "old"}
+	tt.spec.Spec.WorkerNodeGroupConfigurations[0].Labels = map[string]string{"role": "new"}
+	tt.expectExistingCAPIObjects()
+	tt.expectMachineTemplate("test-cluster-control-plane-template-original", "public.ecr.aws/eks-anywhere/kind-node:v1.21.2")
+	tt.expectMachineTemplate("test-cluster-md-0-original", "public.ecr.aws/eks-anywhere/kind-node:v1.21.2")
+	tt.expectKubeadmConfigTemplate("test-cluster-md-0-template-original", existingSpec)
+
+	objs, err := tt.reconciler.generateCAPIObjects(tt.ctx, tt.spec.Cluster, tt.spec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(machineTemplateNames(objs)).To(ConsistOf(
+		"test-cluster-control-plane-template-original",
+		"test-cluster-md-0-original",
+	))
+	tt.Expect(kubeadmConfigTemplateNames(objs)).To(ConsistOf("test-cluster-md-0-template-1"))
+
+	var md *unstructured.Unstructured
+	for _, o := range objs {
+		if o.GetObjectKind().GroupVersionKind().Kind == "MachineDeployment" {
+			md = o.(*unstructured.Unstructured)
+		}
+	}
+	tt.Expect(md).NotTo(BeNil())
+	configRef, _, _ := unstructured.NestedString(md.Object, "spec", "template", "spec", "bootstrap", "configRef", "name")
+	tt.Expect(configRef).To(Equal("test-cluster-md-0-template-1"))
+}
+
+func TestDockerRemoveOldWorkerNodeGroups(t *testing.T) {
+	tt := newDockerTest(t)
+	oldMD := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-md-1", Namespace: "eksa-system"},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &corev1.ObjectReference{APIVersion: kubeadmConfigTemplateAPIVersion, Kind: kubeadmConfigTemplateKind, Name: "test-cluster-md-1-template-1"},
+					},
+					InfrastructureRef: corev1.ObjectReference{APIVersion: dockerMachineTemplateAPIVersion, Kind: dockerMachineTemplateKind, Name: "test-cluster-md-1-1"},
+				},
+			},
+		},
+	}
+	currentMD := &clusterv1.MachineDeployment{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-md-0", Namespace: "eksa-system"}}
+	oldConfigTemplate := &kubeadmv1.KubeadmConfigTemplate{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-md-1-template-1", Namespace: "eksa-system"}}
+	oldMachineTemplate := &unstructured.Unstructured{}
+	oldMachineTemplate.SetAPIVersion(dockerMachineTemplateAPIVersion)
+	oldMachineTemplate.SetKind(dockerMachineTemplateKind)
+	oldMachineTemplate.SetName("test-cluster-md-1-1")
+	oldMachineTemplate.SetNamespace("eksa-system")
+	scheme := runtime.NewScheme()
+	tt.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	tt.Expect(kubeadmv1.AddToScheme(scheme)).To(Succeed())
+	tt.reconciler.client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(oldMD.DeepCopy(), currentMD.DeepCopy(), oldConfigTemplate, oldMachineTemplate).Build()
+	tt.fetcher.EXPECT().MachineDeployments(tt.ctx, tt.spec.Cluster).Return([]*clusterv1.MachineDeployment{currentMD, oldMD}, nil)
+
+	tt.Expect(tt.reconciler.removeOldWorkerNodeGroups(tt.ctx, tt.spec.Cluster, tt.spec)).To(Succeed())
+
+	tt.Expect(tt.reconciler.client.Get(tt.ctx, client.ObjectKeyFromObject(currentMD), &clusterv1.MachineDeployment{})).To(Succeed())
+	tt.Expect(apierrors.IsNotFound(tt.reconciler.client.Get(tt.ctx, client.ObjectKeyFromObject(oldMD), &clusterv1.MachineDeployment{}))).To(BeTrue())
+	tt.Expect(apierrors.IsNotFound(tt.reconciler.client.Get(tt.ctx, client.ObjectKeyFromObject(oldConfigTemplate), &kubeadmv1.KubeadmConfigTemplate{}))).To(BeTrue())
+	tt.Expect(apierrors.IsNotFound(tt.reconciler.client.Get(tt.ctx, client.ObjectKeyFromObject(oldMachineTemplate), oldMachineTemplate.DeepCopy()))).To(BeTrue())
+}
@@ -2,17 +2,341 @@ package clusters
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	kubeadmv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/controllers/controllers/reconciler"
+	"github.com/aws/eks-anywhere/controllers/controllers/resource"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/clusterautoscaler"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
 )
 
 type ProviderClusterReconciler interface {
 	Reconcile(ctx context.Context, cluster *anywherev1.Cluster) (reconciler.Result, error)
 }
 
-func BuildProviderReconciler(datacenterKind string) (ProviderClusterReconciler, error) {
-	return nil, nil
+func BuildProviderReconciler(datacenterKind string, client client.Client, log logr.Logger) (ProviderClusterReconciler, error) {
+	switch datacenterKind {
+	case anywherev1.VSphereDatacenterKind:
+		return NewVSphereReconciler(client, log), nil
+	case anywherev1.DockerDatacenterKind:
+		return NewDockerReconciler(client, log), nil
+	}
+	return nil, fmt.Errorf("invalid data center type %s", datacenterKind)
+}
+
+type providerClusterReconciler struct {
+	client  client.Client
+	log     logr.Logger
+	fetcher resource.ResourceFetcher
+	now     types.NowFunc
+}
+
+func newProviderClusterReconciler(client client.Client, log logr.Logger) *providerClusterReconciler {
+	return &providerClusterReconciler{
+		client:  client,
+		log:     log,
+		fetcher: resource.NewCAPIResourceFetcher(client, log),
+		now:     time.Now,
+	}
+}
+
+// buildSpec builds the full cluster spec for an eks-a Cluster, including its bundle and identity providers.
+func (r *providerClusterReconciler) buildSpec(ctx context.Context, cs *anywherev1.Cluster) (*cluster.Spec, error) {
+	spec, err := r.fetcher.FetchAppliedSpec(ctx, cs)
+	if err != nil {
+		return nil, fmt.Errorf("failed building cluster spec: %v", err)
+	}
+
+	for _, identityProvider := range cs.Spec.IdentityProviderRefs {
+		switch identityProvider.Kind {
+		case anywherev1.AWSIamConfigKind:
+			awsIamConfig, err := r.fetcher.AWSIamConfig(ctx, &identityProvider, cs.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			spec.AWSIamConfig = awsIamConfig
+		case anywherev1.OIDCConfigKind:
+			oidcConfig, err := r.fetcher.OIDCConfig(ctx, &identityProvider, cs.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			spec.OIDCConfig = oidcConfig
+		}
+	}
+
+	return spec, nil
+}
+
+// kubeadmConfigTemplateKind and kubeadmConfigTemplateAPIVersion identify the bootstrap config templates of the MachineDeployments
+const (
+	kubeadmConfigTemplateKind       = "KubeadmConfigTemplate"
+	kubeadmConfigTemplateAPIVersion = "bootstrap.cluster.x-k8s.io/v1alpha3"
+)
+
+// generateCAPIObjectsForWorkers renders the CAPI templates like generateCAPIObjects, setting the KubeadmConfigTemplate of each
+// worker node group. Existing groups keep the template referenced by their MachineDeployment, unless its kubelet args, which
+// include the node labels, or taints change. A KubeadmConfigTemplate only applies to new machines, so those groups get a new
+// template for CAPI to roll out the nodes, like the CLI does on upgrade.
+func (r *providerClusterReconciler) generateCAPIObjectsForWorkers(ctx context.Context, builder providers.TemplateBuilder, spec *cluster.Spec, existingMDs map[string]*clusterv1.MachineDeployment, cpOpt, workersOpt providers.BuildMapOption) ([]client.Object, error) {
+	kubeadmConfigTemplateNames := make(map[string]string, len(spec.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range spec.Spec.WorkerNodeGroupConfigurations {
+		kubeadmConfigTemplateNames[workerNodeGroup.Name] = clusterapi.KubeadmConfigTemplateName(spec.Name, workerNodeGroup, existingMDs[workerNodeGroup.Name])
+	}
+	opt := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		workersOpt(values)
+		values["workloadkubeadmconfigTemplateName"] = kubeadmConfigTemplateNames[workerNodeGroupName]
+	})
+
+	objs, err := generateCAPIObjects(builder, spec, cpOpt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	for _, workerNodeGroup := range spec.Spec.WorkerNodeGroupConfigurations {
+		if _, ok := existingMDs[workerNodeGroup.Name]; !ok {
+			continue
+		}
+		needsNew, err := r.needsNewKubeadmConfigTemplate(ctx, workerNodeGroup.Name, kubeadmConfigTemplateNames[workerNodeGroup.Name], objs)
+		if err != nil {
+			return nil, err
+		}
+		if needsNew {
+			kubeadmConfigTemplateNames[workerNodeGroup.Name] = builder.KubeadmConfigTemplateName(spec.Name, workerNodeGroup.Name)
+			changed = true
+		}
+	}
+	if !changed {
+		return objs, nil
+	}
+
+	return generateCAPIObjects(builder, spec, cpOpt, opt)
+}
+
+// needsNewKubeadmConfigTemplate compares the rendered KubeadmConfigTemplate of a worker node group with the existing one
+func (r *providerClusterReconciler) needsNewKubeadmConfigTemplate(ctx context.Context, workerNodeGroupName, templateName string, objs []client.Object) (bool, error) {
+	var rendered *unstructured.Unstructured
+	for _, o := range objs {
+		if u, ok := o.(*unstructured.Unstructured); ok && u.GetKind() == kubeadmConfigTemplateKind && u.GetName() == templateName {
+			rendered = u
+		}
+	}
+	if rendered == nil {
+		return false, fmt.Errorf("KubeadmConfigTemplate %s not found in the CAPI objects", templateName)
+	}
+
+	existing, err := r.fetcher.Fetch(ctx, templateName, constants.EksaSystemNamespace, kubeadmConfigTemplateKind, kubeadmConfigTemplateAPIVersion)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	existingWorkerNodeGroup, err := workerNodeGroupFromKubeadmConfigTemplate(workerNodeGroupName, existing)
+	if err != nil {
+		return false, err
+	}
+	renderedWorkerNodeGroup, err := workerNodeGroupFromKubeadmConfigTemplate(workerNodeGroupName, rendered)
+	if err != nil {
+		return false, err
+	}
+
+	existingCluster := &anywherev1.Cluster{
+		Spec: anywherev1.ClusterSpec{WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{existingWorkerNodeGroup}},
+	}
+	return clusterapi.NeedsNewKubeadmConfigTemplate(existingCluster, renderedWorkerNodeGroup), nil
+}
+
+// workerNodeGroupFromKubeadmConfigTemplate maps the kubelet args and taints of a KubeadmConfigTemplate to a worker node group,
+// so the templates are compared with the same checks the CLI uses for the cluster specs. The node labels are part of the kubelet args.
+func workerNodeGroupFromKubeadmConfigTemplate(name string, template *unstructured.Unstructured) (anywherev1.WorkerNodeGroupConfiguration, error) {
+	kubeadmConfigTemplate := &kubeadmv1.KubeadmConfigTemplate{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template.Object, kubeadmConfigTemplate); err != nil {
+		return anywherev1.WorkerNodeGroupConfiguration{}, fmt.Errorf("failed reading KubeadmConfigTemplate %s: %v", template.GetName(), err)
+	}
+
+	workerNodeGroup := anywherev1.WorkerNodeGroupConfiguration{Name: name}
+	if joinConfiguration := kubeadmConfigTemplate.Spec.Template.Spec.JoinConfiguration; joinConfiguration != nil {
+		workerNodeGroup.KubeletConfiguration = joinConfiguration.NodeRegistration.KubeletExtraArgs
+		workerNodeGroup.Taints = joinConfiguration.NodeRegistration.Taints
+	}
+	return workerNodeGroup, nil
+}
+
+// generateCAPIObjects renders the control plane and workers CAPI templates for a cluster spec.
+func generateCAPIObjects(builder providers.TemplateBuilder, spec *cluster.Spec, cpOpt, workersOpt providers.BuildMapOption) ([]client.Object, error) {
+	cp, err := builder.GenerateCAPISpecControlPlane(spec, cpOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed generating control plane CAPI spec: %v", err)
+	}
+
+	md, err := builder.GenerateCAPISpecWorkers(spec, workersOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed generating workers CAPI spec: %v", err)
+	}
+
+	return reconciler.YamlToClientObjects(templater.AppendYamlResources(cp, md))
+}
+
+// existingTemplateNames returns the names of the machine templates currently referenced by the control plane
+// and the etcd cluster. Names are empty for objects that don't exist yet.
+func (r *providerClusterReconciler) existingTemplateNames(ctx context.Context, cs *anywherev1.Cluster) (cpName, etcdName string, err error) {
+	kcp, err := r.fetcher.ControlPlane(ctx, cs)
+	if err == nil {
+		cpName = kcp.Spec.InfrastructureTemplate.Name
+	} else if !apierrors.IsNotFound(err) {
+		return "", "", err
+	}
+
+	if cs.Spec.ExternalEtcdConfiguration != nil {
+		etcd, err := r.fetcher.Etcd(ctx, cs)
+		if err == nil {
+			etcdName = etcd.Spec.InfrastructureTemplate.Name
+		} else if !apierrors.IsNotFound(err) {
+			return "", "", err
+		}
+	}
+
+	return cpName, etcdName, nil
+}
+
+// existingMachineDeployments returns the current MachineDeployment of each worker node group, keyed by group name.
+// Worker node groups that haven't been created yet are not included.
+func (r *providerClusterReconciler) existingMachineDeployments(ctx context.Context, cs *anywherev1.Cluster, spec *cluster.Spec) (map[string]*clusterv1.MachineDeployment, error) {
+	mds, err := r.fetcher.MachineDeployments(ctx, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	machineDeployments := make([]clusterv1.MachineDeployment, 0, len(mds))
+	for _, md := range mds {
+		machineDeployments = append(machineDeployments, *md)
+	}
+
+	return clusterapi.MachineDeploymentsByWorkerNodeGroup(spec.Name, spec.Spec.WorkerNodeGroupConfigurations, machineDeployments), nil
+}
+
+// removeOldWorkerNodeGroups deletes the MachineDeployments of the worker node groups that are not in the cluster spec anymore,
+// together with their machine and KubeadmConfig templates
+func (r *providerClusterReconciler) removeOldWorkerNodeGroups(ctx context.Context, cs *anywherev1.Cluster, spec *cluster.Spec) error {
+	mds, err := r.fetcher.MachineDeployments(ctx, cs)
+	if err != nil {
+		return err
+	}
+
+	mdNames := make(map[string]bool, len(spec.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range spec.Spec.WorkerNodeGroupConfigurations {
+		mdNames[clusterapi.MachineDeploymentName(spec.Name, workerNodeGroup)] = true
+	}
+
+	for _, md := range mds {
+		if mdNames[md.Name] {
+			continue
+		}
+
+		r.log.Info("Deleting old worker node group", "machineDeployment", md.Name)
+		if err = r.client.Delete(ctx, md); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed deleting MachineDeployment %s: %v", md.Name, err)
+		}
+
+		refs := []corev1.ObjectReference{md.Spec.Template.Spec.InfrastructureRef}
+		if md.Spec.Template.Spec.Bootstrap.ConfigRef != nil {
+			refs = append(refs, *md.Spec.Template.Spec.Bootstrap.ConfigRef)
+		}
+		for _, ref := range refs {
+			if ref.Name == "" {
+				continue
+			}
+			template := &unstructured.Unstructured{}
+			template.SetAPIVersion(ref.APIVersion)
+			template.SetKind(ref.Kind)
+			template.SetName(ref.Name)
+			template.SetNamespace(md.Namespace)
+			if err = r.client.Delete(ctx, template); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed deleting %s %s: %v", ref.Kind, ref.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// existingWorkerTemplateName returns the name of the machine template referenced by a worker node group's
+// MachineDeployment, or an empty string if the MachineDeployment doesn't exist yet.
+func existingWorkerTemplateName(existingMDs map[string]*clusterv1.MachineDeployment, workerNodeGroupName string) string {
+	md, ok := existingMDs[workerNodeGroupName]
+	if !ok {
+		return ""
+	}
+	return md.Spec.Template.Spec.InfrastructureRef.Name
+}
+
+// reconcileClusterAutoscaler deploys the cluster-autoscaler for clusters with autoscaled worker node groups
+func (r *providerClusterReconciler) reconcileClusterAutoscaler(ctx context.Context, spec *cluster.Spec) error {
+	if !clusterautoscaler.Enabled(spec) {
+		return nil
+	}
+
+	manifest, err := clusterautoscaler.GenerateManifest(spec)
+	if err != nil {
+		return err
+	}
+
+	r.log.Info("Applying cluster autoscaler", "cluster", spec.Name)
+	return reconciler.ReconcileYaml(ctx, r.client, manifest)
+}
+
+// markEtcdUpgrading annotates the etcd cluster as upgrading so KCP doesn't start rolling out the control plane
+// until the new etcd machines are available. The etcdadm controller removes the annotation once the upgrade is complete.
+func (r *providerClusterReconciler) markEtcdUpgrading(ctx context.Context, cs *anywherev1.Cluster) error {
+	etcd, err := r.fetcher.Etcd(ctx, cs)
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(etcd.DeepCopy())
+	annotations := etcd.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[etcdv1.UpgradeInProgressAnnotation] = "true"
+	etcd.SetAnnotations(annotations)
+
+	return r.client.Patch(ctx, etcd, patch)
 }
 
-type providerClusterReconciler struct{}
+// machineTemplateName returns the name of the machine template in use if it can be reused.
+// Otherwise, it returns a new name so a new template is created and CAPI rolls out new machines.
+func machineTemplateName(existingName string, reusable func(name string) (bool, error), newName func() string) (string, error) {
+	if existingName == "" {
+		return newName(), nil
+	}
+
+	reuse, err := reusable(existingName)
+	if err != nil {
+		return "", err
+	}
+	if !reuse {
+		return newName(), nil
+	}
+
+	return existingName, nil
+}
@@ -0,0 +1,44 @@
+package clusters_test
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/clusters"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestBuildProviderReconciler(t *testing.T) {
+	tests := []struct {
+		name           string
+		datacenterKind string
+		want           clusters.ProviderClusterReconciler
+	}{
+		{
+			name:           "vsphere",
+			datacenterKind: anywherev1.VSphereDatacenterKind,
+			want:           &clusters.VSphereReconciler{},
+		},
+		{
+			name:           "docker",
+			datacenterKind: anywherev1.DockerDatacenterKind,
+			want:           &clusters.DockerReconciler{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := clusters.BuildProviderReconciler(tt.datacenterKind, nil, logr.Discard())
+			g.Expect(err).To(Succeed())
+			g.Expect(got).To(BeAssignableToTypeOf(tt.want))
+		})
+	}
+}
+
+func TestBuildProviderReconcilerUnsupportedKind(t *testing.T) {
+	g := NewWithT(t)
+	_, err := clusters.BuildProviderReconciler(anywherev1.TinkerbellDatacenterKind, nil, logr.Discard())
+	g.Expect(err).To(MatchError(ContainSubstring("invalid data center type")))
+}
@@ -0,0 +1,266 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	// CAPI's conditions utils work with the v1alpha4 condition types
+	clusterv1alpha4 "sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/resource"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const (
+	fluxKustomizationKind       = "Kustomization"
+	fluxKustomizationAPIVersion = "kustomize.toolkit.fluxcd.io/v1beta1"
+)
+
+// UpdateClusterStatus computes the status of an eks-a Cluster from the CAPI objects that back it:
+// the CAPI Cluster, KubeadmControlPlane, MachineDeployments and, for unstacked etcd, the EtcdadmCluster.
+// It also reports the state of the Flux Kustomization if the cluster is managed through GitOps.
+func UpdateClusterStatus(ctx context.Context, fetcher resource.ResourceFetcher, cs *anywherev1.Cluster) error {
+	capiCluster, err := fetcher.CAPICluster(ctx, cs)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	updateFailure(cs, capiCluster)
+
+	if err = updateControlPlaneStatus(ctx, fetcher, cs, capiCluster); err != nil {
+		return err
+	}
+
+	if err = updateWorkersStatus(ctx, fetcher, cs); err != nil {
+		return err
+	}
+
+	if err = updateEtcdStatus(ctx, fetcher, cs); err != nil {
+		return err
+	}
+
+	if err = updateGitOpsStatus(ctx, fetcher, cs); err != nil {
+		return err
+	}
+
+	conditions.SetSummary(cs,
+		conditions.WithConditions(
+			anywherev1.ControlPlaneReadyCondition,
+			anywherev1.WorkersReadyCondition,
+			anywherev1.EtcdReadyCondition,
+			anywherev1.CNIReadyCondition,
+			anywherev1.GitOpsSyncedCondition,
+		),
+	)
+
+	return nil
+}
+
+func updateFailure(cs *anywherev1.Cluster, capiCluster *clusterv1.Cluster) {
+	cs.Status.FailureReason = nil
+	cs.Status.FailureMessage = nil
+	if capiCluster == nil || capiCluster.Status.FailureReason == nil {
+		return
+	}
+
+	reason := string(*capiCluster.Status.FailureReason)
+	cs.Status.FailureReason = &reason
+	cs.Status.FailureMessage = capiCluster.Status.FailureMessage
+}
+
+func updateControlPlaneStatus(ctx context.Context, fetcher resource.ResourceFetcher, cs *anywherev1.Cluster, capiCluster *clusterv1.Cluster) error {
+	if capiCluster == nil || capiCluster.Spec.ControlPlaneRef == nil {
+		cs.Status.ControlPlane = nil
+		conditions.MarkFalse(cs, anywherev1.ControlPlaneReadyCondition, anywherev1.WaitingForControlPlaneReason, clusterv1alpha4.ConditionSeverityInfo, "CAPI cluster does not exist yet")
+		conditions.MarkFalse(cs, anywherev1.CNIReadyCondition, anywherev1.WaitingForNodesReadyReason, clusterv1alpha4.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	kcp, err := fetcher.ControlPlane(ctx, cs)
+	if apierrors.IsNotFound(err) {
+		cs.Status.ControlPlane = nil
+		conditions.MarkFalse(cs, anywherev1.ControlPlaneReadyCondition, anywherev1.WaitingForControlPlaneReason, clusterv1alpha4.ConditionSeverityInfo, "KubeadmControlPlane does not exist yet")
+		conditions.MarkFalse(cs, anywherev1.CNIReadyCondition, anywherev1.WaitingForNodesReadyReason, clusterv1alpha4.ConditionSeverityInfo, "")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	desired := int32(1)
+	if kcp.Spec.Replicas != nil {
+		desired = *kcp.Spec.Replicas
+	}
+	cs.Status.ControlPlane = &anywherev1.NodeGroupStatus{
+		Replicas:      desired,
+		ReadyReplicas: kcp.Status.ReadyReplicas,
+	}
+
+	if cs.Status.FailureReason == nil && kcp.Status.FailureReason != "" {
+		reason := string(kcp.Status.FailureReason)
+		cs.Status.FailureReason = &reason
+		cs.Status.FailureMessage = kcp.Status.FailureMessage
+	}
+
+	switch {
+	case !kcp.Status.Initialized:
+		conditions.MarkFalse(cs, anywherev1.ControlPlaneReadyCondition, anywherev1.WaitingForControlPlaneReason, clusterv1alpha4.ConditionSeverityInfo, "Control plane is not initialized yet")
+	case !nodesReady(desired, kcp.Status.Replicas, kcp.Status.UpdatedReplicas, kcp.Status.ReadyReplicas):
+		conditions.MarkFalse(cs, anywherev1.ControlPlaneReadyCondition, anywherev1.ControlPlaneScalingReason, clusterv1alpha4.ConditionSeverityInfo,
+			"%d of %d control plane nodes ready, %d up to date", kcp.Status.ReadyReplicas, desired, kcp.Status.UpdatedReplicas)
+	default:
+		conditions.MarkTrue(cs, anywherev1.ControlPlaneReadyCondition)
+	}
+
+	if kcp.Status.ReadyReplicas > 0 {
+		conditions.MarkTrue(cs, anywherev1.CNIReadyCondition)
+	} else {
+		conditions.MarkFalse(cs, anywherev1.CNIReadyCondition, anywherev1.WaitingForNodesReadyReason, clusterv1alpha4.ConditionSeverityInfo, "No control plane node is ready yet")
+	}
+
+	return nil
+}
+
+func updateWorkersStatus(ctx context.Context, fetcher resource.ResourceFetcher, cs *anywherev1.Cluster) error {
+	mds, err := fetcher.MachineDeployments(ctx, cs)
+	if err != nil {
+		return err
+	}
+
+	if len(mds) == 0 {
+		cs.Status.WorkerNodeGroups = nil
+		conditions.MarkFalse(cs, anywherev1.WorkersReadyCondition, anywherev1.WaitingForWorkersReason, clusterv1alpha4.ConditionSeverityInfo, "MachineDeployments do not exist yet")
+		return nil
+	}
+
+	groups := make([]anywherev1.WorkerNodeGroupStatus, 0, len(mds))
+	notReady := []string{}
+	for _, md := range mds {
+		desired := int32(1)
+		if md.Spec.Replicas != nil {
+			desired = *md.Spec.Replicas
+		}
+		groups = append(groups, anywherev1.WorkerNodeGroupStatus{
+			Name: md.Name,
+			NodeGroupStatus: anywherev1.NodeGroupStatus{
+				Replicas:      desired,
+				ReadyReplicas: md.Status.ReadyReplicas,
+			},
+		})
+
+		if !nodesReady(desired, md.Status.Replicas, md.Status.UpdatedReplicas, md.Status.ReadyReplicas) {
+			notReady = append(notReady, fmt.Sprintf("%s (%d of %d ready)", md.Name, md.Status.ReadyReplicas, desired))
+		}
+	}
+	cs.Status.WorkerNodeGroups = groups
+
+	if len(notReady) > 0 {
+		conditions.MarkFalse(cs, anywherev1.WorkersReadyCondition, anywherev1.WorkersScalingReason, clusterv1alpha4.ConditionSeverityInfo,
+			"Worker node groups not ready: %s", strings.Join(notReady, ", "))
+	} else {
+		conditions.MarkTrue(cs, anywherev1.WorkersReadyCondition)
+	}
+
+	return nil
+}
+
+func updateEtcdStatus(ctx context.Context, fetcher resource.ResourceFetcher, cs *anywherev1.Cluster) error {
+	if cs.Spec.ExternalEtcdConfiguration == nil {
+		conditions.Delete(cs, anywherev1.EtcdReadyCondition)
+		return nil
+	}
+
+	etcd, err := fetcher.Etcd(ctx, cs)
+	if apierrors.IsNotFound(err) {
+		conditions.MarkFalse(cs, anywherev1.EtcdReadyCondition, anywherev1.WaitingForEtcdReason, clusterv1alpha4.ConditionSeverityInfo, "EtcdadmCluster does not exist yet")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	desired := int32(1)
+	if etcd.Spec.Replicas != nil {
+		desired = *etcd.Spec.Replicas
+	}
+
+	switch {
+	case !etcd.Status.Ready:
+		conditions.MarkFalse(cs, anywherev1.EtcdReadyCondition, anywherev1.WaitingForEtcdReason, clusterv1alpha4.ConditionSeverityInfo, "Etcd cluster is not ready yet")
+	case etcd.Status.ReadyReplicas != desired:
+		conditions.MarkFalse(cs, anywherev1.EtcdReadyCondition, anywherev1.EtcdScalingReason, clusterv1alpha4.ConditionSeverityInfo,
+			"%d of %d etcd members ready", etcd.Status.ReadyReplicas, desired)
+	default:
+		conditions.MarkTrue(cs, anywherev1.EtcdReadyCondition)
+	}
+
+	return nil
+}
+
+func updateGitOpsStatus(ctx context.Context, fetcher resource.ResourceFetcher, cs *anywherev1.Cluster) error {
+	if cs.Spec.GitOpsRef == nil {
+		conditions.Delete(cs, anywherev1.GitOpsSyncedCondition)
+		return nil
+	}
+
+	gitOpsConfig := &anywherev1.GitOpsConfig{}
+	err := fetcher.FetchObjectByName(ctx, cs.Spec.GitOpsRef.Name, cs.Namespace, gitOpsConfig)
+	if apierrors.IsNotFound(err) {
+		conditions.MarkFalse(cs, anywherev1.GitOpsSyncedCondition, anywherev1.GitOpsConfigNotFoundReason, clusterv1alpha4.ConditionSeverityWarning,
+			"GitOpsConfig %s not found", cs.Spec.GitOpsRef.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// flux bootstrap names the Kustomization after its namespace
+	namespace := gitOpsConfig.Spec.Flux.SystemNamespace()
+	if namespace == "" {
+		namespace = cluster.FluxDefaultNamespace
+	}
+
+	kustomization, err := fetcher.Fetch(ctx, namespace, namespace, fluxKustomizationKind, fluxKustomizationAPIVersion)
+	if apierrors.IsNotFound(err) {
+		conditions.MarkFalse(cs, anywherev1.GitOpsSyncedCondition, anywherev1.GitOpsKustomizationNotFoundReason, clusterv1alpha4.ConditionSeverityWarning,
+			"Flux Kustomization %s/%s not found", namespace, namespace)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ready, message := fluxReadyCondition(kustomization)
+	if ready {
+		conditions.MarkTrue(cs, anywherev1.GitOpsSyncedCondition)
+	} else {
+		conditions.MarkFalse(cs, anywherev1.GitOpsSyncedCondition, anywherev1.GitOpsNotSyncedReason, clusterv1alpha4.ConditionSeverityWarning, "%s", message)
+	}
+
+	return nil
+}
+
+// fluxReadyCondition returns whether a Flux object has a True Ready condition
+// and the message reported with it.
+func fluxReadyCondition(obj *unstructured.Unstructured) (bool, string) {
+	fluxConditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range fluxConditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != string(clusterv1alpha4.ReadyCondition) {
+			continue
+		}
+		message, _ := condition["message"].(string)
+		return condition["status"] == "True", message
+	}
+
+	return false, "Flux has not reported a Ready condition yet"
+}
+
+func nodesReady(desired, replicas, updated, ready int32) bool {
+	return replicas == desired && updated == desired && ready == desired
+}
@@ -0,0 +1,232 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1alpha3"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/clusters"
+	"github.com/aws/eks-anywhere/controllers/controllers/resource/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+type statusTest struct {
+	*WithT
+	ctx     context.Context
+	fetcher *mocks.MockResourceFetcher
+	cluster *anywherev1.Cluster
+}
+
+func newStatusTest(t *testing.T) *statusTest {
+	ctrl := gomock.NewController(t)
+	return &statusTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		fetcher: mocks.NewMockResourceFetcher(ctrl),
+		cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "default",
+			},
+		},
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func capiCluster() *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "eksa-system"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: &corev1.ObjectReference{Name: "test-cluster", Namespace: "eksa-system"},
+		},
+	}
+}
+
+func readyKCP() *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{Replicas: int32Ptr(3)},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			Initialized:     true,
+			Ready:           true,
+			Replicas:        3,
+			UpdatedReplicas: 3,
+			ReadyReplicas:   3,
+		},
+	}
+}
+
+func machineDeployment(name string, desired, ready int32) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       clusterv1.MachineDeploymentSpec{Replicas: int32Ptr(desired)},
+		Status: clusterv1.MachineDeploymentStatus{
+			Replicas:        desired,
+			UpdatedReplicas: desired,
+			ReadyReplicas:   ready,
+		},
+	}
+}
+
+var notFound = apierrors.NewNotFound(schema.GroupResource{}, "")
+
+func TestUpdateClusterStatusAllReady(t *testing.T) {
+	tt := newStatusTest(t)
+	tt.fetcher.EXPECT().CAPICluster(tt.ctx, tt.cluster).Return(capiCluster(), nil)
+	tt.fetcher.EXPECT().ControlPlane(tt.ctx, tt.cluster).Return(readyKCP(), nil)
+	tt.fetcher.EXPECT().MachineDeployments(tt.ctx, tt.cluster).Return([]*clusterv1.MachineDeployment{
+		machineDeployment("test-cluster-md-0", 2, 2),
+	}, nil)
+
+	tt.Expect(clusters.UpdateClusterStatus(tt.ctx, tt.fetcher, tt.cluster)).To(Succeed())
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.ControlPlaneReadyCondition)).To(BeTrue())
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.WorkersReadyCondition)).To(BeTrue())
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.CNIReadyCondition)).To(BeTrue())
+	tt.Expect(conditions.Has(tt.cluster, anywherev1.EtcdReadyCondition)).To(BeFalse())
+	tt.Expect(conditions.Has(tt.cluster, anywherev1.GitOpsSyncedCondition)).To(BeFalse())
+	tt.Expect(conditions.IsTrue(tt.cluster, "Ready")).To(BeTrue())
+	tt.Expect(tt.cluster.Status.ControlPlane).To(Equal(&anywherev1.NodeGroupStatus{Replicas: 3, ReadyReplicas: 3}))
+	tt.Expect(tt.cluster.Status.WorkerNodeGroups).To(ConsistOf(anywherev1.WorkerNodeGroupStatus{
+		Name:            "test-cluster-md-0",
+		NodeGroupStatus: anywherev1.NodeGroupStatus{Replicas: 2, ReadyReplicas: 2},
+	}))
+	tt.Expect(tt.cluster.Status.FailureReason).To(BeNil())
+}
+
+func TestUpdateClusterStatusNoCAPICluster(t *testing.T) {
+	tt := newStatusTest(t)
+	tt.fetcher.EXPECT().CAPICluster(tt.ctx, tt.cluster).Return(nil, notFound)
+	tt.fetcher.EXPECT().MachineDeployments(tt.ctx, tt.cluster).Return(nil, nil)
+
+	tt.Expect(clusters.UpdateClusterStatus(tt.ctx, tt.fetcher, tt.cluster)).To(Succeed())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.ControlPlaneReadyCondition)).To(Equal(anywherev1.WaitingForControlPlaneReason))
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.WorkersReadyCondition)).To(Equal(anywherev1.WaitingForWorkersReason))
+	tt.Expect(conditions.IsFalse(tt.cluster, anywherev1.CNIReadyCondition)).To(BeTrue())
+	tt.Expect(conditions.IsFalse(tt.cluster, "Ready")).To(BeTrue())
+	tt.Expect(tt.cluster.Status.ControlPlane).To(BeNil())
+}
+
+func TestUpdateClusterStatusWorkersScaling(t *testing.T) {
+	tt := newStatusTest(t)
+	tt.fetcher.EXPECT().CAPICluster(tt.ctx, tt.cluster).Return(capiCluster(), nil)
+	tt.fetcher.EXPECT().ControlPlane(tt.ctx, tt.cluster).Return(readyKCP(), nil)
+	tt.fetcher.EXPECT().MachineDeployments(tt.ctx, tt.cluster).Return([]*clusterv1.MachineDeployment{
+		machineDeployment("test-cluster-md-0", 2, 2),
+		machineDeployment("test-cluster-md-1", 3, 1),
+	}, nil)
+
+	tt.Expect(clusters.UpdateClusterStatus(tt.ctx, tt.fetcher, tt.cluster)).To(Succeed())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.WorkersReadyCondition)).To(Equal(anywherev1.WorkersScalingReason))
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.WorkersReadyCondition)).To(ContainSubstring("test-cluster-md-1 (1 of 3 ready)"))
+	tt.Expect(tt.cluster.Status.WorkerNodeGroups).To(HaveLen(2))
+}
+
+func TestUpdateClusterStatusControlPlaneFailure(t *testing.T) {
+	tt := newStatusTest(t)
+	kcp := readyKCP()
+	kcp.Status.ReadyReplicas = 0
+	kcp.Status.FailureReason = "InvalidConfiguration"
+	message := "invalid kubeadm config"
+	kcp.Status.FailureMessage = &message
+	tt.fetcher.EXPECT().CAPICluster(tt.ctx, tt.cluster).Return(capiCluster(), nil)
+	tt.fetcher.EXPECT().ControlPlane(tt.ctx, tt.cluster).Return(kcp, nil)
+	tt.fetcher.EXPECT().MachineDeployments(tt.ctx, tt.cluster).Return(nil, nil)
+
+	tt.Expect(clusters.UpdateClusterStatus(tt.ctx, tt.fetcher, tt.cluster)).To(Succeed())
+	tt.Expect(*tt.cluster.Status.FailureReason).To(Equal("InvalidConfiguration"))
+	tt.Expect(tt.cluster.Status.FailureMessage).To(Equal(&message))
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.ControlPlaneReadyCondition)).To(Equal(anywherev1.ControlPlaneScalingReason))
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.CNIReadyCondition)).To(Equal(anywherev1.WaitingForNodesReadyReason))
+}
+
+func TestUpdateClusterStatusExternalEtcd(t *testing.T) {
+	tt := newStatusTest(t)
+	tt.cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+	etcd := &etcdv1.EtcdadmCluster{
+		Spec:   etcdv1.EtcdadmClusterSpec{Replicas: int32Ptr(3)},
+		Status: etcdv1.EtcdadmClusterStatus{Ready: true, ReadyReplicas: 2},
+	}
+	tt.fetcher.EXPECT().CAPICluster(tt.ctx, tt.cluster).Return(capiCluster(), nil)
+	tt.fetcher.EXPECT().ControlPlane(tt.ctx, tt.cluster).Return(readyKCP(), nil)
+	tt.fetcher.EXPECT().MachineDeployments(tt.ctx, tt.cluster).Return(nil, nil)
+	tt.fetcher.EXPECT().Etcd(tt.ctx, tt.cluster).Return(etcd, nil)
+
+	tt.Expect(clusters.UpdateClusterStatus(tt.ctx, tt.fetcher, tt.cluster)).To(Succeed())
+	tt.Expect(conditions.GetReason(tt.cluster, anywherev1.EtcdReadyCondition)).To(Equal(anywherev1.EtcdScalingReason))
+	tt.Expect(conditions.GetMessage(tt.cluster, anywherev1.EtcdReadyCondition)).To(Equal("2 of 3 etcd members ready"))
+}
+
+func TestUpdateClusterStatusGitOps(t *testing.T) {
+	tests := []struct {
+		name          string
+		kustomization *unstructured.Unstructured
+		fetchErr      error
+		wantTrue      bool
+		wantReason    string
+	}{
+		{
+			name: "kustomization ready",
+			kustomization: &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "True", "message": "Applied revision: main/abc"},
+					},
+				},
+			}},
+			wantTrue: true,
+		},
+		{
+			name: "kustomization not ready",
+			kustomization: &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "False", "message": "validation failed"},
+					},
+				},
+			}},
+			wantReason: anywherev1.GitOpsNotSyncedReason,
+		},
+		{
+			name:       "kustomization not found",
+			fetchErr:   notFound,
+			wantReason: anywherev1.GitOpsKustomizationNotFoundReason,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newStatusTest(t)
+			tt.cluster.Spec.GitOpsRef = &anywherev1.Ref{Kind: anywherev1.GitOpsConfigKind, Name: "test-gitops"}
+			tt.fetcher.EXPECT().CAPICluster(tt.ctx, tt.cluster).Return(capiCluster(), nil)
+			tt.fetcher.EXPECT().ControlPlane(tt.ctx, tt.cluster).Return(readyKCP(), nil)
+			tt.fetcher.EXPECT().MachineDeployments(tt.ctx, tt.cluster).Return(nil, nil)
+			tt.fetcher.EXPECT().FetchObjectByName(tt.ctx, "test-gitops", "default", gomock.AssignableToTypeOf(&anywherev1.GitOpsConfig{})).DoAndReturn(
+				func(_ context.Context, _, _ string, obj client.Object) error {
+					obj.(*anywherev1.GitOpsConfig).Spec.Flux.Github.FluxSystemNamespace = "custom-flux"
+					return nil
+				},
+			)
+			tt.fetcher.EXPECT().Fetch(tt.ctx, "custom-flux", "custom-flux", "Kustomization", "kustomize.toolkit.fluxcd.io/v1beta1").Return(tc.kustomization, tc.fetchErr)
+
+			tt.Expect(clusters.UpdateClusterStatus(tt.ctx, tt.fetcher, tt.cluster)).To(Succeed())
+			if tc.wantTrue {
+				tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.GitOpsSyncedCondition)).To(BeTrue())
+			} else {
+				tt.Expect(conditions.GetReason(tt.cluster, anywherev1.GitOpsSyncedCondition)).To(Equal(tc.wantReason))
+			}
+		})
+	}
+}
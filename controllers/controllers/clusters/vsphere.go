@@ -2,19 +2,235 @@ package clusters
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/controllers/controllers/reconciler"
+	"github.com/aws/eks-anywhere/controllers/controllers/resource"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 )
 
+// invalidDatacenterRequeue is how long to wait for the VSphereDatacenterConfig to be validated
+const invalidDatacenterRequeue = 10 * time.Second
+
 type VSphereReconciler struct {
 	*providerClusterReconciler
 }
 
-func NewVSphereReconciler() *VSphereReconciler {
-	return &VSphereReconciler{providerClusterReconciler: &providerClusterReconciler{}}
+func NewVSphereReconciler(client client.Client, log logr.Logger) *VSphereReconciler {
+	return &VSphereReconciler{providerClusterReconciler: newProviderClusterReconciler(client, log)}
 }
 
-func (v *VSphereReconciler) Reconcile(ctx context.Context, cluster *anywherev1.Cluster) (reconciler.Result, error) {
+type vsphereMachineConfigs struct {
+	controlPlane *anywherev1.VSphereMachineConfig
+	// workers holds the machine config of each worker node group, keyed by machine config name
+	workers map[string]*anywherev1.VSphereMachineConfig
+	etcd    *anywherev1.VSphereMachineConfig
+}
+
+func (v *VSphereReconciler) Reconcile(ctx context.Context, cs *anywherev1.Cluster) (reconciler.Result, error) {
+	vdc := &anywherev1.VSphereDatacenterConfig{}
+	if err := v.fetcher.FetchObjectByName(ctx, cs.Spec.DatacenterRef.Name, cs.Namespace, vdc); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	// The VSphereDatacenterConfig controller validates the vCenter configuration, wait for it before creating any machines
+	if !vdc.Status.SpecValid {
+		v.log.Info("VSphereDatacenterConfig is not valid yet, requeuing", "datacenterConfig", vdc.Name)
+		return requeueAfter(invalidDatacenterRequeue), nil
+	}
+
+	machineConfigs, err := v.fetchMachineConfigs(ctx, cs)
+	if err != nil {
+		return reconciler.Result{}, err
+	}
+
+	if err = v.setupCredentialsEnv(ctx); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	spec, err := v.buildSpec(ctx, cs)
+	if err != nil {
+		return reconciler.Result{}, err
+	}
+
+	objs, err := v.generateCAPIObjects(ctx, cs, spec, vdc, machineConfigs)
+	if err != nil {
+		return reconciler.Result{}, err
+	}
+
+	v.log.Info("Applying CAPI objects", "cluster", cs.Name, "objects", len(objs))
+	if err = reconciler.ReconcileObjects(ctx, v.client, objs); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	if err = v.removeOldWorkerNodeGroups(ctx, cs, spec); err != nil {
+		return reconciler.Result{}, err
+	}
+
+	if err = v.reconcileClusterAutoscaler(ctx, spec); err != nil {
+		return reconciler.Result{}, err
+	}
+
 	return reconciler.Result{}, nil
 }
+
+func (v *VSphereReconciler) fetchMachineConfigs(ctx context.Context, cs *anywherev1.Cluster) (*vsphereMachineConfigs, error) {
+	configs := &vsphereMachineConfigs{
+		controlPlane: &anywherev1.VSphereMachineConfig{},
+		workers:      map[string]*anywherev1.VSphereMachineConfig{},
+		etcd:         &anywherev1.VSphereMachineConfig{},
+	}
+
+	if err := v.fetcher.FetchObjectByName(ctx, cs.Spec.ControlPlaneConfiguration.MachineGroupRef.Name, cs.Namespace, configs.controlPlane); err != nil {
+		return nil, err
+	}
+
+	for _, workerNodeGroup := range cs.Spec.WorkerNodeGroupConfigurations {
+		name := workerNodeGroup.MachineGroupRef.Name
+		if _, ok := configs.workers[name]; ok {
+			continue
+		}
+		workers := &anywherev1.VSphereMachineConfig{}
+		if err := v.fetcher.FetchObjectByName(ctx, name, cs.Namespace, workers); err != nil {
+			return nil, err
+		}
+		configs.workers[name] = workers
+	}
+
+	if cs.Spec.ExternalEtcdConfiguration != nil {
+		if err := v.fetcher.FetchObjectByName(ctx, cs.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name, cs.Namespace, configs.etcd); err != nil {
+			return nil, err
+		}
+	}
+
+	return configs, nil
+}
+
+// setupCredentialsEnv makes the vSphere credentials available to the template builder,
+// which renders them in the secret used by the CAPV and CPI controllers.
+func (v *VSphereReconciler) setupCredentialsEnv(ctx context.Context) error {
+	secret := &apiv1.Secret{}
+	if err := v.fetcher.FetchObjectByName(ctx, vsphere.CredentialsObjectName, constants.EksaSystemNamespace, secret); err != nil {
+		return fmt.Errorf("failed getting vsphere credentials secret: %v", err)
+	}
+
+	if err := os.Setenv(vsphere.EksavSphereUsernameKey, string(secret.Data["username"])); err != nil {
+		return fmt.Errorf("failed setting env %s: %v", vsphere.EksavSphereUsernameKey, err)
+	}
+
+	if err := os.Setenv(vsphere.EksavSpherePasswordKey, string(secret.Data["password"])); err != nil {
+		return fmt.Errorf("failed setting env %s: %v", vsphere.EksavSpherePasswordKey, err)
+	}
+
+	return nil
+}
+
+func (v *VSphereReconciler) generateCAPIObjects(ctx context.Context, cs *anywherev1.Cluster, spec *cluster.Spec, vdc *anywherev1.VSphereDatacenterConfig, machineConfigs *vsphereMachineConfigs) ([]client.Object, error) {
+	workerNodeGroupMachineSpecs := make(map[string]*anywherev1.VSphereMachineConfigSpec, len(machineConfigs.workers))
+	for name, workers := range machineConfigs.workers {
+		workerNodeGroupMachineSpecs[name] = &workers.Spec
+	}
+	templateBuilder := vsphere.NewVsphereTemplateBuilder(&vdc.Spec, &machineConfigs.controlPlane.Spec, workerNodeGroupMachineSpecs, &machineConfigs.etcd.Spec, v.now)
+	clusterName := spec.ObjectMeta.Name
+
+	existingCP, existingEtcd, err := v.existingTemplateNames(ctx, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	existingMDs, err := v.existingMachineDeployments(ctx, cs, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlaneTemplateName, err := machineTemplateName(existingCP, v.templateMatches(ctx, vdc, machineConfigs.controlPlane), func() string { return templateBuilder.CPMachineTemplateName(clusterName) })
+	if err != nil {
+		return nil, err
+	}
+
+	workloadTemplateNames := make(map[string]string, len(spec.Spec.WorkerNodeGroupConfigurations))
+	workerReplicas := make(map[string]int, len(spec.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range spec.Spec.WorkerNodeGroupConfigurations {
+		workerNodeGroupName := workerNodeGroup.Name
+		workers := machineConfigs.workers[workerNodeGroup.MachineGroupRef.Name]
+		workloadTemplateNames[workerNodeGroupName], err = machineTemplateName(existingWorkerTemplateName(existingMDs, workerNodeGroupName), v.templateMatches(ctx, vdc, workers), func() string {
+			return templateBuilder.WorkerMachineTemplateName(clusterName, workerNodeGroupName)
+		})
+		if err != nil {
+			return nil, err
+		}
+		workerReplicas[workerNodeGroupName] = clusterapi.WorkerReplicas(workerNodeGroup, existingMDs[workerNodeGroupName])
+	}
+
+	var etcdTemplateName string
+	if cs.Spec.ExternalEtcdConfiguration != nil {
+		etcdTemplateName, err = machineTemplateName(existingEtcd, v.templateMatches(ctx, vdc, machineConfigs.etcd), func() string { return templateBuilder.EtcdMachineTemplateName(clusterName) })
+		if err != nil {
+			return nil, err
+		}
+		if existingEtcd != "" && etcdTemplateName != existingEtcd {
+			if err = v.markEtcdUpgrading(ctx, cs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cpOpt := func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = controlPlaneTemplateName
+		values["vsphereControlPlaneSshAuthorizedKey"] = sshAuthorizedKey(machineConfigs.controlPlane.Spec.Users)
+		values["vsphereEtcdSshAuthorizedKey"] = sshAuthorizedKey(machineConfigs.etcd.Spec.Users)
+		values["etcdTemplateName"] = etcdTemplateName
+	}
+	workersOpt := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		values["workloadTemplateName"] = workloadTemplateNames[workerNodeGroupName]
+		values["workerReplicas"] = workerReplicas[workerNodeGroupName]
+	})
+
+	return v.generateCAPIObjectsForWorkers(ctx, templateBuilder, spec, existingMDs, cpOpt, workersOpt)
+}
+
+// templateMatches returns a check for VSphereMachineTemplates that don't differ from the given configs
+// in any field that can't be updated in place.
+func (v *VSphereReconciler) templateMatches(ctx context.Context, vdc *anywherev1.VSphereDatacenterConfig, vmc *anywherev1.VSphereMachineConfig) func(name string) (bool, error) {
+	return func(name string) (bool, error) {
+		template := &vspherev1.VSphereMachineTemplate{}
+		err := v.fetcher.FetchObjectByName(ctx, name, constants.EksaSystemNamespace, template)
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		existingVdc, err := resource.MapMachineTemplateToVSphereDatacenterConfigSpec(template)
+		if err != nil {
+			return false, err
+		}
+		existingVmc, err := resource.MapMachineTemplateToVSphereMachineConfigSpec(template)
+		if err != nil {
+			return false, err
+		}
+
+		return !vsphere.AnyImmutableFieldChanged(existingVdc, vdc, existingVmc, vmc), nil
+	}
+}
+
+func sshAuthorizedKey(users []anywherev1.UserConfiguration) string {
+	if len(users) <= 0 || len(users[0].SshAuthorizedKeys) <= 0 {
+		return ""
+	}
+	return users[0].SshAuthorizedKeys[0]
+}
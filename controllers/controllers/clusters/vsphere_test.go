@@ -0,0 +1,112 @@
+package clusters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/controllers/controllers/resource/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func vsphereMachineTemplate() *vspherev1.VSphereMachineTemplate {
+	return &vspherev1.VSphereMachineTemplate{
+		Spec: vspherev1.VSphereMachineTemplateSpec{
+			Template: vspherev1.VSphereMachineTemplateResource{
+				Spec: vspherev1.VSphereMachineSpec{
+					VirtualMachineCloneSpec: vspherev1.VirtualMachineCloneSpec{
+						Template:     "/SDDC-Datacenter/vm/Templates/ubuntu-v1.21.2",
+						Datastore:    "/SDDC-Datacenter/datastore/WorkloadDatastore",
+						Folder:       "/SDDC-Datacenter/vm/capv",
+						ResourcePool: "*/Resources/Compute-ResourcePool",
+						NumCPUs:      2,
+						MemoryMiB:    8192,
+						DiskGiB:      25,
+						Network: vspherev1.NetworkSpec{
+							Devices: []vspherev1.NetworkDeviceSpec{{NetworkName: "/SDDC-Datacenter/network/sddc-cgw-network-1"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestVSphereTemplateMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		template *vspherev1.VSphereMachineTemplate
+		err      error
+		vmc      func(*anywherev1.VSphereMachineConfig)
+		want     bool
+	}{
+		{
+			name:     "no changes",
+			template: vsphereMachineTemplate(),
+			vmc:      func(*anywherev1.VSphereMachineConfig) {},
+			want:     true,
+		},
+		{
+			name:     "new vm template",
+			template: vsphereMachineTemplate(),
+			vmc: func(vmc *anywherev1.VSphereMachineConfig) {
+				vmc.Spec.Template = "/SDDC-Datacenter/vm/Templates/ubuntu-v1.22.6"
+			},
+			want: false,
+		},
+		{
+			name:     "more memory",
+			template: vsphereMachineTemplate(),
+			vmc: func(vmc *anywherev1.VSphereMachineConfig) {
+				vmc.Spec.MemoryMiB = 16384
+			},
+			want: false,
+		},
+		{
+			name: "template doesn't exist",
+			err:  apierrors.NewNotFound(schema.GroupResource{}, ""),
+			vmc:  func(*anywherev1.VSphereMachineConfig) {},
+			want: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			fetcher := mocks.NewMockResourceFetcher(gomock.NewController(t))
+			r := &VSphereReconciler{providerClusterReconciler: &providerClusterReconciler{log: logr.Discard(), fetcher: fetcher}}
+
+			vdc := &anywherev1.VSphereDatacenterConfig{}
+			vdc.Spec.Network = "/SDDC-Datacenter/network/sddc-cgw-network-1"
+			vmc := &anywherev1.VSphereMachineConfig{}
+			vmc.Spec.Template = "/SDDC-Datacenter/vm/Templates/ubuntu-v1.21.2"
+			vmc.Spec.Datastore = "/SDDC-Datacenter/datastore/WorkloadDatastore"
+			vmc.Spec.Folder = "/SDDC-Datacenter/vm/capv"
+			vmc.Spec.ResourcePool = "*/Resources/Compute-ResourcePool"
+			vmc.Spec.NumCPUs = 2
+			vmc.Spec.MemoryMiB = 8192
+			vmc.Spec.DiskGiB = 25
+			tc.vmc(vmc)
+
+			fetcher.EXPECT().FetchObjectByName(ctx, "template", "eksa-system", &vspherev1.VSphereMachineTemplate{}).DoAndReturn(
+				func(_ context.Context, _, _ string, obj *vspherev1.VSphereMachineTemplate) error {
+					if tc.err != nil {
+						return tc.err
+					}
+					tc.template.DeepCopyInto(obj)
+					return nil
+				},
+			)
+
+			got, err := r.templateMatches(ctx, vdc, vmc)("template")
+			g.Expect(err).To(Succeed())
+			g.Expect(got).To(Equal(tc.want))
+		})
+	}
+}
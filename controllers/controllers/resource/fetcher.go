@@ -25,6 +25,8 @@ import (
 
 type ResourceFetcher interface {
 	MachineDeployment(ctx context.Context, cs *anywherev1.Cluster) (*clusterv1.MachineDeployment, error)
+	MachineDeployments(ctx context.Context, cs *anywherev1.Cluster) ([]*clusterv1.MachineDeployment, error)
+	CAPICluster(ctx context.Context, cs *anywherev1.Cluster) (*clusterv1.Cluster, error)
 	VSphereWorkerMachineTemplate(ctx context.Context, cs *anywherev1.Cluster) (*vspherev1.VSphereMachineTemplate, error)
 	FetchObject(ctx context.Context, objectKey types.NamespacedName, obj client.Object) error
 	FetchObjectByName(ctx context.Context, name string, namespace string, obj client.Object) error
@@ -163,7 +165,11 @@ func (r *capiResourceFetcher) fetchClusterForRef(ctx context.Context, refId type
 	return nil, fmt.Errorf("eksa cluster not found for datacenterRef %v", refId)
 }
 
-func (r *capiResourceFetcher) machineDeployments(ctx context.Context, c *anywherev1.Cluster) ([]*clusterv1.MachineDeployment, error) {
+func (r *capiResourceFetcher) CAPICluster(ctx context.Context, cs *anywherev1.Cluster) (*clusterv1.Cluster, error) {
+	return r.clusterByName(ctx, constants.EksaSystemNamespace, cs.Name)
+}
+
+func (r *capiResourceFetcher) MachineDeployments(ctx context.Context, c *anywherev1.Cluster) ([]*clusterv1.MachineDeployment, error) {
 	machineDeployments := &clusterv1.MachineDeploymentList{}
 	req, err := labels.NewRequirement(clusterv1.ClusterLabelName, selection.Equals, []string{c.Name})
 	if err != nil {
@@ -175,14 +181,15 @@ func (r *capiResourceFetcher) machineDeployments(ctx context.Context, c *anywher
 		return nil, err
 	}
 	deployments := make([]*clusterv1.MachineDeployment, 0, len(machineDeployments.Items))
-	for _, md := range machineDeployments.Items {
-		deployments = append(deployments, &md)
+	// Use a numbered loop to avoid problems when retrieving the pointer
+	for i := range machineDeployments.Items {
+		deployments = append(deployments, &machineDeployments.Items[i])
 	}
 	return deployments, nil
 }
 
 func (r *capiResourceFetcher) MachineDeployment(ctx context.Context, cs *anywherev1.Cluster) (*clusterv1.MachineDeployment, error) {
-	deployments, err := r.machineDeployments(ctx, cs)
+	deployments, err := r.MachineDeployments(ctx, cs)
 	if err != nil {
 		return nil, err
 	}
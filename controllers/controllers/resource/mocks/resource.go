@@ -58,6 +58,21 @@ func (mr *MockResourceFetcherMockRecorder) AWSIamConfig(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AWSIamConfig", reflect.TypeOf((*MockResourceFetcher)(nil).AWSIamConfig), arg0, arg1, arg2)
 }
 
+// CAPICluster mocks base method.
+func (m *MockResourceFetcher) CAPICluster(arg0 context.Context, arg1 *v1alpha1.Cluster) (*v1alpha31.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CAPICluster", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha31.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CAPICluster indicates an expected call of CAPICluster.
+func (mr *MockResourceFetcherMockRecorder) CAPICluster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CAPICluster", reflect.TypeOf((*MockResourceFetcher)(nil).CAPICluster), arg0, arg1)
+}
+
 // ControlPlane mocks base method.
 func (m *MockResourceFetcher) ControlPlane(arg0 context.Context, arg1 *v1alpha1.Cluster) (*v1alpha32.KubeadmControlPlane, error) {
 	m.ctrl.T.Helper()
@@ -236,6 +251,21 @@ func (mr *MockResourceFetcherMockRecorder) MachineDeployment(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachineDeployment", reflect.TypeOf((*MockResourceFetcher)(nil).MachineDeployment), arg0, arg1)
 }
 
+// MachineDeployments mocks base method.
+func (m *MockResourceFetcher) MachineDeployments(arg0 context.Context, arg1 *v1alpha1.Cluster) ([]*v1alpha31.MachineDeployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MachineDeployments", arg0, arg1)
+	ret0, _ := ret[0].([]*v1alpha31.MachineDeployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MachineDeployments indicates an expected call of MachineDeployments.
+func (mr *MockResourceFetcherMockRecorder) MachineDeployments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachineDeployments", reflect.TypeOf((*MockResourceFetcher)(nil).MachineDeployments), arg0, arg1)
+}
+
 // OIDCConfig mocks base method.
 func (m *MockResourceFetcher) OIDCConfig(arg0 context.Context, arg1 *v1alpha1.Ref, arg2 string) (*v1alpha1.OIDCConfig, error) {
 	m.ctrl.T.Helper()
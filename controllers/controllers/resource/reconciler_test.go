@@ -152,7 +152,12 @@ func TestClusterReconcilerReconcile(t *testing.T) {
 				fetcher.EXPECT().ExistingVSphereDatacenterConfig(ctx, gomock.Any()).Return(&anywherev1.VSphereDatacenterConfig{}, nil)
 				fetcher.EXPECT().ExistingVSphereControlPlaneMachineConfig(ctx, gomock.Any()).Return(&anywherev1.VSphereMachineConfig{}, nil)
 				fetcher.EXPECT().ExistingVSphereEtcdMachineConfig(ctx, gomock.Any()).Return(&anywherev1.VSphereMachineConfig{}, nil)
-				fetcher.EXPECT().ExistingVSphereWorkerMachineConfig(ctx, gomock.Any()).Return(&anywherev1.VSphereMachineConfig{}, nil)
+				mcDeployment := &clusterv1.MachineDeployment{}
+				if err := yaml.Unmarshal([]byte(machineDeploymentFile), mcDeployment); err != nil {
+					t.Errorf("unmarshal failed: %v", err)
+				}
+				fetcher.EXPECT().MachineDeployments(ctx, gomock.Any()).Return([]*clusterv1.MachineDeployment{mcDeployment}, nil)
+				fetcher.EXPECT().FetchObjectByName(ctx, "test_cluster-workload-template-1", "eksa-system", gomock.Any()).Return(nil)
 				fetcher.EXPECT().Fetch(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil, errors.NewNotFound(schema.GroupResource{Group: "testgroup", Resource: "testresource"}, ""))
 
 				resourceUpdater.EXPECT().ApplyPatch(ctx, gomock.Any(), false).Return(nil)
@@ -160,7 +165,7 @@ func TestClusterReconcilerReconcile(t *testing.T) {
 					assert.Equal(t, false, dryRun, "Expected dryRun didn't match")
 					switch template.GetKind() {
 					case "VSphereMachineTemplate":
-						if strings.Contains(template.GetName(), "worker-node") {
+						if strings.Contains(template.GetName(), "md-0") {
 							expectedMachineTemplate := &unstructured.Unstructured{}
 							if err := yaml.Unmarshal([]byte(vsphereMachineTemplateFile), expectedMachineTemplate); err != nil {
 								t.Errorf("unmarshal failed: %v", err)
@@ -241,7 +246,6 @@ func TestClusterReconcilerReconcile(t *testing.T) {
 				existingVSMachine := &anywherev1.VSphereMachineConfig{}
 				existingVSMachine.Spec = machineSpec.Spec
 				fetcher.EXPECT().ExistingVSphereControlPlaneMachineConfig(ctx, gomock.Any()).Return(&anywherev1.VSphereMachineConfig{}, nil)
-				fetcher.EXPECT().ExistingVSphereWorkerMachineConfig(ctx, gomock.Any()).Return(existingVSMachine, nil)
 
 				kubeAdmControlPlane := &bootstrapv1.KubeadmControlPlane{}
 				if err := yaml.Unmarshal([]byte(kubeadmcontrolplaneFile), kubeAdmControlPlane); err != nil {
@@ -253,7 +257,12 @@ func TestClusterReconcilerReconcile(t *testing.T) {
 					t.Errorf("unmarshal failed: %v", err)
 				}
 
-				fetcher.EXPECT().MachineDeployment(ctx, gomock.Any()).Return(mcDeployment, nil)
+				fetcher.EXPECT().MachineDeployments(ctx, gomock.Any()).Return([]*clusterv1.MachineDeployment{mcDeployment}, nil)
+				fetcher.EXPECT().FetchObjectByName(ctx, "test_cluster-workload-template-1", "eksa-system", gomock.Any()).Do(func(ctx context.Context, name, namespace string, obj client.Object) {
+					if err := yaml.Unmarshal([]byte(vsphereMachineTemplateFile), obj); err != nil {
+						t.Errorf("unmarshal failed: %v", err)
+					}
+				}).Return(nil)
 				fetcher.EXPECT().Fetch(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil, errors.NewNotFound(schema.GroupResource{Group: "testgroup", Resource: "testresource"}, ""))
 
 				resourceUpdater.EXPECT().ForceApplyTemplate(ctx, gomock.Any(), gomock.Any()).Do(func(ctx context.Context, template *unstructured.Unstructured, dryRun bool) {
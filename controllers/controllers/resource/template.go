@@ -2,15 +2,20 @@ package resource
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1alpha3"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1alpha3"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	"sigs.k8s.io/yaml"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/awsiamauth"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/docker"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
@@ -41,9 +46,20 @@ type TinkerbellTemplate struct {
 	now anywhereTypes.NowFunc
 }
 
-func (r *VsphereTemplate) TemplateResources(ctx context.Context, eksaCluster *anywherev1.Cluster, clusterSpec *cluster.Spec, vdc anywherev1.VSphereDatacenterConfig, cpVmc, workerVmc, etcdVmc anywherev1.VSphereMachineConfig) ([]*unstructured.Unstructured, error) {
+// TemplateResources generates the CAPI objects for a vSphere cluster. workerVmcs holds the machine config of each
+// worker node group keyed by the group name.
+func (r *VsphereTemplate) TemplateResources(ctx context.Context, eksaCluster *anywherev1.Cluster, clusterSpec *cluster.Spec, vdc anywherev1.VSphereDatacenterConfig, cpVmc, etcdVmc anywherev1.VSphereMachineConfig, workerVmcs map[string]anywherev1.VSphereMachineConfig) ([]*unstructured.Unstructured, error) {
+	workerNodeGroupMachineSpecs := make(map[string]*anywherev1.VSphereMachineConfigSpec, len(workerVmcs))
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		workerVmc, ok := workerVmcs[workerNodeGroup.Name]
+		if !ok {
+			return nil, fmt.Errorf("cannot find VSphereMachineConfig for worker node group %s", workerNodeGroup.Name)
+		}
+		workerNodeGroupMachineSpecs[workerNodeGroup.MachineGroupRef.Name] = &workerVmc.Spec
+	}
+
 	// control plane and etcd updates are prohibited in controller so those specs should not change
-	templateBuilder := vsphere.NewVsphereTemplateBuilder(&vdc.Spec, &cpVmc.Spec, &workerVmc.Spec, &etcdVmc.Spec, r.now)
+	templateBuilder := vsphere.NewVsphereTemplateBuilder(&vdc.Spec, &cpVmc.Spec, workerNodeGroupMachineSpecs, &etcdVmc.Spec, r.now)
 	clusterName := clusterSpec.ObjectMeta.Name
 
 	oldVdc, err := r.ExistingVSphereDatacenterConfig(ctx, eksaCluster)
@@ -54,10 +70,6 @@ func (r *VsphereTemplate) TemplateResources(ctx context.Context, eksaCluster *an
 	if err != nil {
 		return nil, err
 	}
-	oldWorkerVmc, err := r.ExistingVSphereWorkerMachineConfig(ctx, eksaCluster)
-	if err != nil {
-		return nil, err
-	}
 
 	var controlPlaneTemplateName string
 	updateControlPlaneTemplate := vsphere.AnyImmutableFieldChanged(oldVdc, &vdc, oldCpVmc, &cpVmc)
@@ -71,16 +83,27 @@ func (r *VsphereTemplate) TemplateResources(ctx context.Context, eksaCluster *an
 		controlPlaneTemplateName = cp.Spec.InfrastructureTemplate.Name
 	}
 
-	var workloadTemplateName string
-	updateWorkloadTemplate := vsphere.AnyImmutableFieldChanged(oldVdc, &vdc, oldWorkerVmc, &workerVmc)
-	if updateWorkloadTemplate {
-		workloadTemplateName = templateBuilder.WorkerMachineTemplateName(clusterName)
-	} else {
-		mcDeployment, err := r.MachineDeployment(ctx, eksaCluster)
+	existingMDs, err := machineDeploymentsByWorkerNodeGroup(ctx, r, eksaCluster, clusterSpec)
+	if err != nil {
+		return nil, err
+	}
+	workloadTemplateNames := make(map[string]string, len(clusterSpec.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		workerVmc := workerVmcs[workerNodeGroup.Name]
+		md, ok := existingMDs[workerNodeGroup.Name]
+		if !ok {
+			workloadTemplateNames[workerNodeGroup.Name] = templateBuilder.WorkerMachineTemplateName(clusterName, workerNodeGroup.Name)
+			continue
+		}
+		oldWorkerVmc, err := r.existingVSphereMachineConfig(ctx, md.Spec.Template.Spec.InfrastructureRef.Name)
 		if err != nil {
 			return nil, err
 		}
-		workloadTemplateName = mcDeployment.Spec.Template.Spec.InfrastructureRef.Name
+		if vsphere.AnyImmutableFieldChanged(oldVdc, &vdc, oldWorkerVmc, &workerVmc) {
+			workloadTemplateNames[workerNodeGroup.Name] = templateBuilder.WorkerMachineTemplateName(clusterName, workerNodeGroup.Name)
+		} else {
+			workloadTemplateNames[workerNodeGroup.Name] = md.Spec.Template.Spec.InfrastructureRef.Name
+		}
 	}
 
 	var etcdTemplateName string
@@ -112,17 +135,47 @@ func (r *VsphereTemplate) TemplateResources(ctx context.Context, eksaCluster *an
 		values["etcdTemplateName"] = etcdTemplateName
 	}
 
-	workersOpt := func(values map[string]interface{}) {
-		values["workloadTemplateName"] = workloadTemplateName
-		values["vsphereWorkerSshAuthorizedKey"] = sshAuthorizedKey(workerVmc.Spec.Users)
-	}
+	workersOpt := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		values["workloadTemplateName"] = workloadTemplateNames[workerNodeGroupName]
+	})
 
 	return generateTemplateResources(templateBuilder, clusterSpec, cpOpt, workersOpt)
 }
 
-func (r *TinkerbellTemplate) TemplateResources(ctx context.Context, eksaCluster *anywherev1.Cluster, clusterSpec *cluster.Spec, tdc anywherev1.TinkerbellDatacenterConfig, cpTmc, workerTmc, etcdTmc anywherev1.TinkerbellMachineConfig) ([]*unstructured.Unstructured, error) {
-	templateBuilder := tinkerbell.NewTinkerbellTemplateBuilder(&tdc.Spec, &cpTmc.Spec, &workerTmc.Spec, &etcdTmc.Spec, r.now)
-	md, err := r.MachineDeployment(ctx, eksaCluster)
+func (r *VsphereTemplate) existingVSphereMachineConfig(ctx context.Context, machineTemplateName string) (*anywherev1.VSphereMachineConfig, error) {
+	vsMachineTemplate := &vspherev1.VSphereMachineTemplate{}
+	if err := r.FetchObjectByName(ctx, machineTemplateName, constants.EksaSystemNamespace, vsMachineTemplate); err != nil {
+		return nil, err
+	}
+	return MapMachineTemplateToVSphereMachineConfigSpec(vsMachineTemplate)
+}
+
+func machineDeploymentsByWorkerNodeGroup(ctx context.Context, fetcher ResourceFetcher, eksaCluster *anywherev1.Cluster, clusterSpec *cluster.Spec) (map[string]*clusterv1.MachineDeployment, error) {
+	mds, err := fetcher.MachineDeployments(ctx, eksaCluster)
+	if err != nil {
+		return nil, err
+	}
+	machineDeployments := make([]clusterv1.MachineDeployment, 0, len(mds))
+	for _, md := range mds {
+		machineDeployments = append(machineDeployments, *md)
+	}
+	return clusterapi.MachineDeploymentsByWorkerNodeGroup(clusterSpec.Name, clusterSpec.Spec.WorkerNodeGroupConfigurations, machineDeployments), nil
+}
+
+// TemplateResources generates the CAPI objects for a Tinkerbell cluster. workerTmcs holds the machine config of each
+// worker node group keyed by the group name.
+func (r *TinkerbellTemplate) TemplateResources(ctx context.Context, eksaCluster *anywherev1.Cluster, clusterSpec *cluster.Spec, tdc anywherev1.TinkerbellDatacenterConfig, cpTmc, etcdTmc anywherev1.TinkerbellMachineConfig, workerTmcs map[string]anywherev1.TinkerbellMachineConfig) ([]*unstructured.Unstructured, error) {
+	workerNodeGroupMachineSpecs := make(map[string]*anywherev1.TinkerbellMachineConfigSpec, len(workerTmcs))
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		workerTmc, ok := workerTmcs[workerNodeGroup.Name]
+		if !ok {
+			return nil, fmt.Errorf("cannot find TinkerbellMachineConfig for worker node group %s", workerNodeGroup.Name)
+		}
+		workerNodeGroupMachineSpecs[workerNodeGroup.MachineGroupRef.Name] = &workerTmc.Spec
+	}
+
+	templateBuilder := tinkerbell.NewTinkerbellTemplateBuilder(&tdc.Spec, &cpTmc.Spec, workerNodeGroupMachineSpecs, &etcdTmc.Spec, r.now)
+	existingMDs, err := machineDeploymentsByWorkerNodeGroup(ctx, r, eksaCluster, clusterSpec)
 	if err != nil {
 		return nil, err
 	}
@@ -146,10 +199,11 @@ func (r *TinkerbellTemplate) TemplateResources(ctx context.Context, eksaCluster
 		values["etcdTemplateName"] = etcdTemplateName
 	}
 
-	workersOpt := func(values map[string]interface{}) {
-		values["workloadTemplateName"] = md.Spec.Template.Spec.InfrastructureRef.Name
-		values["tinkerbellWorkerSshAuthorizedKey"] = sshAuthorizedKey(workerTmc.Spec.Users)
-	}
+	workersOpt := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		values["workloadTemplateName"] = workloadTemplateName(existingMDs, workerNodeGroupName, func() string {
+			return templateBuilder.WorkerMachineTemplateName(clusterSpec.Name, workerNodeGroupName)
+		})
+	})
 
 	return generateTemplateResources(templateBuilder, clusterSpec, cpOpt, workersOpt)
 }
@@ -180,7 +234,7 @@ func generateTemplateResources(builder providers.TemplateBuilder, clusterSpec *c
 
 func (r *DockerTemplate) TemplateResources(ctx context.Context, eksaCluster *anywherev1.Cluster, clusterSpec *cluster.Spec) ([]*unstructured.Unstructured, error) {
 	templateBuilder := docker.NewDockerTemplateBuilder(r.now)
-	mcDeployment, err := r.MachineDeployment(ctx, eksaCluster)
+	existingMDs, err := machineDeploymentsByWorkerNodeGroup(ctx, r, eksaCluster, clusterSpec)
 	if err != nil {
 		return nil, err
 	}
@@ -202,12 +256,23 @@ func (r *DockerTemplate) TemplateResources(ctx context.Context, eksaCluster *any
 		values["controlPlaneTemplateName"] = kubeadmControlPlane.Spec.InfrastructureTemplate.Name
 		values["etcdTemplateName"] = etcdTemplateName
 	}
-	workersOpt := func(values map[string]interface{}) {
-		values["workloadTemplateName"] = mcDeployment.Spec.Template.Spec.InfrastructureRef.Name
-	}
+	workersOpt := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		values["workloadTemplateName"] = workloadTemplateName(existingMDs, workerNodeGroupName, func() string {
+			return templateBuilder.WorkerMachineTemplateName(clusterSpec.Name, workerNodeGroupName)
+		})
+	})
 	return generateTemplateResources(templateBuilder, clusterSpec, cpOpt, workersOpt)
 }
 
+// workloadTemplateName returns the machine template of the existing MachineDeployment of a worker node group,
+// or a new one for groups that don't have a MachineDeployment yet
+func workloadTemplateName(existingMDs map[string]*clusterv1.MachineDeployment, workerNodeGroupName string, newName func() string) string {
+	if md, ok := existingMDs[workerNodeGroupName]; ok {
+		return md.Spec.Template.Spec.InfrastructureRef.Name
+	}
+	return newName()
+}
+
 func sshAuthorizedKey(users []anywherev1.UserConfiguration) string {
 	if len(users) <= 0 || len(users[0].SshAuthorizedKeys) <= 0 {
 		return ""
@@ -6,6 +6,7 @@ require (
 	github.com/aws/aws-sdk-go v1.38.40
 	github.com/aws/eks-anywhere/release v0.0.0-20211130194657-f6e9593c6551
 	github.com/aws/eks-distro-build-tooling/release v0.0.0-20211103003257-a7e2379eae5e
+	github.com/go-git/go-billy/v5 v5.3.1
 	github.com/go-git/go-git/v5 v5.4.2
 	github.com/go-logr/logr v0.4.0
 	github.com/go-logr/zapr v0.4.0
@@ -29,6 +30,7 @@ require (
 	sigs.k8s.io/cluster-api v0.3.11-0.20210430210359-402a4524f006
 	sigs.k8s.io/cluster-api-provider-vsphere v0.7.8
 	sigs.k8s.io/controller-runtime v0.10.3
+	sigs.k8s.io/structured-merge-diff/v4 v4.1.2
 	sigs.k8s.io/yaml v1.3.0
 )
 
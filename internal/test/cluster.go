@@ -42,6 +42,7 @@ func NewClusterSpec(opts ...ClusterSpecOpt) *cluster.Spec {
 		opt(s)
 	}
 
+	s.Cluster.SetDefaults()
 	s.SetDefaultGitOps()
 	return s
 }
@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1alpha3"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
@@ -23,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -33,6 +35,7 @@ const (
 
 func init() {
 	// Register CRDs in Scheme in init so fake clients benefit from it
+	utilruntime.Must(anywherev1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(releasev1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(clusterv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(controlplanev1.AddToScheme(scheme.Scheme))
@@ -47,6 +50,7 @@ type Environment struct {
 	scheme  *runtime.Scheme
 	client  client.Client
 	env     *envtest.Environment
+	config  *rest.Config
 	manager manager.Manager
 	// apiReader is a non cached client (only for reads), helpful when testing the actual state of objects
 	apiReader client.Reader
@@ -116,6 +120,7 @@ func newEnvironment(ctx context.Context) (*Environment, error) {
 	if err != nil {
 		return nil, err
 	}
+	env.config = cfg
 
 	// start webhook server using Manager
 	webhookInstallOptions := &testEnv.WebhookInstallOptions
@@ -132,6 +137,10 @@ func newEnvironment(ctx context.Context) (*Environment, error) {
 	}
 	env.manager = mgr
 
+	if err = setupWebhooks(mgr); err != nil {
+		return nil, err
+	}
+
 	go func() {
 		err = mgr.Start(ctx)
 	}()
@@ -146,6 +155,27 @@ func newEnvironment(ctx context.Context) (*Environment, error) {
 	return env, nil
 }
 
+// setupWebhooks registers the EKS-A webhooks installed from config/webhook, otherwise the API server
+// rejects the EKS-A objects since the webhooks have a fail policy
+func setupWebhooks(mgr manager.Manager) error {
+	webhooks := []interface {
+		SetupWebhookWithManager(ctrl.Manager) error
+	}{
+		&anywherev1.Cluster{},
+		&anywherev1.VSphereDatacenterConfig{},
+		&anywherev1.VSphereMachineConfig{},
+		&anywherev1.GitOpsConfig{},
+		&anywherev1.OIDCConfig{},
+		&anywherev1.AWSIamConfig{},
+	}
+	for _, w := range webhooks {
+		if err := w.SetupWebhookWithManager(mgr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (e *Environment) stop() error {
 	fmt.Println("Stopping the test environment")
 	e.cancelF() // Cancels context that will stop the manager
@@ -156,6 +186,11 @@ func (e *Environment) Client() client.Client {
 	return e.client
 }
 
+// Config returns the rest config to connect to the envtest API server
+func (e *Environment) Config() *rest.Config {
+	return e.config
+}
+
 // APIReader returns a non cached reader client
 func (e *Environment) APIReader() client.Reader {
 	return e.apiReader
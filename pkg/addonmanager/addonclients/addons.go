@@ -0,0 +1,340 @@
+package addonclients
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+//go:embed manifests/addons/kustomization.yaml
+var addonsKustomizeContent string
+
+//go:embed manifests/addons/namespaces.yaml
+var addonsNamespacesContent string
+
+//go:embed manifests/addons/helmrelease-sample.yaml
+var addonsHelmReleaseSampleContent string
+
+//go:embed manifests/addons-sync.yaml
+var addonsSyncContent string
+
+const (
+	addonsDirName                   = "addons"
+	addonsSyncFileName              = "addons-sync.yaml"
+	addonsNamespacesFileName        = "namespaces.yaml"
+	addonsHelmReleaseSampleFileName = "helmrelease-sample.yaml"
+	addonsNamespace                 = "addons"
+
+	addAddonCommitMessage = "Add commit of cluster add-ons; generated by EKS-A CLI"
+)
+
+// AddAddon copies the manifests in manifestsPath, a single file or a directory, to the add-ons folder of the cluster
+// in the GitOps repository, adds them to its kustomization and pushes the change, so Flux installs them in the cluster.
+// A directory with a kustomization file is copied with its subdirectories and added as a single resource, otherwise
+// each of its top level yaml files is added
+func (f *FluxAddonClient) AddAddon(ctx context.Context, clusterSpec *cluster.Spec, manifestsPath string) error {
+	if f.shouldSkipFlux() {
+		return fmt.Errorf("GitOps is not configured for cluster %s", clusterSpec.Name)
+	}
+
+	clusterSpec.SetDefaultGitOps()
+	fc := &fluxForCluster{
+		FluxAddonClient: f,
+		clusterSpec:     clusterSpec,
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return err
+	}
+
+	if err := fc.writeAddonsFiles(); err != nil {
+		return &ConfigVersionControlFailedError{Err: err}
+	}
+
+	resources, err := fc.copyAddonManifests(manifestsPath)
+	if err != nil {
+		return err
+	}
+
+	if err = fc.addAddonsKustomizeResources(resources...); err != nil {
+		return &ConfigVersionControlFailedError{Err: err}
+	}
+
+	if err = fc.addAddonsFilesToGit(); err != nil {
+		return err
+	}
+
+	if err = f.pushToRemoteRepo(ctx, fc.addonsDir(), addAddonCommitMessage); err != nil {
+		return err
+	}
+	logger.V(3).Info("Finished pushing add-on manifests to git",
+		"repository", fc.repository(), "path", fc.addonsDir())
+
+	return nil
+}
+
+// writeAddonsFiles scaffolds the add-ons folder of the cluster and the Flux Kustomization that syncs it.
+// An existing add-ons folder is never overwritten, so the changes made to it are kept
+func (fc *fluxForCluster) writeAddonsFiles() error {
+	if validations.FileExists(filepath.Join(fc.gitOpts.Writer.Dir(), fc.addonsDir(), kustomizeFileName)) {
+		logger.V(3).Info("Add-ons folder already exists, skipping scaffolding", "path", fc.addonsDir())
+		return nil
+	}
+
+	w, err := fc.gitOpts.Writer.WithDir(fc.addonsDir())
+	if err != nil {
+		return fmt.Errorf("error creating %s directory: %v", fc.addonsDir(), err)
+	}
+	w.CleanUpTemp()
+	if err = fc.generateAddonsFiles(w); err != nil {
+		return err
+	}
+
+	syncWriter, err := fc.gitOpts.Writer.WithDir(path.Dir(fc.addonsSyncFile()))
+	if err != nil {
+		return fmt.Errorf("error creating %s directory: %v", path.Dir(fc.addonsSyncFile()), err)
+	}
+	syncWriter.CleanUpTemp()
+	return fc.generateAddonsSyncFile(syncWriter)
+}
+
+// generateAddonsFiles writes the scaffolding of the add-ons folder. HelmReleases are reconciled by the Flux controllers
+// running in the cluster, so the sample is only added for self-managed clusters
+func (fc *fluxForCluster) generateAddonsFiles(w filewriter.FileWriter) error {
+	t := templater.New(w)
+	values := map[string]interface{}{
+		"Namespace": addonsNamespace,
+		"Resources": []string{addonsNamespacesFileName},
+	}
+
+	logger.V(3).Info("Generating add-ons namespaces file...")
+	if filePath, err := t.WriteToFile(addonsNamespacesContent, values, addonsNamespacesFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("error writing add-ons namespaces file into %s: %v", filePath, err)
+	}
+
+	if fc.clusterSpec.IsSelfManaged() {
+		logger.V(3).Info("Generating add-ons sample HelmRelease file...")
+		if filePath, err := t.WriteToFile(addonsHelmReleaseSampleContent, values, addonsHelmReleaseSampleFileName, filewriter.PersistentFile); err != nil {
+			return fmt.Errorf("error writing add-ons sample HelmRelease file into %s: %v", filePath, err)
+		}
+		values["Resources"] = []string{addonsNamespacesFileName, addonsHelmReleaseSampleFileName}
+	}
+
+	logger.V(3).Info("Generating add-ons kustomization file...")
+	if filePath, err := t.WriteToFile(addonsKustomizeContent, values, kustomizeFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("error writing add-ons kustomization file into %s: %v", filePath, err)
+	}
+	return nil
+}
+
+// generateAddonsSyncFile writes the Flux Kustomization that applies the add-ons folder. It's created in the eksa-system
+// namespace, with the kubeconfig secret of the cluster if it's not the management cluster where Flux runs
+func (fc *fluxForCluster) generateAddonsSyncFile(w filewriter.FileWriter) error {
+	values := map[string]string{
+		"ClusterName":         fc.clusterSpec.GetName(),
+		"Namespace":           constants.EksaSystemNamespace,
+		"FluxSystemNamespace": fc.namespace(),
+		"Path":                fc.addonsDir(),
+	}
+	if fc.clusterSpec.IsManaged() {
+		values["KubeconfigSecretName"] = fmt.Sprintf("%s-kubeconfig", fc.clusterSpec.GetName())
+	}
+
+	t := templater.New(w)
+	if filePath, err := t.WriteToFile(addonsSyncContent, values, addonsSyncFileName, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("error writing add-ons sync file into %s: %v", filePath, err)
+	}
+	return nil
+}
+
+// copyAddonManifests copies the add-on manifests to the add-ons folder and returns the kustomization resources for them
+func (fc *fluxForCluster) copyAddonManifests(manifestsPath string) ([]string, error) {
+	info, err := os.Stat(manifestsPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid add-on manifests path: %v", err)
+	}
+
+	if !info.IsDir() {
+		name := filepath.Base(manifestsPath)
+		if !isYamlFile(name) || name == kustomizeFileName {
+			return nil, fmt.Errorf("add-on manifest %s is not a yaml file with Kubernetes resources", manifestsPath)
+		}
+		if err = fc.copyAddonFile(manifestsPath, name); err != nil {
+			return nil, err
+		}
+		return []string{name}, nil
+	}
+
+	absPath, err := filepath.Abs(manifestsPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid add-on manifests path: %v", err)
+	}
+	dirName := filepath.Base(absPath)
+
+	// A kustomization can reference files in subdirectories, so all its files are copied
+	if validations.FileExists(filepath.Join(manifestsPath, kustomizeFileName)) {
+		if err = fc.copyAddonDir(manifestsPath, dirName); err != nil {
+			return nil, err
+		}
+		return []string{dirName}, nil
+	}
+
+	files, err := ioutil.ReadDir(manifestsPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading add-on manifests directory: %v", err)
+	}
+
+	var resources []string
+	for _, file := range files {
+		if file.IsDir() || !isYamlFile(file.Name()) {
+			continue
+		}
+		name := path.Join(dirName, file.Name())
+		if err = fc.copyAddonFile(filepath.Join(manifestsPath, file.Name()), name); err != nil {
+			return nil, err
+		}
+		resources = append(resources, name)
+	}
+
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("add-on manifests directory %s doesn't contain any yaml files", manifestsPath)
+	}
+	return resources, nil
+}
+
+// copyAddonDir copies all the files in the source directory and its subdirectories to dirName in the add-ons folder
+func (fc *fluxForCluster) copyAddonDir(source, dirName string) error {
+	return filepath.Walk(source, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading add-on manifests directory: %v", err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(source, filePath)
+		if err != nil {
+			return err
+		}
+		return fc.copyAddonFile(filePath, path.Join(dirName, filepath.ToSlash(rel)))
+	})
+}
+
+func (fc *fluxForCluster) copyAddonFile(source, name string) error {
+	content, err := ioutil.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("error reading add-on manifest: %v", err)
+	}
+
+	w, err := fc.gitOpts.Writer.WithDir(path.Join(fc.addonsDir(), path.Dir(name)))
+	if err != nil {
+		return fmt.Errorf("error creating add-on directory: %v", err)
+	}
+	w.CleanUpTemp()
+	if filePath, err := w.Write(path.Base(name), content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("error writing add-on manifest into %s: %v", filePath, err)
+	}
+	return nil
+}
+
+// addAddonsKustomizeResources adds the resources that are not listed yet to the add-ons kustomization.
+// The rest of the kustomization, which might have been changed by users, is kept as is
+func (fc *fluxForCluster) addAddonsKustomizeResources(resources ...string) error {
+	kustomizationFile := filepath.Join(fc.gitOpts.Writer.Dir(), fc.addonsDir(), kustomizeFileName)
+	content, err := ioutil.ReadFile(kustomizationFile)
+	if err != nil {
+		return fmt.Errorf("error reading add-ons kustomization file: %v", err)
+	}
+
+	kustomization := map[string]interface{}{}
+	if err = yaml.Unmarshal(content, &kustomization); err != nil {
+		return fmt.Errorf("error parsing add-ons kustomization file: %v", err)
+	}
+
+	var current []string
+	if r, ok := kustomization["resources"].([]interface{}); ok {
+		for _, resource := range r {
+			current = append(current, fmt.Sprint(resource))
+		}
+	}
+
+	updated := current
+	for _, resource := range resources {
+		if !containsString(updated, resource) {
+			updated = append(updated, resource)
+		}
+	}
+	if len(updated) == len(current) {
+		logger.V(3).Info("Add-on resources already in kustomization")
+		return nil
+	}
+	kustomization["resources"] = updated
+
+	content, err = yaml.Marshal(kustomization)
+	if err != nil {
+		return fmt.Errorf("error outputting yaml: %v", err)
+	}
+	if _, err = fc.gitOpts.Writer.Write(path.Join(fc.addonsDir(), kustomizeFileName), content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("error writing add-ons kustomization file: %v", err)
+	}
+	return nil
+}
+
+func (fc *fluxForCluster) addAddonsFilesToGit() error {
+	for _, p := range []string{fc.addonsDir(), fc.addonsSyncFile()} {
+		if err := fc.gitOpts.Git.Add(p); err != nil {
+			return &ConfigVersionControlFailedError{Err: fmt.Errorf("error when adding %s to git: %v", p, err)}
+		}
+	}
+	return nil
+}
+
+// removeAddonsFilesFromGit removes the add-ons of the cluster and the Flux Kustomization that syncs them, if they exist
+func (fc *fluxForCluster) removeAddonsFilesFromGit() error {
+	for _, p := range []string{fc.addonsSyncFile(), fc.addonsDir()} {
+		if !validations.FileExists(path.Join(fc.gitOpts.Writer.Dir(), p)) {
+			continue
+		}
+		if err := fc.gitOpts.Git.Remove(p); err != nil {
+			return &ConfigVersionControlFailedError{Err: fmt.Errorf("error when removing %s in git: %v", p, err)}
+		}
+	}
+	return nil
+}
+
+// addonsDir is the folder with the add-ons of the cluster. It's outside of the cluster config path, which is synced
+// to the management cluster, so the add-ons of workload clusters are only applied to them by their own Kustomization
+func (fc *fluxForCluster) addonsDir() string {
+	return path.Join(addonsDirName, fc.path(), fc.clusterSpec.GetName())
+}
+
+func (fc *fluxForCluster) addonsSyncFile() string {
+	return path.Join(fc.path(), fc.clusterSpec.GetName(), addonsSyncFileName)
+}
+
+func isYamlFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
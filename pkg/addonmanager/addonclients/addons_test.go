@@ -0,0 +1,171 @@
+package addonclients_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/addonmanager/addonclients"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	addonsClusterName = "management-cluster"
+	addonsDir         = "addons/clusters/management-cluster/management-cluster"
+	addonsSyncFile    = "clusters/management-cluster/management-cluster/addons-sync.yaml"
+	addonManifest     = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: addon\n"
+)
+
+// writeAddonManifests writes the given files into a new directory and returns its path
+func writeAddonManifests(t *testing.T, dirName string, files ...string) string {
+	dir := filepath.Join(t.TempDir(), dirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, f)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, f), []byte(addonManifest), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestFluxAddonClientAddAddonFile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, m, gitOpts := newAddonClient(t)
+	clusterSpec := newClusterSpec(v1alpha1.NewCluster(addonsClusterName), "")
+	manifest := filepath.Join(writeAddonManifests(t, "manifests", "ingress.yaml"), "ingress.yaml")
+
+	g.Expect(os.MkdirAll(path.Join(gitOpts.Writer.Dir(), ".git"), 0o755)).To(Succeed())
+	m.git.EXPECT().Branch(clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
+	m.git.EXPECT().Add(addonsDir).Return(nil)
+	m.git.EXPECT().Add(addonsSyncFile).Return(nil)
+	m.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	m.git.EXPECT().Push(ctx).Return(nil)
+
+	g.Expect(f.AddAddon(ctx, clusterSpec, manifest)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(gitOpts.Writer.Dir(), addonsDir, defaultKustomizationManifestFileName), "./testdata/addons-kustomization-added-file.yaml")
+	test.AssertContentToFile(t, addonManifest, path.Join(gitOpts.Writer.Dir(), addonsDir, "ingress.yaml"))
+	test.AssertFilesEquals(t, path.Join(gitOpts.Writer.Dir(), addonsSyncFile), "./testdata/addons-sync-management.yaml")
+}
+
+func TestFluxAddonClientAddAddonDirectory(t *testing.T) {
+	tests := []struct {
+		testName          string
+		files             []string
+		wantKustomization string
+	}{
+		{
+			testName:          "manifests",
+			files:             []string{"deployment.yaml", "service.yml", "README.md"},
+			wantKustomization: "./testdata/addons-kustomization-added-dir.yaml",
+		},
+		{
+			testName:          "kustomization",
+			files:             []string{"deployment.yaml", "kustomization.yaml"},
+			wantKustomization: "./testdata/addons-kustomization-added-kustomization.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			f, m, gitOpts := newAddonClient(t)
+			clusterSpec := newClusterSpec(v1alpha1.NewCluster(addonsClusterName), "")
+			manifests := writeAddonManifests(t, "podinfo", tt.files...)
+
+			g.Expect(os.MkdirAll(path.Join(gitOpts.Writer.Dir(), ".git"), 0o755)).To(Succeed())
+			m.git.EXPECT().Branch(clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
+			m.git.EXPECT().Add(addonsDir).Return(nil)
+			m.git.EXPECT().Add(addonsSyncFile).Return(nil)
+			m.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+			m.git.EXPECT().Push(ctx).Return(nil)
+
+			g.Expect(f.AddAddon(ctx, clusterSpec, manifests)).To(Succeed())
+
+			test.AssertFilesEquals(t, path.Join(gitOpts.Writer.Dir(), addonsDir, defaultKustomizationManifestFileName), tt.wantKustomization)
+			test.AssertContentToFile(t, addonManifest, path.Join(gitOpts.Writer.Dir(), addonsDir, "podinfo", "deployment.yaml"))
+			g.Expect(path.Join(gitOpts.Writer.Dir(), addonsDir, "podinfo", "README.md")).NotTo(BeAnExistingFile())
+		})
+	}
+}
+
+func TestFluxAddonClientAddAddonKustomizationWithSubdirectories(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, m, gitOpts := newAddonClient(t)
+	clusterSpec := newClusterSpec(v1alpha1.NewCluster(addonsClusterName), "")
+	files := []string{"kustomization.yaml", "base/deployment.yaml", "base/kustomization.yaml", "overlays/prod/patch.json"}
+	manifests := writeAddonManifests(t, "podinfo", files...)
+
+	g.Expect(os.MkdirAll(path.Join(gitOpts.Writer.Dir(), ".git"), 0o755)).To(Succeed())
+	m.git.EXPECT().Branch(clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
+	m.git.EXPECT().Add(addonsDir).Return(nil)
+	m.git.EXPECT().Add(addonsSyncFile).Return(nil)
+	m.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	m.git.EXPECT().Push(ctx).Return(nil)
+
+	g.Expect(f.AddAddon(ctx, clusterSpec, manifests)).To(Succeed())
+
+	test.AssertFilesEquals(t, path.Join(gitOpts.Writer.Dir(), addonsDir, defaultKustomizationManifestFileName), "./testdata/addons-kustomization-added-kustomization.yaml")
+	for _, file := range files {
+		test.AssertContentToFile(t, addonManifest, path.Join(gitOpts.Writer.Dir(), addonsDir, "podinfo", file))
+	}
+}
+
+func TestFluxAddonClientAddAddonKeepsExistingKustomization(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, m, gitOpts := newAddonClient(t)
+	clusterSpec := newClusterSpec(v1alpha1.NewCluster(addonsClusterName), "")
+	manifest := filepath.Join(writeAddonManifests(t, "manifests", "ingress.yaml"), "ingress.yaml")
+
+	kustomization := "apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nnamespace: team-a\nresources:\n- ingress.yaml\n"
+	g.Expect(os.MkdirAll(path.Join(gitOpts.Writer.Dir(), addonsDir), 0o755)).To(Succeed())
+	g.Expect(ioutil.WriteFile(path.Join(gitOpts.Writer.Dir(), addonsDir, defaultKustomizationManifestFileName), []byte(kustomization), 0o644)).To(Succeed())
+	g.Expect(os.MkdirAll(path.Join(gitOpts.Writer.Dir(), ".git"), 0o755)).To(Succeed())
+
+	m.git.EXPECT().Branch(clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
+	m.git.EXPECT().Add(addonsDir).Return(nil)
+	m.git.EXPECT().Add(addonsSyncFile).Return(nil)
+	m.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	m.git.EXPECT().Push(ctx).Return(nil)
+
+	g.Expect(f.AddAddon(ctx, clusterSpec, manifest)).To(Succeed())
+
+	test.AssertContentToFile(t, kustomization, path.Join(gitOpts.Writer.Dir(), addonsDir, defaultKustomizationManifestFileName))
+	g.Expect(path.Join(gitOpts.Writer.Dir(), addonsDir, "namespaces.yaml")).NotTo(BeAnExistingFile())
+}
+
+func TestFluxAddonClientAddAddonInvalidManifest(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, m, gitOpts := newAddonClient(t)
+	clusterSpec := newClusterSpec(v1alpha1.NewCluster(addonsClusterName), "")
+	manifest := filepath.Join(writeAddonManifests(t, "manifests", "kustomization.yaml"), "kustomization.yaml")
+
+	g.Expect(os.MkdirAll(path.Join(gitOpts.Writer.Dir(), ".git"), 0o755)).To(Succeed())
+	m.git.EXPECT().Branch(clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
+
+	g.Expect(f.AddAddon(ctx, clusterSpec, manifest)).To(MatchError(ContainSubstring("is not a yaml file with Kubernetes resources")))
+}
+
+func TestFluxAddonClientAddAddonSkipFlux(t *testing.T) {
+	g := NewWithT(t)
+	f := addonclients.NewFluxAddonClient(nil, nil)
+
+	err := f.AddAddon(context.Background(), test.NewClusterSpec(), "addon.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("GitOps is not configured")))
+}
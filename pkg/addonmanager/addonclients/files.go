@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
@@ -127,7 +129,7 @@ func (fc *fluxForCluster) updateGitOpsConfig(fileName string) ([]byte, error) {
 			continue
 		}
 
-		gitopsconfig.Spec.Flux.Github.ClusterConfigPath = fc.path()
+		gitopsconfig.Spec.Flux.SetClusterConfigPath(fc.path())
 
 		gitopsYaml, err := yaml.Marshal(gitopsconfig.ConvertConfigToConfigGenerateStruct())
 		if err != nil {
@@ -138,3 +140,62 @@ func (fc *fluxForCluster) updateGitOpsConfig(fileName string) ([]byte, error) {
 
 	return templater.AppendYamlResources(resources...), nil
 }
+
+// WriteGitOpsManifests writes the files that are committed to the GitOps repository to w, following the same layout
+// as the repository, without pushing anything to it
+func (f *FluxAddonClient) WriteGitOpsManifests(ctx context.Context, w filewriter.FileWriter, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
+	if f.shouldSkipFlux() {
+		return nil
+	}
+
+	fc := &fluxForCluster{
+		FluxAddonClient:  f,
+		clusterSpec:      clusterSpec,
+		datacenterConfig: datacenterConfig,
+		machineConfigs:   machineConfigs,
+	}
+
+	eksaWriter, err := w.WithDir(fc.eksaSystemDir())
+	if err != nil {
+		return fmt.Errorf("error creating %s directory: %v", fc.eksaSystemDir(), err)
+	}
+	eksaWriter.CleanUpTemp()
+	if err = fc.generateClusterConfigFile(eksaWriter); err != nil {
+		return err
+	}
+	if err = fc.generateEksaKustomizeFile(eksaWriter); err != nil {
+		return err
+	}
+
+	fluxWriter, err := w.WithDir(fc.fluxSystemDir())
+	if err != nil {
+		return fmt.Errorf("error creating %s directory: %v", fc.fluxSystemDir(), err)
+	}
+	fluxWriter.CleanUpTemp()
+	t := templater.New(fluxWriter)
+	if err = fc.generateFluxKustomizeFile(t); err != nil {
+		return err
+	}
+	if err = fc.generateFluxSyncFile(t); err != nil {
+		return err
+	}
+	if err = fc.generateFluxPatchFile(t); err != nil {
+		return err
+	}
+
+	addonsWriter, err := w.WithDir(fc.addonsDir())
+	if err != nil {
+		return fmt.Errorf("error creating %s directory: %v", fc.addonsDir(), err)
+	}
+	addonsWriter.CleanUpTemp()
+	if err = fc.generateAddonsFiles(addonsWriter); err != nil {
+		return err
+	}
+
+	addonsSyncWriter, err := w.WithDir(path.Dir(fc.addonsSyncFile()))
+	if err != nil {
+		return fmt.Errorf("error creating %s directory: %v", path.Dir(fc.addonsSyncFile()), err)
+	}
+	addonsSyncWriter.CleanUpTemp()
+	return fc.generateAddonsSyncFile(addonsSyncWriter)
+}
@@ -10,10 +10,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/addonmanager/addonclients"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/providers"
 )
 
 type filesTest struct {
@@ -128,3 +130,38 @@ func TestUpdateLegacyFileStructureSuccess(t *testing.T) {
 	expectedEksaKustomizationPath := path.Join(g.Writer.Dir(), tt.fluxConfig.Github.ClusterConfigPath, tt.newSpec.GetClusterName(), "eksa-system", defaultKustomizationManifestFileName)
 	test.AssertFilesEquals(t, expectedEksaKustomizationPath, "./testdata/kustomization.yaml")
 }
+
+func TestWriteGitOpsManifests(t *testing.T) {
+	f, _, _ := newAddonClient(t)
+	_, w := test.NewWriter(t)
+	clusterName := "management-cluster"
+	clusterConfig := v1alpha1.NewCluster(clusterName)
+	clusterSpec := newClusterSpec(clusterConfig, "clusters/management-cluster")
+
+	err := f.WriteGitOpsManifests(context.Background(), w, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	if err != nil {
+		t.Fatalf("FluxAddonClient.WriteGitOpsManifests() error = %v, want nil", err)
+	}
+
+	eksaSystemDir := path.Join(w.Dir(), "clusters/management-cluster/management-cluster/eksa-system")
+	test.AssertFilesEquals(t, path.Join(eksaSystemDir, defaultEksaClusterConfigFileName), "./testdata/cluster-config-default-path-management.yaml")
+	test.AssertFilesEquals(t, path.Join(eksaSystemDir, defaultKustomizationManifestFileName), "./testdata/kustomization.yaml")
+
+	fluxSystemDir := path.Join(w.Dir(), "clusters/management-cluster/flux-system")
+	test.AssertFilesEquals(t, path.Join(fluxSystemDir, defaultFluxPatchesFileName), "./testdata/gotk-patches.yaml")
+	test.AssertFilesEquals(t, path.Join(fluxSystemDir, defaultFluxSyncFileName), "./testdata/gotk-sync.yaml")
+
+	addonsDir := path.Join(w.Dir(), "addons/clusters/management-cluster/management-cluster")
+	test.AssertFilesEquals(t, path.Join(addonsDir, defaultKustomizationManifestFileName), "./testdata/addons-kustomization-management.yaml")
+	test.AssertFilesEquals(t, path.Join(w.Dir(), "clusters/management-cluster/management-cluster/addons-sync.yaml"), "./testdata/addons-sync-management.yaml")
+}
+
+func TestWriteGitOpsManifestsSkipFlux(t *testing.T) {
+	_, w := test.NewWriter(t)
+	f := addonclients.NewFluxAddonClient(nil, nil)
+
+	err := f.WriteGitOpsManifests(context.Background(), w, test.NewClusterSpec(), nil, nil)
+	if err != nil {
+		t.Fatalf("FluxAddonClient.WriteGitOpsManifests() error = %v, want nil", err)
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"path/filepath"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
@@ -56,6 +59,8 @@ type FluxAddonClient struct {
 	flux    Flux
 	gitOpts *GitOptions
 	retrier *retrier.Retrier
+	// pendingPullRequest has the changes to the cluster config that are waiting to be merged
+	pendingPullRequest *git.PullRequest
 }
 
 type GitOptions struct {
@@ -67,13 +72,13 @@ func NewGitOptions(ctx context.Context, cluster *v1alpha1.Cluster, gitOpsConfig
 	if gitOpsConfig == nil {
 		return nil, nil
 	}
-	localGitRepoPath := filepath.Join(cluster.Name, "git", gitOpsConfig.Spec.Flux.Github.Repository)
+	localGitRepoPath := filepath.Join(cluster.Name, "git", gitOpsConfig.Spec.Flux.Repository())
 	gogitOptions := gogit.Options{
 		RepositoryDirectory: localGitRepoPath,
 	}
 	goGit := gogit.New(gogitOptions)
 
-	gitProviderFactoryOptions := gitFactory.Options{GithubGitClient: goGit}
+	gitProviderFactoryOptions := gitFactory.Options{GithubGitClient: goGit, GenericGitClient: goGit, GitlabGitClient: goGit}
 	gitProviderFactory := gitFactory.New(gitProviderFactoryOptions)
 	gitProvider, err := gitProviderFactory.BuildProvider(ctx, &gitOpsConfig.Spec)
 	if err != nil {
@@ -83,7 +88,7 @@ func NewGitOptions(ctx context.Context, cluster *v1alpha1.Cluster, gitOpsConfig
 	if err != nil {
 		return nil, err
 	}
-	localGitWriterPath := filepath.Join("git", gitOpsConfig.Spec.Flux.Github.Repository)
+	localGitWriterPath := filepath.Join("git", gitOpsConfig.Spec.Flux.Repository())
 	gitwriter, err := writer.WithDir(localGitWriterPath)
 	if err != nil {
 		return nil, fmt.Errorf("error creating file writer: %v", err)
@@ -125,6 +130,9 @@ type Flux interface {
 
 	// Reconcile reconciles sources and resources
 	Reconcile(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error
+
+	// GetObjectByRef gets an object of any kind, like the EKS-A objects and the Flux sources and kustomizations
+	GetObjectByRef(ctx context.Context, ref corev1.ObjectReference, namespace, kubeconfig string) (*unstructured.Unstructured, error)
 }
 
 func (f *FluxAddonClient) SetRetier(retrier *retrier.Retrier) {
@@ -141,11 +149,11 @@ func (f *FluxAddonClient) ForceReconcileGitRepo(ctx context.Context, cluster *ty
 		clusterSpec:     clusterSpec,
 	}
 
-	return f.flux.ForceReconcileGitRepo(ctx, cluster, fc.clusterSpec.GitOpsConfig.Spec.Flux.Github.FluxSystemNamespace)
+	return f.flux.ForceReconcileGitRepo(ctx, cluster, fc.namespace())
 }
 
 // InstallGitOps validates and sets up the gitops/flux config, creates a repository if one doesn’t exist,
-// commits the manifests for both eks-a cluster and flux components to the default branch at the specified path,
+// commits the manifests for both eks-a cluster and flux components, and the add-ons scaffolding, to the default branch at the specified path,
 // and installs the Flux components. Then it configures the target cluster to synchronize with the specified path
 // inside the repository.
 func (f *FluxAddonClient) InstallGitOps(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
@@ -230,6 +238,13 @@ func (f *FluxAddonClient) ResumeGitOpsKustomization(ctx context.Context, cluster
 		clusterSpec:     clusterSpec,
 	}
 
+	if f.pendingPullRequest != nil {
+		logger.MarkWarning("Flux kustomization stays suspended until the pull request with the cluster changes is merged",
+			"url", f.pendingPullRequest.Url,
+			"resume", fmt.Sprintf("flux resume kustomization %s --namespace %s", fc.namespace(), fc.namespace()))
+		return nil
+	}
+
 	logger.V(3).Info("resume reconciliation of all Kustomization", "namespace", fc.namespace())
 	return f.retrier.Retry(func() error {
 		return fc.flux.ResumeKustomization(ctx, cluster, clusterSpec.GitOpsConfig)
@@ -253,6 +268,10 @@ func (f *FluxAddonClient) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cl
 		return err
 	}
 
+	if err := fc.checkoutChangesBranch(); err != nil {
+		return err
+	}
+
 	if err := fc.writeEksaSystemFiles(); err != nil {
 		return err
 	}
@@ -263,7 +282,8 @@ func (f *FluxAddonClient) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cl
 		return &ConfigVersionControlFailedError{Err: fmt.Errorf("error when adding %s to git: %v", path, err)}
 	}
 
-	err = f.pushToRemoteRepo(ctx, path, updateClusterconfigCommitMessage)
+	flux := clusterSpec.GitOpsConfig.Spec.Flux
+	err = fc.publishChanges(ctx, path, updateClusterconfigCommitMessage, flux.UsePullRequests() && flux.PullRequest.WaitForMerge)
 	if err != nil {
 		return err
 	}
@@ -327,6 +347,10 @@ func (f *FluxAddonClient) CleanupGitRepo(ctx context.Context, clusterSpec *clust
 		return &ConfigVersionControlFailedError{Err: fmt.Errorf("error when removing %s in git: %v", p, err)}
 	}
 
+	if err = fc.removeAddonsFilesFromGit(); err != nil {
+		return err
+	}
+
 	err = f.pushToRemoteRepo(ctx, p, deleteClusterconfigCommitMessage)
 	if err != nil {
 		return err
@@ -356,6 +380,8 @@ type fluxForCluster struct {
 	clusterSpec      *cluster.Spec
 	datacenterConfig providers.DatacenterConfig
 	machineConfigs   []providers.MachineConfig
+	// changesBranch is the branch of the pull request with the changes, empty if they are pushed to the configured branch
+	changesBranch string
 }
 
 // commitFluxAndClusterConfigToGit commits the cluster configuration file to the flux-managed git repository.
@@ -365,7 +391,7 @@ type fluxForCluster struct {
 func (fc *fluxForCluster) commitFluxAndClusterConfigToGit(ctx context.Context) error {
 	logger.Info("Adding cluster configuration files to Git")
 	config := fc.clusterSpec.GitOpsConfig
-	repository := config.Spec.Flux.Github.Repository
+	repository := config.Spec.Flux.Repository()
 
 	if err := fc.setupRepository(ctx); err != nil {
 		return err
@@ -392,10 +418,17 @@ func (fc *fluxForCluster) commitFluxAndClusterConfigToGit(ctx context.Context) e
 	} else {
 		logger.V(3).Info("Skipping flux custom manifest files")
 	}
-	p := path.Dir(config.Spec.Flux.Github.ClusterConfigPath)
-	err = fc.gitOpts.Git.Add(p)
-	if err != nil {
-		return &ConfigVersionControlFailedError{Err: fmt.Errorf("error when adding %s to git: %v", p, err)}
+
+	logger.V(3).Info("Generating add-ons manifest files...")
+	if err = fc.writeAddonsFiles(); err != nil {
+		return &ConfigVersionControlFailedError{Err: err}
+	}
+
+	p := path.Dir(config.Spec.Flux.ClusterConfigPath())
+	for _, dir := range []string{p, fc.addonsDir()} {
+		if err = fc.gitOpts.Git.Add(dir); err != nil {
+			return &ConfigVersionControlFailedError{Err: fmt.Errorf("error when adding %s to git: %v", dir, err)}
+		}
 	}
 
 	err = fc.FluxAddonClient.pushToRemoteRepo(ctx, p, initialClusterconfigCommitMessage)
@@ -609,10 +642,10 @@ func (fc *fluxForCluster) createRemoteRepository(ctx context.Context) error {
 	o := fc.owner()
 	p := fc.personal()
 	d := "EKS-A cluster configuration repository"
-	logger.V(3).Info("Remote Github repo does not exist; will create and initialize", "repo", n, "owner", o)
+	logger.V(3).Info("Remote repo does not exist; will create and initialize", "repo", n, "owner", o)
 
 	opts := git.CreateRepoOpts{Name: n, Owner: o, Description: d, Personal: p, Privacy: true}
-	logger.V(3).Info("Creating remote Github repo", "options", opts)
+	logger.V(3).Info("Creating remote repo", "options", opts)
 	err := fc.FluxAddonClient.retrier.Retry(func() error {
 		_, err := fc.gitOpts.Git.CreateRepo(ctx, opts)
 		return err
@@ -666,27 +699,27 @@ func (fc *fluxForCluster) validateRemoteConfigPathDoesNotExist(ctx context.Conte
 }
 
 func (fc *fluxForCluster) namespace() string {
-	return fc.clusterSpec.GitOpsConfig.Spec.Flux.Github.FluxSystemNamespace
+	return fc.clusterSpec.GitOpsConfig.Spec.Flux.SystemNamespace()
 }
 
 func (fc *fluxForCluster) repository() string {
-	return fc.clusterSpec.GitOpsConfig.Spec.Flux.Github.Repository
+	return fc.clusterSpec.GitOpsConfig.Spec.Flux.Repository()
 }
 
 func (fc *fluxForCluster) owner() string {
-	return fc.clusterSpec.GitOpsConfig.Spec.Flux.Github.Owner
+	return fc.clusterSpec.GitOpsConfig.Spec.Flux.Owner()
 }
 
 func (fc *fluxForCluster) branch() string {
-	return fc.clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch
+	return fc.clusterSpec.GitOpsConfig.Spec.Flux.Branch()
 }
 
 func (fc *fluxForCluster) personal() bool {
-	return fc.clusterSpec.GitOpsConfig.Spec.Flux.Github.Personal
+	return fc.clusterSpec.GitOpsConfig.Spec.Flux.Personal()
 }
 
 func (fc *fluxForCluster) path() string {
-	return fc.clusterSpec.GitOpsConfig.Spec.Flux.Github.ClusterConfigPath
+	return fc.clusterSpec.GitOpsConfig.Spec.Flux.ClusterConfigPath()
 }
 
 type ConfigVersionControlFailedError struct {
@@ -94,6 +94,7 @@ func TestFluxAddonClientInstallGitOpsOnManagementClusterWithPrexistingRepo(t *te
 			m.git.EXPECT().Clone(ctx).Return(nil)
 			m.git.EXPECT().Branch(clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
 			m.git.EXPECT().Add(path.Dir(tt.expectedClusterConfigGitPath)).Return(nil)
+			m.git.EXPECT().Add(path.Join("addons", tt.expectedClusterConfigGitPath, tt.clusterName)).Return(nil)
 			m.git.EXPECT().Commit(test.OfType("string")).Return(nil)
 			m.git.EXPECT().Push(ctx).Return(nil)
 			m.git.EXPECT().Pull(ctx, clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
@@ -116,6 +117,11 @@ func TestFluxAddonClientInstallGitOpsOnManagementClusterWithPrexistingRepo(t *te
 
 			expectedFluxSyncPath := path.Join(g.Writer.Dir(), tt.expectedFluxSystemDirPath, tt.expectedFluxSyncFileName)
 			test.AssertFilesEquals(t, expectedFluxSyncPath, "./testdata/gotk-sync.yaml")
+
+			expectedAddonsDirPath := path.Join(g.Writer.Dir(), "addons", tt.expectedClusterConfigGitPath, tt.clusterName)
+			test.AssertFilesEquals(t, path.Join(expectedAddonsDirPath, defaultKustomizationManifestFileName), "./testdata/addons-kustomization-management.yaml")
+			test.AssertFilesEquals(t, path.Join(expectedAddonsDirPath, "namespaces.yaml"), "./testdata/addons-namespaces.yaml")
+			test.AssertFilesEquals(t, path.Join(expectedAddonsDirPath, "helmrelease-sample.yaml"), "./testdata/addons-helmrelease-sample.yaml")
 		})
 	}
 }
@@ -135,6 +141,7 @@ func TestFluxAddonClientInstallGitOpsOnWorkloadClusterWithPrexistingRepo(t *test
 	m.git.EXPECT().Clone(ctx).Return(nil)
 	m.git.EXPECT().Branch(clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
 	m.git.EXPECT().Add(path.Dir("clusters/management-cluster")).Return(nil)
+	m.git.EXPECT().Add("addons/clusters/management-cluster/workload-cluster").Return(nil)
 	m.git.EXPECT().Commit(test.OfType("string")).Return(nil)
 	m.git.EXPECT().Push(ctx).Return(nil)
 	m.git.EXPECT().Pull(ctx, clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
@@ -161,6 +168,13 @@ func TestFluxAddonClientInstallGitOpsOnWorkloadClusterWithPrexistingRepo(t *test
 	if _, err := os.Stat(expectedFluxSyncPath); errors.Is(err, os.ErrExist) {
 		t.Errorf("File exists at %s, should not exist", expectedFluxSyncPath)
 	}
+
+	expectedAddonsDirPath := path.Join(g.Writer.Dir(), "addons/clusters/management-cluster/workload-cluster")
+	test.AssertFilesEquals(t, path.Join(expectedAddonsDirPath, defaultKustomizationManifestFileName), "./testdata/addons-kustomization-workload.yaml")
+	test.AssertFilesEquals(t, path.Join(expectedAddonsDirPath, "namespaces.yaml"), "./testdata/addons-namespaces.yaml")
+
+	expectedAddonsSyncPath := path.Join(g.Writer.Dir(), "clusters/management-cluster/workload-cluster/addons-sync.yaml")
+	test.AssertFilesEquals(t, expectedAddonsSyncPath, "./testdata/addons-sync-workload.yaml")
 }
 
 func TestFluxAddonClientInstallGitOpsNoPrexistingRepo(t *testing.T) {
@@ -235,6 +249,7 @@ func TestFluxAddonClientInstallGitOpsNoPrexistingRepo(t *testing.T) {
 			m.git.EXPECT().Commit(gomock.Any()).Return(nil)
 			m.git.EXPECT().Branch(b).Return(nil)
 			m.git.EXPECT().Add(path.Dir(tt.expectedClusterConfigGitPath)).Return(nil)
+			m.git.EXPECT().Add(path.Join("addons", tt.expectedClusterConfigGitPath, tt.clusterName)).Return(nil)
 			m.git.EXPECT().Commit(test.OfType("string")).Return(nil)
 			m.git.EXPECT().Push(ctx).Return(nil)
 			m.git.EXPECT().Pull(ctx, b).Return(nil)
@@ -305,6 +320,7 @@ func TestFluxAddonClientInstallGitOpsToolkitsBareRepo(t *testing.T) {
 			m.git.EXPECT().Commit(gomock.Any()).Return(nil)
 			m.git.EXPECT().Branch(clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
 			m.git.EXPECT().Add(path.Dir(tt.expectedClusterConfigGitPath)).Return(nil)
+			m.git.EXPECT().Add(path.Join("addons", tt.expectedClusterConfigGitPath, tt.clusterName)).Return(nil)
 			m.git.EXPECT().Commit(test.OfType("string")).Return(nil)
 			m.git.EXPECT().Push(ctx).Return(nil)
 			m.git.EXPECT().Pull(ctx, clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
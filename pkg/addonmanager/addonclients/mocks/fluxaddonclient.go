@@ -11,6 +11,8 @@ import (
 	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // MockFlux is a mock of Flux interface.
@@ -78,6 +80,21 @@ func (mr *MockFluxMockRecorder) ForceReconcileGitRepo(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceReconcileGitRepo", reflect.TypeOf((*MockFlux)(nil).ForceReconcileGitRepo), arg0, arg1, arg2)
 }
 
+// GetObjectByRef mocks base method.
+func (m *MockFlux) GetObjectByRef(arg0 context.Context, arg1 v1.ObjectReference, arg2, arg3 string) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectByRef", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectByRef indicates an expected call of GetObjectByRef.
+func (mr *MockFluxMockRecorder) GetObjectByRef(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectByRef", reflect.TypeOf((*MockFlux)(nil).GetObjectByRef), arg0, arg1, arg2, arg3)
+}
+
 // PauseKustomization mocks base method.
 func (m *MockFlux) PauseKustomization(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.GitOpsConfig) error {
 	m.ctrl.T.Helper()
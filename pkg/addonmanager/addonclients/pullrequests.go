@@ -0,0 +1,114 @@
+package addonclients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const (
+	defaultPullRequestMergeTimeout = time.Hour
+	pullRequestPollPeriod          = 30 * time.Second
+	changesBranchTimeFormat        = "20060102150405"
+
+	pullRequestBody = "Changes to the configuration of cluster %s; generated by EKS-A CLI"
+)
+
+// PullRequestClosedError is returned when a pull request with changes is closed without merging it
+type PullRequestClosedError struct {
+	Url string
+}
+
+func (e *PullRequestClosedError) Error() string {
+	return fmt.Sprintf("pull request %s was closed without merging it", e.Url)
+}
+
+// checkoutChangesBranch switches to a new branch for the changes if they must be proposed in a pull request.
+// It must be called after syncing the repository and before writing any file, since switching branches discards them
+func (fc *fluxForCluster) checkoutChangesBranch() error {
+	if !fc.clusterSpec.GitOpsConfig.Spec.Flux.UsePullRequests() {
+		return nil
+	}
+
+	fc.changesBranch = fmt.Sprintf("eksa-%s-%s", fc.clusterSpec.Name, time.Now().UTC().Format(changesBranchTimeFormat))
+	logger.V(3).Info("Switching to new branch for the changes", "branch", fc.changesBranch)
+	if err := fc.gitOpts.Git.Branch(fc.changesBranch); err != nil {
+		return &ConfigVersionControlFailedError{Err: fmt.Errorf("error creating branch %s for the changes: %v", fc.changesBranch, err)}
+	}
+	return nil
+}
+
+// publishChanges commits the changes and pushes them to the configured branch, or opens a pull request to it when
+// the changes were made in a new branch. If waitForMerge is false, it doesn't wait for the pull request to be
+// merged and keeps it as pending, so the Flux kustomization is not resumed with the old configuration
+func (fc *fluxForCluster) publishChanges(ctx context.Context, path, msg string, waitForMerge bool) error {
+	if err := fc.FluxAddonClient.pushToRemoteRepo(ctx, path, msg); err != nil {
+		return err
+	}
+	if fc.changesBranch == "" {
+		return nil
+	}
+
+	pr, err := fc.gitOpts.Git.CreatePullRequest(ctx, git.PullRequestOpts{
+		Title: msg,
+		Body:  fmt.Sprintf(pullRequestBody, fc.clusterSpec.Name),
+		Head:  fc.changesBranch,
+		Base:  fc.branch(),
+	})
+	if err != nil {
+		return &ConfigVersionControlFailedError{Err: err}
+	}
+	logger.Info("Opened pull request with the changes", "url", pr.Url)
+
+	if !waitForMerge {
+		fc.pendingPullRequest = pr
+		return nil
+	}
+
+	if err = fc.waitForPullRequestMerge(ctx, pr); err != nil {
+		return err
+	}
+
+	// Go back to the configured branch, which now includes the merged changes
+	if err = fc.gitOpts.Git.Branch(fc.branch()); err != nil {
+		return fmt.Errorf("failed to switch to git branch %s: %v", fc.branch(), err)
+	}
+	return nil
+}
+
+func (fc *fluxForCluster) waitForPullRequestMerge(ctx context.Context, pr *git.PullRequest) error {
+	timeout := defaultPullRequestMergeTimeout
+	if t := fc.clusterSpec.GitOpsConfig.Spec.Flux.PullRequest.MergeTimeout; t != nil {
+		timeout = t.Duration
+	}
+
+	logger.Info("Waiting for pull request to be merged", "url", pr.Url, "timeout", timeout)
+	r := retrier.New(timeout, retrier.WithRetryPolicy(func(totalRetries int, err error) (bool, time.Duration) {
+		var closed *PullRequestClosedError
+		return !errors.As(err, &closed), pullRequestPollPeriod
+	}))
+	err := r.Retry(func() error {
+		p, err := fc.gitOpts.Git.GetPullRequest(ctx, pr.Number)
+		if err != nil {
+			return err
+		}
+		if p.Merged {
+			return nil
+		}
+		if p.Closed {
+			return &PullRequestClosedError{Url: pr.Url}
+		}
+		return fmt.Errorf("pull request %s is not merged yet", pr.Url)
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for pull request %s to be merged: %v", pr.Url, err)
+	}
+
+	logger.V(3).Info("Pull request merged", "url", pr.Url)
+	return nil
+}
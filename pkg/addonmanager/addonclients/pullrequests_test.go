@@ -0,0 +1,116 @@
+package addonclients_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/addonmanager/addonclients"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	pullRequestEksaSystemDir = "clusters/management-cluster/management-cluster/eksa-system"
+	pullRequestUrl           = "https://github.com/mFowler/testRepo/pull/1"
+)
+
+func newPullRequestClusterSpec(waitForMerge bool) *c.Spec {
+	clusterSpec := newClusterSpec(v1alpha1.NewCluster("management-cluster"), "")
+	clusterSpec.GitOpsConfig.Spec.Flux.PullRequest = &v1alpha1.PullRequest{WaitForMerge: waitForMerge}
+	return clusterSpec
+}
+
+// expectChangesInPullRequest expects the changes to the cluster config to be pushed to a new branch
+// and proposed in a pull request to the configured branch
+func expectChangesInPullRequest(g *WithT, ctx context.Context, m *mocks, clusterSpec *c.Spec) *gomock.Call {
+	base := clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch
+	var head string
+	created := m.git.EXPECT().CreatePullRequest(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, opts git.PullRequestOpts) (*git.PullRequest, error) {
+			g.Expect(opts.Head).To(Equal(head))
+			g.Expect(strings.HasPrefix(opts.Head, "eksa-management-cluster-")).To(BeTrue())
+			g.Expect(opts.Base).To(Equal(base))
+			return &git.PullRequest{Number: 1, Url: pullRequestUrl}, nil
+		},
+	)
+	gomock.InOrder(
+		m.git.EXPECT().GetRepo(ctx).Return(&git.Repository{Name: clusterSpec.GitOpsConfig.Spec.Flux.Github.Repository}, nil),
+		m.git.EXPECT().Clone(ctx).Return(nil),
+		m.git.EXPECT().Branch(base).Return(nil),
+		m.git.EXPECT().Branch(gomock.Not(base)).Do(func(name string) { head = name }).Return(nil),
+		m.git.EXPECT().Add(pullRequestEksaSystemDir).Return(nil),
+		m.git.EXPECT().Commit(test.OfType("string")).Return(nil),
+		m.git.EXPECT().Push(ctx).Return(nil),
+		created,
+	)
+	return created
+}
+
+func TestFluxAddonClientUpdateGitRepoEksaSpecPullRequestWaitForMerge(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, m, _ := newAddonClient(t)
+	clusterSpec := newPullRequestClusterSpec(true)
+	cluster := &types.Cluster{}
+
+	created := expectChangesInPullRequest(g, ctx, m, clusterSpec)
+	merged := m.git.EXPECT().GetPullRequest(ctx, 1).Return(&git.PullRequest{Number: 1, Url: pullRequestUrl, Merged: true, Closed: true}, nil).After(created)
+	m.git.EXPECT().Branch(clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil).After(merged)
+	m.flux.EXPECT().ResumeKustomization(ctx, cluster, clusterSpec.GitOpsConfig)
+
+	g.Expect(f.UpdateGitEksaSpec(ctx, clusterSpec, datacenterConfig(clusterSpec.Name), []providers.MachineConfig{machineConfig(clusterSpec.Name)})).To(Succeed())
+	g.Expect(f.ResumeGitOpsKustomization(ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestFluxAddonClientUpdateGitRepoEksaSpecPullRequestClosed(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, m, _ := newAddonClient(t)
+	clusterSpec := newPullRequestClusterSpec(true)
+
+	created := expectChangesInPullRequest(g, ctx, m, clusterSpec)
+	m.git.EXPECT().GetPullRequest(ctx, 1).Return(&git.PullRequest{Number: 1, Url: pullRequestUrl, Closed: true}, nil).After(created)
+
+	err := f.UpdateGitEksaSpec(ctx, clusterSpec, datacenterConfig(clusterSpec.Name), []providers.MachineConfig{machineConfig(clusterSpec.Name)})
+	g.Expect(err).To(MatchError(ContainSubstring("was closed without merging it")))
+}
+
+func TestFluxAddonClientUpdateGitRepoEksaSpecPullRequestNoWait(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, m, _ := newAddonClient(t)
+	clusterSpec := newPullRequestClusterSpec(false)
+	cluster := &types.Cluster{}
+
+	expectChangesInPullRequest(g, ctx, m, clusterSpec)
+
+	g.Expect(f.UpdateGitEksaSpec(ctx, clusterSpec, datacenterConfig(clusterSpec.Name), []providers.MachineConfig{machineConfig(clusterSpec.Name)})).To(Succeed())
+	// The kustomization is not resumed, Flux would revert the cluster to the config in the branch
+	g.Expect(f.ResumeGitOpsKustomization(ctx, cluster, clusterSpec)).To(Succeed())
+}
+
+func TestFluxAddonClientUpdateGitRepoEksaSpecPullRequestError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, m, _ := newAddonClient(t)
+	clusterSpec := newPullRequestClusterSpec(false)
+
+	m.git.EXPECT().GetRepo(ctx).Return(&git.Repository{Name: clusterSpec.GitOpsConfig.Spec.Flux.Github.Repository}, nil)
+	m.git.EXPECT().Clone(ctx).Return(nil)
+	m.git.EXPECT().Branch(gomock.Any()).Return(nil).Times(2)
+	m.git.EXPECT().Add(pullRequestEksaSystemDir).Return(nil)
+	m.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	m.git.EXPECT().Push(ctx).Return(nil)
+	m.git.EXPECT().CreatePullRequest(ctx, gomock.Any()).Return(nil, &git.RepositoryDoesNotExistError{})
+
+	err := f.UpdateGitEksaSpec(ctx, clusterSpec, datacenterConfig(clusterSpec.Name), []providers.MachineConfig{machineConfig(clusterSpec.Name)})
+	g.Expect(err).To(BeAssignableToTypeOf(&addonclients.ConfigVersionControlFailedError{}))
+}
@@ -0,0 +1,304 @@
+package addonclients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	gitRepositoryKind = "GitRepository"
+	kustomizationKind = "Kustomization"
+	readyCondition    = "Ready"
+	unknownStatus     = "Unknown"
+	unsetValue        = "<unset>"
+)
+
+var fluxApiVersions = map[string]string{
+	gitRepositoryKind: "source.toolkit.fluxcd.io/v1beta1",
+	kustomizationKind: "kustomize.toolkit.fluxcd.io/v1beta1",
+}
+
+// Status reports the drift between the EKS-A objects of a cluster in its GitOps repository and in the management
+// cluster, and the readiness of the Flux resources that sync them
+type Status struct {
+	Cluster       string               `json:"cluster"`
+	Repository    string               `json:"repository"`
+	Branch        string               `json:"branch"`
+	Path          string               `json:"path"`
+	FluxResources []FluxResourceStatus `json:"fluxResources"`
+	Objects       []ObjectStatus       `json:"objects"`
+}
+
+// FluxResourceStatus describes the readiness of a GitRepository or Kustomization and the last revision it synced
+type FluxResourceStatus struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Ready     string `json:"ready"`
+	Suspended bool   `json:"suspended"`
+	Revision  string `json:"revision,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// ObjectStatus lists the fields of an object spec whose value in the cluster differs from the repository
+type ObjectStatus struct {
+	Kind      string      `json:"kind"`
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Diffs     []FieldDiff `json:"diffs,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// FieldDiff is a field with a different value in the repository and in the cluster
+type FieldDiff struct {
+	Path       string      `json:"path"`
+	Repository interface{} `json:"repository"`
+	Cluster    interface{} `json:"cluster"`
+}
+
+// Drifted returns true if any object in the cluster differs from the repository
+func (s *Status) Drifted() bool {
+	for _, o := range s.Objects {
+		if len(o.Diffs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Status clones the GitOps repository and compares the spec of the EKS-A objects of the cluster in it with the ones
+// in the management cluster. Only the fields set in the repository are compared, so the defaults set in the cluster
+// are not reported as drift
+func (f *FluxAddonClient) Status(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) (*Status, error) {
+	if f.shouldSkipFlux() {
+		return nil, fmt.Errorf("GitOps is not configured for cluster %s", clusterSpec.Name)
+	}
+
+	clusterSpec.SetDefaultGitOps()
+	fc := &fluxForCluster{
+		FluxAddonClient: f,
+		clusterSpec:     clusterSpec,
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	objects, err := fc.repositoryObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Cluster:       clusterSpec.Name,
+		Repository:    fc.repository(),
+		Branch:        fc.branch(),
+		Path:          fc.eksaSystemDir(),
+		FluxResources: fc.fluxResourcesStatus(ctx, managementCluster),
+	}
+	for i := range objects {
+		status.Objects = append(status.Objects, f.objectStatus(ctx, managementCluster, &objects[i]))
+	}
+
+	return status, nil
+}
+
+// repositoryObjects reads the EKS-A objects of the cluster from the eksa-system folder in the repository
+func (fc *fluxForCluster) repositoryObjects() ([]unstructured.Unstructured, error) {
+	fileName := filepath.Join(fc.gitOpts.Writer.Dir(), fc.eksaSystemDir(), clusterConfigFileName)
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading cluster config from GitOps repository: %v", err)
+	}
+
+	var objects []unstructured.Unstructured
+	for _, c := range strings.Split(string(content), v1alpha1.YamlSeparator) {
+		var obj unstructured.Unstructured
+		if err := yaml.Unmarshal([]byte(c), &obj); err != nil {
+			return nil, fmt.Errorf("unable to parse %s\nyaml: %s\n %v", fileName, c, err)
+		}
+		if obj.GetKind() == "" {
+			continue
+		}
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace("default")
+		}
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+func (f *FluxAddonClient) objectStatus(ctx context.Context, managementCluster *types.Cluster, obj *unstructured.Unstructured) ObjectStatus {
+	status := ObjectStatus{
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	live, err := f.flux.GetObjectByRef(ctx, objectRef(obj.GetAPIVersion(), obj.GetKind(), obj.GetName()), obj.GetNamespace(), managementCluster.KubeconfigFile)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Diffs = diffFields("spec", obj.Object["spec"], live.Object["spec"], nil)
+	return status
+}
+
+func (fc *fluxForCluster) fluxResourcesStatus(ctx context.Context, managementCluster *types.Cluster) []FluxResourceStatus {
+	ns := fc.namespace()
+	resources := []FluxResourceStatus{
+		{Kind: gitRepositoryKind, Namespace: ns, Name: ns},
+		{Kind: kustomizationKind, Namespace: ns, Name: ns},
+	}
+	// Clusters created before the add-ons folder was scaffolded don't sync it
+	if validations.FileExists(path.Join(fc.gitOpts.Writer.Dir(), fc.addonsSyncFile())) {
+		resources = append(resources, FluxResourceStatus{Kind: kustomizationKind, Namespace: constants.EksaSystemNamespace, Name: fc.clusterSpec.Name + "-addons"})
+	}
+
+	for i := range resources {
+		r := &resources[i]
+		r.Ready = unknownStatus
+		obj, err := fc.flux.GetObjectByRef(ctx, objectRef(fluxApiVersions[r.Kind], r.Kind, r.Name), r.Namespace, managementCluster.KubeconfigFile)
+		if err != nil {
+			r.Message = err.Error()
+			continue
+		}
+		r.setFromObject(obj)
+	}
+
+	return resources
+}
+
+func (s *FluxResourceStatus) setFromObject(obj *unstructured.Unstructured) {
+	s.Suspended, _, _ = unstructured.NestedBool(obj.Object, "spec", "suspend")
+	if s.Kind == gitRepositoryKind {
+		s.Revision, _, _ = unstructured.NestedString(obj.Object, "status", "artifact", "revision")
+	} else {
+		s.Revision, _, _ = unstructured.NestedString(obj.Object, "status", "lastAppliedRevision")
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != readyCondition {
+			continue
+		}
+		if status, ok := condition["status"].(string); ok {
+			s.Ready = status
+		}
+		s.Message, _ = condition["message"].(string)
+	}
+}
+
+// diffFields compares the fields set in repo with the same fields in live. Lists with the same length are compared
+// element by element, otherwise the whole list is reported
+func diffFields(fieldPath string, repo, live interface{}, diffs []FieldDiff) []FieldDiff {
+	switch r := repo.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return append(diffs, FieldDiff{Path: fieldPath, Repository: repo, Cluster: live})
+		}
+		keys := make([]string, 0, len(r))
+		for k := range r {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			diffs = diffFields(fieldPath+"."+k, r[k], l[k], diffs)
+		}
+		return diffs
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(r) {
+			return append(diffs, FieldDiff{Path: fieldPath, Repository: repo, Cluster: live})
+		}
+		for i := range r {
+			diffs = diffFields(fmt.Sprintf("%s[%d]", fieldPath, i), r[i], l[i], diffs)
+		}
+		return diffs
+	default:
+		if !reflect.DeepEqual(repo, live) {
+			return append(diffs, FieldDiff{Path: fieldPath, Repository: repo, Cluster: live})
+		}
+		return diffs
+	}
+}
+
+func objectRef(apiVersion, kind, name string) corev1.ObjectReference {
+	return corev1.ObjectReference{APIVersion: apiVersion, Kind: kind, Name: name}
+}
+
+// Print writes the readiness of the Flux resources, whether each object is in sync and the fields that drifted
+func (s *Status) Print(w io.Writer) error {
+	fmt.Fprintf(w, "Repository %s, branch %s, path %s\n\n", s.Repository, s.Branch, s.Path)
+
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "FLUX RESOURCE\tNAMESPACE\tNAME\tREADY\tSUSPENDED\tREVISION\tMESSAGE")
+	for _, r := range s.FluxResources {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%s\t%s\n", r.Kind, r.Namespace, r.Name, r.Ready, r.Suspended, r.Revision, r.Message)
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "OBJECT\tNAMESPACE\tNAME\tSTATUS")
+	for _, o := range s.Objects {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", o.Kind, o.Namespace, o.Name, o.status())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, o := range s.Objects {
+		if len(o.Diffs) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s %s/%s:\n", o.Kind, o.Namespace, o.Name)
+		for _, d := range o.Diffs {
+			fmt.Fprintf(w, "  %s\n    repository: %s\n    cluster:    %s\n", d.Path, printValue(d.Repository), printValue(d.Cluster))
+		}
+	}
+
+	return nil
+}
+
+func (o ObjectStatus) status() string {
+	switch {
+	case o.Error != "":
+		return o.Error
+	case len(o.Diffs) > 0:
+		return fmt.Sprintf("Drifted (%d fields)", len(o.Diffs))
+	default:
+		return "InSync"
+	}
+}
+
+func printValue(v interface{}) string {
+	if v == nil {
+		return unsetValue
+	}
+	content, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(content)
+}
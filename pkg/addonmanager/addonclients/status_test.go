@@ -0,0 +1,121 @@
+package addonclients_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/addonmanager/addonclients"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	statusEksaSystemDir = "clusters/management-cluster/management-cluster/eksa-system"
+	statusKubeconfig    = "management-cluster.kubeconfig"
+)
+
+func liveObject(content map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: content}
+}
+
+func objectRef(apiVersion, kind, name string) corev1.ObjectReference {
+	return corev1.ObjectReference{APIVersion: apiVersion, Kind: kind, Name: name}
+}
+
+func TestFluxAddonClientStatus(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, m, gitOpts := newAddonClient(t)
+	clusterSpec := newClusterSpec(v1alpha1.NewCluster("management-cluster"), "")
+	cluster := &types.Cluster{Name: "management-cluster", KubeconfigFile: statusKubeconfig}
+
+	g.Expect(os.MkdirAll(path.Join(gitOpts.Writer.Dir(), ".git"), 0o755)).To(Succeed())
+	g.Expect(os.MkdirAll(path.Join(gitOpts.Writer.Dir(), statusEksaSystemDir), 0o755)).To(Succeed())
+	content, err := ioutil.ReadFile("testdata/status-eksa-cluster.yaml")
+	g.Expect(err).To(Succeed())
+	g.Expect(ioutil.WriteFile(path.Join(gitOpts.Writer.Dir(), statusEksaSystemDir, defaultEksaClusterConfigFileName), content, 0o644)).To(Succeed())
+
+	m.git.EXPECT().Branch(clusterSpec.GitOpsConfig.Spec.Flux.Github.Branch).Return(nil)
+	m.flux.EXPECT().GetObjectByRef(ctx, objectRef("source.toolkit.fluxcd.io/v1beta1", "GitRepository", "flux-system"), "flux-system", statusKubeconfig).Return(
+		liveObject(map[string]interface{}{
+			"status": map[string]interface{}{
+				"artifact": map[string]interface{}{"revision": "testBranch/5f2c1a9"},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "True", "message": "stored artifact for revision 'testBranch/5f2c1a9'"},
+				},
+			},
+		}), nil)
+	m.flux.EXPECT().GetObjectByRef(ctx, objectRef("kustomize.toolkit.fluxcd.io/v1beta1", "Kustomization", "flux-system"), "flux-system", statusKubeconfig).Return(
+		liveObject(map[string]interface{}{
+			"spec": map[string]interface{}{"suspend": true},
+			"status": map[string]interface{}{
+				"lastAppliedRevision": "testBranch/0b7d3e4",
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "False", "message": "kustomization path not found"},
+				},
+			},
+		}), nil)
+	m.flux.EXPECT().GetObjectByRef(ctx, objectRef(v1alpha1.GroupVersion.String(), "Cluster", "management-cluster"), "default", statusKubeconfig).Return(
+		liveObject(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"controlPlaneConfiguration": map[string]interface{}{"count": int64(3)},
+				"kubernetesVersion":         "1.21",
+				"workerNodeGroupConfigurations": []interface{}{
+					map[string]interface{}{"count": int64(5), "name": "md-0"},
+				},
+				"bundlesRef": map[string]interface{}{"name": "bundles-1"},
+			},
+		}), nil)
+	m.flux.EXPECT().GetObjectByRef(ctx, objectRef(v1alpha1.GroupVersion.String(), "VSphereDatacenterConfig", "management-cluster"), "default", statusKubeconfig).Return(
+		nil, errors.New("vspheredatacenterconfigs not found"))
+	m.flux.EXPECT().GetObjectByRef(ctx, objectRef(v1alpha1.GroupVersion.String(), "VSphereMachineConfig", "management-cluster"), "default", statusKubeconfig).Return(
+		liveObject(map[string]interface{}{
+			"spec": map[string]interface{}{"memoryMiB": int64(8192), "numCPUs": int64(2), "osFamily": "bottlerocket"},
+		}), nil)
+
+	status, err := f.Status(ctx, cluster, clusterSpec)
+	g.Expect(err).To(Succeed())
+	g.Expect(status.Drifted()).To(BeTrue())
+	g.Expect(status.Objects).To(HaveLen(3))
+	g.Expect(status.Objects[0].Diffs).To(Equal([]addonclients.FieldDiff{
+		{Path: "spec.workerNodeGroupConfigurations[0].count", Repository: int64(3), Cluster: int64(5)},
+	}))
+	g.Expect(status.Objects[2].Diffs).To(BeEmpty())
+
+	buf := &bytes.Buffer{}
+	g.Expect(status.Print(buf)).To(Succeed())
+	test.AssertContentToFile(t, buf.String(), "testdata/status.txt")
+}
+
+func TestFluxAddonClientStatusSkip(t *testing.T) {
+	g := NewWithT(t)
+	f := addonclients.NewFluxAddonClient(nil, nil)
+	clusterSpec := test.NewClusterSpec()
+
+	_, err := f.Status(context.Background(), &types.Cluster{}, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("GitOps is not configured")))
+}
+
+func TestFluxAddonClientStatusMissingClusterConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	f, m, gitOpts := newAddonClient(t)
+	clusterSpec := newClusterSpec(v1alpha1.NewCluster("management-cluster"), "")
+
+	g.Expect(os.MkdirAll(path.Join(gitOpts.Writer.Dir(), ".git"), 0o755)).To(Succeed())
+	m.git.EXPECT().Branch(gomock.Any()).Return(nil)
+
+	_, err := f.Status(ctx, &types.Cluster{}, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("failed reading cluster config from GitOps repository")))
+}
@@ -33,7 +33,7 @@ func (f *FluxAddonClient) Upgrade(ctx context.Context, managementCluster *types.
 	if err := f.upgradeFilesAndCommit(ctx, newSpec); err != nil {
 		return nil, fmt.Errorf("failed upgrading Flux from bundles %d to bundles %d: %v", currentSpec.Bundles.Spec.Number, newSpec.Bundles.Spec.Number, err)
 	}
-	if err := f.flux.DeleteFluxSystemSecret(ctx, managementCluster, newSpec.GitOpsConfig.Spec.Flux.Github.FluxSystemNamespace); err != nil {
+	if err := f.flux.DeleteFluxSystemSecret(ctx, managementCluster, newSpec.GitOpsConfig.Spec.Flux.SystemNamespace()); err != nil {
 		return nil, fmt.Errorf("failed upgrading Flux when deleting old flux-system secret: %v", err)
 	}
 	if err := f.flux.BootstrapToolkitsComponents(ctx, managementCluster, newSpec.GitOpsConfig); err != nil {
@@ -82,6 +82,10 @@ func (f *FluxAddonClient) upgradeFilesAndCommit(ctx context.Context, newSpec *cl
 		return err
 	}
 
+	if err := fc.checkoutChangesBranch(); err != nil {
+		return err
+	}
+
 	if err := fc.commitFluxUpgradeFilesToGit(ctx); err != nil {
 		return err
 	}
@@ -106,7 +110,8 @@ func (fc *fluxForCluster) commitFluxUpgradeFilesToGit(ctx context.Context) error
 		return &ConfigVersionControlFailedError{Err: fmt.Errorf("error when adding %s to git: %v", fc.path(), err)}
 	}
 
-	if err := fc.FluxAddonClient.pushToRemoteRepo(ctx, fc.path(), upgradeFluxconfigCommitMessage); err != nil {
+	// The new components are bootstrapped from the branch, so the changes must be merged before continuing
+	if err := fc.publishChanges(ctx, fc.path(), upgradeFluxconfigCommitMessage, true); err != nil {
 		return err
 	}
 	logger.V(3).Info("Finished pushing flux custom manifest files to git",
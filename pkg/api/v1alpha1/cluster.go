@@ -11,7 +11,9 @@ import (
 	"strconv"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -160,6 +162,9 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateClusterConfigName,
 	validateControlPlaneReplicas,
 	validateWorkerNodeGroups,
+	validateExtraArgs,
+	validateUpgradeRolloutStrategy,
+	validateNodeDrainTimeout,
 	validateNetworking,
 	validateGitOps,
 	validateEtcdReplicas,
@@ -167,6 +172,7 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateProxyConfig,
 	validateMirrorConfig,
 	validatePodIAMConfig,
+	validateAuditPolicy,
 }
 
 func GetClusterConfig(fileName string) (*Cluster, error) {
@@ -231,6 +237,15 @@ func ParseClusterConfig(fileName string, clusterConfig KindAccessor) error {
 	return fmt.Errorf("cluster spec file %s is invalid or does not contain kind %s", fileName, clusterConfig.ExpectedKind())
 }
 
+// SetDefaults names the worker node groups without a name
+func (c *Cluster) SetDefaults() {
+	for i := range c.Spec.WorkerNodeGroupConfigurations {
+		if c.Spec.WorkerNodeGroupConfigurations[i].Name == "" {
+			c.Spec.WorkerNodeGroupConfigurations[i].Name = DefaultWorkerNodeGroupName(i)
+		}
+	}
+}
+
 func (c *Cluster) PauseReconcile() {
 	if c.Annotations == nil {
 		c.Annotations = map[string]string{}
@@ -312,8 +327,181 @@ func validateWorkerNodeGroups(clusterConfig *Cluster) error {
 	if len(clusterConfig.Spec.WorkerNodeGroupConfigurations) <= 0 {
 		return errors.New("worker node group must be specified")
 	}
-	if len(clusterConfig.Spec.WorkerNodeGroupConfigurations) > 1 {
-		return errors.New("only one worker node group is supported at this time")
+	names := make(map[string]bool, len(clusterConfig.Spec.WorkerNodeGroupConfigurations))
+	for i, workerNodeGroup := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		if workerNodeGroup.Name == "" {
+			workerNodeGroup.Name = DefaultWorkerNodeGroupName(i)
+		}
+		name := workerNodeGroup.Name
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return fmt.Errorf("invalid worker node group name %s: %s", name, strings.Join(errs, ", "))
+		}
+		if names[name] {
+			return fmt.Errorf("worker node group names must be unique, found duplicate name %s", name)
+		}
+		names[name] = true
+		if err := validateWorkerNodeGroupLabels(workerNodeGroup); err != nil {
+			return err
+		}
+		if err := validateWorkerNodeGroupTaints(workerNodeGroup); err != nil {
+			return err
+		}
+		if err := validateAutoScalingConfiguration(workerNodeGroup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateWorkerNodeGroupLabels(workerNodeGroup WorkerNodeGroupConfiguration) error {
+	for k, v := range workerNodeGroup.Labels {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %s in worker node group %s: %s", k, workerNodeGroup.Name, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return fmt.Errorf("invalid label value %s for key %s in worker node group %s: %s", v, k, workerNodeGroup.Name, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+func validateWorkerNodeGroupTaints(workerNodeGroup WorkerNodeGroupConfiguration) error {
+	for _, taint := range workerNodeGroup.Taints {
+		if taint.Key == "" {
+			return fmt.Errorf("taint key is required in worker node group %s", workerNodeGroup.Name)
+		}
+		switch taint.Effect {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return fmt.Errorf("invalid taint effect %s in worker node group %s", taint.Effect, workerNodeGroup.Name)
+		}
+	}
+	return nil
+}
+
+func validateAutoScalingConfiguration(workerNodeGroup WorkerNodeGroupConfiguration) error {
+	autoscaling := workerNodeGroup.AutoScalingConfiguration
+	if autoscaling == nil {
+		return nil
+	}
+	if autoscaling.MinCount < 1 {
+		return errors.New("worker node group autoscaling minCount must be greater than 0")
+	}
+	if autoscaling.MaxCount < autoscaling.MinCount {
+		return errors.New("worker node group autoscaling maxCount must be greater than or equal to minCount")
+	}
+	if workerNodeGroup.Count != 0 && (workerNodeGroup.Count < autoscaling.MinCount || workerNodeGroup.Count > autoscaling.MaxCount) {
+		return fmt.Errorf("worker node group count %d must be between autoscaling minCount %d and maxCount %d", workerNodeGroup.Count, autoscaling.MinCount, autoscaling.MaxCount)
+	}
+	return nil
+}
+
+// kubeletFlagsDenyList holds the kubelet flags set by EKS Anywhere, which can't be overridden from the cluster spec
+var kubeletFlagsDenyList = []string{
+	"cgroup-driver",
+	"cloud-provider",
+	"node-labels",
+	"provider-id",
+	"register-with-taints",
+	"tls-cipher-suites",
+}
+
+// apiServerFlagsDenyList holds the kube-apiserver flags set by EKS Anywhere, which can't be overridden from the cluster spec
+var apiServerFlagsDenyList = []string{
+	"audit-log-maxage",
+	"audit-log-maxbackup",
+	"audit-log-maxsize",
+	"audit-log-path",
+	"audit-policy-file",
+	"authentication-token-webhook-config-file",
+	"cloud-provider",
+	"oidc-client-id",
+	"oidc-groups-claim",
+	"oidc-groups-prefix",
+	"oidc-issuer-url",
+	"oidc-required-claim",
+	"oidc-username-claim",
+	"oidc-username-prefix",
+	"profiling",
+	"service-account-issuer",
+	"tls-cipher-suites",
+}
+
+func validateExtraArgs(clusterConfig *Cluster) error {
+	cp := clusterConfig.Spec.ControlPlaneConfiguration
+	if err := validateFlags("control plane kubeletConfiguration", cp.KubeletConfiguration, kubeletFlagsDenyList); err != nil {
+		return err
+	}
+	if err := validateFlags("control plane apiServerExtraArgs", cp.ApiServerExtraArgs, apiServerFlagsDenyList); err != nil {
+		return err
+	}
+	for i, workerNodeGroup := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		name := workerNodeGroup.Name
+		if name == "" {
+			name = DefaultWorkerNodeGroupName(i)
+		}
+		if err := validateFlags(fmt.Sprintf("worker node group %s kubeletConfiguration", name), workerNodeGroup.KubeletConfiguration, kubeletFlagsDenyList); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateFlags(field string, flags map[string]string, denyList []string) error {
+	for flag := range flags {
+		if flag == "" || strings.HasPrefix(flag, "-") {
+			return fmt.Errorf("invalid flag %q in %s: flags must be set by name without the leading dashes", flag, field)
+		}
+		for _, denied := range denyList {
+			if flag == denied {
+				return fmt.Errorf("flag %s in %s is managed by EKS Anywhere and can't be set", flag, field)
+			}
+		}
+	}
+	return nil
+}
+
+func validateUpgradeRolloutStrategy(clusterConfig *Cluster) error {
+	if s := clusterConfig.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy; s != nil {
+		if s.RollingUpdate.MaxSurge != 0 && s.RollingUpdate.MaxSurge != 1 {
+			return errors.New("control plane upgradeRolloutStrategy maxSurge must be 0 or 1")
+		}
+		// KubeadmControlPlane only allows scale in rollouts with 3 or more replicas
+		if s.RollingUpdate.MaxSurge == 0 && clusterConfig.Spec.ControlPlaneConfiguration.Count < 3 {
+			return errors.New("control plane upgradeRolloutStrategy maxSurge can only be 0 with 3 or more control plane nodes")
+		}
+	}
+	for i, workerNodeGroup := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		s := workerNodeGroup.UpgradeRolloutStrategy
+		if s == nil {
+			continue
+		}
+		name := workerNodeGroup.Name
+		if name == "" {
+			name = DefaultWorkerNodeGroupName(i)
+		}
+		if s.RollingUpdate.MaxSurge < 0 || s.RollingUpdate.MaxUnavailable < 0 {
+			return fmt.Errorf("worker node group %s upgradeRolloutStrategy maxSurge and maxUnavailable can't be negative", name)
+		}
+		if s.RollingUpdate.MaxSurge == 0 && s.RollingUpdate.MaxUnavailable == 0 {
+			return fmt.Errorf("worker node group %s upgradeRolloutStrategy maxSurge and maxUnavailable can't both be 0", name)
+		}
+	}
+	return nil
+}
+
+func validateNodeDrainTimeout(clusterConfig *Cluster) error {
+	if t := clusterConfig.Spec.ControlPlaneConfiguration.NodeDrainTimeout; t != nil && t.Duration < 0 {
+		return errors.New("control plane nodeDrainTimeout can't be negative")
+	}
+	for i, workerNodeGroup := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		if t := workerNodeGroup.NodeDrainTimeout; t != nil && t.Duration < 0 {
+			name := workerNodeGroup.Name
+			if name == "" {
+				name = DefaultWorkerNodeGroupName(i)
+			}
+			return fmt.Errorf("worker node group %s nodeDrainTimeout can't be negative", name)
+		}
 	}
 	return nil
 }
@@ -481,6 +669,41 @@ func updateRegistryMirrorConfig(clusterConfig *Cluster) error {
 	return nil
 }
 
+var auditPolicyApiVersions = map[string]bool{
+	"audit.k8s.io/v1":      true,
+	"audit.k8s.io/v1beta1": true,
+}
+
+type auditPolicyDocument struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Rules      []interface{} `json:"rules"`
+}
+
+func validateAuditPolicy(clusterConfig *Cluster) error {
+	auditPolicy := clusterConfig.Spec.AuditPolicy
+	if auditPolicy == nil {
+		return nil
+	}
+	if auditPolicy.LogMaxAge < 0 || auditPolicy.LogMaxBackup < 0 || auditPolicy.LogMaxSize < 0 {
+		return errors.New("auditPolicy logMaxAge, logMaxBackup and logMaxSize can't be negative")
+	}
+	if auditPolicy.Policy == "" {
+		return nil
+	}
+	policy := &auditPolicyDocument{}
+	if err := yaml.Unmarshal([]byte(auditPolicy.Policy), policy); err != nil {
+		return fmt.Errorf("invalid auditPolicy policy: %v", err)
+	}
+	if !auditPolicyApiVersions[policy.APIVersion] || policy.Kind != "Policy" {
+		return fmt.Errorf("invalid auditPolicy policy: expected kind Policy with apiVersion audit.k8s.io/v1, got kind %q with apiVersion %q", policy.Kind, policy.APIVersion)
+	}
+	if len(policy.Rules) == 0 {
+		return errors.New("invalid auditPolicy policy: at least one rule is required")
+	}
+	return nil
+}
+
 func validateIdentityProviderRefs(clusterConfig *Cluster) error {
 	refs := clusterConfig.Spec.IdentityProviderRefs
 	if len(refs) == 0 {
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -505,8 +506,38 @@ func TestGetAndValidateClusterConfig(t *testing.T) {
 			wantErr:     true,
 		},
 		{
-			testName:    "with multiple worker node groups",
-			fileName:    "testdata/cluster_invalid_multiple_worker_node_groups.yaml",
+			testName:    "with duplicate worker node group names",
+			fileName:    "testdata/cluster_invalid_duplicate_worker_node_group_names.yaml",
+			wantCluster: nil,
+			wantErr:     true,
+		},
+		{
+			testName:    "with invalid worker node group taint effect",
+			fileName:    "testdata/cluster_invalid_worker_node_group_taint_effect.yaml",
+			wantCluster: nil,
+			wantErr:     true,
+		},
+		{
+			testName:    "with kubelet flag managed by EKS Anywhere",
+			fileName:    "testdata/cluster_invalid_kubelet_configuration.yaml",
+			wantCluster: nil,
+			wantErr:     true,
+		},
+		{
+			testName:    "with api server flag managed by EKS Anywhere",
+			fileName:    "testdata/cluster_invalid_api_server_extra_args.yaml",
+			wantCluster: nil,
+			wantErr:     true,
+		},
+		{
+			testName:    "with invalid audit policy",
+			fileName:    "testdata/cluster_invalid_audit_policy.yaml",
+			wantCluster: nil,
+			wantErr:     true,
+		},
+		{
+			testName:    "with worker node count out of autoscaling range",
+			fileName:    "testdata/cluster_invalid_autoscaling_count.yaml",
 			wantCluster: nil,
 			wantErr:     true,
 		},
@@ -1099,6 +1130,262 @@ func TestCluster_IsReconcilePaused(t *testing.T) {
 	}
 }
 
+func TestCluster_SetDefaults(t *testing.T) {
+	c := &Cluster{
+		Spec: ClusterSpec{
+			WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+				{Count: 1},
+				{Name: "ingress", Count: 2},
+				{Count: 3},
+			},
+		},
+	}
+	c.SetDefaults()
+
+	wantNames := []string{"md-0", "ingress", "md-2"}
+	for i, group := range c.Spec.WorkerNodeGroupConfigurations {
+		if group.Name != wantNames[i] {
+			t.Errorf("SetDefaults() worker node group %d name = %s, want %s", i, group.Name, wantNames[i])
+		}
+	}
+}
+
+func TestValidateExtraArgs(t *testing.T) {
+	tests := []struct {
+		name                 string
+		controlPlane         ControlPlaneConfiguration
+		workerNodeGroupFlags map[string]string
+		wantErr              string
+	}{
+		{
+			name: "valid flags",
+			controlPlane: ControlPlaneConfiguration{
+				KubeletConfiguration: map[string]string{"max-pods": "110", "system-reserved": "cpu=500m,memory=1Gi"},
+				ApiServerExtraArgs:   map[string]string{"feature-gates": "EphemeralContainers=true"},
+			},
+			workerNodeGroupFlags: map[string]string{"eviction-hard": "memory.available<500Mi"},
+		},
+		{
+			name: "denied control plane kubelet flag",
+			controlPlane: ControlPlaneConfiguration{
+				KubeletConfiguration: map[string]string{"cloud-provider": "aws"},
+			},
+			wantErr: "flag cloud-provider in control plane kubeletConfiguration is managed by EKS Anywhere and can't be set",
+		},
+		{
+			name: "denied api server flag",
+			controlPlane: ControlPlaneConfiguration{
+				ApiServerExtraArgs: map[string]string{"oidc-issuer-url": "https://issuer"},
+			},
+			wantErr: "flag oidc-issuer-url in control plane apiServerExtraArgs is managed by EKS Anywhere and can't be set",
+		},
+		{
+			name:                 "denied worker node group kubelet flag",
+			workerNodeGroupFlags: map[string]string{"node-labels": "a=b"},
+			wantErr:              "flag node-labels in worker node group md-0 kubeletConfiguration is managed by EKS Anywhere and can't be set",
+		},
+		{
+			name:                 "flag with dashes",
+			workerNodeGroupFlags: map[string]string{"--max-pods": "50"},
+			wantErr:              "invalid flag \"--max-pods\" in worker node group md-0 kubeletConfiguration: flags must be set by name without the leading dashes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: tt.controlPlane,
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{Count: 1, KubeletConfiguration: tt.workerNodeGroupFlags},
+					},
+				},
+			}
+			err := validateExtraArgs(c)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("validateExtraArgs() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("validateExtraArgs() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUpgradeRolloutStrategy(t *testing.T) {
+	tests := []struct {
+		name              string
+		controlPlaneCount int
+		controlPlane      *ControlPlaneUpgradeRolloutStrategy
+		workers           *WorkerNodesUpgradeRolloutStrategy
+		wantErr           string
+	}{
+		{
+			name: "no rollout strategy",
+		},
+		{
+			name:              "valid rollout strategy",
+			controlPlaneCount: 3,
+			controlPlane:      &ControlPlaneUpgradeRolloutStrategy{RollingUpdate: ControlPlaneRollingUpdateParams{MaxSurge: 0}},
+			workers:           &WorkerNodesUpgradeRolloutStrategy{RollingUpdate: WorkerNodesRollingUpdateParams{MaxSurge: 2, MaxUnavailable: 1}},
+		},
+		{
+			name:              "control plane max surge 0 with less than 3 nodes",
+			controlPlaneCount: 1,
+			controlPlane:      &ControlPlaneUpgradeRolloutStrategy{RollingUpdate: ControlPlaneRollingUpdateParams{MaxSurge: 0}},
+			wantErr:           "control plane upgradeRolloutStrategy maxSurge can only be 0 with 3 or more control plane nodes",
+		},
+		{
+			name:              "control plane max surge 1 with less than 3 nodes",
+			controlPlaneCount: 1,
+			controlPlane:      &ControlPlaneUpgradeRolloutStrategy{RollingUpdate: ControlPlaneRollingUpdateParams{MaxSurge: 1}},
+		},
+		{
+			name:         "control plane max surge greater than 1",
+			controlPlane: &ControlPlaneUpgradeRolloutStrategy{RollingUpdate: ControlPlaneRollingUpdateParams{MaxSurge: 2}},
+			wantErr:      "control plane upgradeRolloutStrategy maxSurge must be 0 or 1",
+		},
+		{
+			name:    "negative worker max unavailable",
+			workers: &WorkerNodesUpgradeRolloutStrategy{RollingUpdate: WorkerNodesRollingUpdateParams{MaxSurge: 1, MaxUnavailable: -1}},
+			wantErr: "worker node group md-0 upgradeRolloutStrategy maxSurge and maxUnavailable can't be negative",
+		},
+		{
+			name:    "worker max surge and max unavailable 0",
+			workers: &WorkerNodesUpgradeRolloutStrategy{},
+			wantErr: "worker node group md-0 upgradeRolloutStrategy maxSurge and maxUnavailable can't both be 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{Count: tt.controlPlaneCount, UpgradeRolloutStrategy: tt.controlPlane},
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{Count: 1, UpgradeRolloutStrategy: tt.workers},
+					},
+				},
+			}
+			err := validateUpgradeRolloutStrategy(c)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("validateUpgradeRolloutStrategy() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("validateUpgradeRolloutStrategy() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNodeDrainTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		controlPlane *metav1.Duration
+		workers      *metav1.Duration
+		wantErr      string
+	}{
+		{
+			name: "no node drain timeout",
+		},
+		{
+			name:         "valid node drain timeout",
+			controlPlane: &metav1.Duration{Duration: 10 * time.Minute},
+			workers:      &metav1.Duration{Duration: 0},
+		},
+		{
+			name:         "negative control plane node drain timeout",
+			controlPlane: &metav1.Duration{Duration: -time.Minute},
+			wantErr:      "control plane nodeDrainTimeout can't be negative",
+		},
+		{
+			name:    "negative worker node drain timeout",
+			workers: &metav1.Duration{Duration: -time.Minute},
+			wantErr: "worker node group md-0 nodeDrainTimeout can't be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{NodeDrainTimeout: tt.controlPlane},
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{
+						{Count: 1, NodeDrainTimeout: tt.workers},
+					},
+				},
+			}
+			err := validateNodeDrainTimeout(c)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("validateNodeDrainTimeout() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("validateNodeDrainTimeout() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAuditPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		auditPolicy *AuditPolicy
+		wantErr     string
+	}{
+		{
+			name: "no audit policy",
+		},
+		{
+			name: "valid policy",
+			auditPolicy: &AuditPolicy{
+				Policy:     "apiVersion: audit.k8s.io/v1\nkind: Policy\nomitStages:\n- RequestReceived\nrules:\n- level: Metadata\n",
+				LogMaxAge:  7,
+				LogMaxSize: 100,
+			},
+		},
+		{
+			name: "only rotation settings",
+			auditPolicy: &AuditPolicy{
+				LogMaxBackup: 3,
+			},
+		},
+		{
+			name: "negative rotation setting",
+			auditPolicy: &AuditPolicy{
+				LogMaxAge: -1,
+			},
+			wantErr: "auditPolicy logMaxAge, logMaxBackup and logMaxSize can't be negative",
+		},
+		{
+			name: "wrong kind",
+			auditPolicy: &AuditPolicy{
+				Policy: "apiVersion: v1\nkind: ConfigMap\n",
+			},
+			wantErr: "invalid auditPolicy policy: expected kind Policy with apiVersion audit.k8s.io/v1, got kind \"ConfigMap\" with apiVersion \"v1\"",
+		},
+		{
+			name: "no rules",
+			auditPolicy: &AuditPolicy{
+				Policy: "apiVersion: audit.k8s.io/v1\nkind: Policy\n",
+			},
+			wantErr: "invalid auditPolicy policy: at least one rule is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cluster{
+				Spec: ClusterSpec{
+					AuditPolicy: tt.auditPolicy,
+				},
+			}
+			err := validateAuditPolicy(c)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("validateAuditPolicy() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("validateAuditPolicy() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestGitOpsEquals(t *testing.T) {
 	tests := []struct {
 		name string
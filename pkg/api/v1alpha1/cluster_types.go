@@ -1,10 +1,13 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
 )
 
 const (
@@ -27,6 +30,10 @@ const (
 
 	// defaultEksaNamespace is the default namespace for EKS-A resources when not specified.
 	defaultEksaNamespace = "default"
+
+	// ClusterFinalizerName is the finalizer added to EKS-A clusters managed by the cluster controller
+	// so the CAPI cluster and the EKS-A objects that depend on it are cleaned up before the cluster is removed.
+	ClusterFinalizerName = "clusters.anywhere.eks.amazonaws.com/finalizer"
 )
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -48,6 +55,7 @@ type ClusterSpec struct {
 	RegistryMirrorConfiguration *RegistryMirrorConfiguration `json:"registryMirrorConfiguration,omitempty"`
 	ManagementCluster           ManagementCluster            `json:"managementCluster,omitempty"`
 	PodIAMConfig                *PodIAMConfig                `json:"podIamConfig,omitempty"`
+	AuditPolicy                 *AuditPolicy                 `json:"auditPolicy,omitempty"`
 }
 
 func (n *Cluster) Equal(o *Cluster) bool {
@@ -90,6 +98,9 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.ManagementClusterEqual(o) {
 		return false
 	}
+	if !n.Spec.AuditPolicy.Equal(o.Spec.AuditPolicy) {
+		return false
+	}
 	return true
 }
 
@@ -140,6 +151,41 @@ type ControlPlaneConfiguration struct {
 	MachineGroupRef *Ref `json:"machineGroupRef,omitempty"`
 	// Taints define the set of taints to be applied on control plane nodes
 	Taints []corev1.Taint `json:"taints,omitempty"`
+	// KubeletConfiguration defines extra flags passed to the kubelet of the control plane nodes,
+	// keyed by flag name without the leading dashes. Flags managed by EKS Anywhere can't be set.
+	KubeletConfiguration map[string]string `json:"kubeletConfiguration,omitempty"`
+	// ApiServerExtraArgs defines extra flags passed to the kube-apiserver, keyed by flag name without the leading dashes.
+	// Flags managed by EKS Anywhere can't be set.
+	ApiServerExtraArgs map[string]string `json:"apiServerExtraArgs,omitempty"`
+	// UpgradeRolloutStrategy defines how the control plane nodes are replaced during an upgrade.
+	// Defaults to creating one new node before removing an old one.
+	UpgradeRolloutStrategy *ControlPlaneUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
+	// NodeDrainTimeout is the total time spent draining a control plane node before it's deleted, for example 10m.
+	// Defaults to no timeout, the drain is retried until all the pods are evicted.
+	NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
+}
+
+// ControlPlaneUpgradeRolloutStrategy defines the rollout strategy of the control plane nodes.
+type ControlPlaneUpgradeRolloutStrategy struct {
+	RollingUpdate ControlPlaneRollingUpdateParams `json:"rollingUpdate,omitempty"`
+}
+
+// ControlPlaneRollingUpdateParams defines the rolling update parameters of the control plane nodes.
+// The control plane nodes are always replaced one at a time to keep the etcd quorum, so only maxSurge can be set.
+type ControlPlaneRollingUpdateParams struct {
+	// MaxSurge is the number of nodes that can be created above the desired count during the upgrade, 0 or 1.
+	// With 0, an old node is removed before its replacement is created.
+	MaxSurge int `json:"maxSurge"`
+}
+
+func (n *ControlPlaneUpgradeRolloutStrategy) Equal(o *ControlPlaneUpgradeRolloutStrategy) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.RollingUpdate == o.RollingUpdate
 }
 
 func TaintsSliceEqual(s1, s2 []corev1.Taint) bool {
@@ -166,7 +212,28 @@ func (n *ControlPlaneConfiguration) Equal(o *ControlPlaneConfiguration) bool {
 	if n == nil || o == nil {
 		return false
 	}
-	return n.Count == o.Count && n.Endpoint.Equal(o.Endpoint) && n.MachineGroupRef.Equal(o.MachineGroupRef) && TaintsSliceEqual(n.Taints, o.Taints)
+	return n.Count == o.Count && n.Endpoint.Equal(o.Endpoint) && n.MachineGroupRef.Equal(o.MachineGroupRef) && TaintsSliceEqual(n.Taints, o.Taints) &&
+		MapEqual(n.KubeletConfiguration, o.KubeletConfiguration) && MapEqual(n.ApiServerExtraArgs, o.ApiServerExtraArgs) &&
+		n.UpgradeRolloutStrategy.Equal(o.UpgradeRolloutStrategy) && DurationEqual(n.NodeDrainTimeout, o.NodeDrainTimeout)
+}
+
+func DurationEqual(a, b *metav1.Duration) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Duration == b.Duration
+}
+
+func MapEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
 }
 
 type Endpoint struct {
@@ -185,19 +252,109 @@ func (n *Endpoint) Equal(o *Endpoint) bool {
 }
 
 type WorkerNodeGroupConfiguration struct {
+	// Name is the name of the worker node group. It's used to name the CAPI objects of the group.
+	// Defaults to md-<index of the group>.
+	Name string `json:"name,omitempty"`
 	// Count defines the number of desired worker nodes. Defaults to 1.
 	Count int `json:"count,omitempty"`
 	// MachineGroupRef defines the machine group configuration for the worker nodes.
 	MachineGroupRef *Ref `json:"machineGroupRef,omitempty"`
+	// AutoScalingConfiguration defines the auto scaling configuration
+	AutoScalingConfiguration *AutoScalingConfiguration `json:"autoscalingConfiguration,omitempty"`
+	// Labels define the labels to be applied on the nodes of the worker node group
+	Labels map[string]string `json:"labels,omitempty"`
+	// Taints define the set of taints to be applied on the nodes of the worker node group
+	Taints []corev1.Taint `json:"taints,omitempty"`
+	// KubeletConfiguration defines extra flags passed to the kubelet of the nodes of the worker node group,
+	// keyed by flag name without the leading dashes. Flags managed by EKS Anywhere can't be set.
+	KubeletConfiguration map[string]string `json:"kubeletConfiguration,omitempty"`
+	// UpgradeRolloutStrategy defines how the nodes of the worker node group are replaced during an upgrade.
+	// Defaults to the Cluster API MachineDeployment defaults.
+	UpgradeRolloutStrategy *WorkerNodesUpgradeRolloutStrategy `json:"upgradeRolloutStrategy,omitempty"`
+	// NodeDrainTimeout is the total time spent draining a node of the worker node group before it's deleted, for example 10m.
+	// Defaults to no timeout, the drain is retried until all the pods are evicted.
+	NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
+}
+
+// WorkerNodesUpgradeRolloutStrategy defines the rollout strategy of the nodes of a worker node group.
+type WorkerNodesUpgradeRolloutStrategy struct {
+	RollingUpdate WorkerNodesRollingUpdateParams `json:"rollingUpdate,omitempty"`
+}
+
+// WorkerNodesRollingUpdateParams defines the rolling update parameters of the nodes of a worker node group.
+type WorkerNodesRollingUpdateParams struct {
+	// MaxSurge is the number of nodes that can be created above the desired count during the upgrade.
+	MaxSurge int `json:"maxSurge"`
+	// MaxUnavailable is the number of nodes that can be unavailable during the upgrade.
+	MaxUnavailable int `json:"maxUnavailable"`
+}
+
+// DefaultWorkerNodeGroupName returns the name given to a worker node group without one,
+// which keeps the md-0 name used for clusters created with a single unnamed group
+func DefaultWorkerNodeGroupName(index int) string {
+	return fmt.Sprintf("md-%d", index)
+}
+
+// AutoScalingConfiguration defines the configuration for the node autoscaling feature.
+type AutoScalingConfiguration struct {
+	// MinCount defines the minimum number of nodes for the associated resource group.
+	// +optional
+	MinCount int `json:"minCount,omitempty"`
+
+	// MaxCount defines the maximum number of nodes for the associated resource group.
+	// +optional
+	MaxCount int `json:"maxCount,omitempty"`
+}
+
+func (n *AutoScalingConfiguration) Equal(o *AutoScalingConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.MinCount == o.MinCount && n.MaxCount == o.MaxCount
 }
 
 func generateWorkerNodeGroupKey(c WorkerNodeGroupConfiguration) (key string) {
+	key = c.Name
 	if c.MachineGroupRef != nil {
-		key = c.MachineGroupRef.Kind + c.MachineGroupRef.Name
+		key += c.MachineGroupRef.Kind + c.MachineGroupRef.Name
+	}
+	if c.AutoScalingConfiguration != nil {
+		key += "autoscaling" + strconv.Itoa(c.AutoScalingConfiguration.MinCount) + "-" + strconv.Itoa(c.AutoScalingConfiguration.MaxCount)
+	}
+	key += mapToKey("label", c.Labels)
+	key += mapToKey("kubelet", c.KubeletConfiguration)
+	if c.UpgradeRolloutStrategy != nil {
+		key += "rollout" + strconv.Itoa(c.UpgradeRolloutStrategy.RollingUpdate.MaxSurge) + "-" + strconv.Itoa(c.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable)
+	}
+	if c.NodeDrainTimeout != nil {
+		key += "drain" + c.NodeDrainTimeout.Duration.String()
+	}
+	taints := make([]string, 0, len(c.Taints))
+	for _, t := range c.Taints {
+		taints = append(taints, t.ToString())
+	}
+	sort.Strings(taints)
+	for _, t := range taints {
+		key += "taint" + t
 	}
 	return strconv.Itoa(c.Count) + key
 }
 
+func mapToKey(prefix string, m map[string]string) (key string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		key += prefix + k + "=" + m[k]
+	}
+	return key
+}
+
 func WorkerNodeGroupConfigurationsSliceEqual(a, b []WorkerNodeGroupConfiguration) bool {
 	if len(a) != len(b) {
 		return false
@@ -313,7 +470,50 @@ var validCNIs = map[CNI]struct{}{
 }
 
 // ClusterStatus defines the observed state of Cluster
-type ClusterStatus struct{}
+type ClusterStatus struct {
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// FailureReason indicates that there is a terminal problem reconciling the
+	// cluster, set to a token value suitable for programmatic interpretation.
+	// +optional
+	FailureReason *string `json:"failureReason,omitempty"`
+
+	// FailureMessage indicates that there is a terminal problem reconciling the
+	// cluster, set to a descriptive error message.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// ControlPlane reports the number of ready and desired control plane nodes.
+	// +optional
+	ControlPlane *NodeGroupStatus `json:"controlPlane,omitempty"`
+
+	// WorkerNodeGroups reports the number of ready and desired nodes for each worker node group.
+	// +optional
+	WorkerNodeGroups []WorkerNodeGroupStatus `json:"workerNodeGroups,omitempty"`
+
+	// Conditions defines current service state of the cluster.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// NodeGroupStatus reports the number of nodes in a group of machines.
+type NodeGroupStatus struct {
+	// Replicas is the desired number of nodes.
+	Replicas int32 `json:"replicas"`
+
+	// ReadyReplicas is the number of nodes that are ready.
+	ReadyReplicas int32 `json:"readyReplicas"`
+}
+
+// WorkerNodeGroupStatus reports the number of nodes in a worker node group.
+type WorkerNodeGroupStatus struct {
+	// Name is the name of the MachineDeployment that backs the worker node group.
+	Name string `json:"name"`
+
+	NodeGroupStatus `json:",inline"`
+}
 
 type Ref struct {
 	Kind string `json:"kind,omitempty"`
@@ -383,7 +583,37 @@ func (n *PodIAMConfig) Equal(o *PodIAMConfig) bool {
 	return n.ServiceAccountIssuer == o.ServiceAccountIssuer
 }
 
+// AuditPolicy defines the kube-apiserver audit logging settings for the control plane nodes
+type AuditPolicy struct {
+	// Policy is an audit.k8s.io Policy document that replaces the default EKS Anywhere audit policy
+	Policy string `json:"policy,omitempty"`
+
+	// LogMaxAge is the maximum number of days to retain old audit log files. Defaults to 30
+	LogMaxAge int `json:"logMaxAge,omitempty"`
+
+	// LogMaxBackup is the maximum number of old audit log files to retain. Defaults to 10
+	LogMaxBackup int `json:"logMaxBackup,omitempty"`
+
+	// LogMaxSize is the maximum size in megabytes of the audit log file before it gets rotated. Defaults to 512
+	LogMaxSize int `json:"logMaxSize,omitempty"`
+}
+
+func (n *AuditPolicy) Equal(o *AuditPolicy) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Policy == o.Policy && n.LogMaxAge == o.LogMaxAge && n.LogMaxBackup == o.LogMaxBackup && n.LogMaxSize == o.LogMaxSize
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.kubernetesVersion",description="Kubernetes version of the cluster"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Cluster readiness"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="Reason for the Ready condition"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // Cluster is the Schema for the clusters API
 type Cluster struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -513,6 +743,16 @@ func (c *Cluster) ConvertConfigToConfigGenerateStruct() *ClusterGenerate {
 	return config
 }
 
+// GetConditions returns the conditions of the cluster.
+func (c *Cluster) GetConditions() clusterv1.Conditions {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions of the cluster.
+func (c *Cluster) SetConditions(conditions clusterv1.Conditions) {
+	c.Status.Conditions = conditions
+}
+
 func (c *Cluster) IsManaged() bool {
 	return !c.IsSelfManaged()
 }
@@ -2,8 +2,10 @@ package v1alpha1_test
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -352,6 +354,76 @@ func TestClusterEqualWorkerNodeGroupConfigurations(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			testName: "both exist, name diff",
+			cluster1Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:  "md-0",
+					Count: 1,
+				},
+			},
+			cluster2Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:  "ingress",
+					Count: 1,
+				},
+			},
+			want: false,
+		},
+		{
+			testName: "both exist, labels diff",
+			cluster1Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:   "md-0",
+					Labels: map[string]string{"pool": "batch"},
+				},
+			},
+			cluster2Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:   "md-0",
+					Labels: map[string]string{"pool": "ingress"},
+				},
+			},
+			want: false,
+		},
+		{
+			testName: "both exist, taints order diff",
+			cluster1Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name: "md-0",
+					Taints: []corev1.Taint{
+						{Key: "k1", Value: "v1", Effect: corev1.TaintEffectNoSchedule},
+						{Key: "k2", Effect: corev1.TaintEffectNoExecute},
+					},
+				},
+			},
+			cluster2Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name: "md-0",
+					Taints: []corev1.Taint{
+						{Key: "k2", Effect: corev1.TaintEffectNoExecute},
+						{Key: "k1", Value: "v1", Effect: corev1.TaintEffectNoSchedule},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			testName: "both exist, taints diff",
+			cluster1Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:   "md-0",
+					Taints: []corev1.Taint{{Key: "k1", Value: "v1", Effect: corev1.TaintEffectNoSchedule}},
+				},
+			},
+			cluster2Wngs: []v1alpha1.WorkerNodeGroupConfiguration{
+				{
+					Name:   "md-0",
+					Taints: []corev1.Taint{{Key: "k1", Value: "v1", Effect: corev1.TaintEffectPreferNoSchedule}},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -834,6 +906,68 @@ func TestClusterEqualProxyConfiguration(t *testing.T) {
 	}
 }
 
+func TestClusterEqualAuditPolicy(t *testing.T) {
+	testCases := []struct {
+		testName                     string
+		cluster1Audit, cluster2Audit *v1alpha1.AuditPolicy
+		want                         bool
+	}{
+		{
+			testName:      "both nil",
+			cluster1Audit: nil,
+			cluster2Audit: nil,
+			want:          true,
+		},
+		{
+			testName: "one nil, one exists",
+			cluster1Audit: &v1alpha1.AuditPolicy{
+				LogMaxAge: 7,
+			},
+			cluster2Audit: nil,
+			want:          false,
+		},
+		{
+			testName: "both exist, same",
+			cluster1Audit: &v1alpha1.AuditPolicy{
+				Policy:    "apiVersion: audit.k8s.io/v1",
+				LogMaxAge: 7,
+			},
+			cluster2Audit: &v1alpha1.AuditPolicy{
+				Policy:    "apiVersion: audit.k8s.io/v1",
+				LogMaxAge: 7,
+			},
+			want: true,
+		},
+		{
+			testName: "both exist, diff",
+			cluster1Audit: &v1alpha1.AuditPolicy{
+				LogMaxSize: 100,
+			},
+			cluster2Audit: &v1alpha1.AuditPolicy{
+				LogMaxSize: 200,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			cluster1 := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					AuditPolicy: tt.cluster1Audit,
+				},
+			}
+			cluster2 := &v1alpha1.Cluster{
+				Spec: v1alpha1.ClusterSpec{
+					AuditPolicy: tt.cluster2Audit,
+				},
+			}
+
+			g := NewWithT(t)
+			g.Expect(cluster1.Equal(cluster2)).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestClusterEqualRegistryMirrorConfiguration(t *testing.T) {
 	testCases := []struct {
 		testName                   string
@@ -1087,6 +1221,50 @@ func TestControlPlaneConfigurationEqual(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			testName: "upgrade rollout strategy diff",
+			cluster1CPConfig: &v1alpha1.ControlPlaneConfiguration{
+				UpgradeRolloutStrategy: &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+					RollingUpdate: v1alpha1.ControlPlaneRollingUpdateParams{MaxSurge: 1},
+				},
+			},
+			cluster2CPConfig: &v1alpha1.ControlPlaneConfiguration{
+				UpgradeRolloutStrategy: &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+					RollingUpdate: v1alpha1.ControlPlaneRollingUpdateParams{MaxSurge: 0},
+				},
+			},
+			want: false,
+		},
+		{
+			testName: "node drain timeout diff",
+			cluster1CPConfig: &v1alpha1.ControlPlaneConfiguration{
+				NodeDrainTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			cluster2CPConfig: &v1alpha1.ControlPlaneConfiguration{
+				NodeDrainTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			want: false,
+		},
+		{
+			testName: "same node drain timeout",
+			cluster1CPConfig: &v1alpha1.ControlPlaneConfiguration{
+				NodeDrainTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			cluster2CPConfig: &v1alpha1.ControlPlaneConfiguration{
+				NodeDrainTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			want: true,
+		},
+		{
+			testName: "one upgrade rollout strategy empty",
+			cluster1CPConfig: &v1alpha1.ControlPlaneConfiguration{
+				UpgradeRolloutStrategy: &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+					RollingUpdate: v1alpha1.ControlPlaneRollingUpdateParams{MaxSurge: 1},
+				},
+			},
+			cluster2CPConfig: &v1alpha1.ControlPlaneConfiguration{},
+			want:             false,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
@@ -0,0 +1,73 @@
+package v1alpha1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+
+// Conditions and condition reasons for the Cluster object.
+
+const (
+	// ControlPlaneReadyCondition reports the status of the control plane nodes of the cluster.
+	ControlPlaneReadyCondition clusterv1.ConditionType = "ControlPlaneReady"
+
+	// WaitingForControlPlaneReason (Severity=Info) documents a cluster whose control plane
+	// does not exist yet or has not been initialized.
+	WaitingForControlPlaneReason = "WaitingForControlPlane"
+
+	// ControlPlaneScalingReason (Severity=Info) documents a cluster whose control plane
+	// doesn't have the desired number of ready and up to date nodes.
+	ControlPlaneScalingReason = "ControlPlaneScaling"
+)
+
+const (
+	// WorkersReadyCondition reports the status of the worker node groups of the cluster.
+	WorkersReadyCondition clusterv1.ConditionType = "WorkersReady"
+
+	// WaitingForWorkersReason (Severity=Info) documents a cluster whose worker
+	// MachineDeployments don't exist yet.
+	WaitingForWorkersReason = "WaitingForWorkers"
+
+	// WorkersScalingReason (Severity=Info) documents a cluster with at least one worker node group
+	// that doesn't have the desired number of ready and up to date nodes.
+	WorkersScalingReason = "WorkersScaling"
+)
+
+const (
+	// EtcdReadyCondition reports the status of the external etcd cluster.
+	// It's only set for clusters with unstacked etcd.
+	EtcdReadyCondition clusterv1.ConditionType = "EtcdReady"
+
+	// WaitingForEtcdReason (Severity=Info) documents a cluster whose EtcdadmCluster
+	// does not exist yet or is not ready.
+	WaitingForEtcdReason = "WaitingForEtcd"
+
+	// EtcdScalingReason (Severity=Info) documents a cluster whose external etcd
+	// doesn't have the desired number of ready members.
+	EtcdScalingReason = "EtcdScaling"
+)
+
+const (
+	// CNIReadyCondition reports whether the cluster networking is up. Nodes only
+	// report ready once the CNI is running on them, so this is true once at least
+	// one control plane node is ready.
+	CNIReadyCondition clusterv1.ConditionType = "CNIReady"
+
+	// WaitingForNodesReadyReason (Severity=Info) documents a cluster with no ready nodes yet.
+	WaitingForNodesReadyReason = "WaitingForNodesReady"
+)
+
+const (
+	// GitOpsSyncedCondition reports whether Flux has applied the latest revision
+	// of the GitOps repository. It's only set for clusters with a GitOpsRef.
+	GitOpsSyncedCondition clusterv1.ConditionType = "GitOpsSynced"
+
+	// GitOpsConfigNotFoundReason (Severity=Warning) documents a cluster whose GitOpsRef
+	// points to a GitOpsConfig that doesn't exist.
+	GitOpsConfigNotFoundReason = "GitOpsConfigNotFound"
+
+	// GitOpsKustomizationNotFoundReason (Severity=Warning) documents a cluster whose
+	// Flux Kustomization doesn't exist.
+	GitOpsKustomizationNotFoundReason = "KustomizationNotFound"
+
+	// GitOpsNotSyncedReason (Severity=Warning) documents a cluster whose Flux Kustomization
+	// is not ready. The message contains the reason reported by Flux.
+	GitOpsNotSyncedReason = "KustomizationNotReady"
+)
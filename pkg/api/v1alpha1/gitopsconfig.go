@@ -43,6 +43,19 @@ func validateGitOpsConfig(config *GitOpsConfig, refName string, clusterConfig *C
 
 	flux := config.Spec.Flux
 
+	if flux.IsGit() && flux.IsGitlab() {
+		return errors.New("only one of 'github', 'git' and 'gitlab' can be set in gitOps.flux")
+	}
+	if err := validatePullRequestConfig(flux); err != nil {
+		return err
+	}
+	if flux.IsGit() {
+		return validateGitProviderConfig(flux)
+	}
+	if flux.IsGitlab() {
+		return validateGitlabConfig(flux)
+	}
+
 	if len(flux.Github.Owner) <= 0 {
 		return errors.New("'owner' is not set or empty in gitOps.flux; owner is a required field")
 	}
@@ -63,6 +76,60 @@ func validateGitOpsConfig(config *GitOpsConfig, refName string, clusterConfig *C
 	return nil
 }
 
+func validateGitProviderConfig(flux Flux) error {
+	if flux.Github != (Github{}) {
+		return errors.New("only one of 'github' and 'git' can be set in gitOps.flux")
+	}
+	if len(flux.Git.RepositoryUrl) <= 0 {
+		return errors.New("'repositoryUrl' is not set or empty in gitOps.flux.git; repositoryUrl is a required field")
+	}
+	if err := validateGitRepoName(flux.Git.RepositoryName()); err != nil {
+		return err
+	}
+	if len(flux.Git.Branch) > 0 {
+		if err := validateGitBranchName(flux.Git.Branch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateGitlabConfig(flux Flux) error {
+	if flux.Github != (Github{}) {
+		return errors.New("only one of 'github' and 'gitlab' can be set in gitOps.flux")
+	}
+	if len(flux.Gitlab.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in gitOps.flux.gitlab; owner is a required field")
+	}
+	if len(flux.Gitlab.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in gitOps.flux.gitlab; repository is a required field")
+	}
+	if err := validateGitRepoName(flux.Gitlab.Repository); err != nil {
+		return err
+	}
+	if len(flux.Gitlab.Branch) > 0 {
+		if err := validateGitBranchName(flux.Gitlab.Branch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validatePullRequestConfig(flux Flux) error {
+	if flux.PullRequest == nil {
+		return nil
+	}
+	if flux.IsGit() || flux.IsGitlab() {
+		return errors.New("'pullRequest' in gitOps.flux is only supported with 'github'")
+	}
+	if t := flux.PullRequest.MergeTimeout; t != nil && t.Duration < 0 {
+		return fmt.Errorf("'mergeTimeout' in gitOps.flux.pullRequest can't be negative: %s", t.Duration)
+	}
+	return nil
+}
+
 func validateGitBranchName(branchName string) error {
 	allowedGitBranchNameRegex := regexp.MustCompile(`^([0-9A-Za-z\_\+,]+)\.?\/?([0-9A-Za-z\-\_\+,]+)$`)
 
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -73,6 +74,146 @@ func TestGetAndValidateGitOpsConfig(t *testing.T) {
 			wantGitOpsConfig: nil,
 			wantErr:          true,
 		},
+		{
+			testName: "valid git",
+			fileName: "testdata/cluster_1_19_gitops_git.yaml",
+			refName:  "test-gitops",
+			wantGitOpsConfig: &GitOpsConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "GitOpsConfig",
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-gitops",
+					Namespace: "default",
+				},
+				Spec: GitOpsConfigSpec{
+					Flux: Flux{
+						Git: &GitProviderConfig{
+							RepositoryUrl: "ssh://git@git.example.com/janedoe/flux-fleet.git",
+							Branch:        "abc123",
+						},
+					},
+				},
+			},
+			clusterConfig: &Cluster{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       ClusterKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			testName: "valid gitlab",
+			fileName: "testdata/cluster_1_19_gitops_gitlab.yaml",
+			refName:  "test-gitops",
+			wantGitOpsConfig: &GitOpsConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "GitOpsConfig",
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-gitops",
+					Namespace: "default",
+				},
+				Spec: GitOpsConfigSpec{
+					Flux: Flux{
+						Gitlab: &Gitlab{
+							Hostname:   "gitlab.example.com",
+							Owner:      "platform/clusters",
+							Repository: "flux-fleet",
+						},
+					},
+				},
+			},
+			clusterConfig: &Cluster{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       ClusterKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			testName: "gitlab without owner",
+			fileName: "testdata/cluster_invalid_gitops_gitlab_unset_owner.yaml",
+			refName:  "test-gitops",
+			clusterConfig: &Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			wantGitOpsConfig: nil,
+			wantErr:          true,
+		},
+		{
+			testName: "valid github with pull requests",
+			fileName: "testdata/cluster_1_19_gitops_pull_request.yaml",
+			refName:  "test-gitops",
+			wantGitOpsConfig: &GitOpsConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "GitOpsConfig",
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-gitops",
+					Namespace: "default",
+				},
+				Spec: GitOpsConfigSpec{
+					Flux: Flux{
+						Github: Github{
+							Owner:      "janedoe",
+							Repository: "flux-fleet",
+						},
+						PullRequest: &PullRequest{
+							WaitForMerge: true,
+							MergeTimeout: &metav1.Duration{Duration: 30 * time.Minute},
+						},
+					},
+				},
+			},
+			clusterConfig: &Cluster{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       ClusterKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			testName: "git with pull requests",
+			fileName: "testdata/cluster_invalid_gitops_git_pull_request.yaml",
+			refName:  "test-gitops",
+			clusterConfig: &Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			wantGitOpsConfig: nil,
+			wantErr:          true,
+		},
+		{
+			testName: "github and git",
+			fileName: "testdata/cluster_invalid_gitops_github_and_git.yaml",
+			refName:  "test-gitops",
+			clusterConfig: &Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			wantGitOpsConfig: nil,
+			wantErr:          true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -87,3 +228,25 @@ func TestGetAndValidateGitOpsConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGitProviderConfigRepositoryName(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "ssh://git@git.example.com/janedoe/flux-fleet.git", want: "flux-fleet"},
+		{url: "git@git.example.com:janedoe/flux-fleet.git", want: "flux-fleet"},
+		{url: "git@git.example.com:flux-fleet", want: "flux-fleet"},
+		{url: "https://git.example.com/scm/janedoe/flux-fleet/", want: "flux-fleet"},
+		{url: "file:///tmp/repos/flux-fleet.git", want: "flux-fleet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			g := &GitProviderConfig{RepositoryUrl: tt.url}
+			if got := g.RepositoryName(); got != tt.want {
+				t.Fatalf("RepositoryName() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
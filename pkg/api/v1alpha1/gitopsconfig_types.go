@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -13,6 +15,27 @@ type GitOpsConfigSpec struct {
 type Flux struct {
 	// github is the name of the Git Provider to host the Git repo.
 	Github Github `json:"github,omitempty"`
+
+	// git configures a repository hosted in any Git server, accessed with SSH or HTTPS. Mutually exclusive with github.
+	Git *GitProviderConfig `json:"git,omitempty"`
+
+	// gitlab configures a repository hosted in gitlab.com or a self-managed GitLab. Mutually exclusive with github and git.
+	Gitlab *Gitlab `json:"gitlab,omitempty"`
+
+	// pullRequest makes the CLI push the changes to a new branch and open a pull request to the configured branch,
+	// instead of pushing to it directly. Only supported with github.
+	PullRequest *PullRequest `json:"pullRequest,omitempty"`
+}
+
+// PullRequest configures how the CLI proposes changes to the repository through pull requests
+type PullRequest struct {
+	// WaitForMerge makes the CLI wait until the pull request is merged before continuing.
+	// Otherwise, it opens the pull request, prints its URL and leaves the Flux kustomization suspended.
+	// Flux upgrades always wait, since the components are bootstrapped from the branch.
+	WaitForMerge bool `json:"waitForMerge,omitempty"`
+
+	// MergeTimeout is how long to wait for the pull request to be merged. Defaults to 1h.
+	MergeTimeout *metav1.Duration `json:"mergeTimeout,omitempty"`
 }
 
 type Github struct {
@@ -35,6 +58,171 @@ type Github struct {
 	Personal bool `json:"personal,omitempty"`
 }
 
+type Gitlab struct {
+	// Hostname of a self-managed GitLab instance. Defaults to gitlab.com.
+	Hostname string `json:"hostname,omitempty"`
+
+	// Owner is the user or group path of the repository, subgroups are separated by /.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// FluxSystemNamespace scope for this operation. Defaults to flux-system.
+	FluxSystemNamespace string `json:"fluxSystemNamespace,omitempty"`
+
+	// Git branch. Defaults to main.
+	Branch string `json:"branch,omitempty"`
+
+	// ClusterConfigPath relative to the repository root, when specified the cluster sync will be scoped to this path.
+	ClusterConfigPath string `json:"clusterConfigPath,omitempty"`
+
+	// if true, the owner is assumed to be a GitLab user; otherwise a group.
+	Personal bool `json:"personal,omitempty"`
+}
+
+func (g *Gitlab) Equal(n *Gitlab) bool {
+	if g == n {
+		return true
+	}
+	if g == nil || n == nil {
+		return false
+	}
+	return *g == *n
+}
+
+// GitProviderConfig configures a repository hosted in any Git server. The credentials are read from the environment
+// and never stored in the config, which is committed to the repository
+type GitProviderConfig struct {
+	// RepositoryUrl is the SSH or HTTPS URL of the repository, e.g. ssh://git@git.example.com/org/repo.git.
+	// The repository must exist, it's not created.
+	RepositoryUrl string `json:"repositoryUrl"`
+
+	// FluxSystemNamespace scope for this operation. Defaults to flux-system.
+	FluxSystemNamespace string `json:"fluxSystemNamespace,omitempty"`
+
+	// Git branch. Defaults to main.
+	Branch string `json:"branch,omitempty"`
+
+	// ClusterConfigPath relative to the repository root, when specified the cluster sync will be scoped to this path.
+	ClusterConfigPath string `json:"clusterConfigPath,omitempty"`
+}
+
+// RepositoryName returns the name of the repository, the last element of its URL without the .git suffix
+func (g *GitProviderConfig) RepositoryName() string {
+	url := strings.TrimSuffix(strings.TrimSuffix(g.RepositoryUrl, "/"), ".git")
+	return url[strings.LastIndexAny(url, "/:")+1:]
+}
+
+func (g *GitProviderConfig) Equal(n *GitProviderConfig) bool {
+	if g == n {
+		return true
+	}
+	if g == nil || n == nil {
+		return false
+	}
+	return *g == *n
+}
+
+// IsGit returns true if the repository is configured with the git block instead of github
+func (f *Flux) IsGit() bool {
+	return f.Git != nil
+}
+
+// IsGitlab returns true if the repository is configured with the gitlab block instead of github
+func (f *Flux) IsGitlab() bool {
+	return f.Gitlab != nil
+}
+
+// Repository returns the name of the repository, from the block of its provider
+func (f *Flux) Repository() string {
+	switch {
+	case f.IsGit():
+		return f.Git.RepositoryName()
+	case f.IsGitlab():
+		return f.Gitlab.Repository
+	default:
+		return f.Github.Repository
+	}
+}
+
+// Owner returns the owner of the repository, from the block of its provider. Generic git repositories don't have one
+func (f *Flux) Owner() string {
+	switch {
+	case f.IsGit():
+		return ""
+	case f.IsGitlab():
+		return f.Gitlab.Owner
+	default:
+		return f.Github.Owner
+	}
+}
+
+// Personal returns true if the owner of the repository is a user, from the block of its provider
+func (f *Flux) Personal() bool {
+	switch {
+	case f.IsGit():
+		return false
+	case f.IsGitlab():
+		return f.Gitlab.Personal
+	default:
+		return f.Github.Personal
+	}
+}
+
+// SystemNamespace returns the namespace of the flux components, from the block of the repository provider
+func (f *Flux) SystemNamespace() string {
+	switch {
+	case f.IsGit():
+		return f.Git.FluxSystemNamespace
+	case f.IsGitlab():
+		return f.Gitlab.FluxSystemNamespace
+	default:
+		return f.Github.FluxSystemNamespace
+	}
+}
+
+// Branch returns the branch Flux syncs from, from the block of the repository provider
+func (f *Flux) Branch() string {
+	switch {
+	case f.IsGit():
+		return f.Git.Branch
+	case f.IsGitlab():
+		return f.Gitlab.Branch
+	default:
+		return f.Github.Branch
+	}
+}
+
+// ClusterConfigPath returns the path of the cluster configs in the repository, from the block of the repository provider
+func (f *Flux) ClusterConfigPath() string {
+	switch {
+	case f.IsGit():
+		return f.Git.ClusterConfigPath
+	case f.IsGitlab():
+		return f.Gitlab.ClusterConfigPath
+	default:
+		return f.Github.ClusterConfigPath
+	}
+}
+
+// SetClusterConfigPath sets the path of the cluster configs in the block of the repository provider
+func (f *Flux) SetClusterConfigPath(path string) {
+	switch {
+	case f.IsGit():
+		f.Git.ClusterConfigPath = path
+	case f.IsGitlab():
+		f.Gitlab.ClusterConfigPath = path
+	default:
+		f.Github.ClusterConfigPath = path
+	}
+}
+
+// UsePullRequests returns true if changes to the repository must be proposed in pull requests
+func (f *Flux) UsePullRequests() bool {
+	return f.PullRequest != nil
+}
+
 // GitOpsConfigStatus defines the observed state of GitOpsConfig
 type GitOpsConfigStatus struct{}
 
@@ -65,7 +253,7 @@ func (e *GitOpsConfigSpec) Equal(n *GitOpsConfigSpec) bool {
 	if e == nil || n == nil {
 		return false
 	}
-	return e.Flux == n.Flux
+	return e.Flux.Github == n.Flux.Github && e.Flux.Git.Equal(n.Flux.Git) && e.Flux.Gitlab.Equal(n.Flux.Gitlab)
 }
 
 //+kubebuilder:object:root=true
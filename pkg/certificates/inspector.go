@@ -0,0 +1,283 @@
+package certificates
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/cluster-api/util/secret"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	awsIamAuthCaSecretName = "aws-iam-authenticator-ca"
+	awsIamAuthCaCertKey    = "cert.pem"
+	kubeletPort            = "10250"
+	etcdPort               = "2379"
+	dialTimeout            = 10 * time.Second
+)
+
+// caCertificate is a certificate stored by CAPI in a cluster secret
+type caCertificate struct {
+	name    string
+	purpose secret.Purpose
+}
+
+type KubectlClient interface {
+	GetSecretFromNamespace(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.Secret, error)
+	GetApiServerUrl(ctx context.Context, cluster *types.Cluster) (string, error)
+	GetNodes(ctx context.Context, kubeconfig string) ([]corev1.Node, error)
+	GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*etcdv1.EtcdadmCluster, error)
+}
+
+// PeerCertificatesFunc returns the certificates presented by the server listening in address
+type PeerCertificatesFunc func(ctx context.Context, address string) ([]*x509.Certificate, error)
+
+type Inspector struct {
+	kubectl          KubectlClient
+	peerCertificates PeerCertificatesFunc
+	now              func() time.Time
+}
+
+type InspectorOpt func(*Inspector)
+
+// WithPeerCertificates replaces how the certificates served by the cluster endpoints are retrieved
+func WithPeerCertificates(f PeerCertificatesFunc) InspectorOpt {
+	return func(i *Inspector) {
+		i.peerCertificates = f
+	}
+}
+
+// WithNow replaces the clock used to timestamp the reports
+func WithNow(now func() time.Time) InspectorOpt {
+	return func(i *Inspector) {
+		i.now = now
+	}
+}
+
+func NewInspector(kubectl KubectlClient, opts ...InspectorOpt) *Inspector {
+	i := &Inspector{
+		kubectl:          kubectl,
+		peerCertificates: dialPeerCertificates,
+		now:              time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
+// Certificates reports the expiration of the cluster CAs, stored in the CAPI secrets in the management cluster,
+// and of the certificates served by the API server, etcd and kubelets of the workload cluster.
+// Certificates that can't be read are reported with the error instead of failing the whole report
+func (i *Inspector) Certificates(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) (*Report, error) {
+	report := &Report{
+		Cluster:     clusterSpec.Name,
+		GeneratedAt: i.now(),
+	}
+
+	capiCluster := clusterSpec.Name
+	secretCerts := []caCertificate{
+		{name: "cluster-ca", purpose: secret.ClusterCA},
+		{name: "etcd-ca", purpose: secret.EtcdCA},
+		{name: "front-proxy-ca", purpose: secret.FrontProxyCA},
+	}
+	if clusterSpec.Spec.ExternalEtcdConfiguration != nil {
+		secretCerts = append(secretCerts, caCertificate{name: "apiserver-etcd-client", purpose: secret.APIServerEtcdClient})
+	}
+
+	for _, c := range secretCerts {
+		secretName := secret.Name(capiCluster, c.purpose)
+		report.add(i.secretCertificate(ctx, managementCluster, c.name, secretName, constants.EksaSystemNamespace, secret.TLSCrtDataName))
+	}
+
+	if clusterSpec.AWSIamConfig != nil {
+		report.add(i.secretCertificate(ctx, managementCluster, "aws-iam-authenticator", awsIamAuthCaSecretName, constants.EksaSystemNamespace, awsIamAuthCaCertKey))
+	}
+
+	report.add(i.kubeconfigCertificate(ctx, managementCluster, capiCluster))
+
+	apiServerUrl, err := i.kubectl.GetApiServerUrl(ctx, workloadCluster)
+	if err != nil {
+		return nil, fmt.Errorf("error getting api server url: %v", err)
+	}
+	apiServer, err := url.Parse(apiServerUrl)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing api server url %s: %v", apiServerUrl, err)
+	}
+	report.add(i.servingCertificate(ctx, "apiserver", apiServer.Host))
+
+	nodes, err := i.kubectl.GetNodes(ctx, workloadCluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	externalEtcd := clusterSpec.Spec.ExternalEtcdConfiguration != nil
+	for _, node := range nodes {
+		address := nodeInternalIP(node)
+		if address == "" {
+			report.add(Certificate{Name: "kubelet", Source: node.Name, Error: "node has no internal IP"})
+			continue
+		}
+
+		if !externalEtcd && isControlPlane(node) {
+			report.add(i.servingCertificate(ctx, "etcd", net.JoinHostPort(address, etcdPort)))
+		}
+		report.add(i.servingCertificate(ctx, "kubelet", net.JoinHostPort(address, kubeletPort)))
+	}
+
+	if externalEtcd {
+		etcdadmCluster, err := i.kubectl.GetEtcdadmCluster(ctx, managementCluster, capiCluster, executables.WithNamespace(constants.EksaSystemNamespace))
+		if err != nil {
+			return nil, err
+		}
+		for _, endpoint := range strings.Split(etcdadmCluster.Status.Endpoints, ",") {
+			if endpoint == "" {
+				continue
+			}
+			etcdUrl, err := url.Parse(endpoint)
+			if err != nil {
+				report.add(Certificate{Name: "etcd", Source: endpoint, Error: err.Error()})
+				continue
+			}
+			report.add(i.servingCertificate(ctx, "etcd", etcdUrl.Host))
+		}
+	}
+
+	return report, nil
+}
+
+func (i *Inspector) secretCertificate(ctx context.Context, managementCluster *types.Cluster, name, secretName, namespace, key string) Certificate {
+	c := Certificate{Name: name, Source: fmt.Sprintf("secret/%s", secretName)}
+	s, err := i.kubectl.GetSecretFromNamespace(ctx, managementCluster.KubeconfigFile, secretName, namespace)
+	if err != nil {
+		return c.withError(err)
+	}
+
+	cert, err := parseCertificate(s.Data[key])
+	if err != nil {
+		return c.withError(fmt.Errorf("error reading %s from secret: %v", key, err))
+	}
+
+	return c.withCertificate(cert)
+}
+
+func (i *Inspector) kubeconfigCertificate(ctx context.Context, managementCluster *types.Cluster, clusterName string) Certificate {
+	secretName := secret.Name(clusterName, secret.Kubeconfig)
+	c := Certificate{Name: "admin-kubeconfig", Source: fmt.Sprintf("secret/%s", secretName)}
+	s, err := i.kubectl.GetSecretFromNamespace(ctx, managementCluster.KubeconfigFile, secretName, constants.EksaSystemNamespace)
+	if err != nil {
+		return c.withError(err)
+	}
+
+	config, err := clientcmd.Load(s.Data[secret.KubeconfigDataName])
+	if err != nil {
+		return c.withError(fmt.Errorf("error loading kubeconfig: %v", err))
+	}
+
+	for _, authInfo := range config.AuthInfos {
+		if len(authInfo.ClientCertificateData) == 0 {
+			continue
+		}
+		cert, err := parseCertificate(authInfo.ClientCertificateData)
+		if err != nil {
+			return c.withError(err)
+		}
+		return c.withCertificate(cert)
+	}
+
+	return c.withError(errors.New("kubeconfig doesn't contain a client certificate"))
+}
+
+func (i *Inspector) servingCertificate(ctx context.Context, name, address string) Certificate {
+	c := Certificate{Name: name, Source: address}
+	certs, err := i.peerCertificates(ctx, address)
+	if err != nil {
+		logger.V(4).Info("Failed reading served certificate", "address", address, "error", err)
+		return c.withError(err)
+	}
+	if len(certs) == 0 {
+		return c.withError(errors.New("no certificate served"))
+	}
+
+	return c.withCertificate(certs[0])
+}
+
+// dialPeerCertificates opens a TLS connection to address and returns the certificates presented by the server.
+// The chain is not verified since the goal is only reading the expiration dates. Some endpoints, like etcd,
+// require a client certificate, so the certificates are captured during the handshake instead of after it
+func dialPeerCertificates(ctx context.Context, address string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: dialTimeout},
+		Config: &tls.Config{
+			InsecureSkipVerify: true,
+			VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				for _, raw := range rawCerts {
+					cert, err := x509.ParseCertificate(raw)
+					if err != nil {
+						return err
+					}
+					certs = append(certs, cert)
+				}
+				return nil
+			},
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err == nil {
+		conn.Close()
+	}
+	if len(certs) > 0 {
+		return certs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %v", address, err)
+	}
+
+	return certs, nil
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM certificate found")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func nodeInternalIP(node corev1.Node) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			return address.Address
+		}
+	}
+	return ""
+}
+
+func isControlPlane(node corev1.Node) bool {
+	_, ok := node.Labels["node-role.kubernetes.io/control-plane"]
+	if !ok {
+		_, ok = node.Labels["node-role.kubernetes.io/master"]
+	}
+	return ok
+}
@@ -0,0 +1,239 @@
+package certificates_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1alpha3"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/certificates/mocks"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/crypto"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type inspectorTest struct {
+	*WithT
+	ctx               context.Context
+	kubectl           *mocks.MockKubectlClient
+	managementCluster *types.Cluster
+	workloadCluster   *types.Cluster
+	spec              *cluster.Spec
+	certPEM           []byte
+	cert              *x509.Certificate
+	peers             map[string]*x509.Certificate
+	inspector         *certificates.Inspector
+}
+
+func newInspectorTest(t *testing.T) *inspectorTest {
+	certPEM, _, err := crypto.NewCertificateGenerator().GenerateIamAuthSelfSignCertKeyPair()
+	if err != nil {
+		t.Fatalf("failed generating certificate: %v", err)
+	}
+	block, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed parsing certificate: %v", err)
+	}
+
+	tt := &inspectorTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		kubectl:           mocks.NewMockKubectlClient(gomock.NewController(t)),
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		workloadCluster:   &types.Cluster{Name: "test-cluster", KubeconfigFile: "test-cluster.kubeconfig"},
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Name = "test-cluster"
+		}),
+		certPEM: certPEM,
+		cert:    cert,
+		peers:   map[string]*x509.Certificate{},
+	}
+	tt.inspector = certificates.NewInspector(tt.kubectl,
+		certificates.WithPeerCertificates(tt.peerCertificates),
+		certificates.WithNow(func() time.Time { return cert.NotAfter.Add(-48 * time.Hour) }),
+	)
+
+	return tt
+}
+
+func (tt *inspectorTest) peerCertificates(_ context.Context, address string) ([]*x509.Certificate, error) {
+	cert, ok := tt.peers[address]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return []*x509.Certificate{cert}, nil
+}
+
+func (tt *inspectorTest) expectSecret(name string, data map[string][]byte) {
+	tt.kubectl.EXPECT().GetSecretFromNamespace(tt.ctx, tt.managementCluster.KubeconfigFile, name, constants.EksaSystemNamespace).Return(
+		&corev1.Secret{Data: data}, nil,
+	)
+}
+
+func (tt *inspectorTest) expectKubeconfigSecret() {
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+users:
+- name: admin
+  user:
+    client-certificate-data: %s
+`, base64.StdEncoding.EncodeToString(tt.certPEM))
+	tt.expectSecret("test-cluster-kubeconfig", map[string][]byte{"value": []byte(kubeconfig)})
+}
+
+func (tt *inspectorTest) expectCASecrets() {
+	tt.expectSecret("test-cluster-ca", map[string][]byte{"tls.crt": tt.certPEM})
+	tt.expectSecret("test-cluster-etcd", map[string][]byte{"tls.crt": tt.certPEM})
+	tt.expectSecret("test-cluster-proxy", map[string][]byte{"tls.crt": tt.certPEM})
+}
+
+func node(name, ip string, controlPlane bool) corev1.Node {
+	n := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: ip}},
+		},
+	}
+	if controlPlane {
+		n.Labels["node-role.kubernetes.io/control-plane"] = ""
+	}
+	return n
+}
+
+func certificateNames(report *certificates.Report) []string {
+	names := make([]string, 0, len(report.Certificates))
+	for _, c := range report.Certificates {
+		names = append(names, c.Name+" "+c.Source)
+	}
+	return names
+}
+
+func TestInspectorCertificatesStackedEtcd(t *testing.T) {
+	tt := newInspectorTest(t)
+	tt.expectCASecrets()
+	tt.expectKubeconfigSecret()
+	tt.kubectl.EXPECT().GetApiServerUrl(tt.ctx, tt.workloadCluster).Return("https://1.2.3.4:6443", nil)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.workloadCluster.KubeconfigFile).Return([]corev1.Node{
+		node("cp", "1.2.3.4", true),
+		node("worker", "1.2.3.5", false),
+	}, nil)
+	tt.peers["1.2.3.4:6443"] = tt.cert
+	tt.peers["1.2.3.4:2379"] = tt.cert
+	tt.peers["1.2.3.4:10250"] = tt.cert
+
+	report, err := tt.inspector.Certificates(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.spec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(report.Cluster).To(Equal("test-cluster"))
+	tt.Expect(certificateNames(report)).To(Equal([]string{
+		"cluster-ca secret/test-cluster-ca",
+		"etcd-ca secret/test-cluster-etcd",
+		"front-proxy-ca secret/test-cluster-proxy",
+		"admin-kubeconfig secret/test-cluster-kubeconfig",
+		"apiserver 1.2.3.4:6443",
+		"etcd 1.2.3.4:2379",
+		"kubelet 1.2.3.4:10250",
+		"kubelet 1.2.3.5:10250",
+	}))
+	for _, c := range report.Certificates[:7] {
+		tt.Expect(c.Error).To(BeEmpty(), c.Name)
+		tt.Expect(*c.NotAfter).To(BeTemporally("==", tt.cert.NotAfter), c.Name)
+	}
+	tt.Expect(report.Certificates[7].NotAfter).To(BeNil())
+	tt.Expect(report.Certificates[7].Error).To(Equal("connection refused"))
+}
+
+func TestInspectorCertificatesExternalEtcdAndIamAuth(t *testing.T) {
+	tt := newInspectorTest(t)
+	tt.spec.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 1}
+	tt.spec.AWSIamConfig = &v1alpha1.AWSIamConfig{}
+	tt.expectCASecrets()
+	tt.expectSecret("test-cluster-apiserver-etcd-client", map[string][]byte{"tls.crt": tt.certPEM})
+	tt.expectSecret("aws-iam-authenticator-ca", map[string][]byte{"cert.pem": tt.certPEM})
+	tt.expectKubeconfigSecret()
+	tt.kubectl.EXPECT().GetApiServerUrl(tt.ctx, tt.workloadCluster).Return("https://1.2.3.4:6443", nil)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.workloadCluster.KubeconfigFile).Return([]corev1.Node{
+		node("cp", "1.2.3.4", true),
+	}, nil)
+	etcdadmCluster := &etcdv1.EtcdadmCluster{}
+	etcdadmCluster.Status.Endpoints = "https://1.2.3.10:2379,https://1.2.3.11:2379"
+	tt.kubectl.EXPECT().GetEtcdadmCluster(tt.ctx, tt.managementCluster, "test-cluster", gomock.Any()).Return(etcdadmCluster, nil)
+
+	report, err := tt.inspector.Certificates(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.spec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(certificateNames(report)).To(Equal([]string{
+		"cluster-ca secret/test-cluster-ca",
+		"etcd-ca secret/test-cluster-etcd",
+		"front-proxy-ca secret/test-cluster-proxy",
+		"apiserver-etcd-client secret/test-cluster-apiserver-etcd-client",
+		"aws-iam-authenticator secret/aws-iam-authenticator-ca",
+		"admin-kubeconfig secret/test-cluster-kubeconfig",
+		"apiserver 1.2.3.4:6443",
+		"kubelet 1.2.3.4:10250",
+		"etcd 1.2.3.10:2379",
+		"etcd 1.2.3.11:2379",
+	}))
+}
+
+func TestInspectorCertificatesInvalidSecret(t *testing.T) {
+	tt := newInspectorTest(t)
+	tt.expectSecret("test-cluster-ca", map[string][]byte{"tls.crt": []byte("invalid")})
+	tt.kubectl.EXPECT().GetSecretFromNamespace(tt.ctx, tt.managementCluster.KubeconfigFile, gomock.Any(), constants.EksaSystemNamespace).Return(
+		nil, errors.New("secret not found"),
+	).Times(3)
+	tt.kubectl.EXPECT().GetApiServerUrl(tt.ctx, tt.workloadCluster).Return("https://1.2.3.4:6443", nil)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.workloadCluster.KubeconfigFile).Return(nil, nil)
+
+	report, err := tt.inspector.Certificates(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.spec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(report.Certificates[0].Error).To(Equal("error reading tls.crt from secret: no PEM certificate found"))
+	tt.Expect(report.Certificates[1].Error).To(Equal("secret not found"))
+}
+
+func TestInspectorCertificatesErrorGettingNodes(t *testing.T) {
+	tt := newInspectorTest(t)
+	tt.kubectl.EXPECT().GetSecretFromNamespace(tt.ctx, tt.managementCluster.KubeconfigFile, gomock.Any(), constants.EksaSystemNamespace).Return(
+		nil, errors.New("secret not found"),
+	).Times(4)
+	tt.kubectl.EXPECT().GetApiServerUrl(tt.ctx, tt.workloadCluster).Return("https://1.2.3.4:6443", nil)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.workloadCluster.KubeconfigFile).Return(nil, errors.New("error getting nodes"))
+
+	_, err := tt.inspector.Certificates(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("error getting nodes")))
+}
+
+func TestInspectorCertificatesDialsServingCertificate(t *testing.T) {
+	tt := newInspectorTest(t)
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	inspector := certificates.NewInspector(tt.kubectl)
+
+	tt.kubectl.EXPECT().GetSecretFromNamespace(tt.ctx, tt.managementCluster.KubeconfigFile, gomock.Any(), constants.EksaSystemNamespace).Return(
+		nil, errors.New("secret not found"),
+	).Times(4)
+	tt.kubectl.EXPECT().GetApiServerUrl(tt.ctx, tt.workloadCluster).Return(server.URL, nil)
+	tt.kubectl.EXPECT().GetNodes(tt.ctx, tt.workloadCluster.KubeconfigFile).Return(nil, nil)
+
+	report, err := inspector.Certificates(tt.ctx, tt.managementCluster, tt.workloadCluster, tt.spec)
+	tt.Expect(err).To(Succeed())
+	apiServer := report.Certificates[4]
+	tt.Expect(apiServer.Name).To(Equal("apiserver"))
+	tt.Expect(apiServer.Error).To(BeEmpty())
+	tt.Expect(*apiServer.NotAfter).To(BeTemporally("==", server.Certificate().NotAfter))
+}
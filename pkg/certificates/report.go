@@ -0,0 +1,108 @@
+package certificates
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	TableOutput = "table"
+	JSONOutput  = "json"
+	YAMLOutput  = "yaml"
+)
+
+// Certificate describes the expiration of one of the cluster certificates
+type Certificate struct {
+	Name     string     `json:"name"`
+	Source   string     `json:"source"`
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+func (c Certificate) withCertificate(cert *x509.Certificate) Certificate {
+	notAfter := cert.NotAfter
+	c.NotAfter = &notAfter
+	return c
+}
+
+func (c Certificate) withError(err error) Certificate {
+	c.Error = err.Error()
+	return c
+}
+
+// Report lists the certificates of a cluster
+type Report struct {
+	Cluster      string        `json:"cluster"`
+	GeneratedAt  time.Time     `json:"generatedAt"`
+	Certificates []Certificate `json:"certificates"`
+}
+
+func (r *Report) add(c Certificate) {
+	r.Certificates = append(r.Certificates, c)
+}
+
+// ValidateOutputFormat checks the format is one Print supports
+func ValidateOutputFormat(format string) error {
+	switch format {
+	case "", TableOutput, JSONOutput, YAMLOutput:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %s, supported formats are %s, %s and %s", format, TableOutput, JSONOutput, YAMLOutput)
+	}
+}
+
+// Print writes the report in the given format: table, json or yaml
+func (r *Report) Print(w io.Writer, format string) error {
+	if err := ValidateOutputFormat(format); err != nil {
+		return err
+	}
+
+	switch format {
+	case JSONOutput:
+		content, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed marshalling certificates report: %v", err)
+		}
+		_, err = fmt.Fprintln(w, string(content))
+		return err
+	case YAMLOutput:
+		content, err := yaml.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("failed marshalling certificates report: %v", err)
+		}
+		_, err = w.Write(content)
+		return err
+	default:
+		return r.printTable(w)
+	}
+}
+
+func (r *Report) printTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSOURCE\tEXPIRES\tRESIDUAL TIME")
+	for _, c := range r.Certificates {
+		if c.NotAfter == nil {
+			fmt.Fprintf(tw, "%s\t%s\t<unknown>\t%s\n", c.Name, c.Source, c.Error)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Name, c.Source, c.NotAfter.UTC().Format(time.RFC3339), residualTime(c.NotAfter.Sub(r.GeneratedAt)))
+	}
+
+	return tw.Flush()
+}
+
+func residualTime(d time.Duration) string {
+	if d <= 0 {
+		return "expired"
+	}
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,61 @@
+package certificates_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+func testReport() *certificates.Report {
+	generatedAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	inAYear := generatedAt.Add(365 * 24 * time.Hour)
+	inHours := generatedAt.Add(5 * time.Hour)
+	expired := generatedAt.Add(-time.Hour)
+	return &certificates.Report{
+		Cluster:     "test-cluster",
+		GeneratedAt: generatedAt,
+		Certificates: []certificates.Certificate{
+			{Name: "cluster-ca", Source: "secret/test-cluster-ca", NotAfter: &inAYear},
+			{Name: "apiserver", Source: "1.2.3.4:6443", NotAfter: &inHours},
+			{Name: "etcd", Source: "1.2.3.4:2379", NotAfter: &expired},
+			{Name: "kubelet", Source: "1.2.3.5:10250", Error: "connection refused"},
+		},
+	}
+}
+
+func TestReportPrintTable(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	g.Expect(testReport().Print(out, certificates.TableOutput)).To(Succeed())
+	g.Expect(out.String()).To(Equal(`NAME         SOURCE                   EXPIRES                RESIDUAL TIME
+cluster-ca   secret/test-cluster-ca   2023-01-01T00:00:00Z   365d
+apiserver    1.2.3.4:6443             2022-01-01T05:00:00Z   5h
+etcd         1.2.3.4:2379             2021-12-31T23:00:00Z   expired
+kubelet      1.2.3.5:10250            <unknown>              connection refused
+`))
+}
+
+func TestReportPrintJSON(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	g.Expect(testReport().Print(out, certificates.JSONOutput)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring(`"notAfter": "2023-01-01T00:00:00Z"`))
+	g.Expect(out.String()).To(ContainSubstring(`"error": "connection refused"`))
+}
+
+func TestReportPrintYAML(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	g.Expect(testReport().Print(out, certificates.YAMLOutput)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("cluster: test-cluster"))
+	g.Expect(out.String()).To(ContainSubstring("- error: connection refused"))
+}
+
+func TestReportPrintInvalidFormat(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(testReport().Print(&bytes.Buffer{}, "xml")).To(MatchError(ContainSubstring("invalid output format xml")))
+}
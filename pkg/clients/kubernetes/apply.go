@@ -0,0 +1,228 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// fieldOwner is the field manager of the objects applied by this client
+	fieldOwner = "eks-anywhere"
+
+	deleteMaxRetries    = 60
+	deleteBackOffPeriod = time.Second
+)
+
+// clientSideApplyManagers are the field managers kubectl sets for the objects created or updated with client side apply
+var clientSideApplyManagers = map[string]bool{
+	"kubectl":                   true,
+	"kubectl-client-side-apply": true,
+}
+
+// ApplyKubeSpecFromBytes applies the objects in a multi document yaml with server side apply, namespaced
+// objects without namespace are applied to the default namespace
+func (c *Client) ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	if err := c.apply(ctx, cluster.KubeconfigFile, data, "", false); err != nil {
+		return fmt.Errorf("error executing apply: %v", err)
+	}
+	return nil
+}
+
+// ApplyKubeSpecFromBytesWithNamespace applies the objects in a multi document yaml, namespaced objects
+// without namespace are applied to namespace
+func (c *Client) ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, cluster *types.Cluster, data []byte, namespace string) error {
+	if err := c.apply(ctx, cluster.KubeconfigFile, data, namespace, false); err != nil {
+		return fmt.Errorf("error executing apply: %v", err)
+	}
+	return nil
+}
+
+// ApplyKubeSpecFromBytesForce applies the objects in a multi document yaml, like kubectl apply --force
+// the objects that can't be updated are deleted and created again
+func (c *Client) ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	if err := c.apply(ctx, cluster.KubeconfigFile, data, "", true); err != nil {
+		return fmt.Errorf("error executing apply --force: %v", err)
+	}
+	return nil
+}
+
+func (c *Client) apply(ctx context.Context, kubeconfig string, data []byte, namespace string, recreate bool) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	objs, err := unstructuredObjects(data)
+	if err != nil {
+		return err
+	}
+
+	for i := range objs {
+		obj := &objs[i]
+		if err = setDefaultNamespace(cl, obj, namespace); err != nil {
+			return err
+		}
+
+		err = takeOverClientSideApplyFields(ctx, cl, obj)
+		if err == nil {
+			err = cl.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldOwner), client.ForceOwnership)
+		}
+		// only the objects with immutable fields are recreated, like kubectl apply --force
+		if apierrors.IsInvalid(err) && recreate {
+			err = recreateObject(ctx, cl, obj)
+		}
+		if err != nil {
+			return fmt.Errorf("error applying %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// recreateObject deletes the object and waits until it's gone before creating it again, since with foreground
+// deletion the object is kept until its dependents are deleted
+func recreateObject(ctx context.Context, cl client.Client, obj *unstructured.Unstructured) error {
+	if err := client.IgnoreNotFound(cl.Delete(ctx, obj.DeepCopy(), client.PropagationPolicy(metav1.DeletePropagationForeground))); err != nil {
+		return err
+	}
+
+	err := retrier.NewWithMaxRetries(deleteMaxRetries, deleteBackOffPeriod).Retry(func() error {
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(obj.GroupVersionKind())
+		err := cl.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%s %s is still being deleted", obj.GetKind(), obj.GetName())
+	})
+	if err != nil {
+		return err
+	}
+
+	return cl.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldOwner), client.ForceOwnership)
+}
+
+// takeOverClientSideApplyFields transfers the fields owned by client side apply in an existing object to the field
+// manager of this client. Server side apply only removes the fields missing in the applied object that are owned by
+// the same field manager, so without this the fields removed from the objects created with kubectl apply would never
+// be removed. The kubectl last applied annotation is transferred too, so it's removed by the next apply
+func takeOverClientSideApplyFields(ctx context.Context, cl client.Client, obj *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	var clientSideApplyFound bool
+	var apiVersion string
+	owned := &fieldpath.Set{}
+	managedFields := make([]metav1.ManagedFieldsEntry, 0, len(existing.GetManagedFields()))
+	for _, entry := range existing.GetManagedFields() {
+		fromClientSideApply := clientSideApplyManagers[entry.Manager] && entry.Operation == metav1.ManagedFieldsOperationUpdate
+		fromThisClient := entry.Manager == fieldOwner && entry.Operation == metav1.ManagedFieldsOperationApply
+		if !fromClientSideApply && !fromThisClient {
+			managedFields = append(managedFields, entry)
+			continue
+		}
+		if fromClientSideApply {
+			clientSideApplyFound = true
+		}
+		if apiVersion == "" {
+			apiVersion = entry.APIVersion
+		}
+		if entry.FieldsV1 == nil {
+			continue
+		}
+
+		fields := &fieldpath.Set{}
+		if err := fields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return fmt.Errorf("error parsing managed fields of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		owned = owned.Union(fields)
+	}
+	if !clientSideApplyFound {
+		return nil
+	}
+
+	raw, err := owned.ToJSON()
+	if err != nil {
+		return fmt.Errorf("error serializing managed fields of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+	now := metav1.Now()
+	managedFields = append(managedFields, metav1.ManagedFieldsEntry{
+		Manager:    fieldOwner,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		APIVersion: apiVersion,
+		Time:       &now,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: raw},
+	})
+
+	patch := client.MergeFrom(existing.DeepCopy())
+	existing.SetManagedFields(managedFields)
+	if err = cl.Patch(ctx, existing, patch); err != nil {
+		return fmt.Errorf("error taking over the client side apply fields of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// setDefaultNamespace sets the namespace of the namespaced objects that don't have one, like kubectl does
+func setDefaultNamespace(cl client.Client, obj *unstructured.Unstructured, namespace string) error {
+	if obj.GetNamespace() != "" {
+		return nil
+	}
+
+	gvk := obj.GroupVersionKind()
+	mapping, err := cl.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("error getting resource for %s: %v", gvk, err)
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return nil
+	}
+
+	if namespace == "" {
+		namespace = corev1.NamespaceDefault
+	}
+	obj.SetNamespace(namespace)
+	return nil
+}
+
+func unstructuredObjects(data []byte) ([]unstructured.Unstructured, error) {
+	var objs []unstructured.Unstructured
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		obj := unstructured.Unstructured{}
+		err := decoder.Decode(&obj.Object)
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing objects: %v", err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+}
@@ -0,0 +1,156 @@
+package kubernetes_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func configMapsYaml(namespace, value string) []byte {
+	return []byte(fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm-1
+  namespace: %s
+data:
+  key: %s
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm-2
+data:
+  key: %s
+`, namespace, value, value))
+}
+
+func (tt *clientTest) getConfigMap(name, namespace string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{}
+	tt.Expect(env.APIReader().Get(tt.ctx, client.ObjectKey{Name: name, Namespace: namespace}, cm)).To(Succeed())
+	return cm
+}
+
+func TestClientApplyKubeSpecFromBytesWithNamespace(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+
+	tt.Expect(tt.client.ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, configMapsYaml(ns, "v1"), ns)).To(Succeed())
+	tt.Expect(tt.getConfigMap("cm-1", ns).Data).To(HaveKeyWithValue("key", "v1"))
+	tt.Expect(tt.getConfigMap("cm-2", ns).Data).To(HaveKeyWithValue("key", "v1"))
+
+	tt.Expect(tt.client.ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, configMapsYaml(ns, "v2"), ns)).To(Succeed())
+	tt.Expect(tt.getConfigMap("cm-1", ns).Data).To(HaveKeyWithValue("key", "v2"))
+	tt.Expect(tt.getConfigMap("cm-2", ns).Data).To(HaveKeyWithValue("key", "v2"))
+}
+
+func TestClientApplyKubeSpecFromBytesDefaultNamespace(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+	t.Cleanup(func() {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm-2", Namespace: corev1.NamespaceDefault}}
+		tt.Expect(client.IgnoreNotFound(env.Client().Delete(tt.ctx, cm))).To(Succeed())
+	})
+
+	tt.Expect(tt.client.ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, configMapsYaml(ns, "v1"))).To(Succeed())
+	tt.Expect(tt.getConfigMap("cm-1", ns).Data).To(HaveKeyWithValue("key", "v1"))
+	tt.Expect(tt.getConfigMap("cm-2", corev1.NamespaceDefault).Data).To(HaveKeyWithValue("key", "v1"))
+}
+
+func TestClientApplyKubeSpecFromBytesRemovesFields(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+	withTwoKeys := []byte(fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: %s
+data:
+  key1: v1
+  key2: v1
+`, ns))
+	withOneKey := []byte(fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: %s
+data:
+  key1: v1
+`, ns))
+
+	tt.Expect(tt.client.ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, withTwoKeys)).To(Succeed())
+	tt.Expect(tt.client.ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, withOneKey)).To(Succeed())
+	tt.Expect(tt.getConfigMap("cm", ns).Data).To(Equal(map[string]string{"key1": "v1"}))
+}
+
+func TestClientApplyKubeSpecFromBytesRemovesClientSideApplyFields(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+	// created like kubectl apply without server side apply does
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cm",
+			Namespace:   ns,
+			Annotations: map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+		},
+		Data: map[string]string{"key1": "v1", "key2": "v1"},
+	}
+	tt.Expect(env.Client().Create(tt.ctx, cm, client.FieldOwner("kubectl-client-side-apply"))).To(Succeed())
+
+	tt.Expect(tt.client.ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, []byte(fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: %s
+data:
+  key1: v2
+`, ns)))).To(Succeed())
+
+	got := tt.getConfigMap("cm", ns)
+	tt.Expect(got.Data).To(Equal(map[string]string{"key1": "v2"}))
+	tt.Expect(got.Annotations).NotTo(HaveKey("kubectl.kubernetes.io/last-applied-configuration"))
+}
+
+func TestClientApplyKubeSpecFromBytesForce(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+	tt.createObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: ns},
+		Type:       corev1.SecretTypeOpaque,
+	})
+	// the type of a secret is immutable, so the secret has to be recreated
+	secret := []byte(fmt.Sprintf(`apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+  namespace: %s
+type: kubernetes.io/basic-auth
+stringData:
+  username: admin
+  password: admin
+`, ns))
+
+	tt.Expect(tt.client.ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, secret)).NotTo(Succeed())
+	tt.Expect(tt.client.ApplyKubeSpecFromBytesForce(tt.ctx, tt.cluster, secret)).To(Succeed())
+
+	got := &corev1.Secret{}
+	tt.Expect(env.APIReader().Get(tt.ctx, client.ObjectKey{Name: "secret", Namespace: ns}, got)).To(Succeed())
+	tt.Expect(got.Type).To(Equal(corev1.SecretTypeBasicAuth))
+}
+
+func TestClientApplyKubeSpecFromBytesForceDoesNotRecreateOnOtherErrors(t *testing.T) {
+	tt := newClientTest(t)
+
+	err := tt.client.ApplyKubeSpecFromBytesForce(tt.ctx, tt.cluster, configMapsYaml("missing-namespace", "v1"))
+	tt.Expect(err).To(MatchError(ContainSubstring("not found")))
+}
+
+func TestClientApplyKubeSpecFromBytesInvalidYaml(t *testing.T) {
+	tt := newClientTest(t)
+
+	err := tt.client.ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, []byte("kind: [ConfigMap"))
+	tt.Expect(err).To(MatchError(ContainSubstring("error parsing objects")))
+}
@@ -0,0 +1,303 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(releasev1alpha1.AddToScheme(scheme))
+	utilruntime.Must(clusterv1.AddToScheme(scheme))
+	utilruntime.Must(controlplanev1.AddToScheme(scheme))
+}
+
+// ClientFactory builds a controller-runtime client for the cluster a kubeconfig file points to
+type ClientFactory func(kubeconfig string) (client.Client, error)
+
+// Client implements the kubectl operations used by the cluster manager with a controller-runtime client,
+// talking to the API server directly instead of shelling out to kubectl
+type Client struct {
+	clientFactory ClientFactory
+	clients       map[string]client.Client
+	lock          sync.Mutex
+}
+
+type ClientOpt func(*Client)
+
+// WithClientFactory sets the factory used to build the clients for each kubeconfig file
+func WithClientFactory(f ClientFactory) ClientOpt {
+	return func(c *Client) {
+		c.clientFactory = f
+	}
+}
+
+func New(opts ...ClientOpt) *Client {
+	c := &Client{
+		clientFactory: NewClientForKubeconfig,
+		clients:       map[string]client.Client{},
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// NewClientForKubeconfig builds a non cached client for the cluster in the current context of a kubeconfig file
+func NewClientForKubeconfig(kubeconfig string) (client.Client, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error building rest config from kubeconfig %s: %v", kubeconfig, err)
+	}
+
+	return NewClientForConfig(config)
+}
+
+// NewClientForConfig builds a non cached client with the schemes of the EKS-A and CAPI objects
+func NewClientForConfig(config *rest.Config) (client.Client, error) {
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+func (c *Client) client(kubeconfig string) (client.Client, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cl, ok := c.clients[kubeconfig]; ok {
+		return cl, nil
+	}
+
+	cl, err := c.clientFactory(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes client: %v", err)
+	}
+	c.clients[kubeconfig] = cl
+
+	return cl, nil
+}
+
+func (c *Client) GetNamespace(ctx context.Context, kubeconfig string, namespace string) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	return cl.Get(ctx, client.ObjectKey{Name: namespace}, &corev1.Namespace{})
+}
+
+func (c *Client) CreateNamespace(ctx context.Context, kubeconfig string, namespace string) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	if err = cl.Create(ctx, ns); err != nil {
+		return fmt.Errorf("error creating namespace %v: %v", namespace, err)
+	}
+	return nil
+}
+
+func (c *Client) GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error) {
+	cl, err := c.client(cluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &clusterv1.MachineList{}
+	err = cl.List(ctx, list,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterv1.ClusterLabelName: clusterName},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting machines: %v", err)
+	}
+
+	machines := []types.Machine{}
+	if err = convert(list.Items, &machines); err != nil {
+		return nil, fmt.Errorf("error parsing get machines response: %v", err)
+	}
+
+	return machines, nil
+}
+
+func (c *Client) GetClusters(ctx context.Context, cluster *types.Cluster) ([]types.CAPICluster, error) {
+	cl, err := c.client(cluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &clusterv1.ClusterList{}
+	if err = cl.List(ctx, list, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return nil, fmt.Errorf("error getting clusters: %v", err)
+	}
+
+	clusters := []types.CAPICluster{}
+	if err = convert(list.Items, &clusters); err != nil {
+		return nil, fmt.Errorf("error parsing get clusters response: %v", err)
+	}
+
+	return clusters, nil
+}
+
+// GetMachineDeploymentsForCluster returns the MachineDeployments of the worker node groups of a cluster
+func (c *Client) GetMachineDeploymentsForCluster(ctx context.Context, cluster *types.Cluster, clusterName string) ([]clusterv1.MachineDeployment, error) {
+	cl, err := c.client(cluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &clusterv1.MachineDeploymentList{}
+	if err = cl.List(ctx, list, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return nil, fmt.Errorf("error getting machine deployments: %v", err)
+	}
+
+	mds := make([]clusterv1.MachineDeployment, 0, len(list.Items))
+	for _, md := range list.Items {
+		if md.Spec.ClusterName == clusterName {
+			mds = append(mds, md)
+		}
+	}
+	return mds, nil
+}
+
+// GetEksaCluster returns the EKS-A cluster with the given name from any namespace
+func (c *Client) GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error) {
+	cl, err := c.client(cluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1alpha1.ClusterList{}
+	if err = cl.List(ctx, list, client.MatchingFields{"metadata.name": clusterName}); err != nil {
+		return nil, fmt.Errorf("error getting eksa cluster: %v", err)
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("error getting eksa cluster: cluster %s not found", clusterName)
+	}
+
+	return &list.Items[0], nil
+}
+
+func (c *Client) GetEksaGitOpsConfig(ctx context.Context, gitOpsConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.GitOpsConfig, error) {
+	gitOpsConfig := &v1alpha1.GitOpsConfig{}
+	if err := c.getObject(ctx, kubeconfigFile, gitOpsConfigName, namespace, gitOpsConfig); err != nil {
+		return nil, fmt.Errorf("error getting eksa GitOpsConfig: %v", err)
+	}
+	return gitOpsConfig, nil
+}
+
+func (c *Client) GetEksaVSphereDatacenterConfig(ctx context.Context, vsphereDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereDatacenterConfig, error) {
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
+	if err := c.getObject(ctx, kubeconfigFile, vsphereDatacenterConfigName, namespace, datacenterConfig); err != nil {
+		return nil, fmt.Errorf("error getting eksa vsphere datacenter config: %v", err)
+	}
+	return datacenterConfig, nil
+}
+
+func (c *Client) GetEksaVSphereMachineConfig(ctx context.Context, vsphereMachineConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereMachineConfig, error) {
+	machineConfig := &v1alpha1.VSphereMachineConfig{}
+	if err := c.getObject(ctx, kubeconfigFile, vsphereMachineConfigName, namespace, machineConfig); err != nil {
+		return nil, fmt.Errorf("error getting eksa vsphere machine config: %v", err)
+	}
+	return machineConfig, nil
+}
+
+func (c *Client) GetBundles(ctx context.Context, kubeconfigFile, name, namespace string) (*releasev1alpha1.Bundles, error) {
+	bundles := &releasev1alpha1.Bundles{}
+	if err := c.getObject(ctx, kubeconfigFile, name, namespace, bundles); err != nil {
+		return nil, fmt.Errorf("error getting Bundles: %v", err)
+	}
+	return bundles, nil
+}
+
+// KubeconfigSecretAvailable returns true if the secret with the kubeconfig of a workload cluster exists
+func (c *Client) KubeconfigSecretAvailable(ctx context.Context, kubeconfig string, clusterName string, namespace string) (bool, error) {
+	err := c.getObject(ctx, kubeconfig, fmt.Sprintf("%s-kubeconfig", clusterName), namespace, &corev1.Secret{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Client) DeleteEKSACluster(ctx context.Context, managementCluster *types.Cluster, eksaClusterName, eksaClusterNamespace string) error {
+	if err := c.deleteObject(ctx, managementCluster.KubeconfigFile, eksaClusterName, eksaClusterNamespace, &v1alpha1.Cluster{}); err != nil {
+		return fmt.Errorf("error deleting eksa cluster %s: %v", eksaClusterName, err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteGitOpsConfig(ctx context.Context, managementCluster *types.Cluster, gitOpsConfigName, gitOpsConfigNamespace string) error {
+	if err := c.deleteObject(ctx, managementCluster.KubeconfigFile, gitOpsConfigName, gitOpsConfigNamespace, &v1alpha1.GitOpsConfig{}); err != nil {
+		return fmt.Errorf("error deleting gitops config %s: %v", gitOpsConfigName, err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteOIDCConfig(ctx context.Context, managementCluster *types.Cluster, oidcConfigName, oidcConfigNamespace string) error {
+	if err := c.deleteObject(ctx, managementCluster.KubeconfigFile, oidcConfigName, oidcConfigNamespace, &v1alpha1.OIDCConfig{}); err != nil {
+		return fmt.Errorf("error deleting oidc config %s: %v", oidcConfigName, err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteAWSIamConfig(ctx context.Context, managementCluster *types.Cluster, awsIamConfigName, awsIamConfigNamespace string) error {
+	if err := c.deleteObject(ctx, managementCluster.KubeconfigFile, awsIamConfigName, awsIamConfigNamespace, &v1alpha1.AWSIamConfig{}); err != nil {
+		return fmt.Errorf("error deleting awsIam config %s: %v", awsIamConfigName, err)
+	}
+	return nil
+}
+
+func (c *Client) getObject(ctx context.Context, kubeconfig, name, namespace string, obj client.Object) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	return cl.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, obj)
+}
+
+// deleteObject deletes an object, it doesn't fail if the object doesn't exist
+func (c *Client) deleteObject(ctx context.Context, kubeconfig, name, namespace string, obj client.Object) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return client.IgnoreNotFound(cl.Delete(ctx, obj))
+}
+
+// convert copies the API objects to the types used by the cluster manager, which only hold a subset of their fields
+func convert(from, to interface{}) error {
+	b, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
@@ -0,0 +1,207 @@
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const kubeconfig = "c.kubeconfig"
+
+type clientTest struct {
+	*WithT
+	ctx     context.Context
+	client  *kubernetes.Client
+	cluster *types.Cluster
+}
+
+func newClientTest(t *testing.T) *clientTest {
+	return &clientTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		client: kubernetes.New(kubernetes.WithClientFactory(func(string) (client.Client, error) {
+			return kubernetes.NewClientForConfig(env.Config())
+		})),
+		cluster: &types.Cluster{
+			Name:           "mgmt",
+			KubeconfigFile: kubeconfig,
+		},
+	}
+}
+
+func (tt *clientTest) createObjects(objs ...client.Object) {
+	for _, o := range objs {
+		tt.Expect(env.Client().Create(tt.ctx, o)).To(Succeed())
+	}
+}
+
+func (tt *clientTest) ensureEksaSystemNamespace() {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.EksaSystemNamespace}}
+	if err := env.Client().Create(tt.ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		tt.Expect(err).NotTo(HaveOccurred())
+	}
+}
+
+func machine(name, clusterName string) *clusterv1.Machine {
+	dataSecretName := name + "-bootstrap"
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				clusterv1.ClusterLabelName: clusterName,
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: clusterName,
+			Bootstrap: clusterv1.Bootstrap{
+				DataSecretName: &dataSecretName,
+			},
+			InfrastructureRef: corev1.ObjectReference{
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+				Kind:       "DockerMachine",
+				Name:       name,
+			},
+		},
+	}
+}
+
+func TestClientGetMachines(t *testing.T) {
+	tt := newClientTest(t)
+	tt.ensureEksaSystemNamespace()
+	tt.createObjects(
+		machine("get-machines-cp", "get-machines"),
+		machine("get-machines-md", "get-machines"),
+		machine("get-machines-other", "get-machines-other"),
+	)
+
+	machines, err := tt.client.GetMachines(tt.ctx, tt.cluster, "get-machines")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(machines).To(HaveLen(2))
+	tt.Expect(machines[0].Metadata.Name).To(Equal("get-machines-cp"))
+	tt.Expect(machines[0].Metadata.Labels).To(HaveKeyWithValue(clusterv1.ClusterLabelName, "get-machines"))
+	tt.Expect(machines[1].Metadata.Name).To(Equal("get-machines-md"))
+}
+
+func TestClientGetMachinesEmpty(t *testing.T) {
+	tt := newClientTest(t)
+	tt.ensureEksaSystemNamespace()
+
+	machines, err := tt.client.GetMachines(tt.ctx, tt.cluster, "no-machines")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(machines).To(BeEmpty())
+}
+
+func TestClientGetEksaCluster(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eksa-cluster",
+			Namespace: ns,
+		},
+		Spec: v1alpha1.ClusterSpec{
+			KubernetesVersion: v1alpha1.Kube121,
+		},
+	}
+	// creating clusters is only allowed by the webhook when they are paused
+	cluster.PauseReconcile()
+	tt.createObjects(cluster)
+
+	got, err := tt.client.GetEksaCluster(tt.ctx, tt.cluster, "eksa-cluster")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got.Namespace).To(Equal(ns))
+	tt.Expect(got.Spec.KubernetesVersion).To(Equal(v1alpha1.Kube121))
+}
+
+func TestClientGetEksaClusterNotFound(t *testing.T) {
+	tt := newClientTest(t)
+
+	_, err := tt.client.GetEksaCluster(tt.ctx, tt.cluster, "missing-cluster")
+	tt.Expect(err).To(MatchError(ContainSubstring("cluster missing-cluster not found")))
+}
+
+func TestClientGetEksaGitOpsConfig(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+	tt.createObjects(&v1alpha1.GitOpsConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gitops",
+			Namespace: ns,
+		},
+		Spec: v1alpha1.GitOpsConfigSpec{
+			Flux: v1alpha1.Flux{
+				Github: v1alpha1.Github{
+					Owner:      "owner",
+					Repository: "repo",
+				},
+			},
+		},
+	})
+
+	got, err := tt.client.GetEksaGitOpsConfig(tt.ctx, "gitops", kubeconfig, ns)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got.Spec.Flux.Github.Repository).To(Equal("repo"))
+}
+
+func TestClientCreateAndGetNamespace(t *testing.T) {
+	tt := newClientTest(t)
+	namespace := "create-and-get-namespace"
+
+	tt.Expect(tt.client.GetNamespace(tt.ctx, kubeconfig, namespace)).NotTo(Succeed())
+	tt.Expect(tt.client.CreateNamespace(tt.ctx, kubeconfig, namespace)).To(Succeed())
+	tt.Expect(tt.client.GetNamespace(tt.ctx, kubeconfig, namespace)).To(Succeed())
+}
+
+func TestClientKubeconfigSecretAvailable(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+	tt.createObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workload-kubeconfig",
+			Namespace: ns,
+		},
+	})
+
+	available, err := tt.client.KubeconfigSecretAvailable(tt.ctx, kubeconfig, "workload", ns)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(available).To(BeTrue())
+
+	available, err = tt.client.KubeconfigSecretAvailable(tt.ctx, kubeconfig, "other", ns)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(available).To(BeFalse())
+}
+
+func TestClientDeleteEKSAClusterNotFound(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+
+	tt.Expect(tt.client.DeleteEKSACluster(tt.ctx, tt.cluster, "missing-cluster", ns)).To(Succeed())
+}
+
+func TestClientDeleteGitOpsConfig(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+	gitOpsConfig := &v1alpha1.GitOpsConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gitops",
+			Namespace: ns,
+		},
+	}
+	tt.createObjects(gitOpsConfig)
+
+	tt.Expect(tt.client.DeleteGitOpsConfig(tt.ctx, tt.cluster, "gitops", ns)).To(Succeed())
+	err := env.APIReader().Get(tt.ctx, client.ObjectKeyFromObject(gitOpsConfig), &v1alpha1.GitOpsConfig{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
@@ -0,0 +1,110 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// GetObjectByRef returns the object referenced by ref, it's useful for provider objects, like machine templates,
+// that don't have a type in this package
+func (c *Client) GetObjectByRef(ctx context.Context, ref corev1.ObjectReference, namespace, kubeconfig string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(ref.GroupVersionKind())
+	if err := c.getObject(ctx, kubeconfig, ref.Name, namespace, obj); err != nil {
+		return nil, fmt.Errorf("error getting %s %s: %v", ref.Kind, ref.Name, err)
+	}
+
+	return obj, nil
+}
+
+// MergePatchResource updates a resource with a JSON merge patch
+func (c *Client) MergePatchResource(ctx context.Context, resourceType, name, patch, kubeconfig, namespace string) error {
+	if err := c.mergePatch(ctx, kubeconfig, resourceType, name, namespace, []byte(patch)); err != nil {
+		return fmt.Errorf("error patching %s %s: %v", resourceType, name, err)
+	}
+	return nil
+}
+
+// DeleteResource deletes an object of any resource type, it doesn't fail if the object doesn't exist
+func (c *Client) DeleteResource(ctx context.Context, resourceType, name, kubeconfig, namespace string) error {
+	obj, err := c.objectForResource(kubeconfig, resourceType)
+	if err != nil {
+		return err
+	}
+
+	if err = c.deleteObject(ctx, kubeconfig, name, namespace, obj); err != nil {
+		return fmt.Errorf("error deleting %s %s: %v", resourceType, name, err)
+	}
+	return nil
+}
+
+func (c *Client) UpdateAnnotationInNamespace(ctx context.Context, resourceType, objectName string, annotations map[string]string, cluster *types.Cluster, namespace string) error {
+	if err := c.patchAnnotations(ctx, cluster.KubeconfigFile, resourceType, objectName, namespace, annotations); err != nil {
+		return fmt.Errorf("error updating annotation: %v", err)
+	}
+	return nil
+}
+
+func (c *Client) RemoveAnnotationInNamespace(ctx context.Context, resourceType, objectName, key string, cluster *types.Cluster, namespace string) error {
+	// a null value removes the key with a merge patch
+	if err := c.patchAnnotations(ctx, cluster.KubeconfigFile, resourceType, objectName, namespace, map[string]interface{}{key: nil}); err != nil {
+		return fmt.Errorf("error removing annotation: %v", err)
+	}
+	return nil
+}
+
+func (c *Client) patchAnnotations(ctx context.Context, kubeconfig, resourceType, name, namespace string, annotations interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.mergePatch(ctx, kubeconfig, resourceType, name, namespace, patch)
+}
+
+func (c *Client) mergePatch(ctx context.Context, kubeconfig, resourceType, name, namespace string, patch []byte) error {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	obj, err := c.objectForResource(kubeconfig, resourceType)
+	if err != nil {
+		return err
+	}
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	return cl.Patch(ctx, obj, client.RawPatch(apitypes.MergePatchType, patch))
+}
+
+// objectForResource returns an empty object for a resource type in any of the formats that kubectl accepts
+// (resource, resource.group, kind.group), for example: clusters.cluster.x-k8s.io or machinedeployment.cluster.x-k8s.io
+func (c *Client) objectForResource(kubeconfig, resourceType string) (*unstructured.Unstructured, error) {
+	cl, err := c.client(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	gvk, err := cl.RESTMapper().KindFor(schema.ParseGroupResource(resourceType).WithVersion(""))
+	if err != nil {
+		return nil, fmt.Errorf("error getting kind for resource type %s: %v", resourceType, err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	return obj, nil
+}
@@ -0,0 +1,80 @@
+package kubernetes_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func TestClientUpdateAndRemoveAnnotationInNamespace(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+	tt.createObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cm",
+			Namespace:   ns,
+			Annotations: map[string]string{"a": "1"},
+		},
+	})
+
+	annotations := map[string]string{"a": "2", "b": "3"}
+	tt.Expect(tt.client.UpdateAnnotationInNamespace(tt.ctx, "configmap", "cm", annotations, tt.cluster, ns)).To(Succeed())
+	tt.Expect(tt.getConfigMap("cm", ns).Annotations).To(Equal(annotations))
+
+	tt.Expect(tt.client.RemoveAnnotationInNamespace(tt.ctx, "configmaps", "cm", "a", tt.cluster, ns)).To(Succeed())
+	tt.Expect(tt.getConfigMap("cm", ns).Annotations).To(Equal(map[string]string{"b": "3"}))
+}
+
+func TestClientMergePatchResource(t *testing.T) {
+	tt := newClientTest(t)
+	tt.ensureEksaSystemNamespace()
+	m := machine("merge-patch", "merge-patch")
+	tt.createObjects(m)
+
+	patch := `{"spec":{"providerID":"docker:////merge-patch"}}`
+	tt.Expect(tt.client.MergePatchResource(tt.ctx, "machines.cluster.x-k8s.io", m.Name, patch, kubeconfig, constants.EksaSystemNamespace)).To(Succeed())
+
+	got := m.DeepCopy()
+	tt.Expect(env.APIReader().Get(tt.ctx, client.ObjectKeyFromObject(m), got)).To(Succeed())
+	tt.Expect(*got.Spec.ProviderID).To(Equal("docker:////merge-patch"))
+}
+
+func TestClientMergePatchResourceUnknownType(t *testing.T) {
+	tt := newClientTest(t)
+
+	err := tt.client.MergePatchResource(tt.ctx, "unknown.cluster.x-k8s.io", "name", "{}", kubeconfig, "default")
+	tt.Expect(err).To(MatchError(ContainSubstring("error getting kind for resource type unknown.cluster.x-k8s.io")))
+}
+
+func TestClientDeleteResource(t *testing.T) {
+	tt := newClientTest(t)
+	tt.ensureEksaSystemNamespace()
+	m := machine("delete-resource", "delete-resource")
+	tt.createObjects(m)
+
+	tt.Expect(tt.client.DeleteResource(tt.ctx, "machine.cluster.x-k8s.io", m.Name, kubeconfig, constants.EksaSystemNamespace)).To(Succeed())
+	err := env.APIReader().Get(tt.ctx, client.ObjectKeyFromObject(m), m.DeepCopy())
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	tt.Expect(tt.client.DeleteResource(tt.ctx, "machine.cluster.x-k8s.io", m.Name, kubeconfig, constants.EksaSystemNamespace)).To(Succeed())
+}
+
+func TestClientGetObjectByRef(t *testing.T) {
+	tt := newClientTest(t)
+	ns := env.CreateNamespaceForTest(tt.ctx, t)
+	tt.createObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: ns},
+		Data:       map[string]string{"key": "value"},
+	})
+
+	ref := corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"}
+	obj, err := tt.client.GetObjectByRef(tt.ctx, ref, ns, kubeconfig)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(obj.Object["data"]).To(Equal(map[string]interface{}{"key": "value"}))
+}
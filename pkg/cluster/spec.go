@@ -42,6 +42,7 @@ type Spec struct {
 	eksdRelease         *eksdv1alpha1.Release
 	Bundles             *v1alpha1.Bundles
 	ManagementCluster   *types.Cluster
+	kubernetesVersion   eksav1alpha1.KubernetesVersion
 }
 
 func (s *Spec) DeepCopy() *Spec {
@@ -66,21 +67,32 @@ func (s *Spec) DeepCopy() *Spec {
 func (cs *Spec) SetDefaultGitOps() {
 	if cs != nil && cs.GitOpsConfig != nil {
 		c := &cs.GitOpsConfig.Spec.Flux
-		if len(c.Github.ClusterConfigPath) == 0 {
-			if cs.Cluster.IsSelfManaged() {
-				c.Github.ClusterConfigPath = path.Join("clusters", cs.Name)
-			} else {
-				c.Github.ClusterConfigPath = path.Join("clusters", cs.Cluster.ManagedBy())
-			}
-		}
-		if len(c.Github.FluxSystemNamespace) == 0 {
-			c.Github.FluxSystemNamespace = FluxDefaultNamespace
+		switch {
+		case c.IsGit():
+			cs.setDefaultGitOpsRepositoryOptions(&c.Git.ClusterConfigPath, &c.Git.FluxSystemNamespace, &c.Git.Branch)
+		case c.IsGitlab():
+			cs.setDefaultGitOpsRepositoryOptions(&c.Gitlab.ClusterConfigPath, &c.Gitlab.FluxSystemNamespace, &c.Gitlab.Branch)
+		default:
+			cs.setDefaultGitOpsRepositoryOptions(&c.Github.ClusterConfigPath, &c.Github.FluxSystemNamespace, &c.Github.Branch)
 		}
+	}
+}
 
-		if len(c.Github.Branch) == 0 {
-			c.Github.Branch = FluxDefaultBranch
+func (cs *Spec) setDefaultGitOpsRepositoryOptions(clusterConfigPath, fluxSystemNamespace, branch *string) {
+	if len(*clusterConfigPath) == 0 {
+		if cs.Cluster.IsSelfManaged() {
+			*clusterConfigPath = path.Join("clusters", cs.Name)
+		} else {
+			*clusterConfigPath = path.Join("clusters", cs.Cluster.ManagedBy())
 		}
 	}
+	if len(*fluxSystemNamespace) == 0 {
+		*fluxSystemNamespace = FluxDefaultNamespace
+	}
+
+	if len(*branch) == 0 {
+		*branch = FluxDefaultBranch
+	}
 }
 
 type VersionsBundle struct {
@@ -137,6 +149,14 @@ func WithManagementCluster(cluster *types.Cluster) SpecOpt {
 	}
 }
 
+// WithKubernetesVersion overrides the kubernetes version of the cluster config, used to build the spec of
+// the intermediate versions of a multi-hop upgrade
+func WithKubernetesVersion(kubernetesVersion eksav1alpha1.KubernetesVersion) SpecOpt {
+	return func(s *Spec) {
+		s.kubernetesVersion = kubernetesVersion
+	}
+}
+
 func WithUserAgent(userAgent string) SpecOpt {
 	return func(s *Spec) {
 		s.userAgent = userAgent
@@ -177,6 +197,9 @@ func NewSpecFromClusterConfig(clusterConfigPath string, cliVersion version.Info,
 	if err != nil {
 		return nil, err
 	}
+	if s.kubernetesVersion != "" {
+		clusterConfig.Spec.KubernetesVersion = s.kubernetesVersion
+	}
 
 	bundles, err := s.GetBundles(cliVersion)
 	if err != nil {
@@ -198,6 +221,7 @@ func NewSpecFromClusterConfig(clusterConfigPath string, cliVersion version.Info,
 		return nil, err
 	}
 
+	clusterConfig.SetDefaults()
 	s.Bundles = bundles
 	s.Cluster = clusterConfig
 	s.VersionsBundle = &VersionsBundle{
@@ -276,6 +300,7 @@ func BuildSpecFromBundles(cluster *eksav1alpha1.Cluster, bundles *v1alpha1.Bundl
 		return nil, err
 	}
 
+	cluster.SetDefaults()
 	s.Bundles = bundles
 	s.Cluster = cluster
 	s.VersionsBundle = &VersionsBundle{
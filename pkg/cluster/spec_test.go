@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/aws/eks-anywhere/internal/test"
+	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/version"
 	"github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -140,6 +141,17 @@ func TestNewSpecWithBundlesOverrideValid(t *testing.T) {
 	validateSpecFromSimpleBundle(t, gotSpec)
 }
 
+func TestNewSpecWithKubernetesVersionNotInBundles(t *testing.T) {
+	v := version.Info{GitVersion: "v0.0.1"}
+	_, err := cluster.NewSpecFromClusterConfig("testdata/cluster_1_19.yaml", v,
+		cluster.WithReleasesManifest("testdata/simple_release.yaml"),
+		cluster.WithKubernetesVersion(eksav1alpha1.Kube120),
+	)
+	if err == nil || err.Error() != "kubernetes version 1.20 is not supported by bundles manifest 0" {
+		t.Fatalf("NewSpec() error = %v, want kubernetes version 1.20 is not supported", err)
+	}
+}
+
 func validateSpecFromSimpleBundle(t *testing.T, gotSpec *cluster.Spec) {
 	validateVersionedRepo(t, gotSpec.VersionsBundle.KubeDistro.Kubernetes, "public.ecr.aws/eks-distro/kubernetes", "v1.19.8-eks-1-19-4")
 	validateVersionedRepo(t, gotSpec.VersionsBundle.KubeDistro.CoreDNS, "public.ecr.aws/eks-distro/coredns", "v1.8.0-eks-1-19-4")
@@ -0,0 +1,38 @@
+package clusterapi
+
+import (
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// WorkerReplicas returns the replicas for the MachineDeployment of a worker node group.
+// For groups with autoscaling, the replicas the autoscaler set in the existing MachineDeployment
+// are kept as long as they are within the group limits, so applying the spec doesn't undo its scaling.
+func WorkerReplicas(workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration, existing *clusterv1.MachineDeployment) int {
+	autoscaling := workerNodeGroup.AutoScalingConfiguration
+	if autoscaling == nil {
+		return workerNodeGroup.Count
+	}
+
+	replicas := workerNodeGroup.Count
+	if existing != nil && existing.Spec.Replicas != nil {
+		replicas = int(*existing.Spec.Replicas)
+	}
+
+	if replicas < autoscaling.MinCount {
+		return autoscaling.MinCount
+	}
+	if replicas > autoscaling.MaxCount {
+		return autoscaling.MaxCount
+	}
+	return replicas
+}
+
+// MaxWorkerReplicas returns the maximum number of machines a worker node group can have
+func MaxWorkerReplicas(workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration) int {
+	if workerNodeGroup.AutoScalingConfiguration != nil && workerNodeGroup.AutoScalingConfiguration.MaxCount > workerNodeGroup.Count {
+		return workerNodeGroup.AutoScalingConfiguration.MaxCount
+	}
+	return workerNodeGroup.Count
+}
@@ -0,0 +1,69 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func machineDeploymentWithReplicas(replicas int32) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{Spec: clusterv1.MachineDeploymentSpec{Replicas: &replicas}}
+}
+
+func TestWorkerReplicas(t *testing.T) {
+	autoscaling := &v1alpha1.AutoScalingConfiguration{MinCount: 2, MaxCount: 5}
+	tests := []struct {
+		testName        string
+		workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration
+		existing        *clusterv1.MachineDeployment
+		want            int
+	}{
+		{
+			testName:        "no autoscaling",
+			workerNodeGroup: v1alpha1.WorkerNodeGroupConfiguration{Count: 3},
+			existing:        machineDeploymentWithReplicas(4),
+			want:            3,
+		},
+		{
+			testName:        "autoscaling, no machine deployment",
+			workerNodeGroup: v1alpha1.WorkerNodeGroupConfiguration{Count: 3, AutoScalingConfiguration: autoscaling},
+			want:            3,
+		},
+		{
+			testName:        "autoscaling, no count",
+			workerNodeGroup: v1alpha1.WorkerNodeGroupConfiguration{AutoScalingConfiguration: autoscaling},
+			want:            2,
+		},
+		{
+			testName:        "autoscaling, keep scaled replicas",
+			workerNodeGroup: v1alpha1.WorkerNodeGroupConfiguration{Count: 3, AutoScalingConfiguration: autoscaling},
+			existing:        machineDeploymentWithReplicas(4),
+			want:            4,
+		},
+		{
+			testName:        "autoscaling, replicas over max",
+			workerNodeGroup: v1alpha1.WorkerNodeGroupConfiguration{Count: 3, AutoScalingConfiguration: autoscaling},
+			existing:        machineDeploymentWithReplicas(7),
+			want:            5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.WorkerReplicas(tt.workerNodeGroup, tt.existing)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMaxWorkerReplicas(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.MaxWorkerReplicas(v1alpha1.WorkerNodeGroupConfiguration{Count: 3})).To(Equal(3))
+	g.Expect(clusterapi.MaxWorkerReplicas(v1alpha1.WorkerNodeGroupConfiguration{
+		Count:                    3,
+		AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 10},
+	})).To(Equal(10))
+}
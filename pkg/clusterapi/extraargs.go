@@ -2,6 +2,8 @@ package clusterapi
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/crypto"
@@ -49,6 +51,28 @@ func PodIAMAuthExtraArgs(podIAMConfig *v1alpha1.PodIAMConfig) ExtraArgs {
 	return args
 }
 
+// NodeLabelsExtraArgs returns the kubelet args that register the nodes of a worker node group with its labels
+func NodeLabelsExtraArgs(workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration) ExtraArgs {
+	args := ExtraArgs{}
+	args.AddIfNotEmpty("node-labels", labelsMapToArg(workerNodeGroup.Labels))
+	return args
+}
+
+// ControlPlaneKubeletExtraArgs returns the kubelet args set for the control plane nodes in the cluster spec
+func ControlPlaneKubeletExtraArgs(controlPlane v1alpha1.ControlPlaneConfiguration) ExtraArgs {
+	return ExtraArgs{}.Append(controlPlane.KubeletConfiguration)
+}
+
+// WorkerNodeGroupKubeletExtraArgs returns the kubelet args set for the nodes of a worker node group in the cluster spec
+func WorkerNodeGroupKubeletExtraArgs(workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration) ExtraArgs {
+	return ExtraArgs{}.Append(workerNodeGroup.KubeletConfiguration)
+}
+
+// ApiServerExtraArgs returns the kube-apiserver args set in the cluster spec
+func ApiServerExtraArgs(controlPlane v1alpha1.ControlPlaneConfiguration) ExtraArgs {
+	return ExtraArgs{}.Append(controlPlane.ApiServerExtraArgs)
+}
+
 // We don't need to add these once the Kubernetes components default to using the secure cipher suites
 func SecureTlsCipherSuitesExtraArgs() ExtraArgs {
 	args := ExtraArgs{}
@@ -85,6 +109,20 @@ func (e ExtraArgs) ToPartialYaml() templater.PartialYaml {
 	return p
 }
 
+func labelsMapToArg(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	labelArgs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		labelArgs = append(labelArgs, fmt.Sprintf("%s=%s", k, labels[k]))
+	}
+	return strings.Join(labelArgs, ",")
+}
+
 func requiredClaimToArg(r *v1alpha1.OIDCConfigRequiredClaim) string {
 	if r == nil || r.Claim == "" {
 		return ""
@@ -236,6 +236,68 @@ func TestPodIAMConfigExtraArgs(t *testing.T) {
 	}
 }
 
+func TestNodeLabelsExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName        string
+		workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration
+		want            clusterapi.ExtraArgs
+	}{
+		{
+			testName:        "no labels",
+			workerNodeGroup: v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"},
+			want:            clusterapi.ExtraArgs{},
+		},
+		{
+			testName: "with labels",
+			workerNodeGroup: v1alpha1.WorkerNodeGroupConfiguration{
+				Name: "ingress",
+				Labels: map[string]string{
+					"pool":                   "ingress",
+					"example.com/dedicated":  "true",
+					"node.kubernetes.io/gpu": "false",
+				},
+			},
+			want: clusterapi.ExtraArgs{
+				"node-labels": "example.com/dedicated=true,node.kubernetes.io/gpu=false,pool=ingress",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.NodeLabelsExtraArgs(tt.workerNodeGroup); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NodeLabelsExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubeletAndApiServerExtraArgs(t *testing.T) {
+	controlPlane := v1alpha1.ControlPlaneConfiguration{
+		KubeletConfiguration: map[string]string{"max-pods": "110"},
+		ApiServerExtraArgs:   map[string]string{"feature-gates": "EphemeralContainers=true"},
+	}
+	workerNodeGroup := v1alpha1.WorkerNodeGroupConfiguration{
+		KubeletConfiguration: map[string]string{"system-reserved": "cpu=500m"},
+	}
+
+	if got, want := clusterapi.ControlPlaneKubeletExtraArgs(controlPlane), (clusterapi.ExtraArgs{"max-pods": "110"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ControlPlaneKubeletExtraArgs() = %v, want %v", got, want)
+	}
+	if got, want := clusterapi.ApiServerExtraArgs(controlPlane), (clusterapi.ExtraArgs{"feature-gates": "EphemeralContainers=true"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ApiServerExtraArgs() = %v, want %v", got, want)
+	}
+	if got, want := clusterapi.WorkerNodeGroupKubeletExtraArgs(workerNodeGroup), (clusterapi.ExtraArgs{"system-reserved": "cpu=500m"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("WorkerNodeGroupKubeletExtraArgs() = %v, want %v", got, want)
+	}
+
+	// The returned args are copies, appending to them doesn't change the cluster spec
+	clusterapi.ControlPlaneKubeletExtraArgs(controlPlane).Append(clusterapi.SecureTlsCipherSuitesExtraArgs())
+	if len(controlPlane.KubeletConfiguration) != 1 {
+		t.Errorf("ControlPlaneKubeletExtraArgs() modified the cluster spec: %v", controlPlane.KubeletConfiguration)
+	}
+}
+
 func TestSecureTlsCipherSuitesExtraArgs(t *testing.T) {
 	tests := []struct {
 		testName string
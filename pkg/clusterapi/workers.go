@@ -0,0 +1,94 @@
+package clusterapi
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// MachineDeploymentName returns the name of the MachineDeployment of a worker node group, which is also the name
+// its KubeadmConfigTemplate is created with
+func MachineDeploymentName(clusterName string, workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration) string {
+	return fmt.Sprintf("%s-%s", clusterName, workerNodeGroup.Name)
+}
+
+// MachineDeploymentsByWorkerNodeGroup maps the name of each worker node group to its existing MachineDeployment.
+// Groups without a MachineDeployment, like the ones added in an upgrade, are not in the map.
+func MachineDeploymentsByWorkerNodeGroup(clusterName string, workerNodeGroups []v1alpha1.WorkerNodeGroupConfiguration, mds []clusterv1.MachineDeployment) map[string]*clusterv1.MachineDeployment {
+	byName := make(map[string]*clusterv1.MachineDeployment, len(mds))
+	for i := range mds {
+		byName[mds[i].Name] = &mds[i]
+	}
+
+	byGroup := make(map[string]*clusterv1.MachineDeployment, len(workerNodeGroups))
+	for _, workerNodeGroup := range workerNodeGroups {
+		if md, ok := byName[MachineDeploymentName(clusterName, workerNodeGroup)]; ok {
+			byGroup[workerNodeGroup.Name] = md
+		}
+	}
+	return byGroup
+}
+
+// KubeadmConfigTemplateName returns the name of the KubeadmConfigTemplate referenced by the existing MachineDeployment
+// of a worker node group, or the name it's created with for groups without a MachineDeployment
+func KubeadmConfigTemplateName(clusterName string, workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration, existing *clusterv1.MachineDeployment) string {
+	if existing != nil && existing.Spec.Template.Spec.Bootstrap.ConfigRef != nil {
+		return existing.Spec.Template.Spec.Bootstrap.ConfigRef.Name
+	}
+	return MachineDeploymentName(clusterName, workerNodeGroup)
+}
+
+// NeedsNewKubeadmConfigTemplate compares a worker node group with the one of the same name in the existing cluster.
+// A KubeadmConfigTemplate only applies to the machines created after it changes, so the changes to the kubelet
+// configuration of the nodes need a new template for the MachineDeployment to roll them out
+func NeedsNewKubeadmConfigTemplate(existingCluster *v1alpha1.Cluster, workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration) bool {
+	existing, ok := ExistingWorkerNodeGroup(existingCluster, workerNodeGroup.Name)
+	if !ok {
+		return true
+	}
+	return !stringMapsEqual(existing.Labels, workerNodeGroup.Labels) ||
+		!taintsEqual(existing.Taints, workerNodeGroup.Taints) ||
+		!stringMapsEqual(existing.KubeletConfiguration, workerNodeGroup.KubeletConfiguration)
+}
+
+// ExistingWorkerNodeGroup returns the worker node group with the given name in a cluster, defaulting the group names
+// of clusters created before worker node groups were named
+func ExistingWorkerNodeGroup(cluster *v1alpha1.Cluster, name string) (v1alpha1.WorkerNodeGroupConfiguration, bool) {
+	for i, workerNodeGroup := range cluster.Spec.WorkerNodeGroupConfigurations {
+		groupName := workerNodeGroup.Name
+		if groupName == "" {
+			groupName = v1alpha1.DefaultWorkerNodeGroupName(i)
+		}
+		if groupName == name {
+			return workerNodeGroup, true
+		}
+	}
+	return v1alpha1.WorkerNodeGroupConfiguration{}, false
+}
+
+func stringMapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
+
+func taintsEqual(a, b []corev1.Taint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].MatchTaint(&b[i]) || a[i].Value != b[i].Value {
+			return false
+		}
+	}
+	return true
+}
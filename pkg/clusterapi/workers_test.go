@@ -0,0 +1,130 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+func TestMachineDeploymentName(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.MachineDeploymentName("test-cluster", v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"})).To(Equal("test-cluster-md-0"))
+	g.Expect(clusterapi.MachineDeploymentName("test-cluster", v1alpha1.WorkerNodeGroupConfiguration{Name: "ingress"})).To(Equal("test-cluster-ingress"))
+}
+
+func TestMachineDeploymentsByWorkerNodeGroup(t *testing.T) {
+	g := NewWithT(t)
+	workerNodeGroups := []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}, {Name: "ingress"}}
+	mds := []clusterv1.MachineDeployment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-md-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-batch"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other-cluster-ingress"}},
+	}
+
+	got := clusterapi.MachineDeploymentsByWorkerNodeGroup("test-cluster", workerNodeGroups, mds)
+	g.Expect(got).To(HaveLen(1))
+	g.Expect(got).To(HaveKeyWithValue("md-0", &mds[0]))
+}
+
+func TestKubeadmConfigTemplateName(t *testing.T) {
+	g := NewWithT(t)
+	workerNodeGroup := v1alpha1.WorkerNodeGroupConfiguration{Name: "md-0"}
+	md := &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{ConfigRef: &corev1.ObjectReference{Name: "test-cluster-md-0-template-1"}},
+				},
+			},
+		},
+	}
+
+	g.Expect(clusterapi.KubeadmConfigTemplateName("test-cluster", workerNodeGroup, md)).To(Equal("test-cluster-md-0-template-1"))
+	g.Expect(clusterapi.KubeadmConfigTemplateName("test-cluster", workerNodeGroup, &clusterv1.MachineDeployment{})).To(Equal("test-cluster-md-0"))
+	g.Expect(clusterapi.KubeadmConfigTemplateName("test-cluster", workerNodeGroup, nil)).To(Equal("test-cluster-md-0"))
+}
+
+func TestNeedsNewKubeadmConfigTemplate(t *testing.T) {
+	taint := corev1.Taint{Key: "dedicated", Value: "ingress", Effect: corev1.TaintEffectNoSchedule}
+	existing := v1alpha1.WorkerNodeGroupConfiguration{
+		Name:                 "md-0",
+		Count:                3,
+		Labels:               map[string]string{"node-role": "ingress"},
+		Taints:               []corev1.Taint{taint},
+		KubeletConfiguration: map[string]string{"max-pods": "50"},
+	}
+	existingCluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{existing}},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(w *v1alpha1.WorkerNodeGroupConfiguration)
+		want   bool
+	}{
+		{
+			name:   "no changes",
+			modify: func(w *v1alpha1.WorkerNodeGroupConfiguration) {},
+			want:   false,
+		},
+		{
+			name:   "count changed",
+			modify: func(w *v1alpha1.WorkerNodeGroupConfiguration) { w.Count = 5 },
+			want:   false,
+		},
+		{
+			name:   "label changed",
+			modify: func(w *v1alpha1.WorkerNodeGroupConfiguration) { w.Labels = map[string]string{"node-role": "batch"} },
+			want:   true,
+		},
+		{
+			name:   "labels removed",
+			modify: func(w *v1alpha1.WorkerNodeGroupConfiguration) { w.Labels = nil },
+			want:   true,
+		},
+		{
+			name: "taint effect changed",
+			modify: func(w *v1alpha1.WorkerNodeGroupConfiguration) {
+				w.Taints = []corev1.Taint{{Key: taint.Key, Value: taint.Value, Effect: corev1.TaintEffectNoExecute}}
+			},
+			want: true,
+		},
+		{
+			name: "kubelet configuration changed",
+			modify: func(w *v1alpha1.WorkerNodeGroupConfiguration) {
+				w.KubeletConfiguration = map[string]string{"max-pods": "50", "image-gc-high-threshold": "80"}
+			},
+			want: true,
+		},
+		{
+			name:   "new group",
+			modify: func(w *v1alpha1.WorkerNodeGroupConfiguration) { w.Name = "md-1" },
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			workerNodeGroup := *existing.DeepCopy()
+			tt.modify(&workerNodeGroup)
+			g.Expect(clusterapi.NeedsNewKubeadmConfigTemplate(existingCluster, workerNodeGroup)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestExistingWorkerNodeGroupDefaultName(t *testing.T) {
+	g := NewWithT(t)
+	existingCluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{{Count: 3}}},
+	}
+
+	workerNodeGroup, ok := clusterapi.ExistingWorkerNodeGroup(existingCluster, "md-0")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(workerNodeGroup.Count).To(Equal(3))
+}
@@ -0,0 +1,46 @@
+package clusterautoscaler
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+//go:embed config/cluster-autoscaler.yaml
+var clusterAutoscalerTemplate string
+
+// Enabled returns true if any of the worker node groups of the cluster has autoscaling configured
+func Enabled(clusterSpec *cluster.Spec) bool {
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		if workerNodeGroup.AutoScalingConfiguration != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateManifest renders the cluster-autoscaler for a cluster, configured with the clusterapi provider.
+// The autoscaler runs in the cluster managing the CAPI objects and reaches the workload cluster
+// through the kubeconfig secret CAPI creates for it. It only scales the MachineDeployments annotated with
+// the node group min and max sizes.
+func GenerateManifest(clusterSpec *cluster.Spec) ([]byte, error) {
+	image := clusterSpec.VersionsBundle.ClusterAutoscaler.Autoscaler
+	if image.URI == "" {
+		return nil, fmt.Errorf("cluster-autoscaler image not found in bundle %s", clusterSpec.Bundles.Name)
+	}
+
+	values := map[string]interface{}{
+		"clusterName": clusterSpec.Name,
+		"namespace":   constants.EksaSystemNamespace,
+		"image":       image.VersionedImage(),
+	}
+
+	manifest, err := templater.Execute(clusterAutoscalerTemplate, values)
+	if err != nil {
+		return nil, fmt.Errorf("error generating cluster-autoscaler manifest: %v", err)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,49 @@
+package clusterautoscaler_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterautoscaler"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func givenClusterSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Bundles.Name = "bundles-1"
+		s.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 5}
+		s.VersionsBundle.ClusterAutoscaler = releasev1alpha1.ClusterAutoscalerBundle{
+			Autoscaler: releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/kubernetes/autoscaler/cluster-autoscaler:v1.21.1-eks-1-21-4"},
+		}
+	})
+}
+
+func TestEnabled(t *testing.T) {
+	g := NewWithT(t)
+	s := givenClusterSpec()
+	g.Expect(clusterautoscaler.Enabled(s)).To(BeTrue())
+
+	s.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = nil
+	g.Expect(clusterautoscaler.Enabled(s)).To(BeFalse())
+}
+
+func TestGenerateManifestSuccess(t *testing.T) {
+	g := NewWithT(t)
+	manifest, err := clusterautoscaler.GenerateManifest(givenClusterSpec())
+	g.Expect(err).To(Succeed())
+	test.AssertContentToFile(t, string(manifest), "testdata/expected_cluster_autoscaler.yaml")
+}
+
+func TestGenerateManifestMissingImage(t *testing.T) {
+	g := NewWithT(t)
+	s := givenClusterSpec()
+	s.VersionsBundle.ClusterAutoscaler = releasev1alpha1.ClusterAutoscalerBundle{}
+
+	_, err := clusterautoscaler.GenerateManifest(s)
+	g.Expect(err).To(MatchError("cluster-autoscaler image not found in bundle bundles-1"))
+}
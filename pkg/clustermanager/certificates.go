@@ -0,0 +1,197 @@
+package clustermanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const etcdMachineLabelName = "cluster.x-k8s.io/etcd-cluster"
+
+var (
+	kubeadmControlPlaneResourceType = fmt.Sprintf("kubeadmcontrolplanes.%s", controlplanev1.GroupVersion.Group)
+	etcdadmClusterResourceType      = fmt.Sprintf("etcdadmclusters.%s", etcdv1.GroupVersion.Group)
+)
+
+// RotateCertificates renews the certificates of the control plane and external etcd nodes.
+// kubeadm and etcdadm issue the node certificates when a machine joins the cluster, so the nodes are rolled out to get new ones.
+// The aws-iam-authenticator certificate is regenerated before the rollout so the new control plane nodes pick it up
+func (c *ClusterManager) RotateCertificates(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.AWSIamConfig != nil {
+		logger.V(3).Info("Regenerating aws-iam-authenticator certificate")
+		if err := c.CreateAwsIamAuthCaSecret(ctx, managementCluster); err != nil {
+			return err
+		}
+	}
+
+	// Machine creation timestamps have second precision
+	rolloutAfter := time.Now().Truncate(time.Second)
+	externalEtcd := clusterSpec.Spec.ExternalEtcdConfiguration != nil
+	if externalEtcd {
+		logger.V(3).Info("Rolling out external etcd machines")
+		if err := c.rolloutExternalEtcd(ctx, managementCluster, clusterSpec.Name, rolloutAfter); err != nil {
+			return fmt.Errorf("error rolling out external etcd machines: %v", err)
+		}
+	}
+
+	logger.V(3).Info("Rolling out control plane machines")
+	patch := fmt.Sprintf(`{"spec":{"upgradeAfter":%q}}`, rolloutAfter.UTC().Format(time.RFC3339))
+	err := c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.MergePatchResource(ctx, kubeadmControlPlaneResourceType, clusterSpec.Name, patch, managementCluster.KubeconfigFile, constants.EksaSystemNamespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error rolling out control plane machines: %v", err)
+	}
+
+	if externalEtcd {
+		logger.V(3).Info("Waiting for external etcd machines to be replaced")
+		if err = c.waitForMachinesRolledOut(ctx, managementCluster, clusterSpec.Name, []string{etcdMachineLabelName}, rolloutAfter); err != nil {
+			return err
+		}
+		if err = c.clusterClient.WaitForManagedExternalEtcdReady(ctx, managementCluster, etcdWaitStr, clusterSpec.Name); err != nil {
+			return fmt.Errorf("error waiting for external etcd to be ready: %v", err)
+		}
+	}
+
+	logger.V(3).Info("Waiting for control plane machines to be replaced")
+	if err = c.waitForMachinesRolledOut(ctx, managementCluster, clusterSpec.Name, []string{clusterv1.MachineControlPlaneLabelName}, rolloutAfter); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Waiting for control plane to be ready")
+	if err = c.clusterClient.WaitForControlPlaneReady(ctx, managementCluster, ctrlPlaneWaitStr, clusterSpec.Name); err != nil {
+		return fmt.Errorf("error waiting for control plane to be ready: %v", err)
+	}
+
+	return c.waitForControlPlaneReplicasReady(ctx, managementCluster, clusterSpec)
+}
+
+// rolloutExternalEtcd points the EtcdadmCluster to a copy of its machine template. etcdadm-controller doesn't have
+// an equivalent to the KubeadmControlPlane upgradeAfter, but it replaces the machines created from a different template
+func (c *ClusterManager) rolloutExternalEtcd(ctx context.Context, managementCluster *types.Cluster, clusterName string, rolloutAfter time.Time) error {
+	etcdadmClusterName := fmt.Sprintf("%s-etcd", clusterName)
+	etcdadmCluster, err := c.clusterClient.GetObjectByRef(ctx, corev1.ObjectReference{
+		APIVersion: etcdv1.GroupVersion.String(),
+		Kind:       "EtcdadmCluster",
+		Name:       etcdadmClusterName,
+	}, constants.EksaSystemNamespace, managementCluster.KubeconfigFile)
+	if err != nil {
+		return err
+	}
+
+	templateRef, found, err := unstructured.NestedStringMap(etcdadmCluster.Object, "spec", "infrastructureTemplate")
+	if err != nil || !found {
+		return fmt.Errorf("can't read infrastructure template from EtcdadmCluster %s", etcdadmClusterName)
+	}
+
+	template, err := c.clusterClient.GetObjectByRef(ctx, corev1.ObjectReference{
+		APIVersion: templateRef["apiVersion"],
+		Kind:       templateRef["kind"],
+		Name:       templateRef["name"],
+	}, constants.EksaSystemNamespace, managementCluster.KubeconfigFile)
+	if err != nil {
+		return err
+	}
+
+	newTemplate := &unstructured.Unstructured{}
+	newTemplate.SetAPIVersion(template.GetAPIVersion())
+	newTemplate.SetKind(template.GetKind())
+	newTemplate.SetName(fmt.Sprintf("%s-etcd-template-%d", clusterName, rolloutAfter.UnixNano()/int64(time.Millisecond)))
+	newTemplate.SetNamespace(constants.EksaSystemNamespace)
+	newTemplate.SetLabels(template.GetLabels())
+	newTemplate.Object["spec"] = template.Object["spec"]
+
+	newTemplateContent, err := yaml.Marshal(newTemplate)
+	if err != nil {
+		return fmt.Errorf("error marshalling etcd machine template: %v", err)
+	}
+
+	err = c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.ApplyKubeSpecFromBytesWithNamespace(ctx, managementCluster, newTemplateContent, constants.EksaSystemNamespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error applying etcd machine template: %v", err)
+	}
+
+	// Same as during upgrades, the annotation stops the KubeadmControlPlane rollout until the new etcd members are ready
+	err = c.clusterClient.UpdateAnnotationInNamespace(ctx, "etcdadmcluster", etcdadmClusterName,
+		map[string]string{etcdv1.UpgradeInProgressAnnotation: "true"}, managementCluster, constants.EksaSystemNamespace)
+	if err != nil {
+		return err
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"infrastructureTemplate":{"name":%q}}}`, newTemplate.GetName())
+	return c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.MergePatchResource(ctx, etcdadmClusterResourceType, etcdadmClusterName, patch, managementCluster.KubeconfigFile, constants.EksaSystemNamespace)
+		},
+	)
+}
+
+// waitForMachinesRolledOut waits until all the machines with any of the labels have been created after rolloutAfter
+// and their nodes are healthy
+func (c *ClusterManager) waitForMachinesRolledOut(ctx context.Context, managementCluster *types.Cluster, clusterName string, labels []string, rolloutAfter time.Time) error {
+	total := 0
+	areMachinesRolledOut := func() error {
+		machines, err := c.clusterClient.GetMachines(ctx, managementCluster, clusterName)
+		if err != nil {
+			return fmt.Errorf("error getting machines resources from management cluster: %v", err)
+		}
+
+		total = 0
+		pending := 0
+		for _, m := range machines {
+			if !m.HasAnyLabel(labels) {
+				continue
+			}
+			total += 1
+			if m.Metadata.CreationTimestamp.Before(rolloutAfter) || !types.WithNodeRef()(m.Status) || !types.WithNodeHealthy()(m.Status) {
+				pending += 1
+			}
+		}
+
+		if pending > 0 {
+			logger.V(4).Info("Machines are not rolled out yet", "total", total, "pending", pending, "cluster name", clusterName)
+			return errors.New("machines are not rolled out yet")
+		}
+
+		return nil
+	}
+
+	err := areMachinesRolledOut()
+	if err == nil {
+		return nil
+	}
+
+	timeout := time.Duration(total) * c.machineMaxWait
+	if timeout <= c.machinesMinWait {
+		timeout = c.machinesMinWait
+	}
+
+	r := retrier.New(timeout, retrier.WithRetryPolicy(func(_ int, _ error) (bool, time.Duration) {
+		return true, c.machineBackoff
+	}))
+	if err := r.Retry(areMachinesRolledOut); err != nil {
+		return fmt.Errorf("retries exhausted waiting for machines to be rolled out: %v", err)
+	}
+
+	return nil
+}
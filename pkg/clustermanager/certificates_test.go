@@ -0,0 +1,143 @@
+package clustermanager_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	kubeadmControlPlaneResourceType = "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io"
+	etcdadmClusterResourceType      = "etcdadmclusters.etcdcluster.cluster.x-k8s.io"
+)
+
+func rolledOutMachine(label string, createdAt time.Time) types.Machine {
+	return types.Machine{
+		Metadata: types.MachineMetadata{
+			Labels:            map[string]string{label: ""},
+			CreationTimestamp: createdAt,
+		},
+		Status: types.MachineStatus{
+			NodeRef:    &types.ResourceRef{},
+			Conditions: types.Conditions{{Type: "NodeHealthy", Status: "True"}},
+		},
+	}
+}
+
+func newRotateCertificatesTest(t *testing.T) *testSetup {
+	tt := newTest(t, clustermanager.WithWaitForMachines(0, 10*time.Microsecond, 20*time.Microsecond))
+	tt.cluster.KubeconfigFile = "mgmt.kubeconfig"
+	tt.clusterSpec = test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = tt.clusterName
+		s.Spec.ControlPlaneConfiguration.Count = 1
+	})
+	return tt
+}
+
+func TestClusterManagerRotateCertificatesStackedEtcd(t *testing.T) {
+	tt := newRotateCertificatesTest(t)
+	oldMachine := rolledOutMachine(clusterv1.MachineControlPlaneLabelName, time.Now().Add(-time.Hour))
+	newMachine := rolledOutMachine(clusterv1.MachineControlPlaneLabelName, time.Now().Add(time.Hour))
+
+	gomock.InOrder(
+		tt.mocks.client.EXPECT().MergePatchResource(tt.ctx, kubeadmControlPlaneResourceType, tt.clusterName,
+			test.OfType("string"), tt.cluster.KubeconfigFile, constants.EksaSystemNamespace),
+		tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.cluster, tt.clusterName).Return([]types.Machine{oldMachine}, nil),
+		tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.cluster, tt.clusterName).Return([]types.Machine{newMachine}, nil),
+		tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, tt.cluster, "60m", tt.clusterName),
+		tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, tt.cluster, tt.clusterName),
+	)
+
+	tt.Expect(tt.clusterManager.RotateCertificates(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerRotateCertificatesExternalEtcdAndIamAuth(t *testing.T) {
+	tt := newRotateCertificatesTest(t)
+	tt.clusterSpec.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 1}
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{}
+	etcdadmCluster := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"infrastructureTemplate": map[string]interface{}{
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1alpha3",
+				"kind":       "VSphereMachineTemplate",
+				"name":       "cluster-name-etcd-template-1234",
+			},
+		},
+	}}
+	template := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "infrastructure.cluster.x-k8s.io/v1alpha3",
+		"kind":       "VSphereMachineTemplate",
+		"metadata":   map[string]interface{}{"name": "cluster-name-etcd-template-1234"},
+		"spec":       map[string]interface{}{"template": map[string]interface{}{}},
+	}}
+	etcdMachine := rolledOutMachine("cluster.x-k8s.io/etcd-cluster", time.Now().Add(time.Hour))
+	cpMachine := rolledOutMachine(clusterv1.MachineControlPlaneLabelName, time.Now().Add(time.Hour))
+
+	gomock.InOrder(
+		tt.mocks.awsIamAuth.EXPECT().GenerateCertKeyPairSecret().Return([]byte("secret"), nil),
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, []byte("secret")),
+		tt.mocks.client.EXPECT().GetObjectByRef(tt.ctx, corev1.ObjectReference{
+			APIVersion: "etcdcluster.cluster.x-k8s.io/v1alpha3",
+			Kind:       "EtcdadmCluster",
+			Name:       "cluster-name-etcd",
+		}, constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(etcdadmCluster, nil),
+		tt.mocks.client.EXPECT().GetObjectByRef(tt.ctx, corev1.ObjectReference{
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+			Kind:       "VSphereMachineTemplate",
+			Name:       "cluster-name-etcd-template-1234",
+		}, constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(template, nil),
+		tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, tt.cluster, test.OfType("[]uint8"), constants.EksaSystemNamespace),
+		tt.mocks.client.EXPECT().UpdateAnnotationInNamespace(tt.ctx, "etcdadmcluster", "cluster-name-etcd",
+			map[string]string{"etcdcluster.cluster.x-k8s.io/upgrading": "true"}, tt.cluster, constants.EksaSystemNamespace),
+		tt.mocks.client.EXPECT().MergePatchResource(tt.ctx, etcdadmClusterResourceType, "cluster-name-etcd",
+			test.OfType("string"), tt.cluster.KubeconfigFile, constants.EksaSystemNamespace),
+		tt.mocks.client.EXPECT().MergePatchResource(tt.ctx, kubeadmControlPlaneResourceType, tt.clusterName,
+			test.OfType("string"), tt.cluster.KubeconfigFile, constants.EksaSystemNamespace),
+		tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.cluster, tt.clusterName).Return([]types.Machine{etcdMachine, cpMachine}, nil),
+		tt.mocks.client.EXPECT().WaitForManagedExternalEtcdReady(tt.ctx, tt.cluster, "60m", tt.clusterName),
+		tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.cluster, tt.clusterName).Return([]types.Machine{etcdMachine, cpMachine}, nil),
+		tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, tt.cluster, "60m", tt.clusterName),
+		tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, tt.cluster, tt.clusterName),
+	)
+
+	tt.Expect(tt.clusterManager.RotateCertificates(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerRotateCertificatesMachinesNotRolledOut(t *testing.T) {
+	tt := newRotateCertificatesTest(t)
+	oldMachine := rolledOutMachine(clusterv1.MachineControlPlaneLabelName, time.Now().Add(-time.Hour))
+
+	tt.mocks.client.EXPECT().MergePatchResource(tt.ctx, kubeadmControlPlaneResourceType, tt.clusterName,
+		test.OfType("string"), tt.cluster.KubeconfigFile, constants.EksaSystemNamespace)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, tt.cluster, tt.clusterName).Return([]types.Machine{oldMachine}, nil).MinTimes(2)
+
+	tt.Expect(tt.clusterManager.RotateCertificates(tt.ctx, tt.cluster, tt.clusterSpec)).To(
+		MatchError(ContainSubstring("retries exhausted waiting for machines to be rolled out")),
+	)
+}
+
+func TestClusterManagerRotateCertificatesErrorPatchingControlPlane(t *testing.T) {
+	tt := newRotateCertificatesTest(t)
+	tt.clusterManager.Retrier = retrier.NewWithMaxRetries(2, 0)
+
+	tt.mocks.client.EXPECT().MergePatchResource(tt.ctx, kubeadmControlPlaneResourceType, tt.clusterName,
+		test.OfType("string"), tt.cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(errors.New("error patching")).Times(2)
+
+	tt.Expect(tt.clusterManager.RotateCertificates(tt.ctx, tt.cluster, tt.clusterSpec)).To(
+		MatchError(ContainSubstring("error rolling out control plane machines")),
+	)
+}
@@ -8,11 +8,15 @@ import (
 	"reflect"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/clusterautoscaler"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -53,6 +57,8 @@ type ClusterManager struct {
 
 type ClusterClient interface {
 	MoveManagement(ctx context.Context, org, target *types.Cluster) error
+	BackupManagement(ctx context.Context, cluster *types.Cluster, directory string) error
+	RestoreManagement(ctx context.Context, cluster *types.Cluster, directory string) error
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	ApplyKubeSpecFromBytesWithNamespace(ctx context.Context, cluster *types.Cluster, data []byte, namespace string) error
 	ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error
@@ -69,6 +75,8 @@ type ClusterClient interface {
 	WaitForDeployment(ctx context.Context, cluster *types.Cluster, timeout string, condition string, target string, namespace string) error
 	SaveLog(ctx context.Context, cluster *types.Cluster, deployment *types.Deployment, fileName string, writer filewriter.FileWriter) error
 	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
+	GetMachineDeploymentsForCluster(ctx context.Context, cluster *types.Cluster, clusterName string) ([]clusterv1.MachineDeployment, error)
+	DeleteOldWorkerNodeGroup(ctx context.Context, md *clusterv1.MachineDeployment, kubeconfig string) error
 	GetClusters(ctx context.Context, cluster *types.Cluster) ([]types.CAPICluster, error)
 	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
 	GetEksaVSphereDatacenterConfig(ctx context.Context, VSphereDatacenterName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereDatacenterConfig, error)
@@ -84,6 +92,9 @@ type ClusterClient interface {
 	GetApiServerUrl(ctx context.Context, cluster *types.Cluster) (string, error)
 	GetClusterCATlsCert(ctx context.Context, clusterName string, cluster *types.Cluster, namespace string) ([]byte, error)
 	KubeconfigSecretAvailable(ctx context.Context, kubeconfig string, clusterName string, namespace string) (bool, error)
+	GetObjectByRef(ctx context.Context, ref corev1.ObjectReference, namespace, kubeconfig string) (*unstructured.Unstructured, error)
+	MergePatchResource(ctx context.Context, resourceType, name, patch, kubeconfig, namespace string) error
+	DeleteResource(ctx context.Context, resourceType, name, kubeconfig, namespace string) error
 }
 
 type Networking interface {
@@ -136,6 +147,8 @@ func WithRetrier(retrier *retrier.Retrier) ClusterManagerOpt {
 	}
 }
 
+// MoveCAPI moves the CAPI objects from one management cluster to another. When the cluster is managed by another cluster,
+// only the objects of clusterName are moved
 func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster, clusterName string, clusterSpec *cluster.Spec, checkers ...types.NodeReadyChecker) error {
 	logger.V(3).Info("Waiting for management machines to be ready before move")
 	labels := []string{clusterv1.MachineControlPlaneLabelName, clusterv1.MachineDeploymentLabelName}
@@ -143,7 +156,13 @@ func (c *ClusterManager) MoveCAPI(ctx context.Context, from, to *types.Cluster,
 		return err
 	}
 
-	err := c.clusterClient.MoveManagement(ctx, from, to)
+	var err error
+	if clusterSpec.IsManaged() {
+		// The namespace contains the objects of all the clusters managed by the source cluster
+		err = c.moveClusterCAPIObjects(ctx, from, to, clusterName)
+	} else {
+		err = c.clusterClient.MoveManagement(ctx, from, to)
+	}
 	if err != nil {
 		return fmt.Errorf("error moving CAPI management from source to target: %v", err)
 	}
@@ -293,28 +312,7 @@ func (c *ClusterManager) DeleteCluster(ctx context.Context, managementCluster, c
 					return err
 				}
 
-				if clusterSpec.GitOpsConfig != nil {
-					if err := c.DeleteGitOpsConfig(ctx, managementCluster, clusterSpec.GitOpsConfig.Name, clusterSpec.GitOpsConfig.Namespace); err != nil {
-						return err
-					}
-				}
-				if clusterSpec.OIDCConfig != nil {
-					if err := c.DeleteOIDCConfig(ctx, managementCluster, clusterSpec.OIDCConfig.Name, clusterSpec.OIDCConfig.Namespace); err != nil {
-						return err
-					}
-				}
-
-				if clusterSpec.AWSIamConfig != nil {
-					if err := c.DeleteAWSIamConfig(ctx, managementCluster, clusterSpec.AWSIamConfig.Name, clusterSpec.AWSIamConfig.Namespace); err != nil {
-						return err
-					}
-				}
-
-				if err := provider.DeleteResources(ctx, clusterSpec); err != nil {
-					return err
-				}
-
-				if err := c.DeleteEKSACluster(ctx, managementCluster, clusterSpec.Name, clusterSpec.Namespace); err != nil {
+				if err := c.DeleteEKSAResources(ctx, managementCluster, clusterSpec, provider); err != nil {
 					return err
 				}
 			}
@@ -324,6 +322,33 @@ func (c *ClusterManager) DeleteCluster(ctx context.Context, managementCluster, c
 	)
 }
 
+// DeleteEKSAResources deletes the EKS-A objects of a managed cluster from its management cluster. The objects should be paused
+// first, so the controller releases the cluster without deleting its CAPI objects
+func (c *ClusterManager) DeleteEKSAResources(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	if clusterSpec.GitOpsConfig != nil {
+		if err := c.DeleteGitOpsConfig(ctx, managementCluster, clusterSpec.GitOpsConfig.Name, clusterSpec.GitOpsConfig.Namespace); err != nil {
+			return err
+		}
+	}
+	if clusterSpec.OIDCConfig != nil {
+		if err := c.DeleteOIDCConfig(ctx, managementCluster, clusterSpec.OIDCConfig.Name, clusterSpec.OIDCConfig.Namespace); err != nil {
+			return err
+		}
+	}
+
+	if clusterSpec.AWSIamConfig != nil {
+		if err := c.DeleteAWSIamConfig(ctx, managementCluster, clusterSpec.AWSIamConfig.Name, clusterSpec.AWSIamConfig.Namespace); err != nil {
+			return err
+		}
+	}
+
+	if err := provider.DeleteResources(ctx, clusterSpec); err != nil {
+		return err
+	}
+
+	return c.DeleteEKSACluster(ctx, managementCluster, clusterSpec.Name, clusterSpec.Namespace)
+}
+
 func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) error {
 	currentSpec, err := c.GetCurrentClusterSpec(ctx, workloadCluster, newClusterSpec.Name)
 	if err != nil {
@@ -396,6 +421,10 @@ func (c *ClusterManager) UpgradeCluster(ctx context.Context, managementCluster,
 		return fmt.Errorf("error applying capi machine deployment spec: %v", err)
 	}
 
+	if err = c.removeOldWorkerNodeGroups(ctx, managementCluster, newClusterSpec); err != nil {
+		return fmt.Errorf("error removing old worker node groups: %v", err)
+	}
+
 	logger.V(3).Info("Waiting for workload cluster machine deployment replicas to be ready after upgrade")
 	err = c.waitForMachineDeploymentReplicasReady(ctx, managementCluster, newClusterSpec)
 	if err != nil {
@@ -425,6 +454,8 @@ func (c *ClusterManager) EKSAClusterSpecChanged(ctx context.Context, cluster *ty
 	if err != nil {
 		return false, err
 	}
+	// clusters created before worker node groups had names don't have them set
+	cc.SetDefaults()
 
 	if !cc.Equal(newClusterSpec.Cluster) {
 		logger.V(3).Info("Existing cluster and new cluster spec differ")
@@ -470,14 +501,16 @@ func (c *ClusterManager) EKSAClusterSpecChanged(ctx context.Context, cluster *ty
 			logger.V(3).Info("New control plane machine config spec is different from the existing spec")
 			return true, nil
 		}
-		existingWnVmc, err := c.clusterClient.GetEksaVSphereMachineConfig(ctx, cc.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name, cluster.KubeconfigFile, newClusterSpec.Namespace)
-		if err != nil {
-			return false, err
-		}
-		wnVmc := machineConfigMap[newClusterSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name]
-		if !reflect.DeepEqual(existingWnVmc.Spec, wnVmc.Spec) {
-			logger.V(3).Info("New worker node machine config spec is different from the existing spec")
-			return true, nil
+		for _, workerNodeGroup := range cc.Spec.WorkerNodeGroupConfigurations {
+			existingWnVmc, err := c.clusterClient.GetEksaVSphereMachineConfig(ctx, workerNodeGroup.MachineGroupRef.Name, cluster.KubeconfigFile, newClusterSpec.Namespace)
+			if err != nil {
+				return false, err
+			}
+			wnVmc := machineConfigMap[workerNodeGroup.MachineGroupRef.Name]
+			if !reflect.DeepEqual(existingWnVmc.Spec, wnVmc.Spec) {
+				logger.V(3).Info("New worker node machine config spec is different from the existing spec", "workerNodeGroup", workerNodeGroup.Name)
+				return true, nil
+			}
 		}
 		if cc.Spec.ExternalEtcdConfiguration != nil {
 			existingEtcdVmc, err := c.clusterClient.GetEksaVSphereMachineConfig(ctx, cc.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name, cluster.KubeconfigFile, newClusterSpec.Namespace)
@@ -603,6 +636,28 @@ func (c *ClusterManager) InstallAwsIamAuth(ctx context.Context, managementCluste
 	return nil
 }
 
+// InstallClusterAutoscaler deploys the cluster-autoscaler in the cluster managing the CAPI objects
+// when any of the worker node groups has autoscaling configured.
+func (c *ClusterManager) InstallClusterAutoscaler(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if !clusterautoscaler.Enabled(clusterSpec) {
+		return nil
+	}
+
+	manifest, err := clusterautoscaler.GenerateManifest(clusterSpec)
+	if err != nil {
+		return err
+	}
+	err = c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.ApplyKubeSpecFromBytes(ctx, managementCluster, manifest)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error applying cluster-autoscaler manifest: %v", err)
+	}
+	return nil
+}
+
 func (c *ClusterManager) CreateAwsIamAuthCaSecret(ctx context.Context, cluster *types.Cluster) error {
 	awsIamAuthCaSecret, err := c.awsIamAuth.GenerateCertKeyPairSecret()
 	if err != nil {
@@ -714,9 +769,45 @@ func (c *ClusterManager) waitForControlPlaneReplicasReady(ctx context.Context, m
 	return nil
 }
 
+// removeOldWorkerNodeGroups deletes the MachineDeployments of the worker node groups that are not in the cluster spec anymore
+func (c *ClusterManager) removeOldWorkerNodeGroups(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	mds, err := c.clusterClient.GetMachineDeploymentsForCluster(ctx, managementCluster, clusterSpec.Name)
+	if err != nil {
+		return err
+	}
+
+	mdNames := make(map[string]bool, len(clusterSpec.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		mdNames[clusterapi.MachineDeploymentName(clusterSpec.Name, workerNodeGroup)] = true
+	}
+
+	for i := range mds {
+		md := &mds[i]
+		if mdNames[md.Name] {
+			continue
+		}
+		logger.V(3).Info("Deleting old worker node group", "machineDeployment", md.Name)
+		err := c.Retrier.Retry(
+			func() error {
+				return c.clusterClient.DeleteOldWorkerNodeGroup(ctx, md, managementCluster.KubeconfigFile)
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *ClusterManager) waitForMachineDeploymentReplicasReady(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	drains := newDrainProgress()
 	isMdReady := func() error {
-		return c.clusterClient.ValidateWorkerNodes(ctx, managementCluster, clusterSpec.Name)
+		err := c.clusterClient.ValidateWorkerNodes(ctx, managementCluster, clusterSpec.Name)
+		if err != nil {
+			c.reportNodeDrains(ctx, managementCluster, clusterSpec.Name, drains)
+		}
+		return err
 	}
 
 	err := isMdReady()
@@ -724,7 +815,12 @@ func (c *ClusterManager) waitForMachineDeploymentReplicasReady(ctx context.Conte
 		return nil
 	}
 
-	timeout := time.Duration(clusterSpec.Spec.WorkerNodeGroupConfigurations[0].Count) * c.machineMaxWait
+	// the autoscaler might have scaled the workers up to the max count of their groups
+	maxReplicas := 0
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		maxReplicas += clusterapi.MaxWorkerReplicas(workerNodeGroup)
+	}
+	timeout := time.Duration(maxReplicas) * c.machineMaxWait
 	if timeout <= c.machinesMinWait {
 		timeout = c.machinesMinWait
 	}
@@ -742,14 +838,16 @@ func (c *ClusterManager) waitForNodesReady(ctx context.Context, managementCluste
 		return true, c.machineBackoff * time.Duration(totalNodes-readyNodes)
 	}
 
+	drains := newDrainProgress()
 	areNodesReady := func() error {
-		var err error
-		readyNodes, totalNodes, err = c.countNodesReady(ctx, managementCluster, clusterName, labels, checkers...)
+		machines, err := c.clusterClient.GetMachines(ctx, managementCluster, clusterName)
 		if err != nil {
-			return err
+			return fmt.Errorf("error getting machines resources from management cluster: %v", err)
 		}
 
+		readyNodes, totalNodes = countNodesReady(machines, labels, checkers...)
 		if readyNodes != totalNodes {
+			drains.report(machines)
 			logger.V(4).Info("Nodes are not ready yet", "total", totalNodes, "ready", readyNodes, "cluster name", clusterName)
 			return errors.New("nodes are not ready yet")
 		}
@@ -776,12 +874,17 @@ func (c *ClusterManager) waitForNodesReady(ctx context.Context, managementCluste
 	return nil
 }
 
-func (c *ClusterManager) countNodesReady(ctx context.Context, managementCluster *types.Cluster, clusterName string, labels []string, checkers ...types.NodeReadyChecker) (ready, total int, err error) {
+// reportNodeDrains logs the progress of the node drains of the cluster, it's best effort
+func (c *ClusterManager) reportNodeDrains(ctx context.Context, managementCluster *types.Cluster, clusterName string, drains *drainProgress) {
 	machines, err := c.clusterClient.GetMachines(ctx, managementCluster, clusterName)
 	if err != nil {
-		return 0, 0, fmt.Errorf("error getting machines resources from management cluster: %v", err)
+		logger.V(4).Info("Couldn't get machines to report node drains", "error", err)
+		return
 	}
+	drains.report(machines)
+}
 
+func countNodesReady(machines []types.Machine, labels []string, checkers ...types.NodeReadyChecker) (ready, total int) {
 	for _, m := range machines {
 		// Extracted from cluster-api: NodeRef is considered a better signal than InfrastructureReady,
 		// because it ensures the node in the workload cluster is up and running.
@@ -802,7 +905,7 @@ func (c *ClusterManager) countNodesReady(ctx context.Context, managementCluster
 			ready += 1
 		}
 	}
-	return ready, total, nil
+	return ready, total
 }
 
 func (c *ClusterManager) waitForAllControlPlanes(ctx context.Context, cluster *types.Cluster, waitForCluster time.Duration) error {
@@ -880,9 +983,14 @@ func (c *ClusterManager) PauseEKSAControllerReconcile(ctx context.Context, clust
 		if clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef == nil {
 			return fmt.Errorf("machineGroupRef for control plane is not defined")
 		}
-		if len(clusterSpec.Spec.WorkerNodeGroupConfigurations) <= 0 || clusterSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef == nil {
+		if len(clusterSpec.Spec.WorkerNodeGroupConfigurations) <= 0 {
 			return fmt.Errorf("machineGroupRef for worker nodes is not defined")
 		}
+		for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+			if workerNodeGroup.MachineGroupRef == nil {
+				return fmt.Errorf("machineGroupRef for worker node group %s is not defined", workerNodeGroup.Name)
+			}
+		}
 		if clusterSpec.Spec.ExternalEtcdConfiguration != nil && clusterSpec.Spec.ExternalEtcdConfiguration.MachineGroupRef == nil {
 			return fmt.Errorf("machineGroupRef for etcd machines is not defined")
 		}
@@ -894,10 +1002,11 @@ func (c *ClusterManager) PauseEKSAControllerReconcile(ctx context.Context, clust
 		if err != nil {
 			return fmt.Errorf("error updating annotation when pausing control plane machineconfig reconciliation: %v", err)
 		}
-		if clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name != clusterSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name {
+		for _, name := range workerMachineConfigNames(clusterSpec) {
+			name := name
 			err := c.Retrier.Retry(
 				func() error {
-					return c.clusterClient.UpdateAnnotationInNamespace(ctx, provider.MachineResourceType(), clusterSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name, pausedAnnotation, cluster, clusterSpec.Namespace)
+					return c.clusterClient.UpdateAnnotationInNamespace(ctx, provider.MachineResourceType(), name, pausedAnnotation, cluster, clusterSpec.Namespace)
 				},
 			)
 			if err != nil {
@@ -944,9 +1053,14 @@ func (c *ClusterManager) ResumeEKSAControllerReconcile(ctx context.Context, clus
 		if clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef == nil {
 			return fmt.Errorf("machineGroupRef for control plane is not defined")
 		}
-		if len(clusterSpec.Spec.WorkerNodeGroupConfigurations) <= 0 || clusterSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef == nil {
+		if len(clusterSpec.Spec.WorkerNodeGroupConfigurations) <= 0 {
 			return fmt.Errorf("machineGroupRef for worker nodes is not defined")
 		}
+		for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+			if workerNodeGroup.MachineGroupRef == nil {
+				return fmt.Errorf("machineGroupRef for worker node group %s is not defined", workerNodeGroup.Name)
+			}
+		}
 		if clusterSpec.Spec.ExternalEtcdConfiguration != nil && clusterSpec.Spec.ExternalEtcdConfiguration.MachineGroupRef == nil {
 			return fmt.Errorf("machineGroupRef for etcd machines is not defined")
 		}
@@ -958,10 +1072,11 @@ func (c *ClusterManager) ResumeEKSAControllerReconcile(ctx context.Context, clus
 		if err != nil {
 			return fmt.Errorf("error updating annotation when unpausing control plane machineconfig reconciliation: %v", err)
 		}
-		if clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name != clusterSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name {
+		for _, name := range workerMachineConfigNames(clusterSpec) {
+			name := name
 			err := c.Retrier.Retry(
 				func() error {
-					return c.clusterClient.RemoveAnnotationInNamespace(ctx, provider.MachineResourceType(), clusterSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name, pausedAnnotation, cluster, clusterSpec.Namespace)
+					return c.clusterClient.RemoveAnnotationInNamespace(ctx, provider.MachineResourceType(), name, pausedAnnotation, cluster, clusterSpec.Namespace)
 				},
 			)
 			if err != nil {
@@ -997,6 +1112,22 @@ func (c *ClusterManager) ResumeEKSAControllerReconcile(ctx context.Context, clus
 	return nil
 }
 
+// workerMachineConfigNames returns the names of the machine configs used by the worker node groups,
+// skipping the control plane one, which is handled separately
+func workerMachineConfigNames(clusterSpec *cluster.Spec) []string {
+	seen := map[string]bool{clusterSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name: true}
+	names := make([]string, 0, len(clusterSpec.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		name := workerNodeGroup.MachineGroupRef.Name
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
 func (c *ClusterManager) applyResource(ctx context.Context, cluster *types.Cluster, resourcesSpec []byte) error {
 	err := c.Retrier.Retry(
 		func() error {
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -82,6 +85,29 @@ func TestClusterManagerInstallNetworkingClientError(t *testing.T) {
 	}
 }
 
+func TestClusterManagerInstallClusterAutoscalerDisabled(t *testing.T) {
+	tt := newTest(t)
+
+	tt.Expect(tt.clusterManager.InstallClusterAutoscaler(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerInstallClusterAutoscalerSuccess(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3}
+	tt.clusterSpec.VersionsBundle.ClusterAutoscaler.Autoscaler.URI = "public.ecr.aws/cluster-autoscaler:v1.21.1"
+
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any())
+
+	tt.Expect(tt.clusterManager.InstallClusterAutoscaler(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerInstallClusterAutoscalerMissingImage(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 3}
+
+	tt.Expect(tt.clusterManager.InstallClusterAutoscaler(tt.ctx, tt.cluster, tt.clusterSpec)).NotTo(Succeed())
+}
+
 func TestClusterManagerInstallStorageClassSuccess(t *testing.T) {
 	ctx := context.Background()
 	cluster := &types.Cluster{}
@@ -479,6 +505,41 @@ func TestClusterManagerUpgradeWorkloadClusterSuccess(t *testing.T) {
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
 	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
 	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx, mCluster, clusterName).Return(nil, nil)
+	tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.provider.EXPECT().GetDeployments()
+	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
+
+	if err := tt.clusterManager.UpgradeCluster(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.mocks.provider); err != nil {
+		t.Errorf("ClusterManager.UpgradeCluster() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerUpgradeWorkloadClusterRemovesOldWorkerNodeGroups(t *testing.T) {
+	clusterName := "cluster-name"
+	mCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	wCluster := &types.Cluster{
+		Name: clusterName,
+	}
+	mds := []clusterv1.MachineDeployment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster-name-md-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cluster-name-ingress"}},
+	}
+
+	tt := newSpecChangedTest(t)
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, mCluster, wCluster, tt.clusterSpec, tt.clusterSpec.DeepCopy())
+	tt.mocks.client.EXPECT().ApplyKubeSpecFromBytesWithNamespace(tt.ctx, mCluster, test.OfType("[]uint8"), constants.EksaSystemNamespace).Times(2)
+	tt.mocks.provider.EXPECT().RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, wCluster, mCluster)
+	tt.mocks.client.EXPECT().WaitForControlPlaneReady(tt.ctx, mCluster, "60m", clusterName).MaxTimes(2)
+	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
+	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).MaxTimes(10)
+	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx, mCluster, clusterName).Return(mds, nil)
+	tt.mocks.client.EXPECT().DeleteOldWorkerNodeGroup(tt.ctx, &mds[1], mCluster.KubeconfigFile).Return(nil)
 	tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
 	tt.mocks.provider.EXPECT().GetDeployments()
 	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
@@ -577,6 +638,7 @@ func TestClusterManagerUpgradeWorkloadClusterWaitForCAPITimeout(t *testing.T) {
 	tt.mocks.client.EXPECT().GetMachines(tt.ctx, mCluster, mCluster.Name).Return([]types.Machine{}, nil).Times(2)
 	tt.mocks.client.EXPECT().WaitForDeployment(tt.ctx, wCluster, "30m", "Available", gomock.Any(), gomock.Any()).Return(errors.New("time out"))
 	tt.mocks.client.EXPECT().ValidateControlPlaneNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
+	tt.mocks.client.EXPECT().GetMachineDeploymentsForCluster(tt.ctx, mCluster, clusterName).Return(nil, nil)
 	tt.mocks.client.EXPECT().ValidateWorkerNodes(tt.ctx, mCluster, wCluster.Name).Return(nil)
 	tt.mocks.writer.EXPECT().Write(clusterName+"-eks-a-cluster.yaml", gomock.Any(), gomock.Not(gomock.Nil()))
 
@@ -615,6 +677,114 @@ func TestClusterManagerMoveCAPISuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerMoveCAPIManagedClusterSuccess(t *testing.T) {
+	from := &types.Cluster{
+		Name:           "from-cluster",
+		KubeconfigFile: "from.kubeconfig",
+	}
+	to := &types.Cluster{
+		Name:           "to-cluster",
+		KubeconfigFile: "to.kubeconfig",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "dev"
+		s.SetManagedBy(from.Name)
+		s.Spec.ControlPlaneConfiguration.Count = 3
+		s.Spec.WorkerNodeGroupConfigurations[0].Count = 3
+	})
+	ctx := context.Background()
+	capiObjects := map[string]string{
+		"Cluster_eksa-system_dev.yaml":                     "apiVersion: cluster.x-k8s.io/v1alpha3\nkind: Cluster\nmetadata:\n  name: dev\n  namespace: eksa-system\n",
+		"Cluster_eksa-system_dev-2.yaml":                   "apiVersion: cluster.x-k8s.io/v1alpha3\nkind: Cluster\nmetadata:\n  name: dev-2\n  namespace: eksa-system\n",
+		"Machine_eksa-system_dev-2-md-0.yaml":              "apiVersion: cluster.x-k8s.io/v1alpha3\nkind: Machine\nmetadata:\n  name: dev-2-md-0\n  namespace: eksa-system\n  labels:\n    cluster.x-k8s.io/cluster-name: dev-2\n",
+		"VSphereMachineTemplate_eksa-system_dev-2-cp.yaml": "apiVersion: infrastructure.cluster.x-k8s.io/v1alpha3\nkind: VSphereMachineTemplate\nmetadata:\n  name: dev-2-cp\n  namespace: eksa-system\n",
+		"VSphereMachineTemplate_eksa-system_dev-cp-1.yaml": "apiVersion: infrastructure.cluster.x-k8s.io/v1alpha3\nkind: VSphereMachineTemplate\nmetadata:\n  name: dev-cp-1\n  namespace: eksa-system\n",
+	}
+
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	m.client.EXPECT().GetMachines(ctx, from, clusterSpec.Name)
+	m.writer.EXPECT().Dir().Return(t.TempDir())
+	m.client.EXPECT().GetClusters(ctx, from).Return([]types.CAPICluster{
+		{Metadata: types.Metadata{Name: "dev"}},
+		{Metadata: types.Metadata{Name: "dev-2"}, Spec: types.CAPIClusterSpec{Paused: true}},
+		{Metadata: types.Metadata{Name: "dev-3"}},
+	}, nil)
+	m.client.EXPECT().MergePatchResource(ctx, "clusters.cluster.x-k8s.io", "dev", `{"spec":{"paused":true}}`, from.KubeconfigFile, constants.EksaSystemNamespace).Times(2)
+	m.client.EXPECT().MergePatchResource(ctx, "clusters.cluster.x-k8s.io", "dev-2", `{"spec":{"paused":true}}`, from.KubeconfigFile, constants.EksaSystemNamespace)
+	m.client.EXPECT().BackupManagement(ctx, from, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, dir string) error {
+			for name, content := range capiObjects {
+				if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+	m.client.EXPECT().RestoreManagement(ctx, to, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, dir string) error {
+			files, err := ioutil.ReadDir(dir)
+			if err != nil {
+				return err
+			}
+			names := make([]string, 0, len(files))
+			for _, f := range files {
+				names = append(names, f.Name())
+			}
+			if !reflect.DeepEqual(names, []string{"Cluster_eksa-system_dev.yaml", "VSphereMachineTemplate_eksa-system_dev-cp-1.yaml"}) {
+				return fmt.Errorf("unexpected objects to restore: %v", names)
+			}
+			return nil
+		},
+	)
+	for _, obj := range []struct{ resourceType, name string }{
+		{"cluster.cluster.x-k8s.io", "dev"},
+		{"vspheremachinetemplate.infrastructure.cluster.x-k8s.io", "dev-cp-1"},
+	} {
+		m.client.EXPECT().MergePatchResource(ctx, obj.resourceType, obj.name, `{"metadata":{"finalizers":null}}`, from.KubeconfigFile, constants.EksaSystemNamespace)
+		m.client.EXPECT().DeleteResource(ctx, obj.resourceType, obj.name, from.KubeconfigFile, constants.EksaSystemNamespace)
+	}
+	capiClusterName := "dev"
+	clusters := []types.CAPICluster{{Metadata: types.Metadata{Name: capiClusterName}}}
+	m.client.EXPECT().GetClusters(ctx, to).Return(clusters, nil)
+	m.client.EXPECT().WaitForControlPlaneReady(ctx, to, "15m0s", capiClusterName)
+	m.client.EXPECT().ValidateControlPlaneNodes(ctx, to, clusterSpec.Name)
+	m.client.EXPECT().ValidateWorkerNodes(ctx, to, clusterSpec.Name)
+	m.client.EXPECT().GetMachines(ctx, to, clusterSpec.Name)
+
+	if err := c.MoveCAPI(ctx, from, to, clusterSpec.Name, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.MoveCAPI() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerMoveCAPIManagedClusterNoObjects(t *testing.T) {
+	from := &types.Cluster{
+		Name:           "from-cluster",
+		KubeconfigFile: "from.kubeconfig",
+	}
+	to := &types.Cluster{
+		Name:           "to-cluster",
+		KubeconfigFile: "to.kubeconfig",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "dev"
+		s.SetManagedBy(from.Name)
+	})
+	ctx := context.Background()
+
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	m.client.EXPECT().GetMachines(ctx, from, clusterSpec.Name)
+	m.writer.EXPECT().Dir().Return(t.TempDir())
+	m.client.EXPECT().GetClusters(ctx, from).Return([]types.CAPICluster{{Metadata: types.Metadata{Name: "dev"}, Spec: types.CAPIClusterSpec{Paused: true}}}, nil)
+	m.client.EXPECT().MergePatchResource(ctx, "clusters.cluster.x-k8s.io", "dev", `{"spec":{"paused":true}}`, from.KubeconfigFile, constants.EksaSystemNamespace).Times(2)
+	m.client.EXPECT().BackupManagement(ctx, from, gomock.Any())
+
+	err := c.MoveCAPI(ctx, from, to, clusterSpec.Name, clusterSpec)
+	if err == nil || !strings.Contains(err.Error(), "no CAPI objects found for cluster dev") {
+		t.Errorf("ClusterManager.MoveCAPI() error = %v, want no CAPI objects found", err)
+	}
+}
+
 func TestClusterManagerMoveCAPIErrorMove(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
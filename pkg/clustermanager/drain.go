@@ -0,0 +1,120 @@
+package clustermanager
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// drainProgressInterval is how often the progress of a node drain is logged while it doesn't change
+const drainProgressInterval = time.Minute
+
+// evictionErrorPodRegex matches the pods in the eviction errors that CAPI reports in the DrainingSucceeded condition,
+// for example: error when evicting pods/"web-7d4b9c" -n "default"
+var evictionErrorPodRegex = regexp.MustCompile(`pods/"([^"]+)" -n "([^"]+)"`)
+
+type nodeDrain struct {
+	machine      string
+	node         string
+	duration     time.Duration
+	blockingPods []string
+	failure      string
+}
+
+// nodeDrains returns the nodes that CAPI is draining before deleting their machines
+func nodeDrains(machines []types.Machine, now time.Time) []nodeDrain {
+	var drains []nodeDrain
+	for _, m := range machines {
+		if m.Metadata.DeletionTimestamp == nil || m.Status.NodeRef == nil {
+			continue
+		}
+		condition := drainingCondition(m.Status)
+		if condition == nil || condition.Status == "True" {
+			continue
+		}
+
+		d := nodeDrain{
+			machine:  m.Metadata.Name,
+			node:     m.Status.NodeRef.Name,
+			duration: now.Sub(condition.LastTransitionTime).Round(time.Second),
+		}
+		if condition.Reason == clusterv1.DrainingFailedReason {
+			d.blockingPods = evictionBlockingPods(condition.Message)
+			if len(d.blockingPods) == 0 {
+				d.failure = condition.Message
+			}
+		}
+		drains = append(drains, d)
+	}
+	return drains
+}
+
+func drainingCondition(status types.MachineStatus) *types.Condition {
+	for i := range status.Conditions {
+		if string(status.Conditions[i].Type) == string(clusterv1.DrainingSucceededCondition) {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func evictionBlockingPods(message string) []string {
+	seen := map[string]struct{}{}
+	var pods []string
+	for _, match := range evictionErrorPodRegex.FindAllStringSubmatch(message, -1) {
+		pod := match[2] + "/" + match[1]
+		if _, ok := seen[pod]; ok {
+			continue
+		}
+		seen[pod] = struct{}{}
+		pods = append(pods, pod)
+	}
+	sort.Strings(pods)
+	return pods
+}
+
+// drainProgress logs the nodes being drained while waiting for the machines, a drain is logged when it's first
+// seen, when its blocking pods change and every drainProgressInterval while it's stuck
+type drainProgress struct {
+	now      func() time.Time
+	reported map[string]reportedDrain
+}
+
+type reportedDrain struct {
+	at           time.Time
+	blockingPods string
+}
+
+func newDrainProgress() *drainProgress {
+	return &drainProgress{
+		now:      time.Now,
+		reported: map[string]reportedDrain{},
+	}
+}
+
+func (p *drainProgress) report(machines []types.Machine) {
+	now := p.now()
+	for _, d := range nodeDrains(machines, now) {
+		blockingPods := strings.Join(d.blockingPods, ", ")
+		last, ok := p.reported[d.machine]
+		if ok && last.blockingPods == blockingPods && now.Sub(last.at) < drainProgressInterval {
+			continue
+		}
+		p.reported[d.machine] = reportedDrain{at: now, blockingPods: blockingPods}
+
+		switch {
+		case len(d.blockingPods) > 0:
+			logger.Info("Draining node, pods blocking eviction", "node", d.node, "pods", blockingPods, "duration", d.duration.String())
+		case d.failure != "":
+			logger.Info("Draining node, drain failed", "node", d.node, "error", d.failure, "duration", d.duration.String())
+		default:
+			logger.Info("Draining node", "node", d.node, "duration", d.duration.String())
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package clustermanager
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var drainStart = time.Date(2022, 1, 27, 10, 0, 0, 0, time.UTC)
+
+func drainingMachine(name, node, reason, message string) types.Machine {
+	deletion := drainStart
+	return types.Machine{
+		Metadata: types.MachineMetadata{Name: name, DeletionTimestamp: &deletion},
+		Status: types.MachineStatus{
+			NodeRef: &types.ResourceRef{Kind: "Node", Name: node},
+			Conditions: types.Conditions{
+				{Type: "Ready", Status: "True"},
+				{Type: "DrainingSucceeded", Status: "False", Reason: reason, Message: message, LastTransitionTime: drainStart},
+			},
+		},
+	}
+}
+
+func TestNodeDrains(t *testing.T) {
+	g := NewWithT(t)
+	evictionError := `[error when evicting pods/"web-7d4b9c" -n "default": Cannot evict pod as it would violate the pod's disruption budget., ` +
+		`error when evicting pods/"db-0" -n "data": Cannot evict pod as it would violate the pod's disruption budget., ` +
+		`error when evicting pods/"web-7d4b9c" -n "default": Cannot evict pod as it would violate the pod's disruption budget.]`
+	drained := drainingMachine("md-0-drained", "node-4", "", "")
+	drained.Status.Conditions[1].Status = "True"
+	machines := []types.Machine{
+		drainingMachine("md-0-draining", "node-1", "Draining", "Draining the node before deletion"),
+		drainingMachine("md-0-blocked", "node-2", "DrainingFailed", evictionError),
+		drainingMachine("md-0-failed", "node-3", "DrainingFailed", "error getting node"),
+		drained,
+		{Metadata: types.MachineMetadata{Name: "md-0-running"}, Status: types.MachineStatus{NodeRef: &types.ResourceRef{Name: "node-5"}}},
+	}
+
+	g.Expect(nodeDrains(machines, drainStart.Add(90*time.Second))).To(Equal([]nodeDrain{
+		{machine: "md-0-draining", node: "node-1", duration: 90 * time.Second},
+		{machine: "md-0-blocked", node: "node-2", duration: 90 * time.Second, blockingPods: []string{"data/db-0", "default/web-7d4b9c"}},
+		{machine: "md-0-failed", node: "node-3", duration: 90 * time.Second, failure: "error getting node"},
+	}))
+}
+
+func TestDrainProgressReport(t *testing.T) {
+	g := NewWithT(t)
+	now := drainStart
+	p := newDrainProgress()
+	p.now = func() time.Time { return now }
+	machines := []types.Machine{drainingMachine("md-0-draining", "node-1", "Draining", "")}
+
+	p.report(machines)
+	g.Expect(p.reported).To(HaveKeyWithValue("md-0-draining", reportedDrain{at: drainStart}))
+
+	now = drainStart.Add(30 * time.Second)
+	p.report(machines)
+	g.Expect(p.reported["md-0-draining"].at).To(Equal(drainStart), "unchanged drain is not reported again before the interval")
+
+	machines = []types.Machine{drainingMachine("md-0-draining", "node-1", "DrainingFailed", `error when evicting pods/"web" -n "default": blocked`)}
+	p.report(machines)
+	g.Expect(p.reported).To(HaveKeyWithValue("md-0-draining", reportedDrain{at: now, blockingPods: "default/web"}))
+
+	now = now.Add(drainProgressInterval)
+	p.report(machines)
+	g.Expect(p.reported["md-0-draining"].at).To(Equal(now))
+}
@@ -0,0 +1,96 @@
+package clustermanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterautoscaler"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	controlPlaneManifestFileName        = "control-plane.yaml"
+	workersManifestFileName             = "workers.yaml"
+	networkingManifestFileName          = "networking.yaml"
+	storageClassManifestFileName        = "storage-class.yaml"
+	machineHealthChecksManifestFileName = "machine-health-checks.yaml"
+	awsIamAuthManifestFileName          = "aws-iam-authenticator.yaml"
+	clusterAutoscalerManifestFileName   = "cluster-autoscaler.yaml"
+)
+
+type manifestFile struct {
+	fileName string
+	content  []byte
+}
+
+// WriteManifestsForCreate writes the manifests that creating the cluster applies, without creating or changing any cluster
+func (c *ClusterManager) WriteManifestsForCreate(ctx context.Context, w filewriter.FileWriter, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	workloadCluster := &types.Cluster{Name: clusterSpec.Name}
+	cpContent, mdContent, err := provider.GenerateCAPISpecForCreate(ctx, workloadCluster, clusterSpec)
+	if err != nil {
+		return fmt.Errorf("error generating capi spec: %v", err)
+	}
+
+	return c.writeManifests(w, clusterSpec, provider, cpContent, mdContent)
+}
+
+// WriteManifestsForUpgrade writes the manifests that upgrading the cluster applies. The current state of the cluster
+// is read from the management cluster, but nothing is changed in it
+func (c *ClusterManager) WriteManifestsForUpgrade(ctx context.Context, w filewriter.FileWriter, managementCluster, workloadCluster *types.Cluster, currentSpec, newSpec *cluster.Spec, provider providers.Provider) error {
+	cpContent, mdContent, err := provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, workloadCluster, currentSpec, newSpec)
+	if err != nil {
+		return fmt.Errorf("error generating capi spec: %v", err)
+	}
+
+	return c.writeManifests(w, newSpec, provider, cpContent, mdContent)
+}
+
+func (c *ClusterManager) writeManifests(w filewriter.FileWriter, clusterSpec *cluster.Spec, provider providers.Provider, cpContent, mdContent []byte) error {
+	networking, err := c.networking.GenerateManifest(clusterSpec)
+	if err != nil {
+		return fmt.Errorf("error generating networking manifest: %v", err)
+	}
+
+	mhc, err := provider.GenerateMHC()
+	if err != nil {
+		return fmt.Errorf("error generating machine health checks: %v", err)
+	}
+
+	manifests := []manifestFile{
+		{fileName: controlPlaneManifestFileName, content: cpContent},
+		{fileName: workersManifestFileName, content: mdContent},
+		{fileName: networkingManifestFileName, content: networking},
+		{fileName: storageClassManifestFileName, content: provider.GenerateStorageClass()},
+		{fileName: machineHealthChecksManifestFileName, content: mhc},
+	}
+
+	if clusterSpec.AWSIamConfig != nil {
+		awsIamAuth, err := c.awsIamAuth.GenerateManifest(clusterSpec)
+		if err != nil {
+			return fmt.Errorf("error generating aws-iam-authenticator manifest: %v", err)
+		}
+		manifests = append(manifests, manifestFile{fileName: awsIamAuthManifestFileName, content: awsIamAuth})
+	}
+
+	if clusterautoscaler.Enabled(clusterSpec) {
+		autoscaler, err := clusterautoscaler.GenerateManifest(clusterSpec)
+		if err != nil {
+			return fmt.Errorf("error generating cluster-autoscaler manifest: %v", err)
+		}
+		manifests = append(manifests, manifestFile{fileName: clusterAutoscalerManifestFileName, content: autoscaler})
+	}
+
+	for _, m := range manifests {
+		if len(m.content) == 0 {
+			continue
+		}
+		if _, err := w.Write(m.fileName, m.content, filewriter.PersistentFile); err != nil {
+			return fmt.Errorf("error writing manifest file %s: %v", m.fileName, err)
+		}
+	}
+
+	return nil
+}
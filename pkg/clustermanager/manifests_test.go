@@ -0,0 +1,69 @@
+package clustermanager_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestClusterManagerWriteManifestsForCreate(t *testing.T) {
+	tt := newTest(t)
+	tt.clusterSpec.AWSIamConfig = &v1alpha1.AWSIamConfig{}
+	m := tt.mocks
+	m.provider.EXPECT().GenerateCAPISpecForCreate(tt.ctx, gomock.Any(), tt.clusterSpec).Return([]byte("cp"), []byte("md"), nil)
+	m.networking.EXPECT().GenerateManifest(tt.clusterSpec).Return([]byte("cilium"), nil)
+	m.provider.EXPECT().GenerateMHC().Return([]byte("mhc"), nil)
+	m.provider.EXPECT().GenerateStorageClass().Return(nil)
+	m.awsIamAuth.EXPECT().GenerateManifest(tt.clusterSpec).Return([]byte("iam"), nil)
+	m.writer.EXPECT().Write("control-plane.yaml", []byte("cp"), gomock.Any())
+	m.writer.EXPECT().Write("workers.yaml", []byte("md"), gomock.Any())
+	m.writer.EXPECT().Write("networking.yaml", []byte("cilium"), gomock.Any())
+	m.writer.EXPECT().Write("machine-health-checks.yaml", []byte("mhc"), gomock.Any())
+	m.writer.EXPECT().Write("aws-iam-authenticator.yaml", []byte("iam"), gomock.Any())
+
+	tt.Expect(tt.clusterManager.WriteManifestsForCreate(tt.ctx, m.writer, tt.clusterSpec, m.provider)).To(Succeed())
+}
+
+func TestClusterManagerWriteManifestsForCreateProviderError(t *testing.T) {
+	tt := newTest(t)
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForCreate(tt.ctx, gomock.Any(), tt.clusterSpec).Return(nil, nil, errors.New("error in provider"))
+
+	err := tt.clusterManager.WriteManifestsForCreate(tt.ctx, tt.mocks.writer, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(MatchError(ContainSubstring("error generating capi spec: error in provider")))
+}
+
+func TestClusterManagerWriteManifestsForUpgrade(t *testing.T) {
+	tt := newTest(t)
+	managementCluster := tt.cluster
+	currentSpec := tt.clusterSpec.DeepCopy()
+	m := tt.mocks
+	m.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, managementCluster, tt.cluster, currentSpec, tt.clusterSpec).Return([]byte("cp"), []byte("md"), nil)
+	m.networking.EXPECT().GenerateManifest(tt.clusterSpec).Return([]byte("cilium"), nil)
+	m.provider.EXPECT().GenerateMHC().Return(nil, nil)
+	m.provider.EXPECT().GenerateStorageClass().Return([]byte("storage"))
+	m.writer.EXPECT().Write("control-plane.yaml", []byte("cp"), gomock.Any())
+	m.writer.EXPECT().Write("workers.yaml", []byte("md"), gomock.Any())
+	m.writer.EXPECT().Write("networking.yaml", []byte("cilium"), gomock.Any())
+	m.writer.EXPECT().Write("storage-class.yaml", []byte("storage"), gomock.Any())
+
+	err := tt.clusterManager.WriteManifestsForUpgrade(tt.ctx, m.writer, managementCluster, tt.cluster, currentSpec, tt.clusterSpec, m.provider)
+	tt.Expect(err).To(Succeed())
+}
+
+func TestClusterManagerWriteManifestsForUpgradeWriteError(t *testing.T) {
+	tt := newTest(t)
+	currentSpec := tt.clusterSpec.DeepCopy()
+	m := tt.mocks
+	m.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, currentSpec, tt.clusterSpec).Return([]byte("cp"), []byte("md"), nil)
+	m.networking.EXPECT().GenerateManifest(tt.clusterSpec).Return([]byte("cilium"), nil)
+	m.provider.EXPECT().GenerateMHC().Return(nil, nil)
+	m.provider.EXPECT().GenerateStorageClass().Return(nil)
+	m.writer.EXPECT().Write("control-plane.yaml", []byte("cp"), gomock.Any()).Return("", errors.New("error writing"))
+
+	err := tt.clusterManager.WriteManifestsForUpgrade(tt.ctx, m.writer, tt.cluster, tt.cluster, currentSpec, tt.clusterSpec, m.provider)
+	tt.Expect(err).To(MatchError(ContainSubstring("error writing manifest file control-plane.yaml")))
+}
@@ -0,0 +1,210 @@
+package clustermanager
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var capiClusterResourceType = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+
+// moveClusterCAPIObjects moves the CAPI objects of a single cluster. clusterctl move takes all the clusters of the namespace,
+// so the objects are saved to a directory, filtered and restored in the target cluster. Same as clusterctl move does,
+// the finalizers are removed before deleting the objects from the source cluster so the infrastructure is not deleted
+func (c *ClusterManager) moveClusterCAPIObjects(ctx context.Context, from, to *types.Cluster, clusterName string) error {
+	dir, err := ioutil.TempDir(c.writer.Dir(), "capi-move-")
+	if err != nil {
+		return fmt.Errorf("error creating directory for CAPI objects: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	allObjectsDir := filepath.Join(dir, "all")
+	clusterObjectsDir := filepath.Join(dir, clusterName)
+	for _, d := range []string{allObjectsDir, clusterObjectsDir} {
+		if err = os.MkdirAll(d, os.ModePerm); err != nil {
+			return err
+		}
+	}
+
+	// clusterctl resumes all the clusters once the objects are saved, so the ones already paused are paused again
+	pausedClusters, err := c.pausedCAPIClusters(ctx, from)
+	if err != nil {
+		return err
+	}
+	clustersToPause := []string{clusterName}
+	for _, name := range pausedClusters {
+		if name != clusterName {
+			clustersToPause = append(clustersToPause, name)
+		}
+	}
+
+	if err = c.pauseCAPICluster(ctx, from, clusterName); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Saving CAPI objects from source cluster")
+	if err = c.clusterClient.BackupManagement(ctx, from, allObjectsDir); err != nil {
+		return err
+	}
+
+	for _, name := range clustersToPause {
+		if err = c.pauseCAPICluster(ctx, from, name); err != nil {
+			return err
+		}
+	}
+
+	objs, err := filterClusterObjects(allObjectsDir, clusterObjectsDir, clusterName)
+	if err != nil {
+		return err
+	}
+	if len(objs) == 0 {
+		return fmt.Errorf("no CAPI objects found for cluster %s", clusterName)
+	}
+
+	logger.V(3).Info("Restoring CAPI objects in target cluster", "objects", len(objs))
+	if err = c.clusterClient.RestoreManagement(ctx, to, clusterObjectsDir); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Deleting CAPI objects from source cluster")
+	for i := range objs {
+		err = c.Retrier.Retry(
+			func() error {
+				return c.clusterClient.MergePatchResource(ctx, resourceTypeForObject(&objs[i]), objs[i].GetName(), `{"metadata":{"finalizers":null}}`, from.KubeconfigFile, objs[i].GetNamespace())
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error removing finalizers from %s %s: %v", objs[i].GetKind(), objs[i].GetName(), err)
+		}
+	}
+	for i := range objs {
+		err = c.Retrier.Retry(
+			func() error {
+				return c.clusterClient.DeleteResource(ctx, resourceTypeForObject(&objs[i]), objs[i].GetName(), from.KubeconfigFile, objs[i].GetNamespace())
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error deleting %s %s from source cluster: %v", objs[i].GetKind(), objs[i].GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// pausedCAPIClusters returns the names of the CAPI clusters in the eksa-system namespace that are paused
+func (c *ClusterManager) pausedCAPIClusters(ctx context.Context, cluster *types.Cluster) ([]string, error) {
+	var clusters []types.CAPICluster
+	err := c.Retrier.Retry(
+		func() error {
+			var err error
+			clusters, err = c.clusterClient.GetClusters(ctx, cluster)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting CAPI clusters: %v", err)
+	}
+
+	var paused []string
+	for _, capiCluster := range clusters {
+		if capiCluster.Spec.Paused {
+			paused = append(paused, capiCluster.Metadata.Name)
+		}
+	}
+	return paused, nil
+}
+
+func (c *ClusterManager) pauseCAPICluster(ctx context.Context, cluster *types.Cluster, clusterName string) error {
+	err := c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.MergePatchResource(ctx, capiClusterResourceType, clusterName, `{"spec":{"paused":true}}`, cluster.KubeconfigFile, constants.EksaSystemNamespace)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error pausing CAPI cluster %s: %v", clusterName, err)
+	}
+	return nil
+}
+
+// filterClusterObjects copies to dst the objects in src that belong to clusterName and returns them. Most objects have
+// the cluster name label, the ones that don't, like machine templates and secrets, are matched by the cluster name prefix
+func filterClusterObjects(src, dst, clusterName string) ([]unstructured.Unstructured, error) {
+	files, err := ioutil.ReadDir(src)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CAPI objects: %v", err)
+	}
+
+	objs := make([]unstructured.Unstructured, 0, len(files))
+	names := make([]string, 0, len(files))
+	contents := make([][]byte, 0, len(files))
+	for _, f := range files {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(src, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		obj := unstructured.Unstructured{}
+		if err = yaml.Unmarshal(content, &obj.Object); err != nil {
+			return nil, fmt.Errorf("error parsing CAPI object %s: %v", f.Name(), err)
+		}
+		objs = append(objs, obj)
+		names = append(names, f.Name())
+		contents = append(contents, content)
+	}
+
+	var clusterNames []string
+	for i := range objs {
+		if objs[i].GetKind() == "Cluster" && objs[i].GroupVersionKind().Group == clusterv1.GroupVersion.Group {
+			clusterNames = append(clusterNames, objs[i].GetName())
+		}
+	}
+
+	clusterObjs := make([]unstructured.Unstructured, 0, len(objs))
+	for i := range objs {
+		if !belongsToCluster(&objs[i], clusterName, clusterNames) {
+			continue
+		}
+		if err = ioutil.WriteFile(filepath.Join(dst, names[i]), contents[i], 0o600); err != nil {
+			return nil, err
+		}
+		clusterObjs = append(clusterObjs, objs[i])
+	}
+
+	return clusterObjs, nil
+}
+
+func belongsToCluster(obj *unstructured.Unstructured, clusterName string, clusterNames []string) bool {
+	if label, ok := obj.GetLabels()[clusterv1.ClusterLabelName]; ok {
+		return label == clusterName
+	}
+
+	// The longest match wins, so objects of cluster "dev-2" don't end up in cluster "dev"
+	owner := ""
+	for _, name := range clusterNames {
+		if (obj.GetName() == name || strings.HasPrefix(obj.GetName(), name+"-")) && len(name) > len(owner) {
+			owner = name
+		}
+	}
+	return owner == clusterName
+}
+
+func resourceTypeForObject(obj *unstructured.Unstructured) string {
+	kind := strings.ToLower(obj.GetKind())
+	if group := obj.GroupVersionKind().Group; group != "" {
+		kind = fmt.Sprintf("%s.%s", kind, group)
+	}
+	return kind
+}
@@ -8,12 +8,14 @@ import (
 	"github.com/aws/eks-anywhere/pkg/awsiamauth"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/clients/flux"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
@@ -381,6 +383,13 @@ type clusterManagerClient struct {
 	*executables.Kubectl
 }
 
+// nativeClusterManagerClient uses the native kubernetes client for the operations it implements,
+// which shadow the kubectl ones, and falls back to clusterctl and kubectl for the rest
+type nativeClusterManagerClient struct {
+	*kubernetes.Client
+	clusterManagerClient
+}
+
 func (f *Factory) WithClusterManager(clusterConfig *v1alpha1.Cluster) *Factory {
 	f.WithClusterctl().WithKubectl().WithNetworking(clusterConfig).WithWriter().WithDiagnosticBundleFactory().WithAwsIamAuth()
 
@@ -389,11 +398,22 @@ func (f *Factory) WithClusterManager(clusterConfig *v1alpha1.Cluster) *Factory {
 			return nil
 		}
 
+		var client clustermanager.ClusterClient = &clusterManagerClient{
+			f.dependencies.Clusterctl,
+			f.dependencies.Kubectl,
+		}
+		if features.IsActive(features.NativeKubernetesClient()) {
+			client = &nativeClusterManagerClient{
+				kubernetes.New(),
+				clusterManagerClient{
+					f.dependencies.Clusterctl,
+					f.dependencies.Kubectl,
+				},
+			}
+		}
+
 		f.dependencies.ClusterManager = clustermanager.New(
-			&clusterManagerClient{
-				f.dependencies.Clusterctl,
-				f.dependencies.Kubectl,
-			},
+			client,
 			f.dependencies.Networking,
 			f.dependencies.Writer,
 			f.dependencies.DignosticCollectorFactory,
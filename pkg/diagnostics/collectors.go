@@ -59,6 +59,22 @@ func (c *collectorFactory) EksaHostCollectors(machineConfigs []providers.Machine
 	return collectors
 }
 
+// AuditLogCollectors copies the kube-apiserver audit logs, including the rotated ones, from the nodes.
+// Only control plane nodes run kube-apiserver, so the collected path is empty for the worker nodes
+func (c *collectorFactory) AuditLogCollectors() []*Collect {
+	return []*Collect{
+		{
+			CopyFromHost: &copyFromHost{
+				Name:      hostlogPath("kubernetes-audit"),
+				Namespace: constants.EksaDiagnosticsNamespace,
+				Image:     c.DiagnosticCollectorImage,
+				HostPath:  "/var/log/kubernetes",
+				Timeout:   time.Minute.String(),
+			},
+		},
+	}
+}
+
 func (c *collectorFactory) DataCenterConfigCollectors(datacenter v1alpha1.Ref) []*Collect {
 	switch datacenter.Kind {
 	case v1alpha1.VSphereDatacenterKind:
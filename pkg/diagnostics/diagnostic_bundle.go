@@ -106,6 +106,7 @@ func newDiagnosticBundleFromSpec(af AnalyzerFactory, cf CollectorFactory, spec *
 		WithExternalEtcd(spec.Spec.ExternalEtcdConfiguration).
 		WithDatacenterConfig(spec.Spec.DatacenterRef).
 		WithMachineConfigs(provider.MachineConfigs()).
+		WithAuditLogs().
 		WithManagementCluster(spec.IsSelfManaged()).
 		WithDefaultAnalyzers().
 		WithDefaultCollectors().
@@ -283,6 +284,11 @@ func (e *EksaDiagnosticBundle) WithMachineConfigs(configs []providers.MachineCon
 	return e
 }
 
+func (e *EksaDiagnosticBundle) WithAuditLogs() *EksaDiagnosticBundle {
+	e.bundle.Spec.Collectors = append(e.bundle.Spec.Collectors, e.collectorFactory.AuditLogCollectors()...)
+	return e
+}
+
 func (e *EksaDiagnosticBundle) WithLogTextAnalyzers() *EksaDiagnosticBundle {
 	e.bundle.Spec.Analyzers = append(e.bundle.Spec.Analyzers, e.analyzerFactory.EksaLogTextAnalyzers(e.bundle.Spec.Collectors)...)
 	return e
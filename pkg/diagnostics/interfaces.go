@@ -36,6 +36,7 @@ type DiagnosticBundle interface {
 	WithExternalEtcd(config *v1alpha1.ExternalEtcdConfiguration) *EksaDiagnosticBundle
 	WithGitOpsConfig(config *v1alpha1.GitOpsConfig) *EksaDiagnosticBundle
 	WithMachineConfigs(configs []providers.MachineConfig) *EksaDiagnosticBundle
+	WithAuditLogs() *EksaDiagnosticBundle
 	WithLogTextAnalyzers() *EksaDiagnosticBundle
 }
 
@@ -53,5 +54,6 @@ type CollectorFactory interface {
 	DefaultCollectors() []*Collect
 	ManagementClusterCollectors() []*Collect
 	EksaHostCollectors(configs []providers.MachineConfig) []*Collect
+	AuditLogCollectors() []*Collect
 	DataCenterConfigCollectors(datacenter v1alpha1.Ref) []*Collect
 }
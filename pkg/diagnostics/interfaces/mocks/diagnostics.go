@@ -231,6 +231,20 @@ func (mr *MockDiagnosticBundleMockRecorder) PrintBundleConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrintBundleConfig", reflect.TypeOf((*MockDiagnosticBundle)(nil).PrintBundleConfig))
 }
 
+// WithAuditLogs mocks base method.
+func (m *MockDiagnosticBundle) WithAuditLogs() *diagnostics.EksaDiagnosticBundle {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithAuditLogs")
+	ret0, _ := ret[0].(*diagnostics.EksaDiagnosticBundle)
+	return ret0
+}
+
+// WithAuditLogs indicates an expected call of WithAuditLogs.
+func (mr *MockDiagnosticBundleMockRecorder) WithAuditLogs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithAuditLogs", reflect.TypeOf((*MockDiagnosticBundle)(nil).WithAuditLogs))
+}
+
 // WithDatacenterConfig mocks base method.
 func (m *MockDiagnosticBundle) WithDatacenterConfig(config v1alpha1.Ref) *diagnostics.EksaDiagnosticBundle {
 	m.ctrl.T.Helper()
@@ -516,6 +530,20 @@ func (m *MockCollectorFactory) EXPECT() *MockCollectorFactoryMockRecorder {
 	return m.recorder
 }
 
+// AuditLogCollectors mocks base method.
+func (m *MockCollectorFactory) AuditLogCollectors() []*diagnostics.Collect {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLogCollectors")
+	ret0, _ := ret[0].([]*diagnostics.Collect)
+	return ret0
+}
+
+// AuditLogCollectors indicates an expected call of AuditLogCollectors.
+func (mr *MockCollectorFactoryMockRecorder) AuditLogCollectors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLogCollectors", reflect.TypeOf((*MockCollectorFactory)(nil).AuditLogCollectors))
+}
+
 // DataCenterConfigCollectors mocks base method.
 func (m *MockCollectorFactory) DataCenterConfigCollectors(datacenter v1alpha1.Ref) []*diagnostics.Collect {
 	m.ctrl.T.Helper()
@@ -0,0 +1,321 @@
+package etcdbackup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	etcdMachineLabelName = "cluster.x-k8s.io/etcd-cluster"
+
+	resumeMaxRetries    = 12
+	resumeBackOffPeriod = 5 * time.Second
+)
+
+var capiClusterResourceType = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+
+type KubectlClient interface {
+	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
+	MergePatchResource(ctx context.Context, resourceType, name, patch, kubeconfig, namespace string) error
+}
+
+// Manager takes etcd snapshots of a cluster and restores them. It supports both stacked etcd, running as
+// a static pod in the control plane nodes, and external etcd, running as a service in the etcdadm machines
+type Manager struct {
+	kubectl KubectlClient
+	runner  NodeRunner
+	now     func() time.Time
+	retrier *retrier.Retrier
+}
+
+type ManagerOpt func(*Manager)
+
+// WithNow replaces the clock used to name the snapshots
+func WithNow(now func() time.Time) ManagerOpt {
+	return func(m *Manager) {
+		m.now = now
+	}
+}
+
+// WithRetrier replaces the retrier used to resume the CAPI cluster after a restore
+func WithRetrier(r *retrier.Retrier) ManagerOpt {
+	return func(m *Manager) {
+		m.retrier = r
+	}
+}
+
+func NewManager(kubectl KubectlClient, runner NodeRunner, opts ...ManagerOpt) *Manager {
+	m := &Manager{
+		kubectl: kubectl,
+		runner:  runner,
+		now:     time.Now,
+		retrier: retrier.NewWithMaxRetries(resumeMaxRetries, resumeBackOffPeriod),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Backup takes a snapshot from one of the etcd members of the cluster, saves it in the store and deletes
+// the oldest snapshots, keeping the newest retention ones. It returns the name of the new snapshot
+func (m *Manager) Backup(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, store Store, retention int) (string, error) {
+	members, err := m.etcdMembers(ctx, managementCluster, clusterSpec)
+	if err != nil {
+		return "", err
+	}
+
+	member := members[0]
+	logger.V(3).Info("Taking etcd snapshot", "machine", member.Name)
+	snapshot, err := m.runner.Run(ctx, member, backupScript(clusterSpec), nil)
+	if err != nil {
+		return "", fmt.Errorf("error taking etcd snapshot in machine %s: %v", member.Name, err)
+	}
+	if snapshot.Len() == 0 {
+		return "", fmt.Errorf("etcd snapshot taken in machine %s is empty", member.Name)
+	}
+
+	name := SnapshotName(clusterSpec.Name, m.now())
+	logger.V(3).Info("Saving etcd snapshot", "snapshot", name)
+	if err = store.Put(ctx, name, snapshot.Bytes()); err != nil {
+		return "", err
+	}
+
+	if err = Prune(ctx, store, clusterSpec.Name, retention); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// Restore replaces the etcd data of the cluster with a snapshot from the store. If snapshotName is empty,
+// the newest snapshot of the cluster is restored. The existing CAPI machines are reused: the CAPI cluster
+// is paused, the API servers and etcd members are stopped, every member is restored from the snapshot as
+// part of a new etcd cluster and everything is started again
+func (m *Manager) Restore(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, store Store, snapshotName string) error {
+	if snapshotName == "" {
+		snapshots, err := Snapshots(ctx, store, clusterSpec.Name)
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			return fmt.Errorf("no etcd snapshots found for cluster %s", clusterSpec.Name)
+		}
+		snapshotName = snapshots[len(snapshots)-1]
+	}
+
+	logger.V(3).Info("Reading etcd snapshot", "snapshot", snapshotName)
+	snapshot, err := store.Get(ctx, snapshotName)
+	if err != nil {
+		return err
+	}
+
+	members, err := m.etcdMembers(ctx, managementCluster, clusterSpec)
+	if err != nil {
+		return err
+	}
+
+	controlPlaneNodes := members
+	if isExternalEtcd(clusterSpec) {
+		controlPlaneNodes, err = m.machines(ctx, managementCluster, clusterSpec.Name, clusterv1.MachineControlPlaneLabelName)
+		if err != nil {
+			return err
+		}
+	}
+
+	initialCluster, err := m.initialCluster(ctx, clusterSpec, members)
+	if err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Pausing CAPI cluster reconciliation")
+	if err = m.setCAPIClusterPaused(ctx, managementCluster, clusterSpec.Name, true); err != nil {
+		return err
+	}
+
+	// The CAPI cluster is only resumed once the API servers are healthy again. If the restore fails halfway,
+	// it's left paused so CAPI doesn't replace the machines while the control plane is stopped
+	if err = m.restoreMembers(ctx, clusterSpec, controlPlaneNodes, members, initialCluster, snapshot); err != nil {
+		return fmt.Errorf("%v; CAPI cluster %s was left paused, set spec.paused to false once the control plane is healthy", err, clusterSpec.Name)
+	}
+
+	logger.V(3).Info("Resuming CAPI cluster reconciliation")
+	// The management cluster API server might be the one that was just restored, give it time to be reachable
+	return m.retrier.Retry(func() error {
+		return m.setCAPIClusterPaused(ctx, managementCluster, clusterSpec.Name, false)
+	})
+}
+
+// restoreMembers stops the control plane and etcd, restores the snapshot in every etcd member, starts everything
+// again and waits for the API servers to be healthy
+func (m *Manager) restoreMembers(ctx context.Context, clusterSpec *cluster.Spec, controlPlaneNodes, members []Node, initialCluster *etcdCluster, snapshot []byte) error {
+	logger.V(3).Info("Stopping control plane components")
+	for _, node := range controlPlaneNodes {
+		if _, err := m.runner.Run(ctx, node, stopControlPlaneScript(clusterSpec), nil); err != nil {
+			return fmt.Errorf("error stopping control plane in machine %s: %v", node.Name, err)
+		}
+	}
+
+	if isExternalEtcd(clusterSpec) {
+		logger.V(3).Info("Stopping etcd")
+		for _, node := range members {
+			if _, err := m.runner.Run(ctx, node, stopExternalEtcdScript, nil); err != nil {
+				return fmt.Errorf("error stopping etcd in machine %s: %v", node.Name, err)
+			}
+		}
+	}
+
+	for _, member := range initialCluster.members {
+		logger.V(3).Info("Restoring etcd data", "machine", member.node.Name)
+		if _, err := m.runner.Run(ctx, member.node, restoreScript(clusterSpec, member, initialCluster), snapshot); err != nil {
+			return fmt.Errorf("error restoring etcd data in machine %s: %v", member.node.Name, err)
+		}
+	}
+
+	if isExternalEtcd(clusterSpec) {
+		logger.V(3).Info("Starting etcd")
+		for _, node := range members {
+			if _, err := m.runner.Run(ctx, node, startExternalEtcdScript, nil); err != nil {
+				return fmt.Errorf("error starting etcd in machine %s: %v", node.Name, err)
+			}
+		}
+	}
+
+	logger.V(3).Info("Starting control plane components")
+	for _, node := range controlPlaneNodes {
+		if _, err := m.runner.Run(ctx, node, startControlPlaneScript, nil); err != nil {
+			return fmt.Errorf("error starting control plane in machine %s: %v", node.Name, err)
+		}
+	}
+
+	logger.V(3).Info("Waiting for the API servers to be healthy")
+	for _, node := range controlPlaneNodes {
+		if _, err := m.runner.Run(ctx, node, waitForApiServerScript, nil); err != nil {
+			return fmt.Errorf("error waiting for API server in machine %s: %v", node.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) etcdMembers(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) ([]Node, error) {
+	if !isExternalEtcd(clusterSpec) {
+		return m.machines(ctx, managementCluster, clusterSpec.Name, clusterv1.MachineControlPlaneLabelName)
+	}
+
+	members, err := m.machines(ctx, managementCluster, clusterSpec.Name, etcdMachineLabelName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) != clusterSpec.Spec.ExternalEtcdConfiguration.Count {
+		return nil, fmt.Errorf("found %d etcd machines for cluster %s, expected %d from the external etcd configuration",
+			len(members), clusterSpec.Name, clusterSpec.Spec.ExternalEtcdConfiguration.Count)
+	}
+
+	return members, nil
+}
+
+func (m *Manager) machines(ctx context.Context, managementCluster *types.Cluster, clusterName, label string) ([]Node, error) {
+	machines, err := m.kubectl.GetMachines(ctx, managementCluster, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting machines for cluster %s: %v", clusterName, err)
+	}
+
+	var nodes []Node
+	for i := range machines {
+		if !machines[i].HasAnyLabel([]string{label}) {
+			continue
+		}
+		nodes = append(nodes, Node{
+			Name:    machines[i].Metadata.Name,
+			Address: machines[i].Address(),
+		})
+	}
+
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no machines with label %s found for cluster %s", label, clusterName)
+	}
+
+	return nodes, nil
+}
+
+func (m *Manager) setCAPIClusterPaused(ctx context.Context, managementCluster *types.Cluster, clusterName string, paused bool) error {
+	patch := fmt.Sprintf(`{"spec":{"paused":%t}}`, paused)
+	if err := m.kubectl.MergePatchResource(ctx, capiClusterResourceType, clusterName, patch, managementCluster.KubeconfigFile, constants.EksaSystemNamespace); err != nil {
+		return fmt.Errorf("error updating paused in CAPI cluster %s: %v", clusterName, err)
+	}
+	return nil
+}
+
+type etcdMember struct {
+	node    Node
+	name    string
+	peerURL string
+}
+
+type etcdCluster struct {
+	members []etcdMember
+	token   string
+}
+
+func (c etcdCluster) String() string {
+	peers := make([]string, 0, len(c.members))
+	for _, m := range c.members {
+		peers = append(peers, fmt.Sprintf("%s=%s", m.name, m.peerURL))
+	}
+	return strings.Join(peers, ",")
+}
+
+// initialCluster builds the members of the restored etcd cluster, keeping the name and peer url
+// each member was started with
+func (m *Manager) initialCluster(ctx context.Context, clusterSpec *cluster.Spec, nodes []Node) (*etcdCluster, error) {
+	c := &etcdCluster{
+		// The restored cluster needs a token that hasn't been used before
+		token: fmt.Sprintf("eksa-restore-%d", m.now().Unix()),
+	}
+	for _, node := range nodes {
+		out, err := m.runner.Run(ctx, node, memberConfigScript(clusterSpec), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error reading etcd member configuration in machine %s: %v", node.Name, err)
+		}
+
+		member := etcdMember{node: node}
+		for _, line := range strings.Split(out.String(), "\n") {
+			keyValue := strings.SplitN(strings.TrimSpace(line), "=", 2)
+			if len(keyValue) != 2 {
+				continue
+			}
+			switch strings.TrimLeft(keyValue[0], "-") {
+			case "name", "ETCD_NAME":
+				member.name = keyValue[1]
+			case "initial-advertise-peer-urls", "ETCD_INITIAL_ADVERTISE_PEER_URLS":
+				member.peerURL = keyValue[1]
+			}
+		}
+
+		if member.name == "" || member.peerURL == "" {
+			return nil, errors.New("can't find etcd member name and peer url in machine " + node.Name)
+		}
+		c.members = append(c.members, member)
+	}
+
+	return c, nil
+}
+
+func isExternalEtcd(clusterSpec *cluster.Spec) bool {
+	return clusterSpec.Spec.ExternalEtcdConfiguration != nil
+}
@@ -0,0 +1,296 @@
+package etcdbackup_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+	"github.com/aws/eks-anywhere/pkg/etcdbackup/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const capiClusterResourceType = "clusters.cluster.x-k8s.io"
+
+type managerTest struct {
+	*WithT
+	ctx               context.Context
+	kubectl           *mocks.MockKubectlClient
+	runner            *mocks.MockNodeRunner
+	managementCluster *types.Cluster
+	spec              *cluster.Spec
+	store             *etcdbackup.LocalStore
+	manager           *etcdbackup.Manager
+}
+
+func newManagerTest(t *testing.T) *managerTest {
+	ctrl := gomock.NewController(t)
+	tt := &managerTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		kubectl:           mocks.NewMockKubectlClient(ctrl),
+		runner:            mocks.NewMockNodeRunner(ctrl),
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Name = "test-cluster"
+			s.VersionsBundle.KubeDistro.Etcd.Repository = "public.ecr.aws/eks-distro/etcd-io/etcd"
+			s.VersionsBundle.KubeDistro.Etcd.Tag = "v3.4.16-eks-1-21-4"
+		}),
+		store: etcdbackup.NewLocalStore(t.TempDir()),
+	}
+	tt.manager = etcdbackup.NewManager(tt.kubectl, tt.runner, etcdbackup.WithNow(func() time.Time {
+		return time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	}))
+
+	return tt
+}
+
+func machine(name, label, address string) types.Machine {
+	return types.Machine{
+		Metadata: types.MachineMetadata{Name: name, Labels: map[string]string{label: ""}},
+		Spec:     types.MachineSpec{InfrastructureRef: types.ResourceRef{Name: name + "-infra"}},
+		Status: types.MachineStatus{
+			Addresses: []types.MachineAddress{{Type: "ExternalIP", Address: address}},
+		},
+	}
+}
+
+func (tt *managerTest) expectMachines(machines ...types.Machine) {
+	machines = append(machines, machine("worker", "cluster.x-k8s.io/deployment-name", "1.2.3.100"))
+	tt.kubectl.EXPECT().GetMachines(tt.ctx, tt.managementCluster, "test-cluster").Return(machines, nil).AnyTimes()
+}
+
+type scriptMatcher struct {
+	substrings []string
+}
+
+// script matches the scripts containing all the substrings
+func script(substrings ...string) gomock.Matcher {
+	return scriptMatcher{substrings: substrings}
+}
+
+func (m scriptMatcher) Matches(x interface{}) bool {
+	s, ok := x.(string)
+	if !ok {
+		return false
+	}
+	for _, sub := range m.substrings {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m scriptMatcher) String() string {
+	return fmt.Sprintf("script containing %q", m.substrings)
+}
+
+func TestManagerBackupStackedEtcd(t *testing.T) {
+	tt := newManagerTest(t)
+	tt.expectMachines(
+		machine("cp-1", "cluster.x-k8s.io/control-plane", "1.2.3.4"),
+		machine("cp-2", "cluster.x-k8s.io/control-plane", "1.2.3.5"),
+	)
+	tt.runner.EXPECT().Run(tt.ctx, etcdbackup.Node{Name: "cp-1", Address: "1.2.3.4"}, script(
+		"ctr -n k8s.io run --rm --net-host",
+		"public.ecr.aws/eks-distro/etcd-io/etcd:v3.4.16-eks-1-21-4 eksa-etcd-backup etcdctl --endpoints=https://127.0.0.1:2379",
+		"snapshot save /var/lib/eksa-etcd-snapshot.db",
+	), nil).Return(*bytes.NewBufferString("snapshot"), nil)
+
+	name, err := tt.manager.Backup(tt.ctx, tt.managementCluster, tt.spec, tt.store, 0)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(name).To(Equal("test-cluster-etcd-20220102030405.db"))
+	tt.Expect(tt.store.Get(tt.ctx, name)).To(Equal([]byte("snapshot")))
+}
+
+func TestManagerBackupExternalEtcdWithRetention(t *testing.T) {
+	tt := newManagerTest(t)
+	tt.spec.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 1}
+	tt.Expect(tt.store.Put(tt.ctx, "test-cluster-etcd-20220101000000.db", []byte("old"))).To(Succeed())
+	tt.expectMachines(
+		machine("cp-1", "cluster.x-k8s.io/control-plane", "1.2.3.4"),
+		machine("etcd-1", "cluster.x-k8s.io/etcd-cluster", "1.2.3.10"),
+	)
+	tt.runner.EXPECT().Run(tt.ctx, etcdbackup.Node{Name: "etcd-1", Address: "1.2.3.10"}, script(
+		". /etc/etcd/etcdctl.env",
+		"etcdctl snapshot save /var/lib/eksa-etcd-snapshot.db",
+	), nil).Return(*bytes.NewBufferString("snapshot"), nil)
+
+	_, err := tt.manager.Backup(tt.ctx, tt.managementCluster, tt.spec, tt.store, 1)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(etcdbackup.Snapshots(tt.ctx, tt.store, "test-cluster")).To(Equal([]string{"test-cluster-etcd-20220102030405.db"}))
+}
+
+func TestManagerBackupEtcdMachinesDontMatchConfiguration(t *testing.T) {
+	tt := newManagerTest(t)
+	tt.spec.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+	tt.expectMachines(machine("etcd-1", "cluster.x-k8s.io/etcd-cluster", "1.2.3.10"))
+
+	_, err := tt.manager.Backup(tt.ctx, tt.managementCluster, tt.spec, tt.store, 0)
+	tt.Expect(err).To(MatchError("found 1 etcd machines for cluster test-cluster, expected 3 from the external etcd configuration"))
+}
+
+func TestManagerBackupSnapshotError(t *testing.T) {
+	tt := newManagerTest(t)
+	tt.expectMachines(machine("cp-1", "cluster.x-k8s.io/control-plane", "1.2.3.4"))
+	tt.runner.EXPECT().Run(tt.ctx, gomock.Any(), gomock.Any(), nil).Return(bytes.Buffer{}, errors.New("etcdctl failed"))
+
+	_, err := tt.manager.Backup(tt.ctx, tt.managementCluster, tt.spec, tt.store, 0)
+	tt.Expect(err).To(MatchError("error taking etcd snapshot in machine cp-1: etcdctl failed"))
+}
+
+func TestManagerRestoreStackedEtcd(t *testing.T) {
+	tt := newManagerTest(t)
+	tt.Expect(tt.store.Put(tt.ctx, "test-cluster-etcd-20220101000000.db", []byte("old"))).To(Succeed())
+	tt.Expect(tt.store.Put(tt.ctx, "test-cluster-etcd-20220102000000.db", []byte("newest"))).To(Succeed())
+	cp1 := etcdbackup.Node{Name: "cp-1", Address: "1.2.3.4"}
+	cp2 := etcdbackup.Node{Name: "cp-2", Address: "1.2.3.5"}
+	tt.expectMachines(
+		machine("cp-1", "cluster.x-k8s.io/control-plane", "1.2.3.4"),
+		machine("cp-2", "cluster.x-k8s.io/control-plane", "1.2.3.5"),
+	)
+	initialCluster := "--initial-cluster host-cp-1=https://1.2.3.4:2380,host-cp-2=https://1.2.3.5:2380"
+
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, cp1, script("/etc/kubernetes/manifests/etcd.yaml"), nil).Return(
+			*bytes.NewBufferString("--name=host-cp-1\n--initial-advertise-peer-urls=https://1.2.3.4:2380\n"), nil,
+		),
+		tt.runner.EXPECT().Run(tt.ctx, cp2, script("/etc/kubernetes/manifests/etcd.yaml"), nil).Return(
+			*bytes.NewBufferString("--name=host-cp-2\n--initial-advertise-peer-urls=https://1.2.3.5:2380\n"), nil,
+		),
+		tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "test-cluster", `{"spec":{"paused":true}}`, "mgmt.kubeconfig", constants.EksaSystemNamespace),
+		tt.runner.EXPECT().Run(tt.ctx, cp1, script("kube-apiserver.yaml etcd.yaml"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, cp2, script("kube-apiserver.yaml etcd.yaml"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, cp1, script(
+			"eksa-etcd-restore etcdctl snapshot restore /var/lib/eksa-etcd-snapshot.db --name host-cp-1",
+			initialCluster,
+			"--initial-cluster-token eksa-restore-1641092645",
+			"--initial-advertise-peer-urls https://1.2.3.4:2380",
+		), []byte("newest")),
+		tt.runner.EXPECT().Run(tt.ctx, cp2, script(
+			"--name host-cp-2",
+			initialCluster,
+			"--initial-advertise-peer-urls https://1.2.3.5:2380",
+		), []byte("newest")),
+		tt.runner.EXPECT().Run(tt.ctx, cp1, script("mv /etc/kubernetes/eksa-etcd-restore/*.yaml /etc/kubernetes/manifests/"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, cp2, script("mv /etc/kubernetes/eksa-etcd-restore/*.yaml /etc/kubernetes/manifests/"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, cp1, script("https://127.0.0.1:6443/healthz"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, cp2, script("https://127.0.0.1:6443/healthz"), nil),
+		tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "test-cluster", `{"spec":{"paused":false}}`, "mgmt.kubeconfig", constants.EksaSystemNamespace),
+	)
+
+	tt.Expect(tt.manager.Restore(tt.ctx, tt.managementCluster, tt.spec, tt.store, "")).To(Succeed())
+}
+
+func TestManagerRestoreExternalEtcd(t *testing.T) {
+	tt := newManagerTest(t)
+	tt.spec.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 1}
+	tt.Expect(tt.store.Put(tt.ctx, "snapshot.db", []byte("snapshot"))).To(Succeed())
+	cp := etcdbackup.Node{Name: "cp-1", Address: "1.2.3.4"}
+	etcd := etcdbackup.Node{Name: "etcd-1", Address: "1.2.3.10"}
+	tt.expectMachines(
+		machine("cp-1", "cluster.x-k8s.io/control-plane", "1.2.3.4"),
+		machine("etcd-1", "cluster.x-k8s.io/etcd-cluster", "1.2.3.10"),
+	)
+
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, etcd, script("/etc/etcd/etcd.env"), nil).Return(
+			*bytes.NewBufferString("ETCD_NAME=etcd-1\nETCD_INITIAL_ADVERTISE_PEER_URLS=https://1.2.3.10:2380\n"), nil,
+		),
+		tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "test-cluster", `{"spec":{"paused":true}}`, "mgmt.kubeconfig", constants.EksaSystemNamespace),
+		tt.runner.EXPECT().Run(tt.ctx, cp, script("for manifest in kube-apiserver.yaml; do"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, etcd, "systemctl stop etcd", nil),
+		tt.runner.EXPECT().Run(tt.ctx, etcd, script(
+			"etcdctl snapshot restore /var/lib/eksa-etcd-snapshot.db --name etcd-1 --initial-cluster etcd-1=https://1.2.3.10:2380",
+			"mv /var/lib/eksa-etcd-restore/member /var/lib/etcd/member",
+		), []byte("snapshot")),
+		tt.runner.EXPECT().Run(tt.ctx, etcd, "systemctl start --no-block etcd", nil),
+		tt.runner.EXPECT().Run(tt.ctx, cp, script("mv /etc/kubernetes/eksa-etcd-restore/*.yaml"), nil),
+		tt.runner.EXPECT().Run(tt.ctx, cp, script("https://127.0.0.1:6443/healthz"), nil),
+		tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "test-cluster", `{"spec":{"paused":false}}`, "mgmt.kubeconfig", constants.EksaSystemNamespace),
+	)
+
+	tt.Expect(tt.manager.Restore(tt.ctx, tt.managementCluster, tt.spec, tt.store, "snapshot.db")).To(Succeed())
+}
+
+func TestManagerRestoreLeavesClusterPausedOnError(t *testing.T) {
+	tt := newManagerTest(t)
+	tt.Expect(tt.store.Put(tt.ctx, "snapshot.db", []byte("snapshot"))).To(Succeed())
+	cp := etcdbackup.Node{Name: "cp-1", Address: "1.2.3.4"}
+	tt.expectMachines(machine("cp-1", "cluster.x-k8s.io/control-plane", "1.2.3.4"))
+
+	gomock.InOrder(
+		tt.runner.EXPECT().Run(tt.ctx, cp, script("etcd.yaml"), nil).Return(
+			*bytes.NewBufferString("--name=cp-1\n--initial-advertise-peer-urls=https://1.2.3.4:2380\n"), nil,
+		),
+		tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "test-cluster", `{"spec":{"paused":true}}`, "mgmt.kubeconfig", constants.EksaSystemNamespace),
+		tt.runner.EXPECT().Run(tt.ctx, cp, gomock.Any(), nil).Return(bytes.Buffer{}, errors.New("timed out")),
+	)
+
+	tt.Expect(tt.manager.Restore(tt.ctx, tt.managementCluster, tt.spec, tt.store, "snapshot.db")).To(
+		MatchError("error stopping control plane in machine cp-1: timed out; CAPI cluster test-cluster was left paused, set spec.paused to false once the control plane is healthy"),
+	)
+}
+
+func TestManagerRestoreRetriesResumeUntilApiServerIsReachable(t *testing.T) {
+	tt := newManagerTest(t)
+	tt.manager = etcdbackup.NewManager(tt.kubectl, tt.runner, etcdbackup.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
+	tt.Expect(tt.store.Put(tt.ctx, "snapshot.db", []byte("snapshot"))).To(Succeed())
+	cp := etcdbackup.Node{Name: "cp-1", Address: "1.2.3.4"}
+	tt.expectMachines(machine("cp-1", "cluster.x-k8s.io/control-plane", "1.2.3.4"))
+
+	tt.runner.EXPECT().Run(tt.ctx, cp, script("etcd.yaml"), nil).Return(
+		*bytes.NewBufferString("--name=cp-1\n--initial-advertise-peer-urls=https://1.2.3.4:2380\n"), nil,
+	)
+	tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "test-cluster", `{"spec":{"paused":true}}`, "mgmt.kubeconfig", constants.EksaSystemNamespace)
+	tt.runner.EXPECT().Run(tt.ctx, cp, gomock.Any(), gomock.Any()).Times(4)
+	gomock.InOrder(
+		tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "test-cluster", `{"spec":{"paused":false}}`, "mgmt.kubeconfig", constants.EksaSystemNamespace).Return(errors.New("connection refused")),
+		tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "test-cluster", `{"spec":{"paused":false}}`, "mgmt.kubeconfig", constants.EksaSystemNamespace),
+	)
+
+	tt.Expect(tt.manager.Restore(tt.ctx, tt.managementCluster, tt.spec, tt.store, "snapshot.db")).To(Succeed())
+}
+
+func TestManagerRestoreMissingMemberConfiguration(t *testing.T) {
+	tt := newManagerTest(t)
+	tt.Expect(tt.store.Put(tt.ctx, "snapshot.db", []byte("snapshot"))).To(Succeed())
+	tt.expectMachines(machine("cp-1", "cluster.x-k8s.io/control-plane", "1.2.3.4"))
+	tt.runner.EXPECT().Run(tt.ctx, gomock.Any(), gomock.Any(), nil).Return(*bytes.NewBufferString("--name=cp-1\n"), nil)
+
+	tt.Expect(tt.manager.Restore(tt.ctx, tt.managementCluster, tt.spec, tt.store, "snapshot.db")).To(
+		MatchError("can't find etcd member name and peer url in machine cp-1"),
+	)
+}
+
+func TestManagerRestoreNoSnapshots(t *testing.T) {
+	tt := newManagerTest(t)
+
+	tt.Expect(tt.manager.Restore(tt.ctx, tt.managementCluster, tt.spec, tt.store, "")).To(
+		MatchError("no etcd snapshots found for cluster test-cluster"),
+	)
+}
+
+func TestDockerRunner(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	docker := mocks.NewMockDockerClient(gomock.NewController(t))
+	docker.EXPECT().Exec(ctx, "test-cluster-control-plane-abcde", "hostname", []byte("in")).Return(*bytes.NewBufferString("test-cluster-control-plane-abcde"), nil)
+
+	out, err := etcdbackup.NewDockerRunner(docker).Run(ctx, etcdbackup.Node{Name: "test-cluster-control-plane-abcde"}, "hostname", []byte("in"))
+	g.Expect(err).To(Succeed())
+	g.Expect(out.String()).To(Equal("test-cluster-control-plane-abcde"))
+}
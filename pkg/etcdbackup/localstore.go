@@ -0,0 +1,67 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore saves the snapshots in a local directory
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+func (s *LocalStore) Put(_ context.Context, name string, content []byte) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("error creating snapshot directory %s: %v", s.dir, err)
+	}
+
+	// Snapshots contain all the cluster data, secrets included
+	if err := ioutil.WriteFile(filepath.Join(s.dir, name), content, 0o600); err != nil {
+		return fmt.Errorf("error writing snapshot %s: %v", name, err)
+	}
+
+	return nil
+}
+
+func (s *LocalStore) Get(_ context.Context, name string) ([]byte, error) {
+	content, err := ioutil.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot %s: %v", name, err)
+	}
+
+	return content, nil
+}
+
+func (s *LocalStore) List(_ context.Context) ([]string, error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot directory %s: %v", s.dir, err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+
+	return names, nil
+}
+
+func (s *LocalStore) Delete(_ context.Context, name string) error {
+	if err := os.Remove(filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("error deleting snapshot %s: %v", name, err)
+	}
+
+	return nil
+}
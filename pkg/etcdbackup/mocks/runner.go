@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/etcdbackup/runner.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	bytes "bytes"
+	context "context"
+	reflect "reflect"
+
+	etcdbackup "github.com/aws/eks-anywhere/pkg/etcdbackup"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNodeRunner is a mock of NodeRunner interface.
+type MockNodeRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeRunnerMockRecorder
+}
+
+// MockNodeRunnerMockRecorder is the mock recorder for MockNodeRunner.
+type MockNodeRunnerMockRecorder struct {
+	mock *MockNodeRunner
+}
+
+// NewMockNodeRunner creates a new mock instance.
+func NewMockNodeRunner(ctrl *gomock.Controller) *MockNodeRunner {
+	mock := &MockNodeRunner{ctrl: ctrl}
+	mock.recorder = &MockNodeRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeRunner) EXPECT() *MockNodeRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockNodeRunner) Run(ctx context.Context, node etcdbackup.Node, script string, stdin []byte) (bytes.Buffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, node, script, stdin)
+	ret0, _ := ret[0].(bytes.Buffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockNodeRunnerMockRecorder) Run(ctx, node, script, stdin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockNodeRunner)(nil).Run), ctx, node, script, stdin)
+}
+
+// MockDockerClient is a mock of DockerClient interface.
+type MockDockerClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDockerClientMockRecorder
+}
+
+// MockDockerClientMockRecorder is the mock recorder for MockDockerClient.
+type MockDockerClientMockRecorder struct {
+	mock *MockDockerClient
+}
+
+// NewMockDockerClient creates a new mock instance.
+func NewMockDockerClient(ctrl *gomock.Controller) *MockDockerClient {
+	mock := &MockDockerClient{ctrl: ctrl}
+	mock.recorder = &MockDockerClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDockerClient) EXPECT() *MockDockerClientMockRecorder {
+	return m.recorder
+}
+
+// Exec mocks base method.
+func (m *MockDockerClient) Exec(ctx context.Context, container, command string, stdin []byte) (bytes.Buffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", ctx, container, command, stdin)
+	ret0, _ := ret[0].(bytes.Buffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockDockerClientMockRecorder) Exec(ctx, container, command, stdin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockDockerClient)(nil).Exec), ctx, container, command, stdin)
+}
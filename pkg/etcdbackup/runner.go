@@ -0,0 +1,128 @@
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sshPort        = "22"
+	sshDialTimeout = 30 * time.Second
+)
+
+// Node is a control plane or etcd machine where the snapshot commands are run
+type Node struct {
+	// Name is the name of the CAPI Machine, which CAPD also gives to the container of docker machines
+	Name string
+	// Address is the IP the SSH runner connects to
+	Address string
+}
+
+// NodeRunner runs shell scripts as root in the cluster machines
+type NodeRunner interface {
+	Run(ctx context.Context, node Node, script string, stdin []byte) (stdout bytes.Buffer, err error)
+}
+
+type DockerClient interface {
+	Exec(ctx context.Context, container, command string, stdin []byte) (bytes.Buffer, error)
+}
+
+// DockerRunner runs the scripts in the containers backing the machines of docker clusters
+type DockerRunner struct {
+	docker DockerClient
+}
+
+func NewDockerRunner(docker DockerClient) *DockerRunner {
+	return &DockerRunner{docker: docker}
+}
+
+func (r *DockerRunner) Run(ctx context.Context, node Node, script string, stdin []byte) (bytes.Buffer, error) {
+	return r.docker.Exec(ctx, node.Name, script, stdin)
+}
+
+// SSHRunner runs the scripts through ssh, using sudo
+type SSHRunner struct {
+	config *ssh.ClientConfig
+}
+
+func NewSSHRunner(user, privateKeyFile string) (*SSHRunner, error) {
+	key, err := ioutil.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ssh private key: %v", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ssh private key %s: %v", privateKeyFile, err)
+	}
+
+	return &SSHRunner{
+		config: &ssh.ClientConfig{
+			User: user,
+			Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			// Machines are replaced on every upgrade, so there are no known host keys to check against
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			Timeout:         sshDialTimeout,
+		},
+	}, nil
+}
+
+func (r *SSHRunner) Run(ctx context.Context, node Node, script string, stdin []byte) (bytes.Buffer, error) {
+	var stdout bytes.Buffer
+	if node.Address == "" {
+		return stdout, fmt.Errorf("machine %s doesn't have an address", node.Name)
+	}
+
+	client, err := ssh.Dial("tcp", net.JoinHostPort(node.Address, sshPort), r.config)
+	if err != nil {
+		return stdout, fmt.Errorf("error connecting to machine %s: %v", node.Name, err)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return stdout, fmt.Errorf("error opening ssh session in machine %s: %v", node.Name, err)
+	}
+	defer session.Close()
+
+	var stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	session.Stdin = bytes.NewReader(stdin)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Run(sudoCommand(script))
+	}()
+
+	select {
+	case <-ctx.Done():
+		return stdout, ctx.Err()
+	case err = <-done:
+	}
+
+	if err != nil {
+		if stderr.Len() > 0 {
+			return stdout, errors.New(stderr.String())
+		}
+		return stdout, err
+	}
+
+	return stdout, nil
+}
+
+func sudoCommand(script string) string {
+	return fmt.Sprintf("sudo sh -c %s", shellQuote(script))
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
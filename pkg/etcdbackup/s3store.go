@@ -0,0 +1,119 @@
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const defaultS3Region = "us-east-1"
+
+// S3Store saves the snapshots in an S3 compatible bucket
+type S3Store struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// NewS3Store builds a store for bucket. When an endpoint is configured, requests use path style
+// addressing, which is what most S3 compatible servers, like MinIO, expect
+func NewS3Store(config S3Config, bucket, prefix string) (*S3Store, error) {
+	awsConfig := aws.NewConfig()
+	region := config.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+	awsConfig = awsConfig.WithRegion(region)
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(config.Endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating s3 session: %v", err)
+	}
+
+	return &S3Store{
+		client: s3.New(sess),
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}, nil
+}
+
+func (s *S3Store) key(name string) string {
+	return path.Join(s.prefix, name)
+}
+
+func (s *S3Store) Put(ctx context.Context, name string, content []byte) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(name)),
+		Body:   bytes.NewReader(content),
+	})
+	if err != nil {
+		return fmt.Errorf("error uploading snapshot %s to bucket %s: %v", name, s.bucket, err)
+	}
+
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, name string) ([]byte, error) {
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(name)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error downloading snapshot %s from bucket %s: %v", name, s.bucket, err)
+	}
+	defer out.Body.Close()
+
+	content, err := ioutil.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading snapshot %s from bucket %s: %v", name, s.bucket, err)
+	}
+
+	return content, nil
+}
+
+func (s *S3Store) List(ctx context.Context) ([]string, error) {
+	prefix := ""
+	if s.prefix != "" {
+		prefix = s.prefix + "/"
+	}
+
+	var names []string
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(s.bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range page.Contents {
+			names = append(names, strings.TrimPrefix(aws.StringValue(o.Key), prefix))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing snapshots in bucket %s: %v", s.bucket, err)
+	}
+
+	return names, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, name string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key(name)),
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting snapshot %s from bucket %s: %v", name, s.bucket, err)
+	}
+
+	return nil
+}
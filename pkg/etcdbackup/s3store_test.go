@@ -0,0 +1,112 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+// fakeS3 implements the minimum of the S3 api, with path style addressing, used by the store
+type fakeS3 struct {
+	sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+type listBucketResult struct {
+	XMLName  xml.Name `xml:"ListBucketResult"`
+	Name     string   `xml:"Name"`
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if parts[0] != f.bucket {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if len(parts) == 1 || parts[1] == "" {
+		result := listBucketResult{Name: f.bucket}
+		keys := make([]string, 0, len(f.objects))
+		for key := range f.objects {
+			if strings.HasPrefix(key, r.URL.Query().Get("prefix")) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			result.Contents = append(result.Contents, struct {
+				Key string `xml:"Key"`
+			}{Key: key})
+		}
+		content, _ := xml.Marshal(result)
+		w.Write(content)
+		return
+	}
+
+	key := parts[1]
+	switch r.Method {
+	case http.MethodPut:
+		content, _ := ioutil.ReadAll(r.Body)
+		f.objects[key] = content
+	case http.MethodGet:
+		content, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	g := NewWithT(t)
+	os.Setenv("AWS_ACCESS_KEY_ID", "minioadmin")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "minioadmin")
+	defer os.Unsetenv("AWS_ACCESS_KEY_ID")
+	defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+	fake := &fakeS3{bucket: "backups", objects: map[string][]byte{"other/file.db": []byte("other")}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	ctx := context.Background()
+
+	store, err := etcdbackup.NewStore("s3://backups/etcd/", etcdbackup.S3Config{Endpoint: server.URL})
+	g.Expect(err).To(Succeed())
+
+	g.Expect(store.Put(ctx, "snapshot.db", []byte("data"))).To(Succeed())
+	g.Expect(fake.objects).To(HaveKeyWithValue("etcd/snapshot.db", []byte("data")))
+
+	content, err := store.Get(ctx, "snapshot.db")
+	g.Expect(err).To(Succeed())
+	g.Expect(content).To(Equal([]byte("data")))
+
+	names, err := store.List(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(names).To(Equal([]string{"snapshot.db"}))
+
+	g.Expect(store.Delete(ctx, "snapshot.db")).To(Succeed())
+	g.Expect(fake.objects).NotTo(HaveKey("etcd/snapshot.db"))
+
+	_, err = store.Get(ctx, "snapshot.db")
+	g.Expect(err).To(MatchError(ContainSubstring("error downloading snapshot snapshot.db from bucket backups")))
+}
@@ -0,0 +1,144 @@
+package etcdbackup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const (
+	snapshotFile       = "/var/lib/eksa-etcd-snapshot.db"
+	etcdDataDir        = "/var/lib/etcd"
+	restoredDataDir    = "/var/lib/eksa-etcd-restore"
+	manifestsDir       = "/etc/kubernetes/manifests"
+	stoppedManifestDir = "/etc/kubernetes/eksa-etcd-restore"
+	kubeadmEtcdPkiDir  = "/etc/kubernetes/pki/etcd"
+	etcdadmEnvFile     = "/etc/etcd/etcdctl.env"
+	etcdadmConfigFile  = "/etc/etcd/etcd.env"
+)
+
+// Stacked etcd runs in a static pod and etcdctl is not installed in the control plane nodes,
+// so it's run from the same etcd image with containerd
+func stackedEtcdctl(clusterSpec *cluster.Spec, containerName string, args ...string) string {
+	image := fmt.Sprintf("%s:%s", clusterSpec.VersionsBundle.KubeDistro.Etcd.Repository, clusterSpec.VersionsBundle.KubeDistro.Etcd.Tag)
+	return fmt.Sprintf("ctr -n k8s.io run --rm --net-host"+
+		" --mount type=bind,src=%[1]s,dst=%[1]s,options=rbind:ro"+
+		" --mount type=bind,src=/var/lib,dst=/var/lib,options=rbind:rw"+
+		" %[2]s %[3]s etcdctl %[4]s",
+		kubeadmEtcdPkiDir, image, containerName, strings.Join(args, " "))
+}
+
+// etcdadm installs etcdctl in the etcd machines along with an env file with the endpoint and certificates
+func externalEtcdctl(args ...string) string {
+	return fmt.Sprintf("set -a && . %s && set +a && PATH=$PATH:/opt/bin:/usr/local/bin etcdctl %s", etcdadmEnvFile, strings.Join(args, " "))
+}
+
+// backupScript saves a snapshot in the machine and writes it to stdout. All the command output goes to stderr
+func backupScript(clusterSpec *cluster.Spec) string {
+	var save string
+	if isExternalEtcd(clusterSpec) {
+		save = externalEtcdctl("snapshot", "save", snapshotFile)
+	} else {
+		save = stackedEtcdctl(clusterSpec, "eksa-etcd-backup",
+			"--endpoints=https://127.0.0.1:2379",
+			fmt.Sprintf("--cacert=%s/ca.crt", kubeadmEtcdPkiDir),
+			fmt.Sprintf("--cert=%s/healthcheck-client.crt", kubeadmEtcdPkiDir),
+			fmt.Sprintf("--key=%s/healthcheck-client.key", kubeadmEtcdPkiDir),
+			"snapshot", "save", snapshotFile,
+		)
+	}
+
+	return fmt.Sprintf(`set -e
+trap 'rm -f %[1]s' EXIT
+%[2]s >&2
+cat %[1]s
+`, snapshotFile, save)
+}
+
+// stopControlPlaneScript moves the static pod manifests out of the kubelet manifests directory and
+// waits until the containers are stopped. The etcd static pod is only stopped for stacked etcd
+func stopControlPlaneScript(clusterSpec *cluster.Spec) string {
+	manifests := "kube-apiserver.yaml"
+	containers := "kube-apiserver"
+	if !isExternalEtcd(clusterSpec) {
+		manifests += " etcd.yaml"
+		containers += "|^etcd$"
+	}
+
+	return fmt.Sprintf(`set -e
+mkdir -p %[1]s
+for manifest in %[2]s; do
+  if [ -f %[3]s/$manifest ]; then mv %[3]s/$manifest %[1]s/; fi
+done
+for i in $(seq 60); do
+  if [ -z "$(crictl ps -q --name '%[4]s')" ]; then exit 0; fi
+  sleep 5
+done
+echo "timed out waiting for control plane containers to stop" >&2
+exit 1
+`, stoppedManifestDir, manifests, manifestsDir, containers)
+}
+
+var startControlPlaneScript = fmt.Sprintf(`set -e
+if [ -d %[1]s ]; then
+  mv %[1]s/*.yaml %[2]s/
+  rmdir %[1]s
+fi
+`, stoppedManifestDir, manifestsDir)
+
+// waitForApiServerScript waits until the kube-apiserver of the machine reports as healthy, which requires etcd to
+// have quorum again
+const waitForApiServerScript = `for i in $(seq 60); do
+  if curl -sfk https://127.0.0.1:6443/healthz > /dev/null; then exit 0; fi
+  sleep 5
+done
+echo "timed out waiting for kube-apiserver to be healthy" >&2
+exit 1
+`
+
+const (
+	stopExternalEtcdScript = "systemctl stop etcd"
+	// etcd doesn't report as started until there is quorum, so don't wait for it
+	startExternalEtcdScript = "systemctl start --no-block etcd"
+)
+
+// memberConfigScript prints the name and peer url of the etcd member, one key=value per line.
+// They are read from the etcd static pod manifest for stacked etcd and from the etcd env file for etcdadm
+func memberConfigScript(clusterSpec *cluster.Spec) string {
+	if isExternalEtcd(clusterSpec) {
+		return fmt.Sprintf("grep -E '^ETCD_(NAME|INITIAL_ADVERTISE_PEER_URLS)=' %s", etcdadmConfigFile)
+	}
+	return fmt.Sprintf(`grep -oE -- '--(name|initial-advertise-peer-urls)=[^" ]+' %s/etcd.yaml`, manifestsDir)
+}
+
+// restoreScript reads the snapshot from stdin and replaces the member directory of the etcd data with
+// one restored from it. The previous member directory is kept in the data directory
+func restoreScript(clusterSpec *cluster.Spec, member etcdMember, initialCluster *etcdCluster) string {
+	args := []string{
+		"snapshot", "restore", snapshotFile,
+		"--name", member.name,
+		"--initial-cluster", initialCluster.String(),
+		"--initial-cluster-token", initialCluster.token,
+		"--initial-advertise-peer-urls", member.peerURL,
+		"--data-dir", restoredDataDir,
+	}
+
+	var restore string
+	if isExternalEtcd(clusterSpec) {
+		restore = externalEtcdctl(args...)
+	} else {
+		restore = stackedEtcdctl(clusterSpec, "eksa-etcd-restore", args...)
+	}
+
+	return fmt.Sprintf(`set -e
+trap 'rm -f %[1]s' EXIT
+cat > %[1]s
+rm -rf %[2]s
+%[3]s >&2
+rm -rf %[4]s/member.eksa-backup
+mv %[4]s/member %[4]s/member.eksa-backup
+mv %[2]s/member %[4]s/member
+rm -rf %[2]s
+`, snapshotFile, restoredDataDir, restore, etcdDataDir)
+}
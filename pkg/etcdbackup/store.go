@@ -0,0 +1,108 @@
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	s3LocationPrefix  = "s3://"
+	snapshotExtension = ".db"
+	snapshotTimestamp = "20060102150405"
+)
+
+// Store saves etcd snapshots
+type Store interface {
+	Put(ctx context.Context, name string, content []byte) error
+	Get(ctx context.Context, name string) ([]byte, error)
+	List(ctx context.Context) ([]string, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// S3Config configures the S3 compatible endpoint used by the stores with an s3:// location.
+// The credentials are read from the standard AWS environment variables and config files
+type S3Config struct {
+	Endpoint string
+	Region   string
+}
+
+// NewStore returns a Store for location, which can be a local directory or an s3://bucket/prefix url
+func NewStore(location string, s3Config S3Config) (Store, error) {
+	if location == "" {
+		return nil, fmt.Errorf("snapshot location can't be empty")
+	}
+
+	if strings.HasPrefix(location, s3LocationPrefix) {
+		bucketAndPrefix := strings.SplitN(strings.TrimPrefix(location, s3LocationPrefix), "/", 2)
+		if bucketAndPrefix[0] == "" {
+			return nil, fmt.Errorf("invalid s3 location %s, it should be s3://bucket[/prefix]", location)
+		}
+		prefix := ""
+		if len(bucketAndPrefix) == 2 {
+			prefix = bucketAndPrefix[1]
+		}
+		return NewS3Store(s3Config, bucketAndPrefix[0], prefix)
+	}
+
+	return NewLocalStore(location), nil
+}
+
+// SnapshotName returns the name for a snapshot of the cluster taken at time t
+func SnapshotName(clusterName string, t time.Time) string {
+	return fmt.Sprintf("%s%s%s", snapshotPrefix(clusterName), t.UTC().Format(snapshotTimestamp), snapshotExtension)
+}
+
+func snapshotPrefix(clusterName string) string {
+	return fmt.Sprintf("%s-etcd-", clusterName)
+}
+
+// Snapshots returns the names of the snapshots of the cluster in the store, from oldest to newest
+func Snapshots(ctx context.Context, store Store, clusterName string) ([]string, error) {
+	names, err := store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing etcd snapshots: %v", err)
+	}
+
+	prefix := snapshotPrefix(clusterName)
+	snapshots := make([]string, 0, len(names))
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) && strings.HasSuffix(name, snapshotExtension) {
+			snapshots = append(snapshots, name)
+		}
+	}
+	// The timestamp format sorts alphabetically in chronological order
+	sort.Strings(snapshots)
+
+	return snapshots, nil
+}
+
+// Prune deletes the oldest snapshots of the cluster, keeping the newest retention ones.
+// A retention of 0 keeps all the snapshots
+func Prune(ctx context.Context, store Store, clusterName string, retention int) error {
+	if retention <= 0 {
+		return nil
+	}
+
+	snapshots, err := Snapshots(ctx, store, clusterName)
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) <= retention {
+		return nil
+	}
+
+	for _, name := range snapshots[:len(snapshots)-retention] {
+		logger.V(3).Info("Deleting old etcd snapshot", "snapshot", name)
+		if err := store.Delete(ctx, name); err != nil {
+			return fmt.Errorf("error deleting etcd snapshot %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
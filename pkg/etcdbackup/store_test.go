@@ -0,0 +1,104 @@
+package etcdbackup_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/etcdbackup"
+)
+
+func TestSnapshotName(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(etcdbackup.SnapshotName("test-cluster", time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC))).To(Equal("test-cluster-etcd-20220102030405.db"))
+}
+
+func TestNewStoreLocal(t *testing.T) {
+	g := NewWithT(t)
+	store, err := etcdbackup.NewStore("backups", etcdbackup.S3Config{})
+	g.Expect(err).To(Succeed())
+	g.Expect(store).To(BeAssignableToTypeOf(&etcdbackup.LocalStore{}))
+}
+
+func TestNewStoreS3(t *testing.T) {
+	g := NewWithT(t)
+	store, err := etcdbackup.NewStore("s3://bucket/prefix", etcdbackup.S3Config{Endpoint: "http://localhost:9000"})
+	g.Expect(err).To(Succeed())
+	g.Expect(store).To(BeAssignableToTypeOf(&etcdbackup.S3Store{}))
+}
+
+func TestNewStoreErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		wantErr  string
+	}{
+		{name: "empty location", location: "", wantErr: "snapshot location can't be empty"},
+		{name: "no bucket", location: "s3://", wantErr: "invalid s3 location s3://"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := etcdbackup.NewStore(tt.location, etcdbackup.S3Config{})
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	store := etcdbackup.NewLocalStore(t.TempDir() + "/snapshots")
+
+	names, err := store.List(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(names).To(BeEmpty())
+
+	g.Expect(store.Put(ctx, "snapshot.db", []byte("data"))).To(Succeed())
+	content, err := store.Get(ctx, "snapshot.db")
+	g.Expect(err).To(Succeed())
+	g.Expect(content).To(Equal([]byte("data")))
+
+	names, err = store.List(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(names).To(ConsistOf("snapshot.db"))
+
+	g.Expect(store.Delete(ctx, "snapshot.db")).To(Succeed())
+	_, err = store.Get(ctx, "snapshot.db")
+	g.Expect(err).To(MatchError(ContainSubstring("error reading snapshot snapshot.db")))
+}
+
+func TestSnapshotsAndPrune(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	store := etcdbackup.NewLocalStore(t.TempDir())
+	for _, name := range []string{
+		"test-cluster-etcd-20220103000000.db",
+		"test-cluster-etcd-20220101000000.db",
+		"test-cluster-etcd-20220102000000.db",
+		"other-cluster-etcd-20220101000000.db",
+		"notes.txt",
+	} {
+		g.Expect(store.Put(ctx, name, []byte("data"))).To(Succeed())
+	}
+
+	snapshots, err := etcdbackup.Snapshots(ctx, store, "test-cluster")
+	g.Expect(err).To(Succeed())
+	g.Expect(snapshots).To(Equal([]string{
+		"test-cluster-etcd-20220101000000.db",
+		"test-cluster-etcd-20220102000000.db",
+		"test-cluster-etcd-20220103000000.db",
+	}))
+
+	g.Expect(etcdbackup.Prune(ctx, store, "test-cluster", 0)).To(Succeed())
+	g.Expect(etcdbackup.Snapshots(ctx, store, "test-cluster")).To(HaveLen(3))
+
+	g.Expect(etcdbackup.Prune(ctx, store, "test-cluster", 2)).To(Succeed())
+	g.Expect(etcdbackup.Snapshots(ctx, store, "test-cluster")).To(Equal([]string{
+		"test-cluster-etcd-20220102000000.db",
+		"test-cluster-etcd-20220103000000.db",
+	}))
+	g.Expect(etcdbackup.Snapshots(ctx, store, "other-cluster")).To(HaveLen(1))
+}
@@ -156,6 +156,30 @@ func (c *Clusterctl) MoveManagement(ctx context.Context, from, to *types.Cluster
 	return err
 }
 
+// BackupManagement saves the CAPI objects of the management cluster in a directory. Unlike MoveManagement,
+// the objects are not deleted from the cluster
+func (c *Clusterctl) BackupManagement(ctx context.Context, cluster *types.Cluster, directory string) error {
+	params := []string{"move", "--to-directory", directory, "--namespace", constants.EksaSystemNamespace}
+	if cluster.KubeconfigFile != "" {
+		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
+	}
+	_, err := c.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("failed backing up management cluster: %v", err)
+	}
+	return nil
+}
+
+// RestoreManagement creates in the cluster the CAPI objects saved in a directory by BackupManagement
+func (c *Clusterctl) RestoreManagement(ctx context.Context, cluster *types.Cluster, directory string) error {
+	params := []string{"move", "--from-directory", directory, "--namespace", constants.EksaSystemNamespace, "--to-kubeconfig", cluster.KubeconfigFile}
+	_, err := c.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("failed restoring management cluster: %v", err)
+	}
+	return nil
+}
+
 func (c *Clusterctl) GetWorkloadKubeconfig(ctx context.Context, clusterName string, cluster *types.Cluster) ([]byte, error) {
 	stdOut, err := c.Execute(
 		ctx, "get", "kubeconfig", clusterName,
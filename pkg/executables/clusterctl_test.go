@@ -296,6 +296,33 @@ func TestClusterctlMoveManagement(t *testing.T) {
 	}
 }
 
+func TestClusterctlBackupManagement(t *testing.T) {
+	tt := newClusterctlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx, "move", "--to-directory", "backup", "--namespace", constants.EksaSystemNamespace, "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.clusterctl.BackupManagement(tt.ctx, tt.cluster, "backup")).To(Succeed())
+}
+
+func TestClusterctlBackupManagementError(t *testing.T) {
+	tt := newClusterctlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx, "move", "--to-directory", "backup", "--namespace", constants.EksaSystemNamespace, "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, errors.New("error in clusterctl"))
+
+	tt.Expect(tt.clusterctl.BackupManagement(tt.ctx, tt.cluster, "backup")).To(MatchError(ContainSubstring("failed backing up management cluster")))
+}
+
+func TestClusterctlRestoreManagement(t *testing.T) {
+	tt := newClusterctlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx, "move", "--from-directory", "backup", "--namespace", constants.EksaSystemNamespace, "--to-kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.clusterctl.RestoreManagement(tt.ctx, tt.cluster, "backup")).To(Succeed())
+}
+
 func TestClusterctlUpgradeAllProvidersSucess(t *testing.T) {
 	tt := newClusterctlTest(t)
 
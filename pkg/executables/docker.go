@@ -1,6 +1,7 @@
 package executables
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strconv"
@@ -98,3 +99,8 @@ func (d *Docker) Login(ctx context.Context, endpoint, username, password string)
 	_, err := d.ExecuteWithStdin(ctx, []byte(password), params...)
 	return err
 }
+
+// Exec runs a shell command inside a running container, passing stdin to it
+func (d *Docker) Exec(ctx context.Context, container, command string, stdin []byte) (bytes.Buffer, error) {
+	return d.ExecuteWithStdin(ctx, stdin, "exec", "-i", container, "sh", "-c", command)
+}
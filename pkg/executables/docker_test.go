@@ -78,3 +78,19 @@ func TestDockerAllocatedMemory(t *testing.T) {
 		t.Fatalf("Docker.AllocatedMemory() error = %v, want %v", err, mem)
 	}
 }
+
+func TestDockerExec(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().ExecuteWithStdin(ctx, []byte("input"), "exec", "-i", "container", "sh", "-c", "cat > file").Return(*bytes.NewBufferString("output"), nil)
+	d := executables.NewDocker(executable)
+	out, err := d.Exec(ctx, "container", "cat > file", []byte("input"))
+	if err != nil {
+		t.Fatalf("Docker.Exec() error = %v, want nil", err)
+	}
+	if out.String() != "output" {
+		t.Fatalf("Docker.Exec() out = %s, want output", out.String())
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/aws/eks-anywhere/pkg/git/providers/generic"
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
@@ -16,7 +17,9 @@ const (
 	redactMask = "*****"
 )
 
-var redactedEnvKeys = []string{vSphereUsernameKey, vSpherePasswordKey}
+// redactedEnvKeys are the env vars whose values are masked when logging commands. The git password and ssh key
+// passphrase are passed as args to flux bootstrap, which doesn't read them from the env
+var redactedEnvKeys = []string{vSphereUsernameKey, vSpherePasswordKey, generic.EksaGitPasswordEnv, generic.EksaGitPrivateKeyPassphraseEnv}
 
 type executable struct {
 	cli string
@@ -67,7 +70,7 @@ func (e *executable) Close(ctx context.Context) error {
 func redactCreds(cmd string) string {
 	redactedEnvs := []string{}
 	for _, redactedEnvKey := range redactedEnvKeys {
-		if env, found := os.LookupEnv(redactedEnvKey); found {
+		if env, found := os.LookupEnv(redactedEnvKey); found && env != "" {
 			redactedEnvs = append(redactedEnvs, env)
 		}
 	}
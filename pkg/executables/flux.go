@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git/providers/generic"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -30,6 +32,13 @@ func NewFlux(executable Executable) *Flux {
 // components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
 // If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
 func (f *Flux) BootstrapToolkitsComponents(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error {
+	if gitOpsConfig.Spec.Flux.IsGit() {
+		return f.bootstrapGit(ctx, cluster, gitOpsConfig.Spec.Flux.Git)
+	}
+	if gitOpsConfig.Spec.Flux.IsGitlab() {
+		return f.bootstrapGitlab(ctx, cluster, gitOpsConfig.Spec.Flux.Gitlab)
+	}
+
 	c := gitOpsConfig.Spec.Flux.Github
 	params := []string{
 		"bootstrap",
@@ -69,19 +78,101 @@ func (f *Flux) BootstrapToolkitsComponents(ctx context.Context, cluster *types.C
 	return err
 }
 
-func (f *Flux) UninstallToolkitsComponents(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error {
-	c := gitOpsConfig.Spec.Flux.Github
+// bootstrapGit commits the toolkit components manifests to a repository in any Git server, which must exist.
+// Flux uses the same credentials as the CLI to sync the repository
+func (f *Flux) bootstrapGit(ctx context.Context, cluster *types.Cluster, c *v1alpha1.GitProviderConfig) error {
 	params := []string{
-		"uninstall",
+		"bootstrap",
+		generic.GitProviderName,
+		"--url", c.RepositoryUrl,
+		"--path", c.ClusterConfigPath,
 		"--silent",
 	}
+
 	if cluster.KubeconfigFile != "" {
 		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
 	}
+	if c.Branch != "" {
+		params = append(params, "--branch", c.Branch)
+	}
 	if c.FluxSystemNamespace != "" {
 		params = append(params, "--namespace", c.FluxSystemNamespace)
 	}
 
+	auth, err := generic.GetAuthFromEnv(c.RepositoryUrl)
+	if err != nil {
+		return fmt.Errorf("error getting git credentials: %v", err)
+	}
+	if auth.PrivateKeyFile != "" {
+		params = append(params, "--private-key-file", auth.PrivateKeyFile)
+		if auth.PrivateKeyPassphrase != "" {
+			params = append(params, "--password", auth.PrivateKeyPassphrase)
+		}
+	} else if auth.Username != "" {
+		params = append(params, "--username", auth.Username, "--password", auth.Password, "--token-auth")
+	}
+
+	_, err = f.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("error executing flux bootstrap: %v", err)
+	}
+
+	return err
+}
+
+func (f *Flux) bootstrapGitlab(ctx context.Context, cluster *types.Cluster, c *v1alpha1.Gitlab) error {
+	params := []string{
+		"bootstrap",
+		gitlab.GitProviderName,
+		"--repository", c.Repository,
+		"--owner", c.Owner,
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", privateKeyAlgorithm,
+	}
+
+	if c.Hostname != "" {
+		params = append(params, "--hostname", c.Hostname)
+	}
+	if cluster.KubeconfigFile != "" {
+		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
+	}
+	if c.Personal {
+		params = append(params, "--personal")
+	}
+	if c.Branch != "" {
+		params = append(params, "--branch", c.Branch)
+	}
+	if c.FluxSystemNamespace != "" {
+		params = append(params, "--namespace", c.FluxSystemNamespace)
+	}
+
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("error setting token env: %v", err)
+	}
+
+	env := map[string]string{gitlab.GitlabTokenEnv: token}
+	_, err = f.ExecuteWithEnv(ctx, env, params...)
+	if err != nil {
+		return fmt.Errorf("error executing flux bootstrap: %v", err)
+	}
+
+	return err
+}
+
+func (f *Flux) UninstallToolkitsComponents(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error {
+	namespace := gitOpsConfig.Spec.Flux.SystemNamespace()
+	params := []string{
+		"uninstall",
+		"--silent",
+	}
+	if cluster.KubeconfigFile != "" {
+		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
+	}
+	if namespace != "" {
+		params = append(params, "--namespace", namespace)
+	}
+
 	_, err := f.Execute(ctx, params...)
 	if err != nil {
 		return fmt.Errorf("error uninstalling flux: %v", err)
@@ -90,11 +181,11 @@ func (f *Flux) UninstallToolkitsComponents(ctx context.Context, cluster *types.C
 }
 
 func (f *Flux) PauseKustomization(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error {
-	c := gitOpsConfig.Spec.Flux.Github
-	if c.FluxSystemNamespace == "" {
+	namespace := gitOpsConfig.Spec.Flux.SystemNamespace()
+	if namespace == "" {
 		return fmt.Errorf("error executing flux suspend kustomization: namespace empty")
 	}
-	params := []string{"suspend", "ks", c.FluxSystemNamespace, "--namespace", c.FluxSystemNamespace}
+	params := []string{"suspend", "ks", namespace, "--namespace", namespace}
 
 	if cluster.KubeconfigFile != "" {
 		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
@@ -109,11 +200,11 @@ func (f *Flux) PauseKustomization(ctx context.Context, cluster *types.Cluster, g
 }
 
 func (f *Flux) ResumeKustomization(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error {
-	c := gitOpsConfig.Spec.Flux.Github
-	if c.FluxSystemNamespace == "" {
+	namespace := gitOpsConfig.Spec.Flux.SystemNamespace()
+	if namespace == "" {
 		return fmt.Errorf("error executing flux resume kustomization: namespace empty")
 	}
-	params := []string{"resume", "ks", c.FluxSystemNamespace, "--namespace", c.FluxSystemNamespace}
+	params := []string{"resume", "ks", namespace, "--namespace", namespace}
 
 	if cluster.KubeconfigFile != "" {
 		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
@@ -128,11 +219,11 @@ func (f *Flux) ResumeKustomization(ctx context.Context, cluster *types.Cluster,
 }
 
 func (f *Flux) Reconcile(ctx context.Context, cluster *types.Cluster, gitOpsConfig *v1alpha1.GitOpsConfig) error {
-	c := gitOpsConfig.Spec.Flux.Github
+	namespace := gitOpsConfig.Spec.Flux.SystemNamespace()
 	params := []string{"reconcile", "source", "git"}
 
-	if c.FluxSystemNamespace != "" {
-		params = append(params, c.FluxSystemNamespace, "--namespace", c.FluxSystemNamespace)
+	if namespace != "" {
+		params = append(params, namespace, "--namespace", namespace)
 	} else {
 		params = append(params, "flux-system")
 	}
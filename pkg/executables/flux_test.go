@@ -3,7 +3,9 @@ package executables_test
 import (
 	"bytes"
 	"context"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -155,6 +157,168 @@ func TestFluxInstallGitOpsToolkitsSuccess(t *testing.T) {
 	}
 }
 
+func TestFluxInstallGitOpsToolkitsGitSuccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	privateKeyFile := filepath.Join(t.TempDir(), "id_ecdsa")
+	if err := ioutil.WriteFile(privateKeyFile, []byte("key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	path := "clusters/cluster-name"
+
+	tests := []struct {
+		testName     string
+		env          map[string]string
+		gitConfig    *v1alpha1.GitProviderConfig
+		wantExecArgs []interface{}
+	}{
+		{
+			testName: "local repository",
+			gitConfig: &v1alpha1.GitProviderConfig{
+				RepositoryUrl:       "file:///repos/gitops-fleet.git",
+				ClusterConfigPath:   path,
+				Branch:              "main",
+				FluxSystemNamespace: "flux-system",
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", "git", "--url", "file:///repos/gitops-fleet.git", "--path", path, "--silent", "--branch", "main", "--namespace", "flux-system",
+			},
+		},
+		{
+			testName: "ssh",
+			env:      map[string]string{"EKSA_GIT_PRIVATE_KEY": privateKeyFile, "EKSA_GIT_SSH_KEY_PASSPHRASE": "passphrase"},
+			gitConfig: &v1alpha1.GitProviderConfig{
+				RepositoryUrl:     "ssh://git@git.example.com/janedoe/gitops-fleet.git",
+				ClusterConfigPath: path,
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", "git", "--url", "ssh://git@git.example.com/janedoe/gitops-fleet.git", "--path", path, "--silent",
+				"--private-key-file", privateKeyFile, "--password", "passphrase",
+			},
+		},
+		{
+			testName: "https",
+			env:      map[string]string{"EKSA_GIT_USERNAME": "janedoe", "EKSA_GIT_PASSWORD": "password"},
+			gitConfig: &v1alpha1.GitProviderConfig{
+				RepositoryUrl:     "https://git.example.com/janedoe/gitops-fleet.git",
+				ClusterConfigPath: path,
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", "git", "--url", "https://git.example.com/janedoe/gitops-fleet.git", "--path", path, "--silent",
+				"--username", "janedoe", "--password", "password", "--token-auth",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+			ctx := context.Background()
+			executable := mockexecutables.NewMockExecutable(mockCtrl)
+			gitOpsConfig := v1alpha1.GitOpsConfig{
+				Spec: v1alpha1.GitOpsConfigSpec{
+					Flux: v1alpha1.Flux{Git: tt.gitConfig},
+				},
+			}
+
+			executable.EXPECT().Execute(ctx, tt.wantExecArgs...).Return(bytes.Buffer{}, nil)
+
+			f := executables.NewFlux(executable)
+			if err := f.BootstrapToolkitsComponents(ctx, &types.Cluster{}, &gitOpsConfig); err != nil {
+				t.Errorf("flux.BootstrapToolkitsComponents() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestFluxInstallGitOpsToolkitsGitMissingPrivateKey(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	gitOpsConfig := v1alpha1.GitOpsConfig{
+		Spec: v1alpha1.GitOpsConfigSpec{
+			Flux: v1alpha1.Flux{
+				Git: &v1alpha1.GitProviderConfig{RepositoryUrl: "git@git.example.com:janedoe/gitops-fleet.git"},
+			},
+		},
+	}
+
+	f := executables.NewFlux(executable)
+	if err := f.BootstrapToolkitsComponents(ctx, &types.Cluster{}, &gitOpsConfig); err == nil {
+		t.Error("flux.BootstrapToolkitsComponents() error = nil, want not nil")
+	}
+}
+
+func TestFluxInstallGitOpsToolkitsGitlabSuccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	os.Setenv("EKSA_GITLAB_TOKEN", "glpat-token")
+	defer os.Unsetenv("EKSA_GITLAB_TOKEN")
+	defer os.Unsetenv("GITLAB_TOKEN")
+
+	path := "clusters/cluster-name"
+
+	tests := []struct {
+		testName     string
+		cluster      *types.Cluster
+		gitlabConfig *v1alpha1.Gitlab
+		wantExecArgs []interface{}
+	}{
+		{
+			testName: "gitlab.com personal",
+			cluster:  &types.Cluster{KubeconfigFile: "f.kubeconfig"},
+			gitlabConfig: &v1alpha1.Gitlab{
+				Owner:             "janedoe",
+				Repository:        "gitops-fleet",
+				ClusterConfigPath: path,
+				Personal:          true,
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", "gitlab", "--repository", "gitops-fleet", "--owner", "janedoe", "--path", path, "--ssh-key-algorithm", "ecdsa",
+				"--kubeconfig", "f.kubeconfig", "--personal",
+			},
+		},
+		{
+			testName: "self-managed group",
+			cluster:  &types.Cluster{},
+			gitlabConfig: &v1alpha1.Gitlab{
+				Hostname:            "gitlab.example.com",
+				Owner:               "platform/clusters",
+				Repository:          "gitops-fleet",
+				ClusterConfigPath:   path,
+				Branch:              "main",
+				FluxSystemNamespace: "flux-system",
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", "gitlab", "--repository", "gitops-fleet", "--owner", "platform/clusters", "--path", path, "--ssh-key-algorithm", "ecdsa",
+				"--hostname", "gitlab.example.com", "--branch", "main", "--namespace", "flux-system",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctx := context.Background()
+			executable := mockexecutables.NewMockExecutable(mockCtrl)
+			env := map[string]string{"GITLAB_TOKEN": "glpat-token"}
+			gitOpsConfig := v1alpha1.GitOpsConfig{
+				Spec: v1alpha1.GitOpsConfigSpec{
+					Flux: v1alpha1.Flux{Gitlab: tt.gitlabConfig},
+				},
+			}
+
+			executable.EXPECT().ExecuteWithEnv(ctx, env, tt.wantExecArgs...).Return(bytes.Buffer{}, nil)
+
+			f := executables.NewFlux(executable)
+			if err := f.BootstrapToolkitsComponents(ctx, tt.cluster, &gitOpsConfig); err != nil {
+				t.Errorf("flux.BootstrapToolkitsComponents() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
 func TestFluxUninstallGitOpsToolkitsComponents(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 
@@ -12,6 +12,9 @@ import (
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1alpha3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1alpha3"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
@@ -339,21 +342,33 @@ func (k *Kubectl) ValidateControlPlaneNodes(ctx context.Context, cluster *types.
 
 func (k *Kubectl) ValidateWorkerNodes(ctx context.Context, cluster *types.Cluster, clusterName string) error {
 	logger.V(6).Info("waiting for nodes", "cluster", clusterName)
-	md, err := k.GetMachineDeployment(ctx, cluster, clusterName, WithCluster(cluster), WithNamespace(constants.EksaSystemNamespace))
+	mds, err := k.GetMachineDeployments(ctx, WithCluster(cluster), WithNamespace(constants.EksaSystemNamespace))
 	if err != nil {
 		return err
 	}
 
-	if md.Status.Phase != "Running" {
-		return fmt.Errorf("machine deployment is in %s phase", md.Status.Phase)
-	}
+	found := false
+	for _, md := range mds {
+		if md.Spec.ClusterName != clusterName {
+			continue
+		}
+		found = true
+
+		if md.Status.Phase != "Running" {
+			return fmt.Errorf("machine deployment %s is in %s phase", md.Name, md.Status.Phase)
+		}
 
-	if md.Status.UnavailableReplicas != 0 {
-		return fmt.Errorf("%v machine deployment replicas are unavailable", md.Status.UnavailableReplicas)
+		if md.Status.UnavailableReplicas != 0 {
+			return fmt.Errorf("%v machine deployment %s replicas are unavailable", md.Status.UnavailableReplicas, md.Name)
+		}
+
+		if md.Status.ReadyReplicas != md.Status.Replicas {
+			return fmt.Errorf("%v machine deployment %s replicas are not ready", md.Status.Replicas-md.Status.ReadyReplicas, md.Name)
+		}
 	}
 
-	if md.Status.ReadyReplicas != md.Status.Replicas {
-		return fmt.Errorf("%v machine deployment replicas are not ready", md.Status.Replicas-md.Status.ReadyReplicas)
+	if !found {
+		return fmt.Errorf("no machine deployments found for cluster %s", clusterName)
 	}
 	return nil
 }
@@ -626,6 +641,23 @@ func (k *Kubectl) GetDeployments(ctx context.Context, opts ...KubectlOpt) ([]app
 	return response.Items, nil
 }
 
+func (k *Kubectl) GetPodDisruptionBudgets(ctx context.Context, opts ...KubectlOpt) ([]policyv1beta1.PodDisruptionBudget, error) {
+	params := []string{"get", "poddisruptionbudgets", "-o", "json"}
+	applyOpts(&params, opts...)
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pod disruption budgets: %v", err)
+	}
+
+	response := &policyv1beta1.PodDisruptionBudgetList{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing get pod disruption budgets response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
 func (k *Kubectl) GetSecretFromNamespace(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.Secret, error) {
 	return k.GetSecret(ctx, name, WithKubeconfig(kubeconfigFile), WithNamespace(namespace))
 }
@@ -680,8 +712,8 @@ func (k *Kubectl) GetKubeadmControlPlane(ctx context.Context, cluster *types.Clu
 	return response, nil
 }
 
-func (k *Kubectl) GetMachineDeployment(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...KubectlOpt) (*clusterv1.MachineDeployment, error) {
-	params := []string{"get", fmt.Sprintf("machinedeployments.%s", clusterv1.GroupVersion.Group), fmt.Sprintf("%s-md-0", clusterName), "-o", "json"}
+func (k *Kubectl) GetMachineDeployment(ctx context.Context, cluster *types.Cluster, machineDeploymentName string, opts ...KubectlOpt) (*clusterv1.MachineDeployment, error) {
+	params := []string{"get", fmt.Sprintf("machinedeployments.%s", clusterv1.GroupVersion.Group), machineDeploymentName, "-o", "json"}
 	applyOpts(&params, opts...)
 	stdOut, err := k.Execute(ctx, params...)
 	if err != nil {
@@ -714,6 +746,124 @@ func (k *Kubectl) GetMachineDeployments(ctx context.Context, opts ...KubectlOpt)
 	return response.Items, nil
 }
 
+// GetMachineDeploymentsForCluster returns the MachineDeployments of the worker node groups of a cluster
+func (k *Kubectl) GetMachineDeploymentsForCluster(ctx context.Context, cluster *types.Cluster, clusterName string) ([]clusterv1.MachineDeployment, error) {
+	mds, err := k.GetMachineDeployments(ctx, WithCluster(cluster), WithNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		return nil, err
+	}
+
+	clusterMDs := make([]clusterv1.MachineDeployment, 0, len(mds))
+	for _, md := range mds {
+		if md.Spec.ClusterName == clusterName {
+			clusterMDs = append(clusterMDs, md)
+		}
+	}
+	return clusterMDs, nil
+}
+
+// DeleteOldWorkerNodeGroup deletes the MachineDeployment of a worker node group that was removed from the cluster spec,
+// together with the bootstrap config and machine templates it references
+func (k *Kubectl) DeleteOldWorkerNodeGroup(ctx context.Context, md *clusterv1.MachineDeployment, kubeconfig string) error {
+	kind := fmt.Sprintf("machinedeployments.%s", clusterv1.GroupVersion.Group)
+	if err := k.deleteResource(ctx, kind, md.Name, md.Namespace, kubeconfig); err != nil {
+		return err
+	}
+
+	refs := []corev1.ObjectReference{md.Spec.Template.Spec.InfrastructureRef}
+	if md.Spec.Template.Spec.Bootstrap.ConfigRef != nil {
+		refs = append(refs, *md.Spec.Template.Spec.Bootstrap.ConfigRef)
+	}
+	for _, ref := range refs {
+		if ref.Name == "" {
+			continue
+		}
+		if err := k.deleteResource(ctx, resourceTypeForRef(ref), ref.Name, md.Namespace, kubeconfig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceTypeForRef returns the kind of the referenced object qualified with its group, so kubectl
+// doesn't mix up kinds with the same name from different API groups
+func resourceTypeForRef(ref corev1.ObjectReference) string {
+	kind := strings.ToLower(ref.Kind)
+	if gv, err := schema.ParseGroupVersion(ref.APIVersion); err == nil && gv.Group != "" {
+		kind = fmt.Sprintf("%s.%s", kind, gv.Group)
+	}
+	return kind
+}
+
+// GetObjectByRef returns the object referenced by ref, it's useful for provider objects, like machine templates,
+// that don't have a type in this package
+func (k *Kubectl) GetObjectByRef(ctx context.Context, ref corev1.ObjectReference, namespace, kubeconfig string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	if err := k.getObject(ctx, resourceTypeForRef(ref), ref.Name, namespace, kubeconfig, obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// ListObjects returns all the objects of a resource type in a namespace, or in all namespaces when namespace is empty
+func (k *Kubectl) ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string) ([]unstructured.Unstructured, error) {
+	params := []string{"get", resourceType, "-o", "json", "--kubeconfig", kubeconfig}
+	if namespace == "" {
+		params = append(params, "--all-namespaces")
+	} else {
+		params = append(params, "--namespace", namespace)
+	}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s: %v", resourceType, err)
+	}
+
+	response := &unstructured.UnstructuredList{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing %s response: %v", resourceType, err)
+	}
+
+	return response.Items, nil
+}
+
+// MergePatchResource updates a resource with a JSON merge patch
+func (k *Kubectl) MergePatchResource(ctx context.Context, resourceType, name, patch, kubeconfig, namespace string) error {
+	params := []string{"patch", resourceType, name, "--type=merge", "-p", patch, "--kubeconfig", kubeconfig, "--namespace", namespace}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error patching %s %s: %v", resourceType, name, err)
+	}
+	return nil
+}
+
+// DeleteResource deletes an object of any resource type, it doesn't fail if the object doesn't exist
+func (k *Kubectl) DeleteResource(ctx context.Context, resourceType, name, kubeconfig, namespace string) error {
+	return k.deleteResource(ctx, resourceType, name, namespace, kubeconfig)
+}
+
+func (k *Kubectl) GetNodes(ctx context.Context, kubeconfig string) ([]corev1.Node, error) {
+	stdOut, err := k.Execute(ctx, "get", "nodes", "-o", "json", "--kubeconfig", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error getting nodes: %v", err)
+	}
+
+	response := &corev1.NodeList{}
+	if err = json.Unmarshal(stdOut.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing get nodes response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
+func (k *Kubectl) deleteResource(ctx context.Context, kind, name, namespace, kubeconfig string) error {
+	params := []string{"delete", kind, name, "--kubeconfig", kubeconfig, "--namespace", namespace, "--ignore-not-found=true"}
+	if _, err := k.Execute(ctx, params...); err != nil {
+		return fmt.Errorf("error deleting %s %s: %v", kind, name, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) UpdateEnvironmentVariables(ctx context.Context, resourceType, resourceName string, envMap map[string]string, opts ...KubectlOpt) error {
 	params := []string{"set", "env", resourceType, resourceName}
 	for k, v := range envMap {
@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	addons "sigs.k8s.io/cluster-api/exp/addons/api/v1alpha3"
@@ -348,65 +350,100 @@ func TestKubectlGetMachines(t *testing.T) {
 			jsonResponseFile: "testdata/kubectl_machines_no_node_ref_no_labels.json",
 			wantMachines: []types.Machine{
 				{
+					Metadata: types.MachineMetadata{
+						Name:              "eksa-test-capd-control-plane-5nfdg",
+						CreationTimestamp: time.Date(2021, 5, 20, 19, 20, 12, 0, time.UTC),
+					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-control-plane-mrtzr",
+						},
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
-								Status: "True",
-								Type:   "Ready",
+								Status:             "True",
+								Type:               "Ready",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "APIServerPodHealthy",
+								Status:             "True",
+								Type:               "APIServerPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 15, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "BootstrapReady",
+								Status:             "True",
+								Type:               "BootstrapReady",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "ControllerManagerPodHealthy",
+								Status:             "True",
+								Type:               "ControllerManagerPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 40, 18, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "EtcdMemberHealthy",
+								Status:             "True",
+								Type:               "EtcdMemberHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 15, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "EtcdPodHealthy",
+								Status:             "True",
+								Type:               "EtcdPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 15, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "InfrastructureReady",
+								Status:             "True",
+								Type:               "InfrastructureReady",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "NodeHealthy",
+								Status:             "True",
+								Type:               "NodeHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "SchedulerPodHealthy",
+								Status:             "True",
+								Type:               "SchedulerPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 40, 18, 0, time.UTC),
 							},
 						},
 					},
 				},
 				{
+					Metadata: types.MachineMetadata{
+						Name:              "eksa-test-capd-md-0-bb7885f6f-gkb85",
+						CreationTimestamp: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
+					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-md-0-8xltl",
+						},
+					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
-								Status: "True",
-								Type:   "Ready",
+								Status:             "True",
+								Type:               "Ready",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "BootstrapReady",
+								Status:             "True",
+								Type:               "BootstrapReady",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "InfrastructureReady",
+								Status:             "True",
+								Type:               "InfrastructureReady",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "NodeHealthy",
+								Status:             "True",
+								Type:               "NodeHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 						},
 					},
@@ -419,10 +456,19 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":  "eksa-test-capd",
 							"cluster.x-k8s.io/control-plane": "",
 						},
+						CreationTimestamp: time.Date(2021, 5, 20, 19, 20, 12, 0, time.UTC),
+					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-control-plane-mrtzr",
+						},
 					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
@@ -434,11 +480,20 @@ func TestKubectlGetMachines(t *testing.T) {
 				},
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":    "eksa-test-capd",
 							"cluster.x-k8s.io/deployment-name": "eksa-test-capd-md-0",
 							"machine-template-hash":            "663441929",
 						},
+						CreationTimestamp: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
+					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-md-0-8xltl",
+						},
 					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
@@ -456,10 +511,19 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":  "eksa-test-capd",
 							"cluster.x-k8s.io/control-plane": "",
 						},
+						CreationTimestamp: time.Date(2021, 5, 20, 19, 20, 12, 0, time.UTC),
+					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-control-plane-mrtzr",
+						},
 					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
@@ -469,51 +533,69 @@ func TestKubectlGetMachines(t *testing.T) {
 						},
 						Conditions: types.Conditions{
 							{
-								Status: "True",
-								Type:   "Ready",
+								Status:             "True",
+								Type:               "Ready",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "APIServerPodHealthy",
+								Status:             "True",
+								Type:               "APIServerPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 15, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "BootstrapReady",
+								Status:             "True",
+								Type:               "BootstrapReady",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "ControllerManagerPodHealthy",
+								Status:             "True",
+								Type:               "ControllerManagerPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 40, 18, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "EtcdMemberHealthy",
+								Status:             "True",
+								Type:               "EtcdMemberHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 15, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "EtcdPodHealthy",
+								Status:             "True",
+								Type:               "EtcdPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 15, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "InfrastructureReady",
+								Status:             "True",
+								Type:               "InfrastructureReady",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "NodeHealthy",
+								Status:             "True",
+								Type:               "NodeHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "SchedulerPodHealthy",
+								Status:             "True",
+								Type:               "SchedulerPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 40, 18, 0, time.UTC),
 							},
 						},
 					},
 				},
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-md-0-bb7885f6f-gkb85",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name":    "eksa-test-capd",
 							"cluster.x-k8s.io/deployment-name": "eksa-test-capd-md-0",
 							"machine-template-hash":            "663441929",
 						},
+						CreationTimestamp: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
+					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-md-0-8xltl",
+						},
 					},
 					Status: types.MachineStatus{
 						NodeRef: &types.ResourceRef{
@@ -523,20 +605,24 @@ func TestKubectlGetMachines(t *testing.T) {
 						},
 						Conditions: types.Conditions{
 							{
-								Status: "True",
-								Type:   "Ready",
+								Status:             "True",
+								Type:               "Ready",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "BootstrapReady",
+								Status:             "True",
+								Type:               "BootstrapReady",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "InfrastructureReady",
+								Status:             "True",
+								Type:               "InfrastructureReady",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "NodeHealthy",
+								Status:             "True",
+								Type:               "NodeHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 						},
 					},
@@ -549,48 +635,66 @@ func TestKubectlGetMachines(t *testing.T) {
 			wantMachines: []types.Machine{
 				{
 					Metadata: types.MachineMetadata{
+						Name: "eksa-test-capd-control-plane-5nfdg",
 						Labels: map[string]string{
 							"cluster.x-k8s.io/cluster-name": "eksa-test-capd",
 							"cluster.x-k8s.io/etcd-cluster": "",
 						},
+						CreationTimestamp: time.Date(2021, 5, 20, 19, 20, 12, 0, time.UTC),
+					},
+					Spec: types.MachineSpec{
+						InfrastructureRef: types.ResourceRef{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+							Kind:       "DockerMachine",
+							Name:       "eksa-test-capd-control-plane-mrtzr",
+						},
 					},
 					Status: types.MachineStatus{
 						Conditions: types.Conditions{
 							{
-								Status: "True",
-								Type:   "Ready",
+								Status:             "True",
+								Type:               "Ready",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "APIServerPodHealthy",
+								Status:             "True",
+								Type:               "APIServerPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 15, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "BootstrapReady",
+								Status:             "True",
+								Type:               "BootstrapReady",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "ControllerManagerPodHealthy",
+								Status:             "True",
+								Type:               "ControllerManagerPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 40, 18, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "EtcdMemberHealthy",
+								Status:             "True",
+								Type:               "EtcdMemberHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 15, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "EtcdPodHealthy",
+								Status:             "True",
+								Type:               "EtcdPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 15, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "InfrastructureReady",
+								Status:             "True",
+								Type:               "InfrastructureReady",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "NodeHealthy",
+								Status:             "True",
+								Type:               "NodeHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 20, 13, 0, time.UTC),
 							},
 							{
-								Status: "True",
-								Type:   "SchedulerPodHealthy",
+								Status:             "True",
+								Type:               "SchedulerPodHealthy",
+								LastTransitionTime: time.Date(2021, 5, 20, 19, 40, 18, 0, time.UTC),
 							},
 						},
 					},
@@ -997,6 +1101,21 @@ func TestKubectlGetDeploymentsWithAllNamespaces(t *testing.T) {
 	}
 }
 
+func TestKubectlGetPodDisruptionBudgetsWithAllNamespaces(t *testing.T) {
+	k, ctx, cluster, e := newKubectl(t)
+	fileContent := test.ReadFile(t, "testdata/kubectl_pdbs.json")
+	e.EXPECT().Execute(ctx, []string{"get", "poddisruptionbudgets", "-o", "json", "--kubeconfig", cluster.KubeconfigFile, "-A"}).Return(*bytes.NewBufferString(fileContent), nil)
+
+	gotPDBs, err := k.GetPodDisruptionBudgets(ctx, executables.WithCluster(cluster), executables.WithAllNamespaces())
+	if err != nil {
+		t.Fatalf("Kubectl.GetPodDisruptionBudgets() error = %v, want nil", err)
+	}
+
+	if len(gotPDBs) != 1 || gotPDBs[0].Name != "web" || gotPDBs[0].Status.DisruptionsAllowed != 0 || gotPDBs[0].Status.ExpectedPods != 1 {
+		t.Fatalf("Kubectl.GetPodDisruptionBudgets() pdbs = %+v, want pdb web with 0 disruptions allowed", gotPDBs)
+	}
+}
+
 func TestKubectlGetDeploymentsWithServerSkipTLSAndToken(t *testing.T) {
 	server := "https://127.0.0.1:37479"
 	token := "token"
@@ -1066,6 +1185,38 @@ func TestKubectlGetDeploymentsWithNamespace(t *testing.T) {
 	}
 }
 
+func TestKubectlValidateWorkerNodes(t *testing.T) {
+	tests := []struct {
+		testName    string
+		clusterName string
+		wantErr     bool
+	}{
+		{
+			testName:    "machine deployments ready",
+			clusterName: "test0",
+			wantErr:     false,
+		},
+		{
+			testName:    "no machine deployments for cluster",
+			clusterName: "test2",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			k, ctx, cluster, e := newKubectl(t)
+			fileContent := test.ReadFile(t, "testdata/kubectl_machine_deployments.json")
+			e.EXPECT().Execute(ctx, []string{"get", "machinedeployments.cluster.x-k8s.io", "-o", "json", "--kubeconfig", cluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace}).Return(*bytes.NewBufferString(fileContent), nil)
+
+			err := k.ValidateWorkerNodes(ctx, cluster, tt.clusterName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Kubectl.ValidateWorkerNodes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestKubectlGetMachineDeployments(t *testing.T) {
 	tests := []struct {
 		testName                   string
@@ -1110,6 +1261,43 @@ func TestKubectlGetMachineDeployments(t *testing.T) {
 	}
 }
 
+func TestKubectlDeleteOldWorkerNodeGroup(t *testing.T) {
+	k, ctx, cluster, e := newKubectl(t)
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ingress", Namespace: constants.EksaSystemNamespace},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &corev1.ObjectReference{
+							APIVersion: "bootstrap.cluster.x-k8s.io/v1alpha3",
+							Kind:       "KubeadmConfigTemplate",
+							Name:       "test-ingress",
+						},
+					},
+					InfrastructureRef: corev1.ObjectReference{
+						APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+						Kind:       "VSphereMachineTemplate",
+						Name:       "test-ingress-1234567890000",
+					},
+				},
+			},
+		},
+	}
+
+	for _, resource := range [][]string{
+		{"machinedeployments.cluster.x-k8s.io", "test-ingress"},
+		{"vspheremachinetemplate.infrastructure.cluster.x-k8s.io", "test-ingress-1234567890000"},
+		{"kubeadmconfigtemplate.bootstrap.cluster.x-k8s.io", "test-ingress"},
+	} {
+		e.EXPECT().Execute(ctx, []string{"delete", resource[0], resource[1], "--kubeconfig", cluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace, "--ignore-not-found=true"}).Return(bytes.Buffer{}, nil)
+	}
+
+	if err := k.DeleteOldWorkerNodeGroup(ctx, md, cluster.KubeconfigFile); err != nil {
+		t.Fatalf("Kubectl.DeleteOldWorkerNodeGroup() error = %v, want nil", err)
+	}
+}
+
 func TestKubectlGetKubeAdmControlPlanes(t *testing.T) {
 	tests := []struct {
 		testName         string
@@ -1483,3 +1671,102 @@ func TestKubectlGetDaemonSetError(t *testing.T) {
 		return tt.k.GetDaemonSet(tt.ctx, tt.name, tt.namespace, tt.kubeconfig)
 	}).testError()
 }
+
+func TestKubectlGetObjectByRefSuccess(t *testing.T) {
+	newKubectlGetterTest(t).withResourceType(
+		"vspheremachinetemplate.infrastructure.cluster.x-k8s.io",
+	).withGetter(func(tt *kubectlGetterTest) (client.Object, error) {
+		ref := corev1.ObjectReference{
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1alpha3",
+			Kind:       "VSphereMachineTemplate",
+			Name:       tt.name,
+		}
+		return tt.k.GetObjectByRef(tt.ctx, ref, tt.namespace, tt.kubeconfig)
+	}).withJson(
+		`{"apiVersion":"infrastructure.cluster.x-k8s.io/v1alpha3","kind":"VSphereMachineTemplate","metadata":{"name":"name"},"spec":{"template":{"spec":{"numCPUs":2}}}}`,
+	).andWant(
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1alpha3",
+				"kind":       "VSphereMachineTemplate",
+				"metadata": map[string]interface{}{
+					"name": "name",
+				},
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"numCPUs": int64(2),
+						},
+					},
+				},
+			},
+		},
+	).testSuccess()
+}
+
+func TestKubectlListObjectsAllNamespaces(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "clusters.anywhere.eks.amazonaws.com", "-o", "json", "--kubeconfig", tt.kubeconfig, "--all-namespaces",
+	).Return(*bytes.NewBufferString(`{"apiVersion":"v1","kind":"List","items":[{"apiVersion":"anywhere.eks.amazonaws.com/v1alpha1","kind":"Cluster","metadata":{"name":"mgmt","namespace":"default"}}]}`), nil)
+
+	objs, err := tt.k.ListObjects(tt.ctx, "clusters.anywhere.eks.amazonaws.com", "", tt.kubeconfig)
+	tt.Expect(err).To(Not(HaveOccurred()))
+	tt.Expect(objs).To(HaveLen(1))
+	tt.Expect(objs[0].GetKind()).To(Equal("Cluster"))
+	tt.Expect(objs[0].GetName()).To(Equal("mgmt"))
+	tt.Expect(objs[0].GetNamespace()).To(Equal("default"))
+}
+
+func TestKubectlListObjectsInNamespace(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "secrets", "-o", "json", "--kubeconfig", tt.kubeconfig, "--namespace", tt.namespace,
+	).Return(*bytes.NewBufferString(`{"apiVersion":"v1","kind":"List","items":[]}`), nil)
+
+	objs, err := tt.k.ListObjects(tt.ctx, "secrets", tt.namespace, tt.kubeconfig)
+	tt.Expect(err).To(Not(HaveOccurred()))
+	tt.Expect(objs).To(BeEmpty())
+}
+
+func TestKubectlMergePatchResource(t *testing.T) {
+	tt := newKubectlTest(t)
+	patch := `{"spec":{"upgradeAfter":"2021-11-05T10:00:00Z"}}`
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"patch", "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "test-cluster", "--type=merge", "-p", patch, "--kubeconfig", tt.kubeconfig, "--namespace", tt.namespace,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.MergePatchResource(tt.ctx, "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "test-cluster", patch, tt.kubeconfig, tt.namespace)).To(Succeed())
+}
+
+func TestKubectlDeleteResource(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"delete", "machines.cluster.x-k8s.io", "test-cluster-md-0", "--kubeconfig", tt.kubeconfig, "--namespace", tt.namespace, "--ignore-not-found=true",
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.DeleteResource(tt.ctx, "machines.cluster.x-k8s.io", "test-cluster-md-0", tt.kubeconfig, tt.namespace)).To(Succeed())
+}
+
+func TestKubectlGetNodes(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"get", "nodes", "-o", "json", "--kubeconfig", tt.kubeconfig,
+	).Return(*bytes.NewBufferString(`{"items":[{"metadata":{"name":"node-1"},"status":{"addresses":[{"type":"InternalIP","address":"10.0.0.1"}]}}]}`), nil)
+
+	nodes, err := tt.k.GetNodes(tt.ctx, tt.kubeconfig)
+	tt.Expect(err).To(Not(HaveOccurred()))
+	tt.Expect(nodes).To(Equal([]corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}},
+			},
+		},
+	}))
+}
@@ -1,12 +1,13 @@
 package features
 
 const (
-	AwsIamAuthenticatorEnvVar = "AWS_IAM_AUTHENTICATOR"
-	TaintsSupportEnvVar       = "TAINTS_SUPPORT"
-	TinkerbellProviderEnvVar  = "TINKERBELL_PROVIDER"
-	FullLifecycleAPIEnvVar    = "FULL_LIFECYCLE_API"
-	FullLifecycleGate         = "FullLifecycleAPI"
-	V1beta1BundleRelease      = "V1BETA1_BUNDLE"
+	AwsIamAuthenticatorEnvVar    = "AWS_IAM_AUTHENTICATOR"
+	TaintsSupportEnvVar          = "TAINTS_SUPPORT"
+	TinkerbellProviderEnvVar     = "TINKERBELL_PROVIDER"
+	FullLifecycleAPIEnvVar       = "FULL_LIFECYCLE_API"
+	FullLifecycleGate            = "FullLifecycleAPI"
+	V1beta1BundleRelease         = "V1BETA1_BUNDLE"
+	NativeKubernetesClientEnvVar = "NATIVE_KUBERNETES_CLIENT"
 )
 
 func FeedGates(featureGates []string) {
@@ -56,3 +57,10 @@ func UseV1beta1BundleRelease() Feature {
 		IsActive: globalFeatures.isActiveForEnvVar(V1beta1BundleRelease),
 	}
 }
+
+func NativeKubernetesClient() Feature {
+	return Feature{
+		Name:     "Use a native Kubernetes client instead of kubectl in the cluster manager",
+		IsActive: globalFeatures.isActiveForEnvVar(NativeKubernetesClientEnvVar),
+	}
+}
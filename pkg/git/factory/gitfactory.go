@@ -6,23 +6,41 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/git"
 	"github.com/aws/eks-anywhere/pkg/git/gogithub"
+	"github.com/aws/eks-anywhere/pkg/git/gogitlab"
+	"github.com/aws/eks-anywhere/pkg/git/providers/generic"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 )
 
 type gitProviderFactory struct {
-	GithubGitClient github.GitProviderClient
+	GithubGitClient  github.GitProviderClient
+	GenericGitClient generic.GitProviderClient
+	GitlabGitClient  gitlab.GitProviderClient
 }
 
 type Options struct {
-	GithubGitClient github.GitProviderClient
+	GithubGitClient  github.GitProviderClient
+	GenericGitClient generic.GitProviderClient
+	GitlabGitClient  gitlab.GitProviderClient
 }
 
 func New(opts Options) *gitProviderFactory {
-	return &gitProviderFactory{GithubGitClient: opts.GithubGitClient}
+	return &gitProviderFactory{
+		GithubGitClient:  opts.GithubGitClient,
+		GenericGitClient: opts.GenericGitClient,
+		GitlabGitClient:  opts.GitlabGitClient,
+	}
 }
 
-// BuildProvider will configure and return the proper Github based on the given GitOps configuration.
+// BuildProvider will configure and return the proper Git provider based on the given GitOps configuration.
 func (g *gitProviderFactory) BuildProvider(ctx context.Context, gitOpsConfig *v1alpha1.GitOpsConfigSpec) (git.Provider, error) {
+	if gitOpsConfig.Flux.IsGit() {
+		return g.buildGenericProvider(gitOpsConfig.Flux.Git)
+	}
+	if gitOpsConfig.Flux.IsGitlab() {
+		return g.buildGitlabProvider(gitOpsConfig.Flux.Gitlab)
+	}
+
 	token, err := github.GetGithubAccessTokenFromEnv()
 	if err != nil {
 		return nil, err
@@ -42,3 +60,31 @@ func (g *gitProviderFactory) BuildProvider(ctx context.Context, gitOpsConfig *v1
 
 	return provider, nil
 }
+
+func (g *gitProviderFactory) buildGenericProvider(config *v1alpha1.GitProviderConfig) (git.Provider, error) {
+	auth, err := generic.GetAuthFromEnv(config.RepositoryUrl)
+	if err != nil {
+		return nil, err
+	}
+	opts := generic.Options{
+		RepositoryUrl: config.RepositoryUrl,
+		Repository:    config.RepositoryName(),
+	}
+	return generic.New(g.GenericGitClient, opts, auth)
+}
+
+func (g *gitProviderFactory) buildGitlabProvider(config *v1alpha1.Gitlab) (git.Provider, error) {
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	auth := git.TokenAuth{Token: token, Username: gitlab.TokenAuthUsername}
+	gitlabProviderClient := gogitlab.New(gogitlab.Options{BaseUrl: gitlab.ApiUrl(config.Hostname), Auth: auth})
+	opts := gitlab.Options{
+		Hostname:   config.Hostname,
+		Repository: config.Repository,
+		Owner:      config.Owner,
+		Personal:   config.Personal,
+	}
+	return gitlab.New(g.GitlabGitClient, gitlabProviderClient, opts, auth)
+}
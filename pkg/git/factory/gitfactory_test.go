@@ -9,8 +9,11 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	genericMocks "github.com/aws/eks-anywhere/pkg/git/providers/generic/mocks"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
 	githubMocks "github.com/aws/eks-anywhere/pkg/git/providers/github/mocks"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+	gitlabMocks "github.com/aws/eks-anywhere/pkg/git/providers/gitlab/mocks"
 )
 
 const (
@@ -52,6 +55,53 @@ func TestGitFactoryHappyPath(t *testing.T) {
 	}
 }
 
+func TestGitFactoryGenericProvider(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	gitopsConfig := &v1alpha1.GitOpsConfigSpec{
+		Flux: v1alpha1.Flux{
+			Git: &v1alpha1.GitProviderConfig{RepositoryUrl: "file:///repos/flux-fleet.git"},
+		},
+	}
+
+	genericProviderClient := genericMocks.NewMockGitProviderClient(mockCtrl)
+	opts := gitFactory.Options{GenericGitClient: genericProviderClient}
+	factory := gitFactory.New(opts)
+
+	provider, err := factory.BuildProvider(context.Background(), gitopsConfig)
+	if err != nil {
+		t.Fatalf("gitfactory.BuildProvider returned err, wanted nil. err: %v", err)
+	}
+	repo, err := provider.GetRepo(context.Background())
+	if err != nil {
+		t.Fatalf("provider.GetRepo returned err, wanted nil. err: %v", err)
+	}
+	if repo.Name != "flux-fleet" {
+		t.Errorf("provider.GetRepo returned repository %s, wanted flux-fleet", repo.Name)
+	}
+}
+
+func TestGitFactoryGitlabProvider(t *testing.T) {
+	os.Setenv(gitlab.EksaGitlabTokenEnv, "glpat-token")
+	defer os.Unsetenv(gitlab.EksaGitlabTokenEnv)
+	defer os.Unsetenv(gitlab.GitlabTokenEnv)
+
+	mockCtrl := gomock.NewController(t)
+	gitopsConfig := &v1alpha1.GitOpsConfigSpec{
+		Flux: v1alpha1.Flux{
+			Gitlab: &v1alpha1.Gitlab{Owner: "platform", Repository: "flux-fleet"},
+		},
+	}
+
+	gitlabProviderClient := gitlabMocks.NewMockGitProviderClient(mockCtrl)
+	gitlabProviderClient.EXPECT().SetTokenAuth("glpat-token", gitlab.TokenAuthUsername)
+	opts := gitFactory.Options{GitlabGitClient: gitlabProviderClient}
+	factory := gitFactory.New(opts)
+
+	if _, err := factory.BuildProvider(context.Background(), gitopsConfig); err != nil {
+		t.Fatalf("gitfactory.BuildProvider returned err, wanted nil. err: %v", err)
+	}
+}
+
 type testContext struct {
 	oldGithubToken   string
 	isGithubTokenSet bool
@@ -22,6 +22,8 @@ type Provider interface {
 	DeleteRepo(ctx context.Context, opts DeleteRepoOpts) error
 	Validate(ctx context.Context) error
 	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
+	CreatePullRequest(ctx context.Context, opts PullRequestOpts) (*PullRequest, error)
+	GetPullRequest(ctx context.Context, number int) (*PullRequest, error)
 }
 
 type CreateRepoOpts struct {
@@ -42,6 +44,22 @@ type DeleteRepoOpts struct {
 	Repository string
 }
 
+type PullRequestOpts struct {
+	Title string
+	Body  string
+	// Head is the branch with the changes
+	Head string
+	// Base is the branch the changes are merged into
+	Base string
+}
+
+type PullRequest struct {
+	Number int
+	Url    string
+	Merged bool
+	Closed bool
+}
+
 type Repository struct {
 	Name         string
 	Owner        string
@@ -14,6 +14,8 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
 
 	"github.com/aws/eks-anywhere/pkg/git"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -25,6 +27,8 @@ const (
 	maxRetries     = 5
 	backOffPeriod  = 5 * time.Second
 	emptyRepoError = "remote repository is empty"
+	// defaultSSHUser is the ssh user when the repository url doesn't include one
+	defaultSSHUser = "git"
 )
 
 type GoGit struct {
@@ -51,6 +55,7 @@ type GoGitClient interface {
 	AddGlob(f string, w *gogit.Worktree) error
 	Checkout(w *gogit.Worktree, opts *gogit.CheckoutOptions) error
 	Clone(ctx context.Context, dir string, repoUrl string, auth transport.AuthMethod) (*gogit.Repository, error)
+	CloneInMemory(ctx context.Context, repoUrl string, ref plumbing.ReferenceName, auth transport.AuthMethod) (*gogit.Repository, error)
 	Commit(m string, sig *object.Signature, w *gogit.Worktree) (plumbing.Hash, error)
 	CommitObject(r *gogit.Repository, h plumbing.Hash) (*object.Commit, error)
 	Create(r *gogit.Repository, url string) (*gogit.Remote, error)
@@ -81,6 +86,19 @@ func (ggc *goGitClient) Clone(ctx context.Context, dir string, repourl string, a
 	})
 }
 
+func (ggc *goGitClient) CloneInMemory(ctx context.Context, repourl string, ref plumbing.ReferenceName, auth transport.AuthMethod) (*gogit.Repository, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	return gogit.CloneContext(ctx, memory.NewStorage(), nil, &gogit.CloneOptions{
+		Auth:          auth,
+		URL:           repourl,
+		ReferenceName: ref,
+		SingleBranch:  true,
+		Depth:         1,
+	})
+}
+
 func (ggc *goGitClient) OpenDir(dir string) (*gogit.Repository, error) {
 	return gogit.PlainOpen(dir)
 }
@@ -103,12 +121,21 @@ func (ggc *goGitClient) CommitObject(r *gogit.Repository, h plumbing.Hash) (*obj
 	return r.CommitObject(h)
 }
 
+// PushWithContext pushes only the checked out branch, so other local branches, like the ones of
+// pull requests already merged and deleted in the remote, are not pushed again
 func (ggc *goGitClient) PushWithContext(ctx context.Context, r *gogit.Repository, auth transport.AuthMethod) error {
 	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
 	defer cancel()
 
+	head, err := r.Head()
+	if err != nil {
+		return err
+	}
+	refSpec := config.RefSpec(fmt.Sprintf("%s:%s", head.Name(), head.Name()))
+
 	return r.PushContext(ctx, &gogit.PushOptions{
-		Auth: auth,
+		Auth:     auth,
+		RefSpecs: []config.RefSpec{refSpec},
 	})
 }
 
@@ -368,6 +395,84 @@ func (g *GoGit) SetTokenAuth(token string, username string) {
 	g.Opts.Auth = &http.BasicAuth{Password: token, Username: username}
 }
 
+func (g *GoGit) SetBasicAuth(username, password string) {
+	g.Opts.Auth = &http.BasicAuth{Username: username, Password: password}
+}
+
+// SetSSHAuth authenticates with a private key file. The host keys are checked against the knownHostsFile,
+// or the default known_hosts files if it's empty. The ssh user is read from the repository url
+func (g *GoGit) SetSSHAuth(repourl, privateKeyFile, passphrase, knownHostsFile string) error {
+	user, err := sshUser(repourl)
+	if err != nil {
+		return err
+	}
+
+	auth, err := ssh.NewPublicKeysFromFile(user, privateKeyFile, passphrase)
+	if err != nil {
+		return fmt.Errorf("error reading ssh private key %s: %v", privateKeyFile, err)
+	}
+
+	var knownHostsFiles []string
+	if knownHostsFile != "" {
+		knownHostsFiles = append(knownHostsFiles, knownHostsFile)
+	}
+	auth.HostKeyCallback, err = ssh.NewKnownHostsCallback(knownHostsFiles...)
+	if err != nil {
+		return fmt.Errorf("error reading ssh known hosts: %v", err)
+	}
+
+	g.Opts.Auth = auth
+	return nil
+}
+
+func sshUser(repourl string) (string, error) {
+	endpoint, err := transport.NewEndpoint(repourl)
+	if err != nil {
+		return "", fmt.Errorf("error parsing repository url %s: %v", repourl, err)
+	}
+	if endpoint.User == "" {
+		return defaultSSHUser, nil
+	}
+	return endpoint.User, nil
+}
+
+// PathExists checks if a path exists in the last commit of a branch of the remote repository.
+// Only that commit is fetched, in memory, so it doesn't need a local clone
+func (g *GoGit) PathExists(ctx context.Context, repourl, branch, path string) (bool, error) {
+	r, err := g.Client.CloneInMemory(ctx, repourl, plumbing.NewBranchReferenceName(branch), g.Opts.Auth)
+	if err != nil {
+		if errors.Is(err, gogit.NoMatchingRefSpecError{}) || errors.Is(err, plumbing.ErrReferenceNotFound) || strings.Contains(err.Error(), emptyRepoError) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error cloning repository %s: %v", repourl, err)
+	}
+
+	ref, err := g.Client.Head(r)
+	if err != nil {
+		return false, fmt.Errorf("error getting branch %s: %v", branch, err)
+	}
+
+	commit, err := g.Client.CommitObject(r, ref.Hash())
+	if err != nil {
+		return false, fmt.Errorf("error getting last commit of branch %s: %v", branch, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return false, fmt.Errorf("error getting files of branch %s: %v", branch, err)
+	}
+
+	_, err = tree.FindEntry(path)
+	if errors.Is(err, object.ErrEntryNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error checking path %s: %v", path, err)
+	}
+
+	return true, nil
+}
+
 func (g *GoGit) pullIfRemoteExists(r *gogit.Repository, w *gogit.Worktree, branchName string, localBranchRef plumbing.ReferenceName) error {
 	err := g.Retrier.Retry(func() error {
 		remoteExists, err := g.remoteBranchExists(r, localBranchRef)
@@ -2,15 +2,26 @@ package gogit_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"testing"
 
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
 	goGit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/golang/mock/gomock"
 
 	"github.com/aws/eks-anywhere/pkg/git"
@@ -291,6 +302,179 @@ func TestGoGitBranchRemoteExists(t *testing.T) {
 	}
 }
 
+func TestGoGitPathExists(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		cloneError error
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name: "file exists",
+			path: "clusters/cluster-name/eksa-system/eksa-cluster.yaml",
+			want: true,
+		},
+		{
+			name: "directory exists",
+			path: "clusters/cluster-name",
+			want: true,
+		},
+		{
+			name: "file doesn't exist",
+			path: "clusters/cluster-name/kustomization.yaml",
+			want: false,
+		},
+		{
+			name: "directory doesn't exist",
+			path: "clusters/other-cluster/eksa-system",
+			want: false,
+		},
+		{
+			name:       "empty repository",
+			path:       "clusters/cluster-name",
+			cloneError: fmt.Errorf("remote repository is empty"),
+			want:       false,
+		},
+		{
+			name:       "branch doesn't exist",
+			path:       "clusters/cluster-name",
+			cloneError: goGit.NoMatchingRefSpecError{},
+			want:       false,
+		},
+		{
+			name:       "clone error",
+			path:       "clusters/cluster-name",
+			cloneError: fmt.Errorf("authentication required"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, client, opts := newGoGit(t)
+			repoUrl := "testurl"
+			branch := plumbing.NewBranchReferenceName("main")
+			g := &gogit.GoGit{
+				Opts:   opts,
+				Client: client,
+			}
+
+			if tt.cloneError != nil {
+				client.EXPECT().CloneInMemory(ctx, repoUrl, branch, opts.Auth).Return(nil, tt.cloneError)
+			} else {
+				r, head := newInMemoryRepository(t, "clusters/cluster-name/eksa-system/eksa-cluster.yaml")
+				client.EXPECT().CloneInMemory(ctx, repoUrl, branch, opts.Auth).Return(r, nil)
+				client.EXPECT().Head(r).Return(head, nil)
+				client.EXPECT().CommitObject(r, head.Hash()).DoAndReturn(func(r *goGit.Repository, h plumbing.Hash) (*object.Commit, error) {
+					return r.CommitObject(h)
+				})
+			}
+
+			got, err := g.PathExists(ctx, repoUrl, "main", tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PathExists() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PathExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newInMemoryRepository(t *testing.T, file string) (*goGit.Repository, *plumbing.Reference) {
+	r, err := goGit.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = util.WriteFile(w.Filesystem, file, []byte("content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Add(file); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Commit("initial commit", &goGit.CommitOptions{Author: &object.Signature{Name: "test"}}); err != nil {
+		t.Fatal(err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, head
+}
+
+func TestGoGitSetSSHAuthUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		repourl  string
+		wantUser string
+	}{
+		{
+			name:     "user in ssh url",
+			repourl:  "ssh://janedoe@git.example.com/janedoe/flux-fleet.git",
+			wantUser: "janedoe",
+		},
+		{
+			name:     "user in scp-like url",
+			repourl:  "janedoe@git.example.com:janedoe/flux-fleet.git",
+			wantUser: "janedoe",
+		},
+		{
+			name:     "no user in ssh url",
+			repourl:  "ssh://git.example.com/janedoe/flux-fleet.git",
+			wantUser: "git",
+		},
+	}
+	privateKeyFile, knownHostsFile := writeSSHFiles(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client, opts := newGoGit(t)
+			g := &gogit.GoGit{
+				Opts:   opts,
+				Client: client,
+			}
+
+			if err := g.SetSSHAuth(tt.repourl, privateKeyFile, "", knownHostsFile); err != nil {
+				t.Fatalf("SetSSHAuth() error = %v, want nil", err)
+			}
+
+			auth, ok := g.Opts.Auth.(*ssh.PublicKeys)
+			if !ok {
+				t.Fatalf("SetSSHAuth() auth = %T, want *ssh.PublicKeys", g.Opts.Auth)
+			}
+			if auth.User != tt.wantUser {
+				t.Errorf("SetSSHAuth() user = %s, want %s", auth.User, tt.wantUser)
+			}
+		})
+	}
+}
+
+func writeSSHFiles(t *testing.T) (privateKeyFile, knownHostsFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	privateKeyFile = filepath.Join(dir, "id_ecdsa")
+	if err = ioutil.WriteFile(privateKeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	knownHostsFile = filepath.Join(dir, "known_hosts")
+	if err = ioutil.WriteFile(knownHostsFile, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return privateKeyFile, knownHostsFile
+}
+
 func newGoGit(t *testing.T) (context.Context, *mockGoGit.MockGoGitClient, gogit.Options) {
 	opts := gogit.Options{
 		RepositoryDirectory: "testrepo",
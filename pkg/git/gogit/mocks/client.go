@@ -82,6 +82,21 @@ func (mr *MockGoGitClientMockRecorder) Clone(arg0, arg1, arg2, arg3 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockGoGitClient)(nil).Clone), arg0, arg1, arg2, arg3)
 }
 
+// CloneInMemory mocks base method.
+func (m *MockGoGitClient) CloneInMemory(arg0 context.Context, arg1 string, arg2 plumbing.ReferenceName, arg3 transport.AuthMethod) (*git.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneInMemory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*git.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneInMemory indicates an expected call of CloneInMemory.
+func (mr *MockGoGitClientMockRecorder) CloneInMemory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneInMemory", reflect.TypeOf((*MockGoGitClient)(nil).CloneInMemory), arg0, arg1, arg2, arg3)
+}
+
 // Commit mocks base method.
 func (m *MockGoGitClient) Commit(arg0 string, arg1 *object.Signature, arg2 *git.Worktree) (plumbing.Hash, error) {
 	m.ctrl.T.Helper()
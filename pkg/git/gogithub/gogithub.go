@@ -45,6 +45,8 @@ type Client interface {
 		fileContent *goGithub.RepositoryContent, directoryContent []*goGithub.RepositoryContent, resp *goGithub.Response, err error,
 	)
 	DeleteRepo(ctx context.Context, owner, repo string) (*goGithub.Response, error)
+	CreatePullRequest(ctx context.Context, owner, repo string, pull *goGithub.NewPullRequest) (*goGithub.PullRequest, *goGithub.Response, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*goGithub.PullRequest, *goGithub.Response, error)
 }
 
 type githubClient struct {
@@ -81,6 +83,14 @@ func (ggc *githubClient) DeleteRepo(ctx context.Context, owner, repo string) (*g
 	return ggc.client.Repositories.Delete(ctx, owner, repo)
 }
 
+func (ggc *githubClient) CreatePullRequest(ctx context.Context, owner, repo string, pull *goGithub.NewPullRequest) (*goGithub.PullRequest, *goGithub.Response, error) {
+	return ggc.client.PullRequests.Create(ctx, owner, repo, pull)
+}
+
+func (ggc *githubClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*goGithub.PullRequest, *goGithub.Response, error) {
+	return ggc.client.PullRequests.Get(ctx, owner, repo, number)
+}
+
 // CreateRepo creates an empty Github Repository. The repository must be initialized locally or
 // file must be added to it via the github api before it can be successfully cloned.
 func (g *GoGithub) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (repository *git.Repository, err error) {
@@ -212,6 +222,39 @@ func (g *GoGithub) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) erro
 	return nil
 }
 
+// CreatePullRequest opens a pull request to merge the head branch into the base branch of a repository
+func (g *GoGithub) CreatePullRequest(ctx context.Context, owner, repo string, opts git.PullRequestOpts) (*git.PullRequest, error) {
+	logger.V(3).Info("Opening Github pull request", "repo", repo, "owner", owner, "head", opts.Head, "base", opts.Base)
+	pull, _, err := g.Client.CreatePullRequest(ctx, owner, repo, &goGithub.NewPullRequest{
+		Title: &opts.Title,
+		Body:  &opts.Body,
+		Head:  &opts.Head,
+		Base:  &opts.Base,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pull request from %s to %s in repository %s: %v", opts.Head, opts.Base, repo, err)
+	}
+	return pullRequest(pull), nil
+}
+
+// GetPullRequest describes a pull request of a repository
+func (g *GoGithub) GetPullRequest(ctx context.Context, owner, repo string, number int) (*git.PullRequest, error) {
+	pull, _, err := g.Client.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting pull request %d of repository %s: %v", number, repo, err)
+	}
+	return pullRequest(pull), nil
+}
+
+func pullRequest(pull *goGithub.PullRequest) *git.PullRequest {
+	return &git.PullRequest{
+		Number: pull.GetNumber(),
+		Url:    pull.GetHTMLURL(),
+		Merged: pull.GetMerged(),
+		Closed: pull.GetState() == "closed",
+	}
+}
+
 func newClient(ctx context.Context, opts Options) Client {
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: opts.Auth.Token})
 	tc := oauth2.NewClient(ctx, ts)
@@ -396,6 +396,49 @@ func TestPathExistsItDoesNot(t *testing.T) {
 	tt.Expect(tt.g.PathExists(tt.ctx, owner, repo, branch, path)).To(BeTrue())
 }
 
+func TestCreatePullRequest(t *testing.T) {
+	tt := newTest(t)
+	owner, repo := "aws", "eksa-gitops"
+	opts := git.PullRequestOpts{Title: "title", Body: "body", Head: "eksa-cluster", Base: "main"}
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, owner, repo, &github.NewPullRequest{
+		Title: &opts.Title, Body: &opts.Body, Head: &opts.Head, Base: &opts.Base,
+	}).Return(&github.PullRequest{
+		Number:  github.Int(1),
+		HTMLURL: github.String("https://github.com/aws/eksa-gitops/pull/1"),
+		State:   github.String("open"),
+	}, nil, nil)
+
+	tt.Expect(tt.g.CreatePullRequest(tt.ctx, owner, repo, opts)).To(Equal(&git.PullRequest{
+		Number: 1,
+		Url:    "https://github.com/aws/eksa-gitops/pull/1",
+	}))
+}
+
+func TestCreatePullRequestError(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, "aws", "eksa-gitops", gomock.Any()).Return(nil, nil, errors.New("validation failed"))
+
+	_, err := tt.g.CreatePullRequest(tt.ctx, "aws", "eksa-gitops", git.PullRequestOpts{Head: "eksa-cluster", Base: "main"})
+	tt.Expect(err).To(MatchError(ContainSubstring("failed to open pull request from eksa-cluster to main")))
+}
+
+func TestGetPullRequest(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().GetPullRequest(tt.ctx, "aws", "eksa-gitops", 1).Return(&github.PullRequest{
+		Number:  github.Int(1),
+		HTMLURL: github.String("https://github.com/aws/eksa-gitops/pull/1"),
+		State:   github.String("closed"),
+		Merged:  github.Bool(true),
+	}, nil, nil)
+
+	tt.Expect(tt.g.GetPullRequest(tt.ctx, "aws", "eksa-gitops", 1)).To(Equal(&git.PullRequest{
+		Number: 1,
+		Url:    "https://github.com/aws/eksa-gitops/pull/1",
+		Merged: true,
+		Closed: true,
+	}))
+}
+
 type gogithubTest struct {
 	*WithT
 	g      *gogithub.GoGithub
@@ -0,0 +1,275 @@
+package gogitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	apiPath            = "/api/v4"
+	privateTokenHeader = "PRIVATE-TOKEN"
+	nextPageHeader     = "X-Next-Page"
+	requestTimeout     = 30 * time.Second
+	treePageSize       = 100
+	privateVisibility  = "private"
+)
+
+// GoGitlab is a client of the GitLab REST API, for gitlab.com or a self-managed instance
+type GoGitlab struct {
+	Opts   Options
+	Client HTTPClient
+}
+
+type Options struct {
+	// BaseUrl of the GitLab instance, e.g. https://gitlab.com
+	BaseUrl string
+	Auth    git.TokenAuth
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+func New(opts Options) *GoGitlab {
+	return &GoGitlab{
+		Opts:   opts,
+		Client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+type User struct {
+	Username string `json:"username"`
+}
+
+type Group struct {
+	ID       int    `json:"id"`
+	FullPath string `json:"full_path"`
+}
+
+type project struct {
+	Name          string    `json:"name"`
+	Path          string    `json:"path"`
+	HttpUrlToRepo string    `json:"http_url_to_repo"`
+	Namespace     namespace `json:"namespace"`
+}
+
+type namespace struct {
+	FullPath string `json:"full_path"`
+	Kind     string `json:"kind"`
+}
+
+type createProjectRequest struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Description string `json:"description,omitempty"`
+	Visibility  string `json:"visibility,omitempty"`
+	NamespaceID int    `json:"namespace_id,omitempty"`
+}
+
+type treeEntry struct {
+	Path string `json:"path"`
+}
+
+type accessToken struct {
+	Scopes []string `json:"scopes"`
+}
+
+// APIError is returned when the GitLab API responds with an error status
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gitlab api returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// GetRepo describes a remote repository, return the repo name if it exists.
+// If the repo does not exist, resulting in a 404 exception, it returns a `RepoDoesNotExist` error.
+func (g *GoGitlab) GetRepo(ctx context.Context, opts git.GetRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Describing GitLab repository", "name", opts.Repository, "owner", opts.Owner)
+	p := &project{}
+	if err := g.do(ctx, http.MethodGet, projectPath(opts.Owner, opts.Repository), nil, nil, p); err != nil {
+		if isNotFound(err) {
+			return nil, &git.RepositoryDoesNotExistError{Err: err}
+		}
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", opts.Repository, err)
+	}
+	return p.repository(), nil
+}
+
+// CreateRepo creates an empty GitLab project in the user namespace, or in the owner group if it's not personal.
+// The repository must be initialized locally before it can be successfully cloned.
+func (g *GoGitlab) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new GitLab repo", "repo", opts.Name, "owner", opts.Owner)
+	req := &createProjectRequest{
+		Name:        opts.Name,
+		Path:        opts.Name,
+		Description: opts.Description,
+	}
+	if opts.Privacy {
+		req.Visibility = privateVisibility
+	}
+	if !opts.Personal {
+		group, err := g.Group(ctx, opts.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new GitLab repo %s: %v", opts.Name, err)
+		}
+		req.NamespaceID = group.ID
+	}
+
+	p := &project{}
+	if err := g.do(ctx, http.MethodPost, "/projects", nil, req, p); err != nil {
+		return nil, fmt.Errorf("failed to create new GitLab repo %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new GitLab repo", "repo", p.Path, "owner", p.Namespace.FullPath)
+	return p.repository(), nil
+}
+
+// DeleteRepo deletes a GitLab project.
+func (g *GoGitlab) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	logger.V(3).Info("Deleting GitLab repository", "name", opts.Repository, "owner", opts.Owner)
+	if err := g.do(ctx, http.MethodDelete, projectPath(opts.Owner, opts.Repository), nil, nil, nil); err != nil {
+		return fmt.Errorf("error when deleting repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+func (g *GoGitlab) AuthenticatedUser(ctx context.Context) (*User, error) {
+	user := &User{}
+	if err := g.do(ctx, http.MethodGet, "/user", nil, nil, user); err != nil {
+		return nil, fmt.Errorf("failed while getting the authenticated gitlab user %v", err)
+	}
+	return user, nil
+}
+
+func (g *GoGitlab) Group(ctx context.Context, group string) (*Group, error) {
+	gr := &Group{}
+	if err := g.do(ctx, http.MethodGet, "/groups/"+url.PathEscape(group), nil, nil, gr); err != nil {
+		return nil, fmt.Errorf("failed while getting gitlab group %s details %v", group, err)
+	}
+	return gr, nil
+}
+
+// AccessTokenScopes returns the scopes of the personal access token used to authenticate
+func (g *GoGitlab) AccessTokenScopes(ctx context.Context) ([]string, error) {
+	token := &accessToken{}
+	if err := g.do(ctx, http.MethodGet, "/personal_access_tokens/self", nil, nil, token); err != nil {
+		return nil, fmt.Errorf("error getting GitLab Personal Access Token scopes %v", err)
+	}
+	return token.Scopes, nil
+}
+
+// PathExists checks if a path exists in the remote repository. If the owner, repository or branch doesn't exist,
+// it returns false and no error
+func (g *GoGitlab) PathExists(ctx context.Context, owner, repo, branch, p string) (bool, error) {
+	query := url.Values{
+		"ref":      []string{branch},
+		"per_page": []string{strconv.Itoa(treePageSize)},
+	}
+	if dir := path.Dir(p); dir != "." {
+		query.Set("path", dir)
+	}
+
+	for page := "1"; page != ""; {
+		query.Set("page", page)
+		var entries []treeEntry
+		resp, err := g.request(ctx, http.MethodGet, projectPath(owner, repo)+"/repository/tree", query, nil, &entries)
+		if isNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed checking if path %s exists in remote gitlab repository: %v", p, err)
+		}
+
+		for _, e := range entries {
+			if e.Path == p {
+				return true, nil
+			}
+		}
+		page = resp.Header.Get(nextPageHeader)
+	}
+
+	return false, nil
+}
+
+func (g *GoGitlab) do(ctx context.Context, method, p string, query url.Values, body, out interface{}) error {
+	_, err := g.request(ctx, method, p, query, body, out)
+	return err
+}
+
+func (g *GoGitlab) request(ctx context.Context, method, p string, query url.Values, body, out interface{}) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	u := g.Opts.BaseUrl + apiPath + p
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(privateTokenHeader, g.Opts.Auth.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(resp.Body)
+		return resp, &APIError{StatusCode: resp.StatusCode, Message: string(message)}
+	}
+
+	if out != nil {
+		if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, fmt.Errorf("error parsing gitlab api response: %v", err)
+		}
+	}
+	return resp, nil
+}
+
+func (p *project) repository() *git.Repository {
+	r := &git.Repository{
+		Name:     p.Path,
+		CloneUrl: p.HttpUrlToRepo,
+		Owner:    p.Namespace.FullPath,
+	}
+	if p.Namespace.Kind == "group" {
+		r.Organization = p.Namespace.FullPath
+	}
+	return r
+}
+
+// projectPath returns the API path of a project, identified by its url encoded full path
+func projectPath(owner, repo string) string {
+	return "/projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+func isNotFound(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,194 @@
+package gogitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/gogitlab"
+)
+
+const token = "glpat-token"
+
+// newTestGitlab starts a stand-in of the GitLab API that serves the given handlers, keyed by method and escaped path
+func newTestGitlab(t *testing.T, handlers map[string]http.HandlerFunc) *gogitlab.GoGitlab {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		h, ok := handlers[r.Method+" "+r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"404 Not Found"}`))
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return gogitlab.New(gogitlab.Options{BaseUrl: server.URL, Auth: git.TokenAuth{Token: token}})
+}
+
+func respond(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestGoGitlabGetRepo(t *testing.T) {
+	g := NewWithT(t)
+	client := newTestGitlab(t, map[string]http.HandlerFunc{
+		"GET /api/v4/projects/platform%2Fclusters%2Fflux-fleet": respond(`{
+			"name": "flux-fleet",
+			"path": "flux-fleet",
+			"http_url_to_repo": "https://gitlab.example.com/platform/clusters/flux-fleet.git",
+			"namespace": {"full_path": "platform/clusters", "kind": "group"}
+		}`),
+	})
+
+	repo, err := client.GetRepo(context.Background(), git.GetRepoOpts{Owner: "platform/clusters", Repository: "flux-fleet"})
+	g.Expect(err).To(Succeed())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "flux-fleet",
+		Owner:        "platform/clusters",
+		Organization: "platform/clusters",
+		CloneUrl:     "https://gitlab.example.com/platform/clusters/flux-fleet.git",
+	}))
+}
+
+func TestGoGitlabGetRepoNotFound(t *testing.T) {
+	g := NewWithT(t)
+	client := newTestGitlab(t, nil)
+
+	_, err := client.GetRepo(context.Background(), git.GetRepoOpts{Owner: "janedoe", Repository: "flux-fleet"})
+	var e *git.RepositoryDoesNotExistError
+	g.Expect(errors.As(err, &e)).To(BeTrue())
+}
+
+func TestGoGitlabGetRepoUnauthorized(t *testing.T) {
+	g := NewWithT(t)
+	client := newTestGitlab(t, nil)
+	client.Opts.Auth.Token = "invalid"
+
+	_, err := client.GetRepo(context.Background(), git.GetRepoOpts{Owner: "janedoe", Repository: "flux-fleet"})
+	g.Expect(err).To(MatchError(ContainSubstring("status 401")))
+}
+
+func TestGoGitlabCreateRepo(t *testing.T) {
+	tests := []struct {
+		testName        string
+		opts            git.CreateRepoOpts
+		wantNamespaceID int
+	}{
+		{
+			testName:        "group repo",
+			opts:            git.CreateRepoOpts{Name: "flux-fleet", Owner: "platform", Privacy: true},
+			wantNamespaceID: 42,
+		},
+		{
+			testName: "personal repo",
+			opts:     git.CreateRepoOpts{Name: "flux-fleet", Owner: "janedoe", Personal: true, Privacy: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			var got map[string]interface{}
+			client := newTestGitlab(t, map[string]http.HandlerFunc{
+				"GET /api/v4/groups/platform": respond(`{"id": 42, "full_path": "platform"}`),
+				"POST /api/v4/projects": func(w http.ResponseWriter, r *http.Request) {
+					body, _ := ioutil.ReadAll(r.Body)
+					_ = json.Unmarshal(body, &got)
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"name": "flux-fleet", "path": "flux-fleet", "namespace": {"full_path": "` + tt.opts.Owner + `"}}`))
+				},
+			})
+
+			repo, err := client.CreateRepo(context.Background(), tt.opts)
+			g.Expect(err).To(Succeed())
+			g.Expect(repo.Name).To(Equal("flux-fleet"))
+			g.Expect(got["path"]).To(Equal("flux-fleet"))
+			g.Expect(got["visibility"]).To(Equal("private"))
+			if tt.wantNamespaceID == 0 {
+				g.Expect(got).NotTo(HaveKey("namespace_id"))
+			} else {
+				g.Expect(got["namespace_id"]).To(BeEquivalentTo(tt.wantNamespaceID))
+			}
+		})
+	}
+}
+
+func TestGoGitlabDeleteRepo(t *testing.T) {
+	g := NewWithT(t)
+	deleted := false
+	client := newTestGitlab(t, map[string]http.HandlerFunc{
+		"DELETE /api/v4/projects/janedoe%2Fflux-fleet": func(w http.ResponseWriter, r *http.Request) {
+			deleted = true
+			w.WriteHeader(http.StatusAccepted)
+		},
+	})
+
+	g.Expect(client.DeleteRepo(context.Background(), git.DeleteRepoOpts{Owner: "janedoe", Repository: "flux-fleet"})).To(Succeed())
+	g.Expect(deleted).To(BeTrue())
+}
+
+func TestGoGitlabAuthenticatedUserAndScopes(t *testing.T) {
+	g := NewWithT(t)
+	client := newTestGitlab(t, map[string]http.HandlerFunc{
+		"GET /api/v4/user":                        respond(`{"username": "janedoe"}`),
+		"GET /api/v4/personal_access_tokens/self": respond(`{"scopes": ["api", "read_user"]}`),
+	})
+
+	user, err := client.AuthenticatedUser(context.Background())
+	g.Expect(err).To(Succeed())
+	g.Expect(user.Username).To(Equal("janedoe"))
+
+	scopes, err := client.AccessTokenScopes(context.Background())
+	g.Expect(err).To(Succeed())
+	g.Expect(scopes).To(ConsistOf("api", "read_user"))
+}
+
+func TestGoGitlabPathExists(t *testing.T) {
+	treePath := "GET /api/v4/projects/janedoe%2Fflux-fleet/repository/tree"
+	tests := []struct {
+		testName string
+		path     string
+		want     bool
+	}{
+		{testName: "path in second page", path: "clusters/cluster-name", want: true},
+		{testName: "missing path", path: "clusters/other-cluster", want: false},
+		{testName: "missing parent path", path: "missing/cluster-name", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			client := newTestGitlab(t, map[string]http.HandlerFunc{
+				treePath: func(w http.ResponseWriter, r *http.Request) {
+					q := r.URL.Query()
+					if q.Get("ref") != "main" || q.Get("path") != "clusters" {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					if q.Get("page") == "1" {
+						w.Header().Set("X-Next-Page", "2")
+						_, _ = w.Write([]byte(`[{"path": "clusters/management"}]`))
+						return
+					}
+					_, _ = w.Write([]byte(`[{"path": "clusters/cluster-name"}]`))
+				},
+			})
+
+			got, err := client.PathExists(context.Background(), "janedoe", "flux-fleet", "main", tt.path)
+			g.Expect(err).To(Succeed())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName                = "git"
+	EksaGitPrivateKeyEnv           = "EKSA_GIT_PRIVATE_KEY"
+	EksaGitPrivateKeyPassphraseEnv = "EKSA_GIT_SSH_KEY_PASSPHRASE"
+	EksaGitKnownHostsEnv           = "EKSA_GIT_KNOWN_HOSTS"
+	EksaGitUsernameEnv             = "EKSA_GIT_USERNAME"
+	EksaGitPasswordEnv             = "EKSA_GIT_PASSWORD"
+)
+
+type genericProvider struct {
+	gitProviderClient GitProviderClient
+	options           Options
+}
+
+type Options struct {
+	RepositoryUrl string
+	Repository    string
+}
+
+// Auth holds the credentials to access the repository. They are read from the environment
+// so they are never written to the cluster config
+type Auth struct {
+	PrivateKeyFile       string
+	PrivateKeyPassphrase string
+	KnownHostsFile       string
+	Username             string
+	Password             string
+}
+
+// GitProviderClient represents the attributes that the generic Git provider requires of a low-level git implementation (e.g. gogit).
+// The repository is accessed only with the git protocol, so any Git server that supports SSH or HTTPS can host it.
+type GitProviderClient interface {
+	Add(filename string) error
+	Remove(filename string) error
+	Clone(ctx context.Context, repourl string) error
+	Commit(message string) error
+	Push(ctx context.Context) error
+	Pull(ctx context.Context, branch string) error
+	Init(url string) error
+	Branch(name string) error
+	PathExists(ctx context.Context, repourl, branch, path string) (bool, error)
+	SetBasicAuth(username, password string)
+	SetSSHAuth(repourl, privateKeyFile, passphrase, knownHostsFile string) error
+}
+
+func New(gitProviderClient GitProviderClient, opts Options, auth Auth) (git.Provider, error) {
+	if auth.PrivateKeyFile != "" {
+		if err := gitProviderClient.SetSSHAuth(opts.RepositoryUrl, auth.PrivateKeyFile, auth.PrivateKeyPassphrase, auth.KnownHostsFile); err != nil {
+			return nil, err
+		}
+	} else if auth.Username != "" {
+		gitProviderClient.SetBasicAuth(auth.Username, auth.Password)
+	}
+
+	return &genericProvider{
+		gitProviderClient: gitProviderClient,
+		options:           opts,
+	}, nil
+}
+
+func (g *genericProvider) Add(filename string) error {
+	return g.gitProviderClient.Add(filename)
+}
+
+func (g *genericProvider) Remove(filename string) error {
+	return g.gitProviderClient.Remove(filename)
+}
+
+func (g *genericProvider) Clone(ctx context.Context) error {
+	return g.gitProviderClient.Clone(ctx, g.options.RepositoryUrl)
+}
+
+func (g *genericProvider) Commit(message string) error {
+	return g.gitProviderClient.Commit(message)
+}
+
+func (g *genericProvider) Push(ctx context.Context) error {
+	return g.gitProviderClient.Push(ctx)
+}
+
+func (g *genericProvider) Pull(ctx context.Context, branch string) error {
+	return g.gitProviderClient.Pull(ctx, branch)
+}
+
+func (g *genericProvider) Init() error {
+	return g.gitProviderClient.Init(g.options.RepositoryUrl)
+}
+
+func (g *genericProvider) Branch(name string) error {
+	return g.gitProviderClient.Branch(name)
+}
+
+// GetRepo always describes the configured repository. Git servers don't share an API to check if a repository exists,
+// so a missing repository is reported when it's cloned
+func (g *genericProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	logger.V(3).Info("Using Git repository", "url", g.options.RepositoryUrl)
+	return &git.Repository{
+		Name:     g.options.Repository,
+		CloneUrl: g.options.RepositoryUrl,
+	}, nil
+}
+
+func (g *genericProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	return nil, fmt.Errorf("creating repository %s is not supported by the %s provider, create it in the Git server", opts.Name, GitProviderName)
+}
+
+func (g *genericProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	return fmt.Errorf("deleting repository %s is not supported by the %s provider, delete it in the Git server", opts.Repository, GitProviderName)
+}
+
+func (g *genericProvider) CreatePullRequest(ctx context.Context, opts git.PullRequestOpts) (*git.PullRequest, error) {
+	return nil, fmt.Errorf("opening pull requests is not supported by the %s provider", GitProviderName)
+}
+
+func (g *genericProvider) GetPullRequest(ctx context.Context, number int) (*git.PullRequest, error) {
+	return nil, fmt.Errorf("getting pull requests is not supported by the %s provider", GitProviderName)
+}
+
+// Validate has nothing to check without a provider API, the credentials are checked when they are read
+// and the access to the repository when it's cloned
+func (g *genericProvider) Validate(ctx context.Context) error {
+	return nil
+}
+
+// PathExists checks if the path exists in the branch of the configured repository, owner and repo are ignored
+func (g *genericProvider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	return g.gitProviderClient.PathExists(ctx, g.options.RepositoryUrl, branch, path)
+}
+
+// GetAuthFromEnv reads the credentials for the repository URL from the environment. SSH URLs require a private key,
+// HTTPS URLs use basic auth if a username is set and local repositories don't use any credentials
+func GetAuthFromEnv(repositoryUrl string) (Auth, error) {
+	switch {
+	case strings.HasPrefix(repositoryUrl, "file://"), strings.HasPrefix(repositoryUrl, "/"):
+		return Auth{}, nil
+	case strings.HasPrefix(repositoryUrl, "https://"), strings.HasPrefix(repositoryUrl, "http://"):
+		return basicAuthFromEnv()
+	default:
+		return sshAuthFromEnv()
+	}
+}
+
+func basicAuthFromEnv() (Auth, error) {
+	auth := Auth{
+		Username: os.Getenv(EksaGitUsernameEnv),
+		Password: os.Getenv(EksaGitPasswordEnv),
+	}
+	if auth.Username == "" && auth.Password != "" {
+		return Auth{}, fmt.Errorf("%s is set but %s is not, both are required for basic auth", EksaGitPasswordEnv, EksaGitUsernameEnv)
+	}
+	return auth, nil
+}
+
+func sshAuthFromEnv() (Auth, error) {
+	auth := Auth{
+		PrivateKeyFile:       os.Getenv(EksaGitPrivateKeyEnv),
+		PrivateKeyPassphrase: os.Getenv(EksaGitPrivateKeyPassphraseEnv),
+		KnownHostsFile:       os.Getenv(EksaGitKnownHostsEnv),
+	}
+	if auth.PrivateKeyFile == "" {
+		return Auth{}, fmt.Errorf("%s is not set, a private key is required to access a repository with ssh", EksaGitPrivateKeyEnv)
+	}
+	if _, err := os.Stat(auth.PrivateKeyFile); err != nil {
+		return Auth{}, fmt.Errorf("invalid %s: %v", EksaGitPrivateKeyEnv, err)
+	}
+	if auth.KnownHostsFile != "" {
+		if _, err := os.Stat(auth.KnownHostsFile); err != nil {
+			return Auth{}, fmt.Errorf("invalid %s: %v", EksaGitKnownHostsEnv, err)
+		}
+	}
+	return auth, nil
+}
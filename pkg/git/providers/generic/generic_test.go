@@ -0,0 +1,215 @@
+package generic_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/gogit"
+	"github.com/aws/eks-anywhere/pkg/git/providers/generic"
+	"github.com/aws/eks-anywhere/pkg/git/providers/generic/mocks"
+)
+
+const repositoryUrl = "ssh://git@git.example.com/janedoe/flux-fleet.git"
+
+func TestNewSSHAuth(t *testing.T) {
+	g := NewWithT(t)
+	client := mocks.NewMockGitProviderClient(gomock.NewController(t))
+	auth := generic.Auth{PrivateKeyFile: "id_ecdsa", PrivateKeyPassphrase: "passphrase", KnownHostsFile: "known_hosts"}
+
+	client.EXPECT().SetSSHAuth(repositoryUrl, "id_ecdsa", "passphrase", "known_hosts")
+
+	_, err := generic.New(client, generic.Options{RepositoryUrl: repositoryUrl}, auth)
+	g.Expect(err).To(Succeed())
+}
+
+func TestNewBasicAuth(t *testing.T) {
+	g := NewWithT(t)
+	client := mocks.NewMockGitProviderClient(gomock.NewController(t))
+	auth := generic.Auth{Username: "janedoe", Password: "password"}
+
+	client.EXPECT().SetBasicAuth("janedoe", "password")
+
+	_, err := generic.New(client, generic.Options{RepositoryUrl: "https://git.example.com/janedoe/flux-fleet.git"}, auth)
+	g.Expect(err).To(Succeed())
+}
+
+func TestGetRepo(t *testing.T) {
+	g := NewWithT(t)
+	client := mocks.NewMockGitProviderClient(gomock.NewController(t))
+	p, err := generic.New(client, generic.Options{RepositoryUrl: repositoryUrl, Repository: "flux-fleet"}, generic.Auth{})
+	g.Expect(err).To(Succeed())
+
+	repo, err := p.GetRepo(context.Background())
+	g.Expect(err).To(Succeed())
+	g.Expect(repo).To(Equal(&git.Repository{Name: "flux-fleet", CloneUrl: repositoryUrl}))
+}
+
+func TestCreateRepoNotSupported(t *testing.T) {
+	g := NewWithT(t)
+	client := mocks.NewMockGitProviderClient(gomock.NewController(t))
+	p, err := generic.New(client, generic.Options{RepositoryUrl: repositoryUrl}, generic.Auth{})
+	g.Expect(err).To(Succeed())
+
+	_, err = p.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "flux-fleet"})
+	g.Expect(err).To(MatchError(ContainSubstring("creating repository flux-fleet is not supported")))
+}
+
+func TestPathExists(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockGitProviderClient(gomock.NewController(t))
+	p, err := generic.New(client, generic.Options{RepositoryUrl: repositoryUrl}, generic.Auth{})
+	g.Expect(err).To(Succeed())
+
+	client.EXPECT().PathExists(ctx, repositoryUrl, "main", "clusters/cluster-name").Return(true, nil)
+
+	g.Expect(p.PathExists(ctx, "", "", "main", "clusters/cluster-name")).To(BeTrue())
+}
+
+func TestGetAuthFromEnv(t *testing.T) {
+	privateKeyFile := filepath.Join(t.TempDir(), "id_ecdsa")
+	if err := ioutil.WriteFile(privateKeyFile, []byte("key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		testName string
+		url      string
+		env      map[string]string
+		want     generic.Auth
+		wantErr  string
+	}{
+		{
+			testName: "local repository",
+			url:      "file:///repos/flux-fleet.git",
+			env:      map[string]string{generic.EksaGitPrivateKeyEnv: privateKeyFile},
+			want:     generic.Auth{},
+		},
+		{
+			testName: "ssh",
+			url:      repositoryUrl,
+			env:      map[string]string{generic.EksaGitPrivateKeyEnv: privateKeyFile, generic.EksaGitPrivateKeyPassphraseEnv: "passphrase"},
+			want:     generic.Auth{PrivateKeyFile: privateKeyFile, PrivateKeyPassphrase: "passphrase"},
+		},
+		{
+			testName: "scp-like ssh without private key",
+			url:      "git@git.example.com:janedoe/flux-fleet.git",
+			wantErr:  "EKSA_GIT_PRIVATE_KEY is not set",
+		},
+		{
+			testName: "ssh with missing private key file",
+			url:      repositoryUrl,
+			env:      map[string]string{generic.EksaGitPrivateKeyEnv: "missing"},
+			wantErr:  "invalid EKSA_GIT_PRIVATE_KEY",
+		},
+		{
+			testName: "https",
+			url:      "https://git.example.com/janedoe/flux-fleet.git",
+			env:      map[string]string{generic.EksaGitUsernameEnv: "janedoe", generic.EksaGitPasswordEnv: "password"},
+			want:     generic.Auth{Username: "janedoe", Password: "password"},
+		},
+		{
+			testName: "https without username",
+			url:      "https://git.example.com/janedoe/flux-fleet.git",
+			env:      map[string]string{generic.EksaGitPasswordEnv: "password"},
+			wantErr:  "EKSA_GIT_USERNAME is not",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+
+			got, err := generic.GetAuthFromEnv(tt.url)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).To(Succeed())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+// TestLocalBareRepository pushes to and reads from a bare repository served over file://, like a self-hosted Git server
+func TestLocalBareRepository(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	url := "file://" + seedBareRepository(t, dir)
+
+	repoDir := filepath.Join(dir, "clone")
+	client := gogit.New(gogit.Options{RepositoryDirectory: repoDir})
+	p, err := generic.New(client, generic.Options{RepositoryUrl: url, Repository: "flux-fleet"}, generic.Auth{})
+	g.Expect(err).To(Succeed())
+
+	g.Expect(p.Clone(ctx)).To(Succeed())
+	g.Expect(p.Branch("main")).To(Succeed())
+
+	clusterFile := filepath.Join("clusters", "cluster-name", "eksa-cluster.yaml")
+	g.Expect(os.MkdirAll(filepath.Join(repoDir, filepath.Dir(clusterFile)), 0o755)).To(Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(repoDir, clusterFile), []byte("cluster"), 0o644)).To(Succeed())
+	g.Expect(p.Add("clusters")).To(Succeed())
+	g.Expect(p.Commit("Add cluster")).To(Succeed())
+	g.Expect(p.Push(ctx)).To(Succeed())
+
+	g.Expect(p.PathExists(ctx, "", "", "main", "clusters/cluster-name")).To(BeTrue())
+	g.Expect(p.PathExists(ctx, "", "", "main", "clusters/other-cluster")).To(BeFalse())
+}
+
+// seedBareRepository creates a bare repository with one commit in the main branch
+func seedBareRepository(t *testing.T, dir string) string {
+	bareDir := filepath.Join(dir, "flux-fleet.git")
+	bare, err := goGit.PlainInit(bareDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainBranch := plumbing.NewBranchReferenceName("main")
+	if err = bare.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, mainBranch)); err != nil {
+		t.Fatal(err)
+	}
+
+	seedDir := filepath.Join(dir, "seed")
+	r, err := goGit.PlainInit(seedDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, mainBranch)); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(seedDir, "README.md"), []byte("flux-fleet"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Add("README.md"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Commit("initial commit", &goGit.CommitOptions{Author: &object.Signature{Name: "test"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.CreateRemote(&config.RemoteConfig{Name: goGit.DefaultRemoteName, URLs: []string{bareDir}}); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Push(&goGit.PushOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	return bareDir
+}
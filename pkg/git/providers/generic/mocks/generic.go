@@ -0,0 +1,188 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/git/providers/generic (interfaces: GitProviderClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGitProviderClient is a mock of GitProviderClient interface.
+type MockGitProviderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitProviderClientMockRecorder
+}
+
+// MockGitProviderClientMockRecorder is the mock recorder for MockGitProviderClient.
+type MockGitProviderClientMockRecorder struct {
+	mock *MockGitProviderClient
+}
+
+// NewMockGitProviderClient creates a new mock instance.
+func NewMockGitProviderClient(ctrl *gomock.Controller) *MockGitProviderClient {
+	mock := &MockGitProviderClient{ctrl: ctrl}
+	mock.recorder = &MockGitProviderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitProviderClient) EXPECT() *MockGitProviderClientMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockGitProviderClient) Add(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockGitProviderClientMockRecorder) Add(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockGitProviderClient)(nil).Add), arg0)
+}
+
+// Branch mocks base method.
+func (m *MockGitProviderClient) Branch(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Branch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Branch indicates an expected call of Branch.
+func (mr *MockGitProviderClientMockRecorder) Branch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Branch", reflect.TypeOf((*MockGitProviderClient)(nil).Branch), arg0)
+}
+
+// Clone mocks base method.
+func (m *MockGitProviderClient) Clone(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockGitProviderClientMockRecorder) Clone(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockGitProviderClient)(nil).Clone), arg0, arg1)
+}
+
+// Commit mocks base method.
+func (m *MockGitProviderClient) Commit(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockGitProviderClientMockRecorder) Commit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockGitProviderClient)(nil).Commit), arg0)
+}
+
+// Init mocks base method.
+func (m *MockGitProviderClient) Init(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Init", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Init indicates an expected call of Init.
+func (mr *MockGitProviderClientMockRecorder) Init(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockGitProviderClient)(nil).Init), arg0)
+}
+
+// PathExists mocks base method.
+func (m *MockGitProviderClient) PathExists(arg0 context.Context, arg1, arg2, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathExists", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PathExists indicates an expected call of PathExists.
+func (mr *MockGitProviderClientMockRecorder) PathExists(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathExists", reflect.TypeOf((*MockGitProviderClient)(nil).PathExists), arg0, arg1, arg2, arg3)
+}
+
+// Pull mocks base method.
+func (m *MockGitProviderClient) Pull(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockGitProviderClientMockRecorder) Pull(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockGitProviderClient)(nil).Pull), arg0, arg1)
+}
+
+// Push mocks base method.
+func (m *MockGitProviderClient) Push(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockGitProviderClientMockRecorder) Push(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockGitProviderClient)(nil).Push), arg0)
+}
+
+// Remove mocks base method.
+func (m *MockGitProviderClient) Remove(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockGitProviderClientMockRecorder) Remove(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockGitProviderClient)(nil).Remove), arg0)
+}
+
+// SetBasicAuth mocks base method.
+func (m *MockGitProviderClient) SetBasicAuth(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBasicAuth", arg0, arg1)
+}
+
+// SetBasicAuth indicates an expected call of SetBasicAuth.
+func (mr *MockGitProviderClientMockRecorder) SetBasicAuth(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBasicAuth", reflect.TypeOf((*MockGitProviderClient)(nil).SetBasicAuth), arg0, arg1)
+}
+
+// SetSSHAuth mocks base method.
+func (m *MockGitProviderClient) SetSSHAuth(arg0, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSSHAuth", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSSHAuth indicates an expected call of SetSSHAuth.
+func (mr *MockGitProviderClientMockRecorder) SetSSHAuth(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSSHAuth", reflect.TypeOf((*MockGitProviderClient)(nil).SetSSHAuth), arg0, arg1, arg2, arg3)
+}
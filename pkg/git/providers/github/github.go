@@ -60,6 +60,8 @@ type GithubProviderClient interface {
 	CheckAccessTokenPermissions(checkPATPermission string, allPermissionScopes string) error
 	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
 	DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error
+	CreatePullRequest(ctx context.Context, owner, repo string, opts git.PullRequestOpts) (*git.PullRequest, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*git.PullRequest, error)
 }
 
 func New(gitProviderClient GitProviderClient, githubProviderClient GithubProviderClient, opts Options, auth git.TokenAuth) (git.Provider, error) {
@@ -203,6 +205,16 @@ func (g *githubProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts
 	return g.githubProviderClient.DeleteRepo(ctx, opts)
 }
 
+// CreatePullRequest opens a pull request in the configured repository
+func (g *githubProvider) CreatePullRequest(ctx context.Context, opts git.PullRequestOpts) (*git.PullRequest, error) {
+	return g.githubProviderClient.CreatePullRequest(ctx, g.options.Owner, g.options.Repository, opts)
+}
+
+// GetPullRequest describes a pull request of the configured repository
+func (g *githubProvider) GetPullRequest(ctx context.Context, number int) (*git.PullRequest, error) {
+	return g.githubProviderClient.GetPullRequest(ctx, g.options.Owner, g.options.Repository, number)
+}
+
 type GitProviderNotFoundError struct {
 	Provider string
 }
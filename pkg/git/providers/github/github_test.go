@@ -258,3 +258,34 @@ func TestGetNonExistantRepoSucceeds(t *testing.T) {
 		})
 	}
 }
+
+func TestPullRequests(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	gitproviderclient := mocks.NewMockGitProviderClient(mockCtrl)
+	gitproviderclient.EXPECT().SetTokenAuth(validPATValue, "Jeff")
+	githubproviderclient := mocks.NewMockGithubProviderClient(mockCtrl)
+
+	opts := git.PullRequestOpts{Title: "title", Head: "eksa-cluster", Base: "main"}
+	pr := &git.PullRequest{Number: 1, Url: "https://github.com/Jeff/testRepo/pull/1"}
+	githubproviderclient.EXPECT().CreatePullRequest(ctx, "Jeff", "testRepo", opts).Return(pr, nil)
+	githubproviderclient.EXPECT().GetPullRequest(ctx, "Jeff", "testRepo", 1).Return(pr, nil)
+
+	auth := git.TokenAuth{Token: validPATValue, Username: "Jeff"}
+	githubProvider, err := github.New(gitproviderclient, githubproviderclient, github.Options{Repository: "testRepo", Owner: "Jeff"}, auth)
+	if err != nil {
+		t.Fatalf("error when instantiating github provider: %v, wanted nil", err)
+	}
+
+	created, err := githubProvider.CreatePullRequest(ctx, opts)
+	if err != nil {
+		t.Fatalf("error when calling CreatePullRequest %v, wanted nil", err)
+	}
+	assert.Equal(t, pr, created)
+
+	got, err := githubProvider.GetPullRequest(ctx, 1)
+	if err != nil {
+		t.Fatalf("error when calling GetPullRequest %v, wanted nil", err)
+	}
+	assert.Equal(t, pr, got)
+}
@@ -212,6 +212,21 @@ func (mr *MockGithubProviderClientMockRecorder) CheckAccessTokenPermissions(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAccessTokenPermissions", reflect.TypeOf((*MockGithubProviderClient)(nil).CheckAccessTokenPermissions), arg0, arg1)
 }
 
+// CreatePullRequest mocks base method.
+func (m *MockGithubProviderClient) CreatePullRequest(arg0 context.Context, arg1, arg2 string, arg3 git.PullRequestOpts) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockGithubProviderClientMockRecorder) CreatePullRequest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockGithubProviderClient)(nil).CreatePullRequest), arg0, arg1, arg2, arg3)
+}
+
 // CreateRepo mocks base method.
 func (m *MockGithubProviderClient) CreateRepo(arg0 context.Context, arg1 git.CreateRepoOpts) (*git.Repository, error) {
 	m.ctrl.T.Helper()
@@ -256,6 +271,21 @@ func (mr *MockGithubProviderClientMockRecorder) GetAccessTokenPermissions(arg0 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessTokenPermissions", reflect.TypeOf((*MockGithubProviderClient)(nil).GetAccessTokenPermissions), arg0)
 }
 
+// GetPullRequest mocks base method.
+func (m *MockGithubProviderClient) GetPullRequest(arg0 context.Context, arg1, arg2 string, arg3 int) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequest indicates an expected call of GetPullRequest.
+func (mr *MockGithubProviderClientMockRecorder) GetPullRequest(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockGithubProviderClient)(nil).GetPullRequest), arg0, arg1, arg2, arg3)
+}
+
 // GetRepo mocks base method.
 func (m *MockGithubProviderClient) GetRepo(arg0 context.Context, arg1 git.GetRepoOpts) (*git.Repository, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,207 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/gogitlab"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName    = "gitlab"
+	EksaGitlabTokenEnv = "EKSA_GITLAB_TOKEN"
+	GitlabTokenEnv     = "GITLAB_TOKEN"
+	DefaultHostname    = "gitlab.com"
+	// TokenAuthUsername is the username used with a personal access token over https, GitLab only checks the token
+	TokenAuthUsername = "oauth2"
+	gitlabUrlTemplate = "https://%v/%v/%v.git"
+	apiScope          = "api"
+)
+
+type gitlabProvider struct {
+	gitProviderClient    GitProviderClient
+	gitlabProviderClient GitlabProviderClient
+	options              Options
+	auth                 git.TokenAuth
+}
+
+type Options struct {
+	Hostname   string
+	Repository string
+	Owner      string
+	Personal   bool
+}
+
+// GitProviderClient represents the attributes that the GitLab provider requires of a low-level git implementation (e.g. gogit) in order to function.
+// Any basic git implementation (gogit, an executable wrapper, etc) which supports these methods can be used by the GitLab specific provider.
+type GitProviderClient interface {
+	Add(filename string) error
+	Remove(filename string) error
+	Clone(ctx context.Context, repourl string) error
+	Commit(message string) error
+	Push(ctx context.Context) error
+	Pull(ctx context.Context, branch string) error
+	Init(url string) error
+	Branch(name string) error
+	SetTokenAuth(token string, username string)
+}
+
+// GitlabProviderClient represents the attributes that the GitLab provider requires of a library to directly connect to and interact with the GitLab API.
+type GitlabProviderClient interface {
+	GetRepo(ctx context.Context, opts git.GetRepoOpts) (repo *git.Repository, err error)
+	CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (repo *git.Repository, err error)
+	AuthenticatedUser(ctx context.Context) (*gogitlab.User, error)
+	Group(ctx context.Context, group string) (*gogitlab.Group, error)
+	AccessTokenScopes(ctx context.Context) ([]string, error)
+	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
+	DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error
+}
+
+func New(gitProviderClient GitProviderClient, gitlabProviderClient GitlabProviderClient, opts Options, auth git.TokenAuth) (git.Provider, error) {
+	if opts.Hostname == "" {
+		opts.Hostname = DefaultHostname
+	}
+	gitProviderClient.SetTokenAuth(auth.Token, auth.Username)
+	return &gitlabProvider{
+		gitProviderClient:    gitProviderClient,
+		gitlabProviderClient: gitlabProviderClient,
+		options:              opts,
+		auth:                 auth,
+	}, nil
+}
+
+func (g *gitlabProvider) Add(filename string) error {
+	return g.gitProviderClient.Add(filename)
+}
+
+func (g *gitlabProvider) Remove(filename string) error {
+	return g.gitProviderClient.Remove(filename)
+}
+
+func (g *gitlabProvider) Clone(ctx context.Context) error {
+	return g.gitProviderClient.Clone(ctx, g.RepoUrl())
+}
+
+func (g *gitlabProvider) Commit(message string) error {
+	return g.gitProviderClient.Commit(message)
+}
+
+func (g *gitlabProvider) Push(ctx context.Context) error {
+	return g.gitProviderClient.Push(ctx)
+}
+
+func (g *gitlabProvider) Pull(ctx context.Context, branch string) error {
+	return g.gitProviderClient.Pull(ctx, branch)
+}
+
+func (g *gitlabProvider) Init() error {
+	return g.gitProviderClient.Init(g.RepoUrl())
+}
+
+func (g *gitlabProvider) Branch(name string) error {
+	return g.gitProviderClient.Branch(name)
+}
+
+// CreateRepo creates an empty GitLab project. The repository must be initialized locally
+// before it can be successfully cloned.
+func (g *gitlabProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	return g.gitlabProviderClient.CreateRepo(ctx, opts)
+}
+
+// GetRepo describes a remote repository, return the repo name if it exists.
+// If the repo does not exist, a nil repo is returned.
+func (g *gitlabProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := g.options.Repository
+	o := g.options.Owner
+	logger.V(3).Info("Describing GitLab repository", "name", r, "owner", o, "hostname", g.options.Hostname)
+	repo, err := g.gitlabProviderClient.GetRepo(ctx, git.GetRepoOpts{Owner: o, Repository: r})
+	if err != nil {
+		var e *git.RepositoryDoesNotExistError
+		if errors.As(err, &e) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	return repo, nil
+}
+
+// Validate checks the GitLab access token has the api scope and the authenticated user can access the owner
+func (g *gitlabProvider) Validate(ctx context.Context) error {
+	user, err := g.gitlabProviderClient.AuthenticatedUser(ctx)
+	if err != nil {
+		return err
+	}
+	scopes, err := g.gitlabProviderClient.AccessTokenScopes(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasScope(scopes, apiScope) {
+		return fmt.Errorf("gitlab access token scopes %v don't include the required scope %s", scopes, apiScope)
+	}
+	logger.MarkPass("GitLab personal access token has the required api scope")
+	if g.options.Personal {
+		if !strings.EqualFold(g.options.Owner, user.Username) {
+			return fmt.Errorf("the authenticated GitLab user and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", g.options.Owner, g.options.Owner)
+		}
+		return nil
+	}
+	if _, err = g.gitlabProviderClient.Group(ctx, g.options.Owner); err != nil {
+		return fmt.Errorf("the authenticated gitlab user doesn't have proper access to gitlab group %s, %v", g.options.Owner, err)
+	}
+	return nil
+}
+
+func (g *gitlabProvider) RepoUrl() string {
+	return fmt.Sprintf(gitlabUrlTemplate, g.options.Hostname, g.options.Owner, g.options.Repository)
+}
+
+func (g *gitlabProvider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	return g.gitlabProviderClient.PathExists(ctx, owner, repo, branch, path)
+}
+
+func (g *gitlabProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	return g.gitlabProviderClient.DeleteRepo(ctx, opts)
+}
+
+func (g *gitlabProvider) CreatePullRequest(ctx context.Context, opts git.PullRequestOpts) (*git.PullRequest, error) {
+	return nil, fmt.Errorf("opening merge requests is not supported by the %s provider", GitProviderName)
+}
+
+func (g *gitlabProvider) GetPullRequest(ctx context.Context, number int) (*git.PullRequest, error) {
+	return nil, fmt.Errorf("getting merge requests is not supported by the %s provider", GitProviderName)
+}
+
+// GetGitlabAccessTokenFromEnv reads the GitLab access token and exports it as GITLAB_TOKEN, the variable Flux reads it from
+func GetGitlabAccessTokenFromEnv() (string, error) {
+	logger.V(4).Info("Checking validity of GitLab Access Token environment variable", "env var", EksaGitlabTokenEnv)
+	val, ok := os.LookupEnv(EksaGitlabTokenEnv)
+	if !ok || val == "" {
+		return "", fmt.Errorf("gitlab access token environment variable %s is invalid; could not get var from environment", EksaGitlabTokenEnv)
+	}
+	if err := os.Setenv(GitlabTokenEnv, val); err != nil {
+		return "", fmt.Errorf("unable to set %s: %v", GitlabTokenEnv, err)
+	}
+	return val, nil
+}
+
+// ApiUrl returns the base url of the GitLab API of a hostname
+func ApiUrl(hostname string) string {
+	if hostname == "" {
+		hostname = DefaultHostname
+	}
+	return "https://" + hostname
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
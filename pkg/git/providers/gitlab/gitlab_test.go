@@ -0,0 +1,175 @@
+package gitlab_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/gogitlab"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab/mocks"
+)
+
+const token = "glpat-token"
+
+var auth = git.TokenAuth{Token: token, Username: gitlab.TokenAuthUsername}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		testName          string
+		owner             string
+		personal          bool
+		authenticatedUser string
+		scopes            []string
+		groupErr          error
+		wantErr           string
+	}{
+		{
+			testName:          "good personal repo",
+			owner:             "janedoe",
+			personal:          true,
+			authenticatedUser: "janedoe",
+			scopes:            []string{"api", "read_user"},
+		},
+		{
+			testName:          "good group repo",
+			owner:             "platform/clusters",
+			authenticatedUser: "janedoe",
+			scopes:            []string{"api"},
+		},
+		{
+			testName:          "token without api scope",
+			owner:             "janedoe",
+			personal:          true,
+			authenticatedUser: "janedoe",
+			scopes:            []string{"read_repository", "write_repository"},
+			wantErr:           "don't include the required scope api",
+		},
+		{
+			testName:          "wrong owner for a personal repo",
+			owner:             "nobody",
+			personal:          true,
+			authenticatedUser: "janedoe",
+			scopes:            []string{"api"},
+			wantErr:           "the authenticated GitLab user and owner nobody specified in the EKS-A gitops spec don't match",
+		},
+		{
+			testName:          "user can't access the group",
+			owner:             "hidden",
+			authenticatedUser: "janedoe",
+			scopes:            []string{"api"},
+			groupErr:          errors.New("404 Not Found"),
+			wantErr:           "doesn't have proper access to gitlab group hidden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			gitProviderClient := mocks.NewMockGitProviderClient(mockCtrl)
+			gitlabProviderClient := mocks.NewMockGitlabProviderClient(mockCtrl)
+
+			gitProviderClient.EXPECT().SetTokenAuth(token, gitlab.TokenAuthUsername)
+			gitlabProviderClient.EXPECT().AuthenticatedUser(ctx).Return(&gogitlab.User{Username: tt.authenticatedUser}, nil)
+			gitlabProviderClient.EXPECT().AccessTokenScopes(ctx).Return(tt.scopes, nil)
+			if !tt.personal {
+				gitlabProviderClient.EXPECT().Group(ctx, tt.owner).Return(&gogitlab.Group{FullPath: tt.owner}, tt.groupErr)
+			}
+
+			p, err := gitlab.New(gitProviderClient, gitlabProviderClient, gitlab.Options{Owner: tt.owner, Repository: "flux-fleet", Personal: tt.personal}, auth)
+			g.Expect(err).To(Succeed())
+
+			err = p.Validate(ctx)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestGetRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	gitProviderClient := mocks.NewMockGitProviderClient(mockCtrl)
+	gitlabProviderClient := mocks.NewMockGitlabProviderClient(mockCtrl)
+	opts := git.GetRepoOpts{Owner: "janedoe", Repository: "flux-fleet"}
+	want := &git.Repository{Name: "flux-fleet", Owner: "janedoe"}
+
+	gitProviderClient.EXPECT().SetTokenAuth(token, gitlab.TokenAuthUsername)
+	gitlabProviderClient.EXPECT().GetRepo(ctx, opts).Return(want, nil)
+
+	p, err := gitlab.New(gitProviderClient, gitlabProviderClient, gitlab.Options{Owner: "janedoe", Repository: "flux-fleet"}, auth)
+	g.Expect(err).To(Succeed())
+	g.Expect(p.GetRepo(ctx)).To(Equal(want))
+}
+
+func TestGetRepoNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	gitProviderClient := mocks.NewMockGitProviderClient(mockCtrl)
+	gitlabProviderClient := mocks.NewMockGitlabProviderClient(mockCtrl)
+
+	gitProviderClient.EXPECT().SetTokenAuth(token, gitlab.TokenAuthUsername)
+	gitlabProviderClient.EXPECT().GetRepo(ctx, gomock.Any()).Return(nil, &git.RepositoryDoesNotExistError{Err: errors.New("404")})
+
+	p, err := gitlab.New(gitProviderClient, gitlabProviderClient, gitlab.Options{Owner: "janedoe", Repository: "flux-fleet"}, auth)
+	g.Expect(err).To(Succeed())
+	repo, err := p.GetRepo(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestClone(t *testing.T) {
+	tests := []struct {
+		testName string
+		hostname string
+		wantUrl  string
+	}{
+		{testName: "default hostname", wantUrl: "https://gitlab.com/platform/clusters/flux-fleet.git"},
+		{testName: "self-managed", hostname: "gitlab.example.com", wantUrl: "https://gitlab.example.com/platform/clusters/flux-fleet.git"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			mockCtrl := gomock.NewController(t)
+			gitProviderClient := mocks.NewMockGitProviderClient(mockCtrl)
+			gitlabProviderClient := mocks.NewMockGitlabProviderClient(mockCtrl)
+
+			gitProviderClient.EXPECT().SetTokenAuth(token, gitlab.TokenAuthUsername)
+			gitProviderClient.EXPECT().Clone(ctx, tt.wantUrl)
+
+			p, err := gitlab.New(gitProviderClient, gitlabProviderClient, gitlab.Options{Hostname: tt.hostname, Owner: "platform/clusters", Repository: "flux-fleet"}, auth)
+			g.Expect(err).To(Succeed())
+			g.Expect(p.Clone(ctx)).To(Succeed())
+		})
+	}
+}
+
+func TestGetGitlabAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	os.Setenv(gitlab.EksaGitlabTokenEnv, token)
+	defer os.Unsetenv(gitlab.EksaGitlabTokenEnv)
+	defer os.Unsetenv(gitlab.GitlabTokenEnv)
+
+	g.Expect(gitlab.GetGitlabAccessTokenFromEnv()).To(Equal(token))
+	g.Expect(os.Getenv(gitlab.GitlabTokenEnv)).To(Equal(token))
+}
+
+func TestGetGitlabAccessTokenFromEnvUnset(t *testing.T) {
+	g := NewWithT(t)
+	os.Unsetenv(gitlab.EksaGitlabTokenEnv)
+
+	_, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring("EKSA_GITLAB_TOKEN is invalid")))
+}
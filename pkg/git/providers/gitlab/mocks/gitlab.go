@@ -0,0 +1,288 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/git/providers/gitlab (interfaces: GitProviderClient,GitlabProviderClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	git "github.com/aws/eks-anywhere/pkg/git"
+	gogitlab "github.com/aws/eks-anywhere/pkg/git/gogitlab"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGitProviderClient is a mock of GitProviderClient interface.
+type MockGitProviderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitProviderClientMockRecorder
+}
+
+// MockGitProviderClientMockRecorder is the mock recorder for MockGitProviderClient.
+type MockGitProviderClientMockRecorder struct {
+	mock *MockGitProviderClient
+}
+
+// NewMockGitProviderClient creates a new mock instance.
+func NewMockGitProviderClient(ctrl *gomock.Controller) *MockGitProviderClient {
+	mock := &MockGitProviderClient{ctrl: ctrl}
+	mock.recorder = &MockGitProviderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitProviderClient) EXPECT() *MockGitProviderClientMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockGitProviderClient) Add(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockGitProviderClientMockRecorder) Add(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockGitProviderClient)(nil).Add), arg0)
+}
+
+// Branch mocks base method.
+func (m *MockGitProviderClient) Branch(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Branch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Branch indicates an expected call of Branch.
+func (mr *MockGitProviderClientMockRecorder) Branch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Branch", reflect.TypeOf((*MockGitProviderClient)(nil).Branch), arg0)
+}
+
+// Clone mocks base method.
+func (m *MockGitProviderClient) Clone(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockGitProviderClientMockRecorder) Clone(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockGitProviderClient)(nil).Clone), arg0, arg1)
+}
+
+// Commit mocks base method.
+func (m *MockGitProviderClient) Commit(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockGitProviderClientMockRecorder) Commit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockGitProviderClient)(nil).Commit), arg0)
+}
+
+// Init mocks base method.
+func (m *MockGitProviderClient) Init(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Init", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Init indicates an expected call of Init.
+func (mr *MockGitProviderClientMockRecorder) Init(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockGitProviderClient)(nil).Init), arg0)
+}
+
+// Pull mocks base method.
+func (m *MockGitProviderClient) Pull(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockGitProviderClientMockRecorder) Pull(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockGitProviderClient)(nil).Pull), arg0, arg1)
+}
+
+// Push mocks base method.
+func (m *MockGitProviderClient) Push(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockGitProviderClientMockRecorder) Push(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockGitProviderClient)(nil).Push), arg0)
+}
+
+// Remove mocks base method.
+func (m *MockGitProviderClient) Remove(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockGitProviderClientMockRecorder) Remove(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockGitProviderClient)(nil).Remove), arg0)
+}
+
+// SetTokenAuth mocks base method.
+func (m *MockGitProviderClient) SetTokenAuth(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTokenAuth", arg0, arg1)
+}
+
+// SetTokenAuth indicates an expected call of SetTokenAuth.
+func (mr *MockGitProviderClientMockRecorder) SetTokenAuth(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTokenAuth", reflect.TypeOf((*MockGitProviderClient)(nil).SetTokenAuth), arg0, arg1)
+}
+
+// MockGitlabProviderClient is a mock of GitlabProviderClient interface.
+type MockGitlabProviderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitlabProviderClientMockRecorder
+}
+
+// MockGitlabProviderClientMockRecorder is the mock recorder for MockGitlabProviderClient.
+type MockGitlabProviderClientMockRecorder struct {
+	mock *MockGitlabProviderClient
+}
+
+// NewMockGitlabProviderClient creates a new mock instance.
+func NewMockGitlabProviderClient(ctrl *gomock.Controller) *MockGitlabProviderClient {
+	mock := &MockGitlabProviderClient{ctrl: ctrl}
+	mock.recorder = &MockGitlabProviderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitlabProviderClient) EXPECT() *MockGitlabProviderClientMockRecorder {
+	return m.recorder
+}
+
+// AccessTokenScopes mocks base method.
+func (m *MockGitlabProviderClient) AccessTokenScopes(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccessTokenScopes", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccessTokenScopes indicates an expected call of AccessTokenScopes.
+func (mr *MockGitlabProviderClientMockRecorder) AccessTokenScopes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccessTokenScopes", reflect.TypeOf((*MockGitlabProviderClient)(nil).AccessTokenScopes), arg0)
+}
+
+// AuthenticatedUser mocks base method.
+func (m *MockGitlabProviderClient) AuthenticatedUser(arg0 context.Context) (*gogitlab.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticatedUser", arg0)
+	ret0, _ := ret[0].(*gogitlab.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticatedUser indicates an expected call of AuthenticatedUser.
+func (mr *MockGitlabProviderClientMockRecorder) AuthenticatedUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticatedUser", reflect.TypeOf((*MockGitlabProviderClient)(nil).AuthenticatedUser), arg0)
+}
+
+// CreateRepo mocks base method.
+func (m *MockGitlabProviderClient) CreateRepo(arg0 context.Context, arg1 git.CreateRepoOpts) (*git.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRepo", arg0, arg1)
+	ret0, _ := ret[0].(*git.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRepo indicates an expected call of CreateRepo.
+func (mr *MockGitlabProviderClientMockRecorder) CreateRepo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepo", reflect.TypeOf((*MockGitlabProviderClient)(nil).CreateRepo), arg0, arg1)
+}
+
+// DeleteRepo mocks base method.
+func (m *MockGitlabProviderClient) DeleteRepo(arg0 context.Context, arg1 git.DeleteRepoOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRepo", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRepo indicates an expected call of DeleteRepo.
+func (mr *MockGitlabProviderClientMockRecorder) DeleteRepo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRepo", reflect.TypeOf((*MockGitlabProviderClient)(nil).DeleteRepo), arg0, arg1)
+}
+
+// GetRepo mocks base method.
+func (m *MockGitlabProviderClient) GetRepo(arg0 context.Context, arg1 git.GetRepoOpts) (*git.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepo", arg0, arg1)
+	ret0, _ := ret[0].(*git.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepo indicates an expected call of GetRepo.
+func (mr *MockGitlabProviderClientMockRecorder) GetRepo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepo", reflect.TypeOf((*MockGitlabProviderClient)(nil).GetRepo), arg0, arg1)
+}
+
+// Group mocks base method.
+func (m *MockGitlabProviderClient) Group(arg0 context.Context, arg1 string) (*gogitlab.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Group", arg0, arg1)
+	ret0, _ := ret[0].(*gogitlab.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Group indicates an expected call of Group.
+func (mr *MockGitlabProviderClientMockRecorder) Group(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Group", reflect.TypeOf((*MockGitlabProviderClient)(nil).Group), arg0, arg1)
+}
+
+// PathExists mocks base method.
+func (m *MockGitlabProviderClient) PathExists(arg0 context.Context, arg1, arg2, arg3, arg4 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathExists", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PathExists indicates an expected call of PathExists.
+func (mr *MockGitlabProviderClientMockRecorder) PathExists(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathExists", reflect.TypeOf((*MockGitlabProviderClient)(nil).PathExists), arg0, arg1, arg2, arg3, arg4)
+}
@@ -0,0 +1,163 @@
+package managementbackup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ArchiveVersion is the version of the archive layout written by Backup. Restore refuses archives with a different version
+	ArchiveVersion = "v1"
+
+	metadataFileName = "metadata.yaml"
+	capiDir          = "capi"
+	eksaDir          = "eksa"
+	secretsDir       = "secrets"
+)
+
+// Metadata describes the content of a management cluster backup archive
+type Metadata struct {
+	Version           string    `json:"version"`
+	EksaVersion       string    `json:"eksaVersion,omitempty"`
+	ManagementCluster string    `json:"managementCluster"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+func writeMetadata(dir string, metadata *Metadata) error {
+	content, err := yaml.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("error marshalling backup metadata: %v", err)
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, metadataFileName), content, 0o600)
+}
+
+func readMetadata(dir string) (*Metadata, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, metadataFileName))
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup metadata, the file is not a management cluster backup: %v", err)
+	}
+
+	metadata := &Metadata{}
+	if err = yaml.UnmarshalStrict(content, metadata); err != nil {
+		return nil, fmt.Errorf("error parsing backup metadata: %v", err)
+	}
+
+	if metadata.Version != ArchiveVersion {
+		return nil, fmt.Errorf("backup archive version %s is not supported, only %s is supported", metadata.Version, ArchiveVersion)
+	}
+
+	return metadata, nil
+}
+
+// writeArchive packs all the files in dir in a gzipped tarball
+func writeArchive(dir, archivePath string) (err error) {
+	f, err := os.OpenFile(archivePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("error creating backup archive: %v", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("error writing backup archive: %v", closeErr)
+		}
+	}()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(content)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error writing backup archive: %v", err)
+	}
+
+	if err = tw.Close(); err != nil {
+		return fmt.Errorf("error writing backup archive: %v", err)
+	}
+	if err = gw.Close(); err != nil {
+		return fmt.Errorf("error writing backup archive: %v", err)
+	}
+
+	return nil
+}
+
+// extractArchive unpacks a tarball written by writeArchive in dir
+func extractArchive(archivePath, dir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("error opening backup archive: %v", err)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("error reading backup archive: %v", err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading backup archive: %v", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid file path %s in backup archive", header.Name)
+		}
+
+		if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return err
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("error reading %s from backup archive: %v", header.Name, err)
+		}
+		if err = ioutil.WriteFile(path, content, 0o600); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,395 @@
+package managementbackup
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+var (
+	pausedAnnotation        = (&v1alpha1.Cluster{}).PausedAnnotation()
+	capiClusterResourceType = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+
+	// eksaResourceTypes are all the EKS-A objects saved in the backup
+	eksaResourceTypes = []string{
+		"bundles",
+		"awsiamconfigs",
+		"oidcconfigs",
+		"gitopsconfigs",
+		"awsdatacenterconfigs",
+		"dockerdatacenterconfigs",
+		"vspheredatacenterconfigs",
+		"vspheremachineconfigs",
+		"tinkerbelldatacenterconfigs",
+		"tinkerbellmachineconfigs",
+		"clusters",
+	}
+)
+
+type ClusterctlClient interface {
+	BackupManagement(ctx context.Context, cluster *types.Cluster, directory string) error
+	RestoreManagement(ctx context.Context, cluster *types.Cluster, directory string) error
+}
+
+type KubectlClient interface {
+	ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string) ([]unstructured.Unstructured, error)
+	GetNamespace(ctx context.Context, kubeconfig string, namespace string) error
+	CreateNamespace(ctx context.Context, kubeconfig string, namespace string) error
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	GetResource(ctx context.Context, resourceType string, name string, kubeconfig string, namespace string) (bool, error)
+	KubeconfigSecretAvailable(ctx context.Context, kubeconfig string, clusterName string, namespace string) (bool, error)
+	MergePatchResource(ctx context.Context, resourceType, name, patch, kubeconfig, namespace string) error
+	RemoveAnnotationInNamespace(ctx context.Context, resourceType, objectName, key string, cluster *types.Cluster, namespace string) error
+}
+
+// Manager saves all the EKS-A and CAPI objects of a management cluster in an archive and restores them
+// in a different cluster
+type Manager struct {
+	clusterctl ClusterctlClient
+	kubectl    KubectlClient
+	workDir    string
+	now        func() time.Time
+}
+
+type ManagerOpt func(*Manager)
+
+// WithNow replaces the clock used to set the backup creation time
+func WithNow(now func() time.Time) ManagerOpt {
+	return func(m *Manager) {
+		m.now = now
+	}
+}
+
+// NewManager builds a Manager. The archive content is staged in a temporary folder inside workDir,
+// which needs to be reachable by clusterctl
+func NewManager(clusterctl ClusterctlClient, kubectl KubectlClient, workDir string, opts ...ManagerOpt) *Manager {
+	m := &Manager{
+		clusterctl: clusterctl,
+		kubectl:    kubectl,
+		workDir:    workDir,
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Backup writes to archivePath a gzipped tarball with the CAPI objects, as saved by clusterctl move --to-directory,
+// the EKS-A objects from all namespaces and the secrets of the eksa-system namespace
+func (m *Manager) Backup(ctx context.Context, managementCluster *types.Cluster, archivePath string) error {
+	dir, err := m.stagingDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	// clusterctl resumes all the clusters once the objects are saved, so the ones already paused are paused again
+	capiClusters, err := m.kubectl.ListObjects(ctx, capiClusterResourceType, constants.EksaSystemNamespace, managementCluster.KubeconfigFile)
+	if err != nil {
+		return err
+	}
+	pausedCAPIClusters := pausedClusters(capiClusters)
+
+	logger.V(3).Info("Saving CAPI objects")
+	if err = os.MkdirAll(filepath.Join(dir, capiDir), 0o700); err != nil {
+		return err
+	}
+	if err = m.clusterctl.BackupManagement(ctx, managementCluster, filepath.Join(dir, capiDir)); err != nil {
+		return err
+	}
+	if err = m.setCAPIClustersPaused(ctx, managementCluster, pausedCAPIClusters, true); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Saving EKS-A objects")
+	for _, resourceType := range eksaResourceTypes {
+		objs, err := m.kubectl.ListObjects(ctx, qualifiedEksaResourceType(resourceType), "", managementCluster.KubeconfigFile)
+		if err != nil {
+			return err
+		}
+		for i := range objs {
+			cleanObject(&objs[i])
+		}
+		if err = writeObjects(filepath.Join(dir, eksaDir), objs); err != nil {
+			return err
+		}
+	}
+
+	logger.V(3).Info("Saving secrets", "namespace", constants.EksaSystemNamespace)
+	secrets, err := m.kubectl.ListObjects(ctx, "secrets", constants.EksaSystemNamespace, managementCluster.KubeconfigFile)
+	if err != nil {
+		return err
+	}
+	secrets = filterSecrets(secrets)
+	for i := range secrets {
+		cleanObject(&secrets[i])
+	}
+	if err = writeObjects(filepath.Join(dir, secretsDir), secrets); err != nil {
+		return err
+	}
+
+	metadata := &Metadata{
+		Version:           ArchiveVersion,
+		EksaVersion:       version.Get().GitVersion,
+		ManagementCluster: managementCluster.Name,
+		CreatedAt:         m.now().UTC().Truncate(time.Second),
+	}
+	if err = writeMetadata(dir, metadata); err != nil {
+		return err
+	}
+
+	return writeArchive(dir, archivePath)
+}
+
+// Restore creates in the cluster all the objects saved in a backup archive. The EKS-A and CAPI objects are paused
+// until all the objects are verified to exist. If the verification fails, they are left paused so they can be
+// fixed before the controllers start reconciling them. clusterctl resumes the CAPI clusters once it has restored
+// them, so the CAPI objects are paused with an annotation before being restored, which keeps the controllers away
+// from them until they are resumed
+func (m *Manager) Restore(ctx context.Context, cluster *types.Cluster, archivePath string) (*Metadata, error) {
+	dir, err := m.stagingDir()
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err = extractArchive(archivePath, dir); err != nil {
+		return nil, err
+	}
+	metadata, err := readMetadata(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	capiObjects, err := readObjects(filepath.Join(dir, capiDir))
+	if err != nil {
+		return nil, err
+	}
+	eksaObjects, err := readObjects(filepath.Join(dir, eksaDir))
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := readObjects(filepath.Join(dir, secretsDir))
+	if err != nil {
+		return nil, err
+	}
+	clustersLast(eksaObjects)
+	capiClusters := capiClustersIn(capiObjects)
+
+	if err = m.createNamespaces(ctx, cluster, capiObjects, eksaObjects, secrets); err != nil {
+		return nil, err
+	}
+
+	// Objects already paused in the backup are left paused after the restore
+	capiToResume, err := pauseObjectsInDir(filepath.Join(dir, capiDir))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.V(3).Info("Restoring CAPI objects")
+	if err = m.clusterctl.RestoreManagement(ctx, cluster, filepath.Join(dir, capiDir)); err != nil {
+		return nil, err
+	}
+	if err = m.setCAPIClustersPaused(ctx, cluster, capiClusters, true); err != nil {
+		return nil, err
+	}
+
+	if len(secrets) > 0 {
+		logger.V(3).Info("Restoring secrets")
+		if err = m.apply(ctx, cluster, secrets); err != nil {
+			return nil, err
+		}
+	}
+
+	// Objects already paused in the backup are left paused after the restore
+	var toResume []unstructured.Unstructured
+	for i := range eksaObjects {
+		annotations := eksaObjects[i].GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		if _, paused := annotations[pausedAnnotation]; !paused {
+			toResume = append(toResume, eksaObjects[i])
+		}
+		annotations[pausedAnnotation] = "true"
+		eksaObjects[i].SetAnnotations(annotations)
+	}
+
+	if len(eksaObjects) > 0 {
+		logger.V(3).Info("Restoring EKS-A objects")
+		if err = m.apply(ctx, cluster, eksaObjects); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.V(3).Info("Verifying restored objects")
+	if err = m.verify(ctx, cluster, capiClusters, eksaObjects, secrets); err != nil {
+		return nil, fmt.Errorf("restored objects are paused until the problem is fixed: %v", err)
+	}
+
+	logger.V(3).Info("Resuming reconciliation")
+	for i := range toResume {
+		obj := &toResume[i]
+		err = m.kubectl.RemoveAnnotationInNamespace(ctx, resourceType(obj), obj.GetName(), pausedAnnotation, cluster, obj.GetNamespace())
+		if err != nil {
+			return nil, fmt.Errorf("error resuming reconciliation of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	for i := range capiToResume {
+		obj := &capiToResume[i]
+		err = m.kubectl.RemoveAnnotationInNamespace(ctx, resourceType(obj), obj.GetName(), clusterv1.PausedAnnotation, cluster, obj.GetNamespace())
+		if err != nil {
+			return nil, fmt.Errorf("error resuming reconciliation of %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	if err = m.setCAPIClustersPaused(ctx, cluster, capiClusters, false); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func (m *Manager) stagingDir() (string, error) {
+	if err := os.MkdirAll(m.workDir, os.ModePerm); err != nil {
+		return "", err
+	}
+	dir, err := ioutil.TempDir(m.workDir, "management-backup-")
+	if err != nil {
+		return "", fmt.Errorf("error creating backup staging directory: %v", err)
+	}
+	return dir, nil
+}
+
+func (m *Manager) createNamespaces(ctx context.Context, cluster *types.Cluster, objLists ...[]unstructured.Unstructured) error {
+	created := map[string]bool{}
+	for _, objs := range objLists {
+		for i := range objs {
+			namespace := objs[i].GetNamespace()
+			if namespace == "" || created[namespace] {
+				continue
+			}
+			created[namespace] = true
+			if err := m.kubectl.GetNamespace(ctx, cluster.KubeconfigFile, namespace); err == nil {
+				continue
+			}
+			if err := m.kubectl.CreateNamespace(ctx, cluster.KubeconfigFile, namespace); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) apply(ctx context.Context, cluster *types.Cluster, objs []unstructured.Unstructured) error {
+	content, err := marshalObjects(objs)
+	if err != nil {
+		return err
+	}
+	return m.kubectl.ApplyKubeSpecFromBytes(ctx, cluster, content)
+}
+
+func (m *Manager) setCAPIClustersPaused(ctx context.Context, cluster *types.Cluster, capiClusters []unstructured.Unstructured, paused bool) error {
+	patch := fmt.Sprintf(`{"spec":{"paused":%t}}`, paused)
+	for i := range capiClusters {
+		name, namespace := capiClusters[i].GetName(), capiClusters[i].GetNamespace()
+		if err := m.kubectl.MergePatchResource(ctx, capiClusterResourceType, name, patch, cluster.KubeconfigFile, namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verify checks that all the restored objects exist and that every CAPI cluster has its kubeconfig secret
+func (m *Manager) verify(ctx context.Context, cluster *types.Cluster, capiClusters []unstructured.Unstructured, objLists ...[]unstructured.Unstructured) error {
+	for i := range capiClusters {
+		name, namespace := capiClusters[i].GetName(), capiClusters[i].GetNamespace()
+		available, err := m.kubectl.KubeconfigSecretAvailable(ctx, cluster.KubeconfigFile, name, namespace)
+		if err != nil {
+			return err
+		}
+		if !available {
+			return fmt.Errorf("kubeconfig secret for cluster %s not found", name)
+		}
+	}
+
+	for _, objs := range objLists {
+		for i := range objs {
+			obj := &objs[i]
+			found, err := m.kubectl.GetResource(ctx, resourceType(obj), obj.GetName(), cluster.KubeconfigFile, obj.GetNamespace())
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("%s %s not found in cluster", obj.GetKind(), obj.GetName())
+			}
+		}
+	}
+
+	return nil
+}
+
+func qualifiedEksaResourceType(resourceType string) string {
+	return fmt.Sprintf("%s.%s", resourceType, v1alpha1.GroupVersion.Group)
+}
+
+// filterSecrets skips service account tokens, which are recreated by the api server, and secrets owned
+// by other objects, which are either moved by clusterctl or regenerated by their owner
+func filterSecrets(secrets []unstructured.Unstructured) []unstructured.Unstructured {
+	filtered := make([]unstructured.Unstructured, 0, len(secrets))
+	for _, s := range secrets {
+		secretType, _, _ := unstructured.NestedString(s.Object, "type")
+		if secretType == string(corev1.SecretTypeServiceAccountToken) || len(s.GetOwnerReferences()) > 0 {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+	return filtered
+}
+
+func capiClustersIn(objs []unstructured.Unstructured) []unstructured.Unstructured {
+	var clusters []unstructured.Unstructured
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		if gvk.Group == clusterv1.GroupVersion.Group && gvk.Kind == "Cluster" {
+			clusters = append(clusters, obj)
+		}
+	}
+	return clusters
+}
+
+func pausedClusters(capiClusters []unstructured.Unstructured) []unstructured.Unstructured {
+	var paused []unstructured.Unstructured
+	for _, c := range capiClusters {
+		if isPaused, _, _ := unstructured.NestedBool(c.Object, "spec", "paused"); isPaused {
+			paused = append(paused, c)
+		}
+	}
+	return paused
+}
+
+// clustersLast sorts the EKS-A objects so the clusters are restored and resumed after the objects they reference
+func clustersLast(objs []unstructured.Unstructured) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		return objs[i].GetKind() != v1alpha1.ClusterKind && objs[j].GetKind() == v1alpha1.ClusterKind
+	})
+}
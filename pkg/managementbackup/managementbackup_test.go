@@ -0,0 +1,237 @@
+package managementbackup_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/managementbackup"
+	"github.com/aws/eks-anywhere/pkg/managementbackup/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	eksaClusterResourceType    = "clusters.anywhere.eks.amazonaws.com"
+	eksaDatacenterResourceType = "vspheredatacenterconfigs.anywhere.eks.amazonaws.com"
+	capiClusterResourceType    = "clusters.cluster.x-k8s.io"
+)
+
+type managementBackupTest struct {
+	*WithT
+	ctx        context.Context
+	cluster    *types.Cluster
+	clusterctl *mocks.MockClusterctlClient
+	kubectl    *mocks.MockKubectlClient
+	manager    *managementbackup.Manager
+	archive    string
+}
+
+func newManagementBackupTest(t *testing.T) *managementBackupTest {
+	ctrl := gomock.NewController(t)
+	clusterctl := mocks.NewMockClusterctlClient(ctrl)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	dir := t.TempDir()
+	now := func() time.Time { return time.Date(2021, 11, 5, 10, 0, 0, 0, time.UTC) }
+
+	return &managementBackupTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		cluster: &types.Cluster{
+			Name:           "mgmt",
+			KubeconfigFile: "mgmt.kubeconfig",
+		},
+		clusterctl: clusterctl,
+		kubectl:    kubectl,
+		manager:    managementbackup.NewManager(clusterctl, kubectl, filepath.Join(dir, "work"), managementbackup.WithNow(now)),
+		archive:    filepath.Join(dir, "backup.tar.gz"),
+	}
+}
+
+func object(apiVersion, kind, namespace, name string) unstructured.Unstructured {
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func (tt *managementBackupTest) expectBackup(capiClusters ...unstructured.Unstructured) {
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, capiClusterResourceType, constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(capiClusters, nil)
+	tt.clusterctl.EXPECT().BackupManagement(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, dir string) error {
+			capiCluster := "apiVersion: cluster.x-k8s.io/v1alpha3\nkind: Cluster\nmetadata:\n  name: workload\n  namespace: eksa-system\n"
+			return ioutil.WriteFile(filepath.Join(dir, "Cluster_eksa-system_workload.yaml"), []byte(capiCluster), 0o600)
+		},
+	)
+
+	eksaCluster := object("anywhere.eks.amazonaws.com/v1alpha1", "Cluster", "default", "workload")
+	eksaCluster.SetUID("1234")
+	eksaCluster.SetResourceVersion("10")
+	eksaCluster.SetAnnotations(map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"})
+	eksaCluster.Object["status"] = map[string]interface{}{"failureMessage": "error"}
+
+	datacenter := object("anywhere.eks.amazonaws.com/v1alpha1", "VSphereDatacenterConfig", "default", "workload")
+	datacenter.SetAnnotations(map[string]string{"anywhere.eks.amazonaws.com/paused": "true"})
+
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, gomock.Any(), "", tt.cluster.KubeconfigFile).DoAndReturn(
+		func(_ context.Context, resourceType, _, _ string) ([]unstructured.Unstructured, error) {
+			switch resourceType {
+			case eksaClusterResourceType:
+				return []unstructured.Unstructured{eksaCluster}, nil
+			case eksaDatacenterResourceType:
+				return []unstructured.Unstructured{datacenter}, nil
+			default:
+				return nil, nil
+			}
+		},
+	).Times(11)
+
+	credentials := object("v1", "Secret", constants.EksaSystemNamespace, "vsphere-credentials")
+	credentials.Object["type"] = "Opaque"
+	token := object("v1", "Secret", constants.EksaSystemNamespace, "default-token")
+	token.Object["type"] = "kubernetes.io/service-account-token"
+	ownedSecret := object("v1", "Secret", constants.EksaSystemNamespace, "workload-ca")
+	ownedSecret.Object["metadata"].(map[string]interface{})["ownerReferences"] = []interface{}{
+		map[string]interface{}{"apiVersion": "controlplane.cluster.x-k8s.io/v1alpha3", "kind": "KubeadmControlPlane", "name": "workload", "uid": "5678"},
+	}
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, "secrets", constants.EksaSystemNamespace, tt.cluster.KubeconfigFile).Return(
+		[]unstructured.Unstructured{credentials, token, ownedSecret}, nil,
+	)
+}
+
+func (tt *managementBackupTest) expectRestoreUntilVerification() {
+	gomock.InOrder(
+		tt.kubectl.EXPECT().GetNamespace(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(errors.New("not found")),
+		tt.kubectl.EXPECT().CreateNamespace(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace),
+		tt.kubectl.EXPECT().GetNamespace(tt.ctx, tt.cluster.KubeconfigFile, "default"),
+		tt.clusterctl.EXPECT().RestoreManagement(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *types.Cluster, dir string) error {
+				content, err := ioutil.ReadFile(filepath.Join(dir, "Cluster_eksa-system_workload.yaml"))
+				tt.Expect(string(content)).To(ContainSubstring("cluster.x-k8s.io/paused: \"true\""))
+				return err
+			},
+		),
+		tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "workload", `{"spec":{"paused":true}}`, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace),
+		tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *types.Cluster, data []byte) error {
+				tt.Expect(string(data)).To(Equal("apiVersion: v1\nkind: Secret\nmetadata:\n  name: vsphere-credentials\n  namespace: eksa-system\ntype: Opaque\n"))
+				return nil
+			},
+		),
+		tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.cluster, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *types.Cluster, data []byte) error {
+				tt.Expect(string(data)).To(Equal(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereDatacenterConfig
+metadata:
+  annotations:
+    anywhere.eks.amazonaws.com/paused: "true"
+  name: workload
+  namespace: default
+
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  annotations:
+    anywhere.eks.amazonaws.com/paused: "true"
+  name: workload
+  namespace: default
+`))
+				return nil
+			},
+		),
+		tt.kubectl.EXPECT().KubeconfigSecretAvailable(tt.ctx, tt.cluster.KubeconfigFile, "workload", constants.EksaSystemNamespace).Return(true, nil),
+		tt.kubectl.EXPECT().GetResource(tt.ctx, "vspheredatacenterconfig.anywhere.eks.amazonaws.com", "workload", tt.cluster.KubeconfigFile, "default").Return(true, nil),
+	)
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.expectBackup()
+	tt.Expect(tt.manager.Backup(tt.ctx, tt.cluster, tt.archive)).To(Succeed())
+
+	tt.expectRestoreUntilVerification()
+	gomock.InOrder(
+		tt.kubectl.EXPECT().GetResource(tt.ctx, "cluster.anywhere.eks.amazonaws.com", "workload", tt.cluster.KubeconfigFile, "default").Return(true, nil),
+		tt.kubectl.EXPECT().GetResource(tt.ctx, "secret", "vsphere-credentials", tt.cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(true, nil),
+		tt.kubectl.EXPECT().RemoveAnnotationInNamespace(tt.ctx, "cluster.anywhere.eks.amazonaws.com", "workload", "anywhere.eks.amazonaws.com/paused", tt.cluster, "default"),
+		tt.kubectl.EXPECT().RemoveAnnotationInNamespace(tt.ctx, "cluster.cluster.x-k8s.io", "workload", "cluster.x-k8s.io/paused", tt.cluster, constants.EksaSystemNamespace),
+		tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "workload", `{"spec":{"paused":false}}`, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace),
+	)
+
+	metadata, err := tt.manager.Restore(tt.ctx, tt.cluster, tt.archive)
+	tt.Expect(err).To(Not(HaveOccurred()))
+	tt.Expect(metadata).To(Equal(&managementbackup.Metadata{
+		Version:           managementbackup.ArchiveVersion,
+		ManagementCluster: "mgmt",
+		CreatedAt:         time.Date(2021, 11, 5, 10, 0, 0, 0, time.UTC),
+	}))
+}
+
+func TestRestoreVerificationErrorLeavesClustersPaused(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.expectBackup()
+	tt.Expect(tt.manager.Backup(tt.ctx, tt.cluster, tt.archive)).To(Succeed())
+
+	tt.expectRestoreUntilVerification()
+	tt.kubectl.EXPECT().GetResource(tt.ctx, "cluster.anywhere.eks.amazonaws.com", "workload", tt.cluster.KubeconfigFile, "default").Return(false, nil)
+
+	_, err := tt.manager.Restore(tt.ctx, tt.cluster, tt.archive)
+	tt.Expect(err).To(MatchError("restored objects are paused until the problem is fixed: Cluster workload not found in cluster"))
+}
+
+func TestBackupKeepsPausedClustersPaused(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	paused := object("cluster.x-k8s.io/v1alpha3", "Cluster", constants.EksaSystemNamespace, "paused")
+	paused.Object["spec"] = map[string]interface{}{"paused": true}
+	tt.expectBackup(object("cluster.x-k8s.io/v1alpha3", "Cluster", constants.EksaSystemNamespace, "workload"), paused)
+	tt.kubectl.EXPECT().MergePatchResource(tt.ctx, capiClusterResourceType, "paused", `{"spec":{"paused":true}}`, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace)
+
+	tt.Expect(tt.manager.Backup(tt.ctx, tt.cluster, tt.archive)).To(Succeed())
+}
+
+func TestBackupClusterctlError(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.kubectl.EXPECT().ListObjects(tt.ctx, capiClusterResourceType, constants.EksaSystemNamespace, tt.cluster.KubeconfigFile)
+	tt.clusterctl.EXPECT().BackupManagement(tt.ctx, tt.cluster, gomock.Any()).Return(errors.New("error in clusterctl"))
+
+	tt.Expect(tt.manager.Backup(tt.ctx, tt.cluster, tt.archive)).To(MatchError("error in clusterctl"))
+}
+
+func TestRestoreInvalidArchive(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	tt.Expect(ioutil.WriteFile(tt.archive, []byte("not an archive"), 0o600)).To(Succeed())
+
+	_, err := tt.manager.Restore(tt.ctx, tt.cluster, tt.archive)
+	tt.Expect(err).To(MatchError(ContainSubstring("error reading backup archive")))
+}
+
+func TestRestoreUnsupportedArchiveVersion(t *testing.T) {
+	tt := newManagementBackupTest(t)
+	f, err := os.Create(tt.archive)
+	tt.Expect(err).To(Not(HaveOccurred()))
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	metadata := []byte("version: v2\nmanagementCluster: mgmt\ncreatedAt: \"2021-11-05T10:00:00Z\"\n")
+	tt.Expect(tw.WriteHeader(&tar.Header{Name: "metadata.yaml", Mode: 0o600, Size: int64(len(metadata)), Typeflag: tar.TypeReg})).To(Succeed())
+	_, err = tw.Write(metadata)
+	tt.Expect(err).To(Not(HaveOccurred()))
+	tt.Expect(tw.Close()).To(Succeed())
+	tt.Expect(gw.Close()).To(Succeed())
+	tt.Expect(f.Close()).To(Succeed())
+
+	_, err = tt.manager.Restore(tt.ctx, tt.cluster, tt.archive)
+	tt.Expect(err).To(MatchError("backup archive version v2 is not supported, only v1 is supported"))
+}
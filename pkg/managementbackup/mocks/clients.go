@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/managementbackup/managementbackup.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// MockClusterctlClient is a mock of ClusterctlClient interface.
+type MockClusterctlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClusterctlClientMockRecorder
+}
+
+// MockClusterctlClientMockRecorder is the mock recorder for MockClusterctlClient.
+type MockClusterctlClientMockRecorder struct {
+	mock *MockClusterctlClient
+}
+
+// NewMockClusterctlClient creates a new mock instance.
+func NewMockClusterctlClient(ctrl *gomock.Controller) *MockClusterctlClient {
+	mock := &MockClusterctlClient{ctrl: ctrl}
+	mock.recorder = &MockClusterctlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClusterctlClient) EXPECT() *MockClusterctlClientMockRecorder {
+	return m.recorder
+}
+
+// BackupManagement mocks base method.
+func (m *MockClusterctlClient) BackupManagement(ctx context.Context, cluster *types.Cluster, directory string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupManagement", ctx, cluster, directory)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackupManagement indicates an expected call of BackupManagement.
+func (mr *MockClusterctlClientMockRecorder) BackupManagement(ctx, cluster, directory interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupManagement", reflect.TypeOf((*MockClusterctlClient)(nil).BackupManagement), ctx, cluster, directory)
+}
+
+// RestoreManagement mocks base method.
+func (m *MockClusterctlClient) RestoreManagement(ctx context.Context, cluster *types.Cluster, directory string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreManagement", ctx, cluster, directory)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreManagement indicates an expected call of RestoreManagement.
+func (mr *MockClusterctlClientMockRecorder) RestoreManagement(ctx, cluster, directory interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreManagement", reflect.TypeOf((*MockClusterctlClient)(nil).RestoreManagement), ctx, cluster, directory)
+}
+
+// MockKubectlClient is a mock of KubectlClient interface.
+type MockKubectlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubectlClientMockRecorder
+}
+
+// MockKubectlClientMockRecorder is the mock recorder for MockKubectlClient.
+type MockKubectlClientMockRecorder struct {
+	mock *MockKubectlClient
+}
+
+// NewMockKubectlClient creates a new mock instance.
+func NewMockKubectlClient(ctrl *gomock.Controller) *MockKubectlClient {
+	mock := &MockKubectlClient{ctrl: ctrl}
+	mock.recorder = &MockKubectlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
+	return m.recorder
+}
+
+// ApplyKubeSpecFromBytes mocks base method.
+func (m *MockKubectlClient) ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytes", ctx, cluster, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytes indicates an expected call of ApplyKubeSpecFromBytes.
+func (mr *MockKubectlClientMockRecorder) ApplyKubeSpecFromBytes(ctx, cluster, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytes", reflect.TypeOf((*MockKubectlClient)(nil).ApplyKubeSpecFromBytes), ctx, cluster, data)
+}
+
+// CreateNamespace mocks base method.
+func (m *MockKubectlClient) CreateNamespace(ctx context.Context, kubeconfig, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNamespace", ctx, kubeconfig, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNamespace indicates an expected call of CreateNamespace.
+func (mr *MockKubectlClientMockRecorder) CreateNamespace(ctx, kubeconfig, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNamespace", reflect.TypeOf((*MockKubectlClient)(nil).CreateNamespace), ctx, kubeconfig, namespace)
+}
+
+// GetNamespace mocks base method.
+func (m *MockKubectlClient) GetNamespace(ctx context.Context, kubeconfig, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamespace", ctx, kubeconfig, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetNamespace indicates an expected call of GetNamespace.
+func (mr *MockKubectlClientMockRecorder) GetNamespace(ctx, kubeconfig, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespace", reflect.TypeOf((*MockKubectlClient)(nil).GetNamespace), ctx, kubeconfig, namespace)
+}
+
+// GetResource mocks base method.
+func (m *MockKubectlClient) GetResource(ctx context.Context, resourceType, name, kubeconfig, namespace string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResource", ctx, resourceType, name, kubeconfig, namespace)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResource indicates an expected call of GetResource.
+func (mr *MockKubectlClientMockRecorder) GetResource(ctx, resourceType, name, kubeconfig, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResource", reflect.TypeOf((*MockKubectlClient)(nil).GetResource), ctx, resourceType, name, kubeconfig, namespace)
+}
+
+// KubeconfigSecretAvailable mocks base method.
+func (m *MockKubectlClient) KubeconfigSecretAvailable(ctx context.Context, kubeconfig, clusterName, namespace string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KubeconfigSecretAvailable", ctx, kubeconfig, clusterName, namespace)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KubeconfigSecretAvailable indicates an expected call of KubeconfigSecretAvailable.
+func (mr *MockKubectlClientMockRecorder) KubeconfigSecretAvailable(ctx, kubeconfig, clusterName, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KubeconfigSecretAvailable", reflect.TypeOf((*MockKubectlClient)(nil).KubeconfigSecretAvailable), ctx, kubeconfig, clusterName, namespace)
+}
+
+// ListObjects mocks base method.
+func (m *MockKubectlClient) ListObjects(ctx context.Context, resourceType, namespace, kubeconfig string) ([]unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjects", ctx, resourceType, namespace, kubeconfig)
+	ret0, _ := ret[0].([]unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjects indicates an expected call of ListObjects.
+func (mr *MockKubectlClientMockRecorder) ListObjects(ctx, resourceType, namespace, kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockKubectlClient)(nil).ListObjects), ctx, resourceType, namespace, kubeconfig)
+}
+
+// MergePatchResource mocks base method.
+func (m *MockKubectlClient) MergePatchResource(ctx context.Context, resourceType, name, patch, kubeconfig, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePatchResource", ctx, resourceType, name, patch, kubeconfig, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergePatchResource indicates an expected call of MergePatchResource.
+func (mr *MockKubectlClientMockRecorder) MergePatchResource(ctx, resourceType, name, patch, kubeconfig, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePatchResource", reflect.TypeOf((*MockKubectlClient)(nil).MergePatchResource), ctx, resourceType, name, patch, kubeconfig, namespace)
+}
+
+// RemoveAnnotationInNamespace mocks base method.
+func (m *MockKubectlClient) RemoveAnnotationInNamespace(ctx context.Context, resourceType, objectName, key string, cluster *types.Cluster, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAnnotationInNamespace", ctx, resourceType, objectName, key, cluster, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAnnotationInNamespace indicates an expected call of RemoveAnnotationInNamespace.
+func (mr *MockKubectlClientMockRecorder) RemoveAnnotationInNamespace(ctx, resourceType, objectName, key, cluster, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAnnotationInNamespace", reflect.TypeOf((*MockKubectlClient)(nil).RemoveAnnotationInNamespace), ctx, resourceType, objectName, key, cluster, namespace)
+}
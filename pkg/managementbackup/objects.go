@@ -0,0 +1,160 @@
+package managementbackup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// cleanObject removes the fields set by the api server, so the object can be created in a different cluster.
+// Owner references are removed too, since the owners get new uids when they are restored
+func cleanObject(obj *unstructured.Unstructured) {
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetSelfLink("")
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetManagedFields(nil)
+	obj.SetOwnerReferences(nil)
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, lastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+}
+
+func objectFileName(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s_%s_%s.yaml", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
+
+// resourceType returns the kubectl resource type of an object, qualified with the group to avoid
+// clashes between CAPI and EKS-A kinds, like Cluster
+func resourceType(obj *unstructured.Unstructured) string {
+	kind := strings.ToLower(obj.GetKind())
+	if group := obj.GroupVersionKind().Group; group != "" {
+		return fmt.Sprintf("%s.%s", kind, group)
+	}
+	return kind
+}
+
+func writeObjects(dir string, objs []unstructured.Unstructured) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	for i := range objs {
+		content, err := yaml.Marshal(objs[i].Object)
+		if err != nil {
+			return fmt.Errorf("error marshalling %s %s: %v", objs[i].GetKind(), objs[i].GetName(), err)
+		}
+		if err = ioutil.WriteFile(filepath.Join(dir, objectFileName(&objs[i])), content, 0o600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readObjects reads all the objects in dir, one per file. A missing dir is treated as empty
+func readObjects(dir string) ([]unstructured.Unstructured, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup directory %s: %v", dir, err)
+	}
+
+	objs := make([]unstructured.Unstructured, 0, len(files))
+	for _, f := range files {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		obj := unstructured.Unstructured{}
+		if err = yaml.Unmarshal(content, &obj.Object); err != nil {
+			return nil, fmt.Errorf("error parsing %s from backup: %v", f.Name(), err)
+		}
+		objs = append(objs, obj)
+	}
+
+	return objs, nil
+}
+
+// pauseObjectsInDir adds the CAPI paused annotation to all the objects in dir, rewriting their files in place,
+// and returns the objects that were not already paused
+func pauseObjectsInDir(dir string) ([]unstructured.Unstructured, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup directory %s: %v", dir, err)
+	}
+
+	var paused []unstructured.Unstructured
+	for _, f := range files {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		obj := unstructured.Unstructured{}
+		if err = yaml.Unmarshal(content, &obj.Object); err != nil {
+			return nil, fmt.Errorf("error parsing %s from backup: %v", f.Name(), err)
+		}
+
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		if _, ok := annotations[clusterv1.PausedAnnotation]; ok {
+			continue
+		}
+		annotations[clusterv1.PausedAnnotation] = "true"
+		obj.SetAnnotations(annotations)
+
+		if content, err = yaml.Marshal(obj.Object); err != nil {
+			return nil, fmt.Errorf("error marshalling %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		if err = ioutil.WriteFile(path, content, 0o600); err != nil {
+			return nil, err
+		}
+		paused = append(paused, obj)
+	}
+
+	return paused, nil
+}
+
+func marshalObjects(objs []unstructured.Unstructured) ([]byte, error) {
+	resources := make([][]byte, 0, len(objs))
+	for i := range objs {
+		content, err := yaml.Marshal(objs[i].Object)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling %s %s: %v", objs[i].GetKind(), objs[i].GetName(), err)
+		}
+		resources = append(resources, content)
+	}
+
+	return templater.JoinYamlResources(resources...), nil
+}
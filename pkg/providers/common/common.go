@@ -2,6 +2,16 @@ package common
 
 import (
 	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+const (
+	defaultAuditLogMaxAge    = 30
+	defaultAuditLogMaxBackup = 10
+	defaultAuditLogMaxSize   = 512
 )
 
 //go:embed config/audit-policy.yaml
@@ -10,3 +20,60 @@ var auditPolicy string
 func GetAuditPolicy() string {
 	return auditPolicy
 }
+
+// AuditLogConfig holds the audit settings passed to kube-apiserver on the control plane nodes
+type AuditLogConfig struct {
+	Policy    string
+	MaxAge    int
+	MaxBackup int
+	MaxSize   int
+}
+
+// GetAuditLogConfig returns the audit settings for the cluster, using the EKS Anywhere defaults
+// for the ones not set in the cluster spec
+func GetAuditLogConfig(cluster *v1alpha1.Cluster) AuditLogConfig {
+	config := AuditLogConfig{
+		Policy:    GetAuditPolicy(),
+		MaxAge:    defaultAuditLogMaxAge,
+		MaxBackup: defaultAuditLogMaxBackup,
+		MaxSize:   defaultAuditLogMaxSize,
+	}
+	a := cluster.Spec.AuditPolicy
+	if a == nil {
+		return config
+	}
+	if a.Policy != "" {
+		// the policy is indented into a kubeadm file, a trailing new line would leave a blank line behind
+		config.Policy = strings.TrimRight(a.Policy, "\n")
+	}
+	if a.LogMaxAge != 0 {
+		config.MaxAge = a.LogMaxAge
+	}
+	if a.LogMaxBackup != 0 {
+		config.MaxBackup = a.LogMaxBackup
+	}
+	if a.LogMaxSize != 0 {
+		config.MaxSize = a.LogMaxSize
+	}
+	return config
+}
+
+// ValidateUpgradeRolloutStrategy returns an error for the upgrade rollout strategies the providers using the CAPI
+// v1alpha3 KubeadmControlPlane can't honor: a control plane strategy, which that KubeadmControlPlane version doesn't
+// support, or a worker node group strategy with maxSurge 0. Only providers that can't create extra machines during an
+// upgrade, like the ones with a fixed hardware inventory, should allow the latter
+func ValidateUpgradeRolloutStrategy(cluster *v1alpha1.Cluster, providerName string) error {
+	if cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
+		return fmt.Errorf("control plane upgradeRolloutStrategy is not supported by provider %s", providerName)
+	}
+	for i, workerNodeGroup := range cluster.Spec.WorkerNodeGroupConfigurations {
+		if s := workerNodeGroup.UpgradeRolloutStrategy; s != nil && s.RollingUpdate.MaxSurge == 0 {
+			name := workerNodeGroup.Name
+			if name == "" {
+				name = v1alpha1.DefaultWorkerNodeGroupName(i)
+			}
+			return fmt.Errorf("worker node group %s upgradeRolloutStrategy maxSurge 0 is not supported by provider %s", name, providerName)
+		}
+	}
+	return nil
+}
@@ -53,7 +53,7 @@ type provider struct {
 type ProviderKubectlClient interface {
 	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
 	GetKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*bootstrapv1.KubeadmControlPlane, error)
-	GetMachineDeployment(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error)
+	GetMachineDeployments(ctx context.Context, opts ...executables.KubectlOpt) ([]clusterv1.MachineDeployment, error)
 	GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*etcdv1.EtcdadmCluster, error)
 	UpdateAnnotation(ctx context.Context, resourceType, objectName string, annotations map[string]string, opts ...executables.KubectlOpt) error
 }
@@ -98,15 +98,15 @@ func (p *provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 	if clusterSpec.Spec.ControlPlaneConfiguration.Endpoint != nil && clusterSpec.Spec.ControlPlaneConfiguration.Endpoint.Host != "" {
 		return fmt.Errorf("specifying endpoint host configuration in Cluster is not supported")
 	}
-	return nil
+	return common.ValidateUpgradeRolloutStrategy(clusterSpec.Cluster, constants.DockerProviderName)
 }
 
 func (p *provider) SetupAndValidateDeleteCluster(ctx context.Context) error {
 	return nil
 }
 
-func (p *provider) SetupAndValidateUpgradeCluster(ctx context.Context, _ *types.Cluster, _ *cluster.Spec) error {
-	return nil
+func (p *provider) SetupAndValidateUpgradeCluster(ctx context.Context, _ *types.Cluster, clusterSpec *cluster.Spec) error {
+	return common.ValidateUpgradeRolloutStrategy(clusterSpec.Cluster, constants.DockerProviderName)
 }
 
 func (p *provider) UpdateSecrets(ctx context.Context, cluster *types.Cluster) error {
@@ -124,9 +124,14 @@ type DockerTemplateBuilder struct {
 	now types.NowFunc
 }
 
-func (d *DockerTemplateBuilder) WorkerMachineTemplateName(clusterName string) string {
+func (d *DockerTemplateBuilder) WorkerMachineTemplateName(clusterName, workerNodeGroupName string) string {
+	t := d.now().UnixNano() / int64(time.Millisecond)
+	return fmt.Sprintf("%s-%s-%d", clusterName, workerNodeGroupName, t)
+}
+
+func (d *DockerTemplateBuilder) KubeadmConfigTemplateName(clusterName, workerNodeGroupName string) string {
 	t := d.now().UnixNano() / int64(time.Millisecond)
-	return fmt.Sprintf("%s-worker-node-template-%d", clusterName, t)
+	return fmt.Sprintf("%s-%s-template-%d", clusterName, workerNodeGroupName, t)
 }
 
 func (d *DockerTemplateBuilder) CPMachineTemplateName(clusterName string) string {
@@ -154,27 +159,45 @@ func (d *DockerTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluste
 }
 
 func (d *DockerTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
-	values := buildTemplateMapMD(clusterSpec)
-	for _, buildOption := range buildOptions {
-		buildOption(values)
-	}
+	workerSpecs := make([][]byte, 0, len(clusterSpec.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		values := buildTemplateMapMD(clusterSpec, workerNodeGroup)
+		for _, buildOption := range buildOptions {
+			buildOption(values)
+		}
 
-	bytes, err := templater.Execute(defaultCAPIConfigMD, values)
-	if err != nil {
-		return nil, err
+		bytes, err := templater.Execute(defaultCAPIConfigMD, values)
+		if err != nil {
+			return nil, err
+		}
+		workerSpecs = append(workerSpecs, bytes)
 	}
 
-	return bytes, nil
+	return templater.JoinYamlResources(workerSpecs...), nil
+}
+
+// kindKubeletExtraArgs returns the kubelet args the kind node image needs. The eviction thresholds are disabled
+// since the nodes share the host disk, but they can be overridden from the cluster spec.
+func kindKubeletExtraArgs() clusterapi.ExtraArgs {
+	return clusterapi.ExtraArgs{
+		"cgroup-driver": "cgroupfs",
+		"eviction-hard": "nodefs.available<0%,nodefs.inodesFree<0%,imagefs.available<0%",
+	}
 }
 
 func buildTemplateMapCP(clusterSpec *cluster.Spec) map[string]interface{} {
 	bundle := clusterSpec.VersionsBundle
 	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs()
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
-	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
+	apiServerExtraArgs := clusterapi.ApiServerExtraArgs(clusterSpec.Spec.ControlPlaneConfiguration).
+		Append(clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig)).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Spec.PodIAMConfig)).
 		Append(sharedExtraArgs)
+	kubeletExtraArgs := kindKubeletExtraArgs().
+		Append(clusterapi.ControlPlaneKubeletExtraArgs(clusterSpec.Spec.ControlPlaneConfiguration)).
+		Append(sharedExtraArgs)
+	auditLogConfig := common.GetAuditLogConfig(clusterSpec.Cluster)
 
 	values := map[string]interface{}{
 		"clusterName":                clusterSpec.Name,
@@ -191,10 +214,13 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) map[string]interface{} {
 		"apiserverExtraArgs":         apiServerExtraArgs.ToPartialYaml(),
 		"controllermanagerExtraArgs": sharedExtraArgs.ToPartialYaml(),
 		"schedulerExtraArgs":         sharedExtraArgs.ToPartialYaml(),
-		"kubeletExtraArgs":           sharedExtraArgs.ToPartialYaml(),
+		"kubeletExtraArgs":           kubeletExtraArgs.ToPartialYaml(),
 		"externalEtcdVersion":        bundle.KubeDistro.EtcdVersion,
 		"eksaSystemNamespace":        constants.EksaSystemNamespace,
-		"auditPolicy":                common.GetAuditPolicy(),
+		"auditPolicy":                auditLogConfig.Policy,
+		"auditLogMaxAge":             auditLogConfig.MaxAge,
+		"auditLogMaxBackup":          auditLogConfig.MaxBackup,
+		"auditLogMaxSize":            auditLogConfig.MaxSize,
 		"podCidrs":                   clusterSpec.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":               clusterSpec.Spec.ClusterNetwork.Services.CidrBlocks,
 	}
@@ -211,21 +237,48 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) map[string]interface{} {
 		values["controlPlaneTaints"] = clusterSpec.Spec.ControlPlaneConfiguration.Taints
 	}
 
+	if clusterSpec.Spec.ControlPlaneConfiguration.NodeDrainTimeout != nil {
+		values["nodeDrainTimeout"] = clusterSpec.Spec.ControlPlaneConfiguration.NodeDrainTimeout.Duration.String()
+	}
+
 	return values
 }
 
-func buildTemplateMapMD(clusterSpec *cluster.Spec) map[string]interface{} {
+func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration) map[string]interface{} {
 	bundle := clusterSpec.VersionsBundle
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
+	kubeletExtraArgs := kindKubeletExtraArgs().
+		Append(clusterapi.WorkerNodeGroupKubeletExtraArgs(workerNodeGroup)).
+		Append(clusterapi.SecureTlsCipherSuitesExtraArgs()).
+		Append(clusterapi.NodeLabelsExtraArgs(workerNodeGroup))
 
 	values := map[string]interface{}{
-		"clusterName":         clusterSpec.Name,
-		"worker_replicas":     clusterSpec.Spec.WorkerNodeGroupConfigurations[0].Count,
-		"kubernetesVersion":   bundle.KubeDistro.Kubernetes.Tag,
-		"kindNodeImage":       bundle.EksD.KindNode.VersionedImage(),
-		"eksaSystemNamespace": constants.EksaSystemNamespace,
-		"kubeletExtraArgs":    kubeletExtraArgs.ToPartialYaml(),
+		"clusterName":                    clusterSpec.Name,
+		providers.WorkerNodeGroupNameKey: workerNodeGroup.Name,
+		"worker_replicas":                clusterapi.WorkerReplicas(workerNodeGroup, nil),
+		"kubernetesVersion":              bundle.KubeDistro.Kubernetes.Tag,
+		"kindNodeImage":                  bundle.EksD.KindNode.VersionedImage(),
+		"eksaSystemNamespace":            constants.EksaSystemNamespace,
+		"kubeletExtraArgs":               kubeletExtraArgs.ToPartialYaml(),
+		// upgrades override it with the existing template, or a new one if the kubelet configuration changes
+		"workloadkubeadmconfigTemplateName": clusterapi.MachineDeploymentName(clusterSpec.Name, workerNodeGroup),
 	}
+
+	if workerNodeGroup.AutoScalingConfiguration != nil {
+		values["autoscalingConfig"] = workerNodeGroup.AutoScalingConfiguration
+	}
+
+	if len(workerNodeGroup.Taints) > 0 {
+		values["workerNodeGroupTaints"] = workerNodeGroup.Taints
+	}
+
+	if workerNodeGroup.UpgradeRolloutStrategy != nil {
+		values["upgradeRolloutStrategy"] = workerNodeGroup.UpgradeRolloutStrategy
+	}
+
+	if workerNodeGroup.NodeDrainTimeout != nil {
+		values["nodeDrainTimeout"] = workerNodeGroup.NodeDrainTimeout.Duration.String()
+	}
+
 	return values
 }
 
@@ -243,7 +296,7 @@ func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec) bool {
 
 func (p *provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	clusterName := newClusterSpec.ObjectMeta.Name
-	var controlPlaneTemplateName, workloadTemplateName, etcdTemplateName string
+	var controlPlaneTemplateName, etcdTemplateName string
 	var needsNewEtcdTemplate bool
 
 	needsNewControlPlaneTemplate := NeedsNewControlPlaneTemplate(currentSpec, newClusterSpec)
@@ -258,14 +311,28 @@ func (p *provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 	}
 
 	needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec)
-	if !needsNewWorkloadTemplate {
-		md, err := p.providerKubectlClient.GetMachineDeployment(ctx, workloadCluster, newClusterSpec.Name, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
-		if err != nil {
-			return nil, nil, err
+	workloadTemplateNames := make(map[string]string, len(newClusterSpec.Spec.WorkerNodeGroupConfigurations))
+	kubeadmconfigTemplateNames := make(map[string]string, len(newClusterSpec.Spec.WorkerNodeGroupConfigurations))
+	workerReplicas := make(map[string]int, len(newClusterSpec.Spec.WorkerNodeGroupConfigurations))
+	mds, err := p.providerKubectlClient.GetMachineDeployments(ctx, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		return nil, nil, err
+	}
+	existingMDs := clusterapi.MachineDeploymentsByWorkerNodeGroup(clusterName, newClusterSpec.Spec.WorkerNodeGroupConfigurations, mds)
+	for _, workerNodeGroup := range newClusterSpec.Spec.WorkerNodeGroupConfigurations {
+		// new worker node groups don't have a MachineDeployment yet
+		md, ok := existingMDs[workerNodeGroup.Name]
+		if ok && !needsNewWorkloadTemplate {
+			workloadTemplateNames[workerNodeGroup.Name] = md.Spec.Template.Spec.InfrastructureRef.Name
+		} else {
+			workloadTemplateNames[workerNodeGroup.Name] = p.templateBuilder.WorkerMachineTemplateName(clusterName, workerNodeGroup.Name)
 		}
-		workloadTemplateName = md.Spec.Template.Spec.InfrastructureRef.Name
-	} else {
-		workloadTemplateName = p.templateBuilder.WorkerMachineTemplateName(clusterName)
+		kubeadmconfigTemplateNames[workerNodeGroup.Name] = clusterapi.KubeadmConfigTemplateName(clusterName, workerNodeGroup, md)
+		if ok && clusterapi.NeedsNewKubeadmConfigTemplate(currentSpec.Cluster, workerNodeGroup) {
+			kubeadmconfigTemplateNames[workerNodeGroup.Name] = p.templateBuilder.KubeadmConfigTemplateName(clusterName, workerNodeGroup.Name)
+		}
+		// keep the replicas set by the cluster autoscaler instead of resetting them to the spec count
+		workerReplicas[workerNodeGroup.Name] = clusterapi.WorkerReplicas(workerNodeGroup, md)
 	}
 
 	if newClusterSpec.Spec.ExternalEtcdConfiguration != nil {
@@ -302,9 +369,11 @@ func (p *provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapClus
 		return nil, nil, err
 	}
 
-	workersOpts := func(values map[string]interface{}) {
-		values["workloadTemplateName"] = workloadTemplateName
-	}
+	workersOpts := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		values["workloadTemplateName"] = workloadTemplateNames[workerNodeGroupName]
+		values["workloadkubeadmconfigTemplateName"] = kubeadmconfigTemplateNames[workerNodeGroupName]
+		values["worker_replicas"] = workerReplicas[workerNodeGroupName]
+	})
 	workersSpec, err = p.templateBuilder.GenerateCAPISpecWorkers(newClusterSpec, workersOpts)
 	if err != nil {
 		return nil, nil, err
@@ -323,9 +392,9 @@ func (p *provider) generateCAPISpecForCreate(ctx context.Context, cluster *types
 	if err != nil {
 		return nil, nil, err
 	}
-	workersOpts := func(values map[string]interface{}) {
-		values["workloadTemplateName"] = p.templateBuilder.WorkerMachineTemplateName(clusterName)
-	}
+	workersOpts := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		values["workloadTemplateName"] = p.templateBuilder.WorkerMachineTemplateName(clusterName, workerNodeGroupName)
+	})
 	workersSpec, err = p.templateBuilder.GenerateCAPISpecWorkers(clusterSpec, workersOpts)
 	if err != nil {
 		return nil, nil, err
@@ -444,6 +513,25 @@ func (p *provider) UpgradeNeeded(_ context.Context, _, _ *cluster.Spec) (bool, e
 	return false, nil
 }
 
+func (p *provider) MachineGroupRollouts(_ context.Context, _ *types.Cluster, currentSpec, newSpec *cluster.Spec) ([]types.MachineGroupRollout, error) {
+	rollouts := []types.MachineGroupRollout{
+		{Name: types.ControlPlaneMachineGroup, Replaced: NeedsNewControlPlaneTemplate(currentSpec, newSpec)},
+	}
+	for _, workerNodeGroup := range newSpec.Spec.WorkerNodeGroupConfigurations {
+		rollouts = append(rollouts, types.MachineGroupRollout{
+			Name:     types.WorkerNodeGroupMachineGroup(workerNodeGroup.Name),
+			Replaced: NeedsNewWorkloadTemplate(currentSpec, newSpec) || clusterapi.NeedsNewKubeadmConfigTemplate(currentSpec.Cluster, workerNodeGroup),
+		})
+	}
+	if newSpec.Spec.ExternalEtcdConfiguration != nil {
+		rollouts = append(rollouts, types.MachineGroupRollout{Name: types.EtcdMachineGroup, Replaced: NeedsNewEtcdTemplate(currentSpec, newSpec)})
+	}
+	return rollouts, nil
+}
+
 func (p *provider) RunPostControlPlaneCreation(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
 	return nil
 }
+
+// EnableDryRun is a no-op, the docker setup doesn't create any infrastructure
+func (p *provider) EnableDryRun() {}
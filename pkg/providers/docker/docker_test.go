@@ -6,12 +6,14 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1alpha3"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	bootstrapv1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
 
@@ -239,6 +241,7 @@ func TestProviderGenerateDeploymentFileSuccessUpdateMachineTemplate(t *testing.T
 			bootstrapCluster := &types.Cluster{
 				Name: "bootstrap-test",
 			}
+			kubectl.EXPECT().GetMachineDeployments(ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
 			kubectl.EXPECT().UpdateAnnotation(ctx, "etcdadmcluster", fmt.Sprintf("%s-etcd", tt.clusterSpec.Name),
 				map[string]string{etcdv1.UpgradeInProgressAnnotation: "true"}, gomock.Any(), gomock.Any())
 			cpContent, mdContent, err := p.GenerateCAPISpecForUpgrade(ctx, bootstrapCluster, cluster, currentSpec, tt.clusterSpec)
@@ -275,7 +278,10 @@ func TestProviderGenerateDeploymentFileSuccessNotUpdateMachineTemplate(t *testin
 			},
 		},
 	}
-	md := &clusterv1.MachineDeployment{
+	md := clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fluxAddonTestCluster-md-0",
+		},
 		Spec: clusterv1.MachineDeploymentSpec{
 			Template: clusterv1.MachineTemplateSpec{
 				Spec: clusterv1.MachineSpec{
@@ -289,7 +295,7 @@ func TestProviderGenerateDeploymentFileSuccessNotUpdateMachineTemplate(t *testin
 	os.Setenv(features.TaintsSupportEnvVar, "true")
 
 	kubectl.EXPECT().GetKubeadmControlPlane(ctx, cluster, cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(cp, nil)
-	kubectl.EXPECT().GetMachineDeployment(ctx, cluster, clusterSpec.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(md, nil)
+	kubectl.EXPECT().GetMachineDeployments(ctx, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster)), gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return([]clusterv1.MachineDeployment{md}, nil)
 
 	cpContent, mdContent, err := p.GenerateCAPISpecForUpgrade(ctx, bootstrapCluster, cluster, currentSpec, clusterSpec)
 	if err != nil {
@@ -300,6 +306,119 @@ func TestProviderGenerateDeploymentFileSuccessNotUpdateMachineTemplate(t *testin
 	test.AssertContentToFile(t, string(mdContent), "testdata/no_machinetemplate_update_md_expected.yaml")
 }
 
+func TestProviderGenerateCAPISpecForUpgradeAutoscalingKeepsReplicas(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	clusterSpec := test.NewClusterSpec()
+	clusterSpec.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+	clusterSpec.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+	clusterSpec.Spec.WorkerNodeGroupConfigurations[0].Count = 1
+	clusterSpec.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &v1alpha1.AutoScalingConfiguration{MinCount: 1, MaxCount: 5}
+	p := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	cluster := &types.Cluster{
+		Name: "test",
+	}
+	currentSpec := clusterSpec.DeepCopy()
+	bootstrapCluster := &types.Cluster{
+		Name: "bootstrap-test",
+	}
+
+	cp := &bootstrapv1.KubeadmControlPlane{
+		Spec: bootstrapv1.KubeadmControlPlaneSpec{
+			InfrastructureTemplate: v1.ObjectReference{
+				Name: "test-control-plane-template-original",
+			},
+		},
+	}
+	replicas := int32(4)
+	md := clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fluxAddonTestCluster-md-0",
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Replicas: &replicas,
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					InfrastructureRef: v1.ObjectReference{
+						Name: "test-worker-node-template-original",
+					},
+				},
+			},
+		},
+	}
+
+	kubectl.EXPECT().GetKubeadmControlPlane(ctx, cluster, cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(cp, nil)
+	kubectl.EXPECT().GetMachineDeployments(ctx, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster)), gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return([]clusterv1.MachineDeployment{md}, nil)
+
+	_, mdContent, err := p.GenerateCAPISpecForUpgrade(ctx, bootstrapCluster, cluster, currentSpec, clusterSpec)
+	if err != nil {
+		t.Fatalf("provider.GenerateCAPISpecForUpgrade() error = %v, wantErr nil", err)
+	}
+
+	test.AssertContentToFile(t, string(mdContent), "testdata/autoscaling_upgrade_md_expected.yaml")
+}
+
+func TestProviderGenerateCAPISpecForUpgradeWorkerNodeGroupTaints(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	clusterSpec := test.NewClusterSpec()
+	clusterSpec.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+	clusterSpec.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+	clusterSpec.Spec.WorkerNodeGroupConfigurations[0].Count = 3
+	p := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	cluster := &types.Cluster{
+		Name: "test",
+	}
+	currentSpec := clusterSpec.DeepCopy()
+	clusterSpec.Spec.WorkerNodeGroupConfigurations[0].Taints = []v1.Taint{{Key: "dedicated", Value: "ingress", Effect: v1.TaintEffectNoSchedule}}
+	bootstrapCluster := &types.Cluster{
+		Name: "bootstrap-test",
+	}
+
+	cp := &bootstrapv1.KubeadmControlPlane{
+		Spec: bootstrapv1.KubeadmControlPlaneSpec{
+			InfrastructureTemplate: v1.ObjectReference{
+				Name: "test-control-plane-template-original",
+			},
+		},
+	}
+	replicas := int32(3)
+	md := clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fluxAddonTestCluster-md-0",
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Replicas: &replicas,
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &v1.ObjectReference{
+							Name: "fluxAddonTestCluster-md-0",
+						},
+					},
+					InfrastructureRef: v1.ObjectReference{
+						Name: "test-worker-node-template-original",
+					},
+				},
+			},
+		},
+	}
+
+	kubectl.EXPECT().GetKubeadmControlPlane(ctx, cluster, cluster.Name, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return(cp, nil)
+	kubectl.EXPECT().GetMachineDeployments(ctx, gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster)), gomock.AssignableToTypeOf(executables.WithCluster(bootstrapCluster))).Return([]clusterv1.MachineDeployment{md}, nil)
+
+	_, mdContent, err := p.GenerateCAPISpecForUpgrade(ctx, bootstrapCluster, cluster, currentSpec, clusterSpec)
+	if err != nil {
+		t.Fatalf("provider.GenerateCAPISpecForUpgrade() error = %v, wantErr nil", err)
+	}
+
+	test.AssertContentToFile(t, string(mdContent), "testdata/worker_taints_upgrade_md_expected.yaml")
+}
+
 func TestSetupAndValidateClusterWithEndpoint(t *testing.T) {
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Name = "test-cluster"
@@ -426,6 +545,59 @@ func TestChangeDiffWithChange(t *testing.T) {
 	tt.Expect(tt.provider.ChangeDiff(clusterSpec, newClusterSpec)).To(Equal(wantDiff))
 }
 
+func TestMachineGroupRollouts(t *testing.T) {
+	tt := newTest(t)
+	currentSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.KubernetesVersion = v1alpha1.Kube120
+		s.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+	})
+	newSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.KubernetesVersion = v1alpha1.Kube121
+		s.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{Count: 3}
+	})
+
+	rollouts, err := tt.provider.MachineGroupRollouts(context.Background(), &types.Cluster{}, currentSpec, newSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(rollouts).To(Equal([]types.MachineGroupRollout{
+		{Name: types.ControlPlaneMachineGroup, Replaced: true},
+		{Name: types.WorkerNodeGroupMachineGroup("md-0"), Replaced: true},
+		{Name: types.EtcdMachineGroup, Replaced: true},
+	}))
+
+	rollouts, err = tt.provider.MachineGroupRollouts(context.Background(), &types.Cluster{}, currentSpec, currentSpec)
+	tt.Expect(err).To(Succeed())
+	for _, r := range rollouts {
+		tt.Expect(r.Replaced).To(BeFalse(), "%s shouldn't be replaced", r.Name)
+	}
+}
+
+func TestMachineGroupRolloutsWorkerNodeGroupKubeletConfiguration(t *testing.T) {
+	tt := newTest(t)
+	currentSpec := test.NewClusterSpec()
+	newSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.WorkerNodeGroupConfigurations[0].KubeletConfiguration = map[string]string{"max-pods": "50"}
+	})
+
+	rollouts, err := tt.provider.MachineGroupRollouts(context.Background(), &types.Cluster{}, currentSpec, newSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(rollouts).To(ContainElement(types.MachineGroupRollout{Name: types.WorkerNodeGroupMachineGroup("md-0"), Replaced: true}))
+}
+
+func TestMachineGroupRolloutsWorkerNodeGroupLabels(t *testing.T) {
+	tt := newTest(t)
+	currentSpec := test.NewClusterSpec()
+	newSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.WorkerNodeGroupConfigurations[0].Labels = map[string]string{"node-role": "ingress"}
+	})
+
+	rollouts, err := tt.provider.MachineGroupRollouts(context.Background(), &types.Cluster{}, currentSpec, newSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(rollouts).To(Equal([]types.MachineGroupRollout{
+		{Name: types.ControlPlaneMachineGroup, Replaced: false},
+		{Name: types.WorkerNodeGroupMachineGroup("md-0"), Replaced: true},
+	}))
+}
+
 func TestProviderGenerateCAPISpecForCreateWithPodIAMConfig(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	ctx := context.Background()
@@ -495,3 +667,193 @@ func TestProviderGenerateCAPISpecForCreateWithStackedEtcd(t *testing.T) {
 	}
 	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_stacked_etcd_expected.yaml")
 }
+
+func TestProviderGenerateCAPISpecForCreateWithMultipleWorkerNodeGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = "1.19"
+		s.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Spec.ControlPlaneConfiguration.Count = 1
+		s.VersionsBundle = versionsBundle
+		s.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+			{
+				Name:   "ingress",
+				Count:  2,
+				Labels: map[string]string{"pool": "ingress"},
+				Taints: []v1.Taint{{Key: "dedicated", Value: "ingress", Effect: v1.TaintEffectNoSchedule}},
+			},
+			{
+				Name:   "batch",
+				Count:  3,
+				Labels: map[string]string{"pool": "batch"},
+			},
+		}
+	})
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	_, md, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(md), "testdata/valid_deployment_multiple_worker_node_groups_md_expected.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithKubeletAndApiServerExtraArgs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = "1.19"
+		s.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Spec.ControlPlaneConfiguration.Count = 1
+		s.Spec.ControlPlaneConfiguration.KubeletConfiguration = map[string]string{"max-pods": "50"}
+		s.Spec.ControlPlaneConfiguration.ApiServerExtraArgs = map[string]string{"enable-admission-plugins": "NodeRestriction,PodSecurityPolicy"}
+		s.Spec.WorkerNodeGroupConfigurations[0].Count = 3
+		s.Spec.WorkerNodeGroupConfigurations[0].KubeletConfiguration = map[string]string{
+			"eviction-hard":   "memory.available<500Mi",
+			"system-reserved": "cpu=500m,memory=1Gi",
+		}
+		s.VersionsBundle = versionsBundle
+	})
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_extra_args_expected.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/valid_deployment_md_extra_args_expected.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithUpgradeRolloutStrategy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = "1.19"
+		s.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Spec.ControlPlaneConfiguration.Count = 1
+		s.Spec.WorkerNodeGroupConfigurations[0].Count = 3
+		s.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+			RollingUpdate: v1alpha1.WorkerNodesRollingUpdateParams{MaxSurge: 2, MaxUnavailable: 1},
+		}
+		s.VersionsBundle = versionsBundle
+	})
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	_, md, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(md), "testdata/valid_deployment_md_upgrade_rollout_strategy_expected.yaml")
+}
+
+func TestSetupAndValidateClusterWithUpgradeRolloutStrategyMaxSurgeZero(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0", Count: 1}}
+		s.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+			RollingUpdate: v1alpha1.WorkerNodesRollingUpdateParams{MaxSurge: 0, MaxUnavailable: 1},
+		}
+	})
+	mockCtrl := gomock.NewController(t)
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	p := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	ctx := context.Background()
+	err := p.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	wantErr := fmt.Errorf("worker node group md-0 upgradeRolloutStrategy maxSurge 0 is not supported by provider docker")
+
+	if !reflect.DeepEqual(wantErr, err) {
+		t.Errorf("got = <%v>, want = <%v>", err, wantErr)
+	}
+}
+
+func TestSetupAndValidateClusterWithControlPlaneUpgradeRolloutStrategy(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+			RollingUpdate: v1alpha1.ControlPlaneRollingUpdateParams{MaxSurge: 1},
+		}
+	})
+	mockCtrl := gomock.NewController(t)
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	p := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	ctx := context.Background()
+	err := p.SetupAndValidateUpgradeCluster(ctx, &types.Cluster{Name: "test-cluster"}, clusterSpec)
+	wantErr := fmt.Errorf("control plane upgradeRolloutStrategy is not supported by provider docker")
+
+	if !reflect.DeepEqual(wantErr, err) {
+		t.Errorf("got = <%v>, want = <%v>", err, wantErr)
+	}
+}
+
+func TestProviderGenerateCAPISpecForCreateWithNodeDrainTimeout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{
+		Name: "test-cluster",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = "1.19"
+		s.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+		s.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.128.0.0/12"}
+		s.Spec.ControlPlaneConfiguration.Count = 1
+		s.Spec.ControlPlaneConfiguration.NodeDrainTimeout = &metav1.Duration{Duration: 5 * time.Minute}
+		s.Spec.WorkerNodeGroupConfigurations[0].Count = 3
+		s.Spec.WorkerNodeGroupConfigurations[0].NodeDrainTimeout = &metav1.Duration{Duration: 10 * time.Minute}
+		s.VersionsBundle = versionsBundle
+	})
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/valid_deployment_cp_node_drain_timeout_expected.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/valid_deployment_md_node_drain_timeout_expected.yaml")
+}
@@ -133,24 +133,24 @@ func (mr *MockProviderKubectlClientMockRecorder) GetKubeadmControlPlane(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubeadmControlPlane", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetKubeadmControlPlane), varargs...)
 }
 
-// GetMachineDeployment mocks base method.
-func (m *MockProviderKubectlClient) GetMachineDeployment(arg0 context.Context, arg1 *types.Cluster, arg2 string, arg3 ...executables.KubectlOpt) (*v1alpha30.MachineDeployment, error) {
+// GetMachineDeployments mocks base method.
+func (m *MockProviderKubectlClient) GetMachineDeployments(arg0 context.Context, arg1 ...executables.KubectlOpt) ([]v1alpha30.MachineDeployment, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{arg0, arg1, arg2}
-	for _, a := range arg3 {
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "GetMachineDeployment", varargs...)
-	ret0, _ := ret[0].(*v1alpha30.MachineDeployment)
+	ret := m.ctrl.Call(m, "GetMachineDeployments", varargs...)
+	ret0, _ := ret[0].([]v1alpha30.MachineDeployment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMachineDeployment indicates an expected call of GetMachineDeployment.
-func (mr *MockProviderKubectlClientMockRecorder) GetMachineDeployment(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+// GetMachineDeployments indicates an expected call of GetMachineDeployments.
+func (mr *MockProviderKubectlClientMockRecorder) GetMachineDeployments(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineDeployment", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetMachineDeployment), varargs...)
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineDeployments", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetMachineDeployments), varargs...)
 }
 
 // UpdateAnnotation mocks base method.
@@ -124,6 +124,18 @@ func (mr *MockProviderMockRecorder) DeleteResources(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResources", reflect.TypeOf((*MockProvider)(nil).DeleteResources), arg0, arg1)
 }
 
+// EnableDryRun mocks base method.
+func (m *MockProvider) EnableDryRun() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EnableDryRun")
+}
+
+// EnableDryRun indicates an expected call of EnableDryRun.
+func (mr *MockProviderMockRecorder) EnableDryRun() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableDryRun", reflect.TypeOf((*MockProvider)(nil).EnableDryRun))
+}
+
 // EnvMap mocks base method.
 func (m *MockProvider) EnvMap() (map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -242,6 +254,21 @@ func (mr *MockProviderMockRecorder) MachineConfigs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachineConfigs", reflect.TypeOf((*MockProvider)(nil).MachineConfigs))
 }
 
+// MachineGroupRollouts mocks base method.
+func (m *MockProvider) MachineGroupRollouts(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 *cluster.Spec) ([]types.MachineGroupRollout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MachineGroupRollouts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]types.MachineGroupRollout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MachineGroupRollouts indicates an expected call of MachineGroupRollouts.
+func (mr *MockProviderMockRecorder) MachineGroupRollouts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachineGroupRollouts", reflect.TypeOf((*MockProvider)(nil).MachineGroupRollouts), arg0, arg1, arg2, arg3)
+}
+
 // MachineResourceType mocks base method.
 func (m *MockProvider) MachineResourceType() string {
 	m.ctrl.T.Helper()
@@ -34,8 +34,11 @@ type Provider interface {
 	ChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ComponentChangeDiff
 	RunPostControlPlaneUpgrade(ctx context.Context, oldClusterSpec *cluster.Spec, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, managementCluster *types.Cluster) error
 	UpgradeNeeded(ctx context.Context, newSpec, currentSpec *cluster.Spec) (bool, error)
+	MachineGroupRollouts(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) ([]types.MachineGroupRollout, error)
 	DeleteResources(ctx context.Context, clusterSpec *cluster.Spec) error
 	RunPostControlPlaneCreation(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error
+	// EnableDryRun makes the setup and validations only read the infrastructure, failing instead of creating what's missing
+	EnableDryRun()
 }
 
 type DatacenterConfig interface {
@@ -47,12 +50,25 @@ type DatacenterConfig interface {
 
 type BuildMapOption func(map[string]interface{})
 
+// WorkerNodeGroupNameKey is the template value holding the name of the worker node group being rendered.
+// GenerateCAPISpecWorkers renders the templates once per worker node group.
+const WorkerNodeGroupNameKey = "workerNodeGroupName"
+
+// WorkerNodeGroupOption returns a BuildMapOption for GenerateCAPISpecWorkers that sets values depending on the worker node group
+func WorkerNodeGroupOption(opt func(workerNodeGroupName string, values map[string]interface{})) BuildMapOption {
+	return func(values map[string]interface{}) {
+		workerNodeGroupName, _ := values[WorkerNodeGroupNameKey].(string)
+		opt(workerNodeGroupName, values)
+	}
+}
+
 type TemplateBuilder interface {
 	GenerateCAPISpecControlPlane(clusterSpec *cluster.Spec, buildOptions ...BuildMapOption) (content []byte, err error)
 	GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, buildOptions ...BuildMapOption) (content []byte, err error)
-	WorkerMachineTemplateName(clusterName string) string
+	WorkerMachineTemplateName(clusterName, workerNodeGroupName string) string
 	CPMachineTemplateName(clusterName string) string
 	EtcdMachineTemplateName(clusterName string) string
+	KubeadmConfigTemplateName(clusterName, workerNodeGroupName string) string
 }
 
 type MachineConfig interface {
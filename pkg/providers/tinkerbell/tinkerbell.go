@@ -11,6 +11,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -39,6 +40,7 @@ var (
 	eksaTinkerbellDatacenterResourceType = fmt.Sprintf("tinkerbelldatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaTinkerbellMachineResourceType    = fmt.Sprintf("tinkerbellmachineconfigs.%s", v1alpha1.GroupVersion.Group)
 	requiredEnvs                         = []string{tinkerbellCertURLKey, tinkerbellGRPCAuthKey, tinkerbellIPKey, tinkerbellPBnJGRPCAuthorityKey}
+	errUpgradeNotSupported               = errors.New("upgrade for tinkerbell provider isn't currently supported")
 )
 
 type tinkerbellProvider struct {
@@ -60,12 +62,15 @@ type ProviderKubectlClient interface {
 }
 
 func NewProvider(datacenterConfig *v1alpha1.TinkerbellDatacenterConfig, machineConfigs map[string]*v1alpha1.TinkerbellMachineConfig, clusterConfig *v1alpha1.Cluster, providerKubectlClient ProviderKubectlClient, now types.NowFunc, hardwareConfigFile string) *tinkerbellProvider {
-	var controlPlaneMachineSpec, workerNodeGroupMachineSpec, etcdMachineSpec *v1alpha1.TinkerbellMachineConfigSpec
+	var controlPlaneMachineSpec, etcdMachineSpec *v1alpha1.TinkerbellMachineConfigSpec
 	if clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef != nil && machineConfigs[clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name] != nil {
 		controlPlaneMachineSpec = &machineConfigs[clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec
 	}
-	if len(clusterConfig.Spec.WorkerNodeGroupConfigurations) > 0 && clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef != nil && machineConfigs[clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name] != nil {
-		workerNodeGroupMachineSpec = &machineConfigs[clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name].Spec
+	workerNodeGroupMachineSpecs := make(map[string]*v1alpha1.TinkerbellMachineConfigSpec, len(machineConfigs))
+	for _, workerNodeGroup := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		if workerNodeGroup.MachineGroupRef != nil && machineConfigs[workerNodeGroup.MachineGroupRef.Name] != nil {
+			workerNodeGroupMachineSpecs[workerNodeGroup.MachineGroupRef.Name] = &machineConfigs[workerNodeGroup.MachineGroupRef.Name].Spec
+		}
 	}
 	if clusterConfig.Spec.ExternalEtcdConfiguration != nil {
 		if clusterConfig.Spec.ExternalEtcdConfiguration.MachineGroupRef != nil && machineConfigs[clusterConfig.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name] != nil {
@@ -79,11 +84,11 @@ func NewProvider(datacenterConfig *v1alpha1.TinkerbellDatacenterConfig, machineC
 		providerKubectlClient: providerKubectlClient,
 		hardwareConfigFile:    hardwareConfigFile,
 		templateBuilder: &TinkerbellTemplateBuilder{
-			datacenterSpec:             &datacenterConfig.Spec,
-			controlPlaneMachineSpec:    controlPlaneMachineSpec,
-			workerNodeGroupMachineSpec: workerNodeGroupMachineSpec,
-			etcdMachineSpec:            etcdMachineSpec,
-			now:                        now,
+			datacenterSpec:              &datacenterConfig.Spec,
+			controlPlaneMachineSpec:     controlPlaneMachineSpec,
+			workerNodeGroupMachineSpecs: workerNodeGroupMachineSpecs,
+			etcdMachineSpec:             etcdMachineSpec,
+			now:                         now,
 		},
 	}
 }
@@ -133,6 +138,10 @@ func (p *tinkerbellProvider) DeleteResources(_ context.Context, _ *cluster.Spec)
 
 func (p *tinkerbellProvider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpec *cluster.Spec) error {
 	logger.Info("Warning: The tinkerbell infrastructure provider is still in development and should not be used in production")
+	// the control plane template doesn't configure audit logging, so the setting would be silently ignored
+	if clusterSpec.Spec.AuditPolicy != nil {
+		return fmt.Errorf("auditPolicy is not supported by provider %s", constants.TinkerbellProviderName)
+	}
 	if err := setupEnvVars(p.datacenterConfig); err != nil {
 		return fmt.Errorf("failed setup and validations: %v", err)
 	}
@@ -153,7 +162,7 @@ func (p *tinkerbellProvider) SetupAndValidateDeleteCluster(ctx context.Context)
 
 func (p *tinkerbellProvider) SetupAndValidateUpgradeCluster(ctx context.Context, _ *types.Cluster, _ *cluster.Spec) error {
 	// TODO: Add validations when this is supported
-	return errors.New("upgrade for tinkerbell provider isn't currently supported")
+	return errUpgradeNotSupported
 }
 
 func (p *tinkerbellProvider) UpdateSecrets(ctx context.Context, cluster *types.Cluster) error {
@@ -162,26 +171,33 @@ func (p *tinkerbellProvider) UpdateSecrets(ctx context.Context, cluster *types.C
 }
 
 type TinkerbellTemplateBuilder struct {
-	controlPlaneMachineSpec    *v1alpha1.TinkerbellMachineConfigSpec
-	datacenterSpec             *v1alpha1.TinkerbellDatacenterConfigSpec
-	workerNodeGroupMachineSpec *v1alpha1.TinkerbellMachineConfigSpec
-	etcdMachineSpec            *v1alpha1.TinkerbellMachineConfigSpec
-	now                        types.NowFunc
+	controlPlaneMachineSpec     *v1alpha1.TinkerbellMachineConfigSpec
+	datacenterSpec              *v1alpha1.TinkerbellDatacenterConfigSpec
+	workerNodeGroupMachineSpecs map[string]*v1alpha1.TinkerbellMachineConfigSpec
+	etcdMachineSpec             *v1alpha1.TinkerbellMachineConfigSpec
+	now                         types.NowFunc
 }
 
-func NewTinkerbellTemplateBuilder(datacenterSpec *v1alpha1.TinkerbellDatacenterConfigSpec, controlPlaneMachineSpec, workerNodeGroupMachineSpec, etcdMachineSpec *v1alpha1.TinkerbellMachineConfigSpec, now types.NowFunc) providers.TemplateBuilder {
+// NewTinkerbellTemplateBuilder returns a builder for the CAPI specs of a cluster.
+// workerNodeGroupMachineSpecs holds the machine spec of each worker node group keyed by the TinkerbellMachineConfig name.
+func NewTinkerbellTemplateBuilder(datacenterSpec *v1alpha1.TinkerbellDatacenterConfigSpec, controlPlaneMachineSpec *v1alpha1.TinkerbellMachineConfigSpec, workerNodeGroupMachineSpecs map[string]*v1alpha1.TinkerbellMachineConfigSpec, etcdMachineSpec *v1alpha1.TinkerbellMachineConfigSpec, now types.NowFunc) providers.TemplateBuilder {
 	return &TinkerbellTemplateBuilder{
-		controlPlaneMachineSpec:    controlPlaneMachineSpec,
-		datacenterSpec:             datacenterSpec,
-		workerNodeGroupMachineSpec: workerNodeGroupMachineSpec,
-		etcdMachineSpec:            etcdMachineSpec,
-		now:                        now,
+		controlPlaneMachineSpec:     controlPlaneMachineSpec,
+		datacenterSpec:              datacenterSpec,
+		workerNodeGroupMachineSpecs: workerNodeGroupMachineSpecs,
+		etcdMachineSpec:             etcdMachineSpec,
+		now:                         now,
 	}
 }
 
-func (vs *TinkerbellTemplateBuilder) WorkerMachineTemplateName(clusterName string) string {
+func (vs *TinkerbellTemplateBuilder) WorkerMachineTemplateName(clusterName, workerNodeGroupName string) string {
+	t := vs.now().UnixNano() / int64(time.Millisecond)
+	return fmt.Sprintf("%s-%s-%d", clusterName, workerNodeGroupName, t)
+}
+
+func (vs *TinkerbellTemplateBuilder) KubeadmConfigTemplateName(clusterName, workerNodeGroupName string) string {
 	t := vs.now().UnixNano() / int64(time.Millisecond)
-	return fmt.Sprintf("%s-worker-node-template-%d", clusterName, t)
+	return fmt.Sprintf("%s-%s-template-%d", clusterName, workerNodeGroupName, t)
 }
 
 func (vs *TinkerbellTemplateBuilder) CPMachineTemplateName(clusterName string) string {
@@ -207,18 +223,21 @@ func (vs *TinkerbellTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *c
 }
 
 func (vs *TinkerbellTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
-	values := buildTemplateMapMD(clusterSpec, *vs.workerNodeGroupMachineSpec)
-
-	for _, buildOption := range buildOptions {
-		buildOption(values)
-	}
+	workerSpecs := make([][]byte, 0, len(clusterSpec.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		values := buildTemplateMapMD(clusterSpec, *vs.workerNodeGroupMachineSpecs[workerNodeGroup.MachineGroupRef.Name], workerNodeGroup)
+		for _, buildOption := range buildOptions {
+			buildOption(values)
+		}
 
-	bytes, err := templater.Execute(defaultClusterConfigMD, values)
-	if err != nil {
-		return nil, err
+		bytes, err := templater.Execute(defaultClusterConfigMD, values)
+		if err != nil {
+			return nil, err
+		}
+		workerSpecs = append(workerSpecs, bytes)
 	}
 
-	return bytes, nil
+	return templater.JoinYamlResources(workerSpecs...), nil
 }
 
 func (p *tinkerbellProvider) GenerateCAPISpecForCreate(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
@@ -242,10 +261,9 @@ func (p *tinkerbellProvider) generateCAPISpecForCreate(ctx context.Context, clus
 	if err != nil {
 		return nil, nil, err
 	}
-	workersOpt := func(values map[string]interface{}) {
-		values["workloadTemplateName"] = p.templateBuilder.WorkerMachineTemplateName(clusterName)
-		values["workerSshAuthorizedKey"] = p.machineConfigs[p.clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name].Spec.Users[0].SshAuthorizedKeys[0]
-	}
+	workersOpt := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		values["workloadTemplateName"] = p.templateBuilder.WorkerMachineTemplateName(clusterName, workerNodeGroupName)
+	})
 	workersSpec, err = p.templateBuilder.GenerateCAPISpecWorkers(clusterSpec, workersOpt)
 	if err != nil {
 		return nil, nil, err
@@ -326,14 +344,19 @@ func (p *tinkerbellProvider) MachineConfigs() []providers.MachineConfig {
 	// TODO: Figure out if something is needed here
 	var configs []providers.MachineConfig
 	controlPlaneMachineName := p.clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
-	workerMachineName := p.clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name
 	p.machineConfigs[controlPlaneMachineName].Annotations = map[string]string{p.clusterConfig.ControlPlaneAnnotation(): "true"}
 	if p.clusterConfig.IsManaged() {
 		p.machineConfigs[controlPlaneMachineName].SetManagement(p.clusterConfig.ManagedBy())
 	}
 
 	configs = append(configs, p.machineConfigs[controlPlaneMachineName])
-	if workerMachineName != controlPlaneMachineName {
+	added := map[string]bool{controlPlaneMachineName: true}
+	for _, workerNodeGroup := range p.clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		workerMachineName := workerNodeGroup.MachineGroupRef.Name
+		if added[workerMachineName] {
+			continue
+		}
+		added[workerMachineName] = true
 		configs = append(configs, p.machineConfigs[workerMachineName])
 		if p.clusterConfig.IsManaged() {
 			p.machineConfigs[workerMachineName].SetManagement(p.clusterConfig.ManagedBy())
@@ -362,11 +385,19 @@ func (p *tinkerbellProvider) UpgradeNeeded(_ context.Context, _, _ *cluster.Spec
 	return false, nil
 }
 
+// MachineGroupRollouts fails since upgrades aren't supported, so the upgrade plan doesn't report that no machines are replaced
+func (p *tinkerbellProvider) MachineGroupRollouts(_ context.Context, _ *types.Cluster, _, _ *cluster.Spec) ([]types.MachineGroupRollout, error) {
+	return nil, errUpgradeNotSupported
+}
+
 func (p *tinkerbellProvider) RunPostControlPlaneCreation(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
 	// TODO: Figure out if something is needed here
 	return nil
 }
 
+// EnableDryRun is a no-op, the tinkerbell setup doesn't create any infrastructure
+func (p *tinkerbellProvider) EnableDryRun() {}
+
 func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec v1alpha1.TinkerbellMachineConfigSpec) map[string]interface{} {
 	bundle := clusterSpec.VersionsBundle
 	format := "cloud-config"
@@ -391,23 +422,51 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec v1alp
 		"corednsVersion":               bundle.KubeDistro.CoreDNS.Tag,
 		"etcdRepository":               bundle.KubeDistro.Etcd.Repository,
 		"etcdImageTag":                 bundle.KubeDistro.Etcd.Tag,
+		"apiserverExtraArgs":           clusterapi.ApiServerExtraArgs(clusterSpec.Spec.ControlPlaneConfiguration).ToPartialYaml(),
+		"kubeletExtraArgs":             clusterapi.ControlPlaneKubeletExtraArgs(clusterSpec.Spec.ControlPlaneConfiguration).ToPartialYaml(),
+	}
+
+	if clusterSpec.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy != nil {
+		values["upgradeRolloutStrategy"] = clusterSpec.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy
+	}
+
+	if clusterSpec.Spec.ControlPlaneConfiguration.NodeDrainTimeout != nil {
+		values["nodeDrainTimeout"] = clusterSpec.Spec.ControlPlaneConfiguration.NodeDrainTimeout.Duration.String()
 	}
+
 	return values
 }
 
-func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1alpha1.TinkerbellMachineConfigSpec) map[string]interface{} {
+func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1alpha1.TinkerbellMachineConfigSpec, workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration) map[string]interface{} {
 	bundle := clusterSpec.VersionsBundle
 	format := "cloud-config"
 
+	kubeletExtraArgs := clusterapi.WorkerNodeGroupKubeletExtraArgs(workerNodeGroup).
+		Append(clusterapi.NodeLabelsExtraArgs(workerNodeGroup))
+
 	values := map[string]interface{}{
 		"clusterName":            clusterSpec.ObjectMeta.Name,
 		"eksaSystemNamespace":    constants.EksaSystemNamespace,
 		"format":                 format,
 		"kubernetesVersion":      bundle.KubeDistro.Kubernetes.Tag,
-		"workerReplicas":         clusterSpec.Spec.WorkerNodeGroupConfigurations[0].Count,
-		"workerPoolName":         "md-0",
-		"workerSshAuthorizedKey": workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys,
+		"workerReplicas":         workerNodeGroup.Count,
+		"workerPoolName":         workerNodeGroup.Name,
+		"workerSshAuthorizedKey": workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys[0],
 		"workerSshUsername":      workerNodeGroupMachineSpec.Users[0].Name,
+		"kubeletExtraArgs":       kubeletExtraArgs.ToPartialYaml(),
+	}
+
+	if len(workerNodeGroup.Taints) > 0 {
+		values["workerNodeGroupTaints"] = workerNodeGroup.Taints
+	}
+
+	if workerNodeGroup.UpgradeRolloutStrategy != nil {
+		values["upgradeRolloutStrategy"] = workerNodeGroup.UpgradeRolloutStrategy
+	}
+
+	if workerNodeGroup.NodeDrainTimeout != nil {
+		values["nodeDrainTimeout"] = workerNodeGroup.NodeDrainTimeout.Duration.String()
 	}
+
 	return values
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -142,3 +143,38 @@ func TestTinkerbellProviderGenerateDeploymentFile(t *testing.T) {
 	test.AssertContentToFile(t, string(cp), "testdata/expected_results_cluster_tinkerbell_cp.yaml")
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_cluster_tinkerbell_md.yaml")
 }
+
+func TestTinkerbellProviderMachineGroupRolloutsNotSupported(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell.yaml"
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	clusterSpec := test.NewClusterSpec()
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	rollouts, err := provider.MachineGroupRollouts(context.Background(), &types.Cluster{Name: "test"}, clusterSpec, clusterSpec)
+	if err == nil {
+		t.Fatalf("MachineGroupRollouts() error = nil, want not supported error")
+	}
+	if rollouts != nil {
+		t.Fatalf("MachineGroupRollouts() rollouts = %v, want nil", rollouts)
+	}
+}
+
+func TestTinkerbellProviderSetupAndValidateCreateClusterAuditPolicyNotSupported(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell.yaml"
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Spec.AuditPolicy = &v1alpha1.AuditPolicy{LogMaxAge: 7}
+	})
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+
+	err := provider.SetupAndValidateCreateCluster(context.Background(), clusterSpec)
+	if err == nil || !strings.Contains(err.Error(), "auditPolicy is not supported") {
+		t.Fatalf("SetupAndValidateCreateCluster() error = %v, want auditPolicy not supported error", err)
+	}
+}
@@ -14,6 +14,8 @@ import (
 
 type Defaulter struct {
 	govc ProviderGovcClient
+	// dryRun makes the defaulter fail when the default template is missing instead of importing it
+	dryRun bool
 }
 
 func NewDefaulter(govc ProviderGovcClient) *Defaulter {
@@ -127,6 +129,10 @@ func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *spec, machin
 	templateName := fmt.Sprintf("%s-%s-%s-%s-%s", osFamily, eksd.KubeVersion, eksd.Name, strings.Join(ova.Arch, "-"), ova.SHA256[:7])
 	machineConfig.Spec.Template = filepath.Join("/", spec.datacenterConfig.Spec.Datacenter, defaultTemplatesFolder, templateName)
 
+	if d.dryRun {
+		return d.searchDefaultTemplate(ctx, spec, machineConfig, ova.URI)
+	}
+
 	tags := requiredTemplateTagsByCategory(spec.Spec, machineConfig)
 
 	// TODO: figure out if it's worth refactoring the factory to be able to reuse across machine configs.
@@ -140,6 +146,21 @@ func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *spec, machin
 	return nil
 }
 
+// searchDefaultTemplate sets the full path of the default template without importing it when it's missing
+func (d *Defaulter) searchDefaultTemplate(ctx context.Context, spec *spec, machineConfig *anywherev1.VSphereMachineConfig, ovaURL string) error {
+	templateFullPath, err := d.govc.SearchTemplate(ctx, spec.datacenterConfig.Spec.Datacenter, machineConfig)
+	if err != nil {
+		return fmt.Errorf("error checking for template: %v", err)
+	}
+
+	if len(templateFullPath) <= 0 {
+		return fmt.Errorf("default template <%s> not found and dry run doesn't import it from %s: import it or set the VSphereMachineConfig template", machineConfig.Spec.Template, ovaURL)
+	}
+
+	machineConfig.Spec.Template = templateFullPath
+	return nil
+}
+
 func (d *Defaulter) setDiskDefaults(ctx context.Context, machineConfig *anywherev1.VSphereMachineConfig) error {
 	templateHasSnapshot, err := d.govc.TemplateHasSnapshot(ctx, machineConfig.Spec.Template)
 	if err != nil {
@@ -7,9 +7,10 @@ import (
 	"net"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
-	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -74,6 +75,10 @@ func (v *Validator) validateCluster(ctx context.Context, vsphereClusterSpec *spe
 		return errors.New("must specify machineGroupRef for control plane")
 	}
 
+	if err := common.ValidateUpgradeRolloutStrategy(vsphereClusterSpec.Cluster, constants.VSphereProviderName); err != nil {
+		return err
+	}
+
 	controlPlaneMachineConfig := vsphereClusterSpec.controlPlaneMachineConfig()
 	if controlPlaneMachineConfig == nil {
 		return fmt.Errorf("cannot find VSphereMachineConfig %v for control plane", vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name)
@@ -87,22 +92,26 @@ func (v *Validator) validateCluster(ctx context.Context, vsphereClusterSpec *spe
 	if len(controlPlaneMachineConfig.Spec.ResourcePool) <= 0 {
 		return errors.New("VSphereMachineConfig VM resourcePool for control plane is not set or is empty")
 	}
-	if vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef == nil {
-		return errors.New("must specify machineGroupRef for worker nodes")
-	}
+	workerNodeGroupMachineConfigs := make([]*anywherev1.VSphereMachineConfig, 0, len(vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if workerNodeGroup.MachineGroupRef == nil {
+			return errors.New("must specify machineGroupRef for worker nodes")
+		}
 
-	workerNodeGroupMachineConfig := vsphereClusterSpec.firstWorkerMachineConfig()
-	if workerNodeGroupMachineConfig == nil {
-		return fmt.Errorf("cannot find VSphereMachineConfig %v for worker nodes", vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name)
-	}
-	if len(workerNodeGroupMachineConfig.Spec.Datastore) <= 0 {
-		return errors.New("VSphereMachineConfig datastore for worker nodes is not set or is empty")
-	}
-	if len(workerNodeGroupMachineConfig.Spec.Folder) <= 0 {
-		logger.Info("VSphereMachineConfig folder for worker nodes is not set or is empty. Will default to root vSphere folder.")
-	}
-	if len(workerNodeGroupMachineConfig.Spec.ResourcePool) <= 0 {
-		return errors.New("VSphereMachineConfig VM resourcePool for worker nodes is not set or is empty")
+		workerNodeGroupMachineConfig := vsphereClusterSpec.workerMachineConfig(workerNodeGroup)
+		if workerNodeGroupMachineConfig == nil {
+			return fmt.Errorf("cannot find VSphereMachineConfig %v for worker nodes", workerNodeGroup.MachineGroupRef.Name)
+		}
+		if len(workerNodeGroupMachineConfig.Spec.Datastore) <= 0 {
+			return errors.New("VSphereMachineConfig datastore for worker nodes is not set or is empty")
+		}
+		if len(workerNodeGroupMachineConfig.Spec.Folder) <= 0 {
+			logger.Info("VSphereMachineConfig folder for worker nodes is not set or is empty. Will default to root vSphere folder.")
+		}
+		if len(workerNodeGroupMachineConfig.Spec.ResourcePool) <= 0 {
+			return errors.New("VSphereMachineConfig VM resourcePool for worker nodes is not set or is empty")
+		}
+		workerNodeGroupMachineConfigs = append(workerNodeGroupMachineConfigs, workerNodeGroupMachineConfig)
 	}
 
 	if vsphereClusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
@@ -140,16 +149,20 @@ func (v *Validator) validateCluster(ctx context.Context, vsphereClusterSpec *spe
 		return fmt.Errorf("control plane osFamily: %s is not supported, please use one of the following: %s, %s", controlPlaneMachineConfig.Spec.OSFamily, anywherev1.Bottlerocket, anywherev1.Ubuntu)
 	}
 
-	if workerNodeGroupMachineConfig.Spec.OSFamily != anywherev1.Bottlerocket && workerNodeGroupMachineConfig.Spec.OSFamily != anywherev1.Ubuntu {
-		return fmt.Errorf("worker node osFamily: %s is not supported, please use one of the following: %s, %s", workerNodeGroupMachineConfig.Spec.OSFamily, anywherev1.Bottlerocket, anywherev1.Ubuntu)
+	for _, workerNodeGroupMachineConfig := range workerNodeGroupMachineConfigs {
+		if workerNodeGroupMachineConfig.Spec.OSFamily != anywherev1.Bottlerocket && workerNodeGroupMachineConfig.Spec.OSFamily != anywherev1.Ubuntu {
+			return fmt.Errorf("worker node osFamily: %s is not supported, please use one of the following: %s, %s", workerNodeGroupMachineConfig.Spec.OSFamily, anywherev1.Bottlerocket, anywherev1.Ubuntu)
+		}
 	}
 
 	if etcdMachineConfig != nil && etcdMachineConfig.Spec.OSFamily != anywherev1.Bottlerocket && etcdMachineConfig.Spec.OSFamily != anywherev1.Ubuntu {
 		return fmt.Errorf("etcd node osFamily: %s is not supported, please use one of the following: %s, %s", etcdMachineConfig.Spec.OSFamily, anywherev1.Bottlerocket, anywherev1.Ubuntu)
 	}
 
-	if controlPlaneMachineConfig.Spec.OSFamily != workerNodeGroupMachineConfig.Spec.OSFamily {
-		return errors.New("control plane and worker nodes must have the same osFamily specified")
+	for _, workerNodeGroupMachineConfig := range workerNodeGroupMachineConfigs {
+		if controlPlaneMachineConfig.Spec.OSFamily != workerNodeGroupMachineConfig.Spec.OSFamily {
+			return errors.New("control plane and worker nodes must have the same osFamily specified")
+		}
 	}
 
 	if etcdMachineConfig != nil && controlPlaneMachineConfig.Spec.OSFamily != etcdMachineConfig.Spec.OSFamily {
@@ -157,8 +170,10 @@ func (v *Validator) validateCluster(ctx context.Context, vsphereClusterSpec *spe
 	}
 
 	if err := v.validateSSHUsername(controlPlaneMachineConfig); err == nil {
-		if err = v.validateSSHUsername(workerNodeGroupMachineConfig); err != nil {
-			return fmt.Errorf("error validating SSHUsername for worker node VSphereMachineConfig %v: %v", workerNodeGroupMachineConfig.Name, err)
+		for _, workerNodeGroupMachineConfig := range workerNodeGroupMachineConfigs {
+			if err = v.validateSSHUsername(workerNodeGroupMachineConfig); err != nil {
+				return fmt.Errorf("error validating SSHUsername for worker node VSphereMachineConfig %v: %v", workerNodeGroupMachineConfig.Name, err)
+			}
 		}
 		if etcdMachineConfig != nil {
 			if err = v.validateSSHUsername(etcdMachineConfig); err != nil {
@@ -184,8 +199,10 @@ func (v *Validator) validateCluster(ctx context.Context, vsphereClusterSpec *spe
 		return err
 	}
 
-	if controlPlaneMachineConfig.Spec.Template != workerNodeGroupMachineConfig.Spec.Template {
-		return errors.New("control plane and worker nodes must have the same template specified")
+	for _, workerNodeGroupMachineConfig := range workerNodeGroupMachineConfigs {
+		if controlPlaneMachineConfig.Spec.Template != workerNodeGroupMachineConfig.Spec.Template {
+			return errors.New("control plane and worker nodes must have the same template specified")
+		}
 	}
 	logger.MarkPass("Control plane and Workload templates validated")
 
@@ -195,7 +212,7 @@ func (v *Validator) validateCluster(ctx context.Context, vsphereClusterSpec *spe
 		}
 	}
 
-	return v.validateDatastoreUsage(ctx, vsphereClusterSpec.Spec, controlPlaneMachineConfig, workerNodeGroupMachineConfig, etcdMachineConfig)
+	return v.validateDatastoreUsage(ctx, vsphereClusterSpec, controlPlaneMachineConfig, etcdMachineConfig)
 }
 
 func (v *Validator) validateControlPlaneIp(ip string) error {
@@ -263,30 +280,35 @@ type datastoreUsage struct {
 
 // TODO: cleanup this method signature
 // TODO: dry out implementation
-func (v *Validator) validateDatastoreUsage(ctx context.Context, clusterSpec *cluster.Spec, controlPlaneMachineConfig *anywherev1.VSphereMachineConfig, workerNodeGroupMachineConfig *anywherev1.VSphereMachineConfig, etcdMachineConfig *anywherev1.VSphereMachineConfig) error {
+func (v *Validator) validateDatastoreUsage(ctx context.Context, vsphereClusterSpec *spec, controlPlaneMachineConfig *anywherev1.VSphereMachineConfig, etcdMachineConfig *anywherev1.VSphereMachineConfig) error {
+	clusterSpec := vsphereClusterSpec.Spec
 	usage := make(map[string]*datastoreUsage)
 	controlPlaneAvailableSpace, err := v.govc.GetWorkloadAvailableSpace(ctx, controlPlaneMachineConfig.Spec.Datastore) // TODO: remove dependency on machineConfig
 	if err != nil {
 		return fmt.Errorf("error getting datastore details: %v", err)
 	}
-	workerAvailableSpace, err := v.govc.GetWorkloadAvailableSpace(ctx, workerNodeGroupMachineConfig.Spec.Datastore)
-	if err != nil {
-		return fmt.Errorf("error getting datastore details: %v", err)
-	}
 
 	controlPlaneNeedGiB := controlPlaneMachineConfig.Spec.DiskGiB * clusterSpec.Spec.ControlPlaneConfiguration.Count
 	usage[controlPlaneMachineConfig.Spec.Datastore] = &datastoreUsage{
 		availableSpace: controlPlaneAvailableSpace,
 		needGiBSpace:   controlPlaneNeedGiB,
 	}
-	workerNeedGiB := workerNodeGroupMachineConfig.Spec.DiskGiB * clusterSpec.Spec.WorkerNodeGroupConfigurations[0].Count
-	_, ok := usage[workerNodeGroupMachineConfig.Spec.Datastore]
-	if ok {
-		usage[workerNodeGroupMachineConfig.Spec.Datastore].needGiBSpace += workerNeedGiB
-	} else {
-		usage[workerNodeGroupMachineConfig.Spec.Datastore] = &datastoreUsage{
-			availableSpace: workerAvailableSpace,
-			needGiBSpace:   workerNeedGiB,
+
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		workerNodeGroupMachineConfig := vsphereClusterSpec.workerMachineConfig(workerNodeGroup)
+		workerAvailableSpace, err := v.govc.GetWorkloadAvailableSpace(ctx, workerNodeGroupMachineConfig.Spec.Datastore)
+		if err != nil {
+			return fmt.Errorf("error getting datastore details: %v", err)
+		}
+
+		workerNeedGiB := workerNodeGroupMachineConfig.Spec.DiskGiB * workerNodeGroup.Count
+		if _, ok := usage[workerNodeGroupMachineConfig.Spec.Datastore]; ok {
+			usage[workerNodeGroupMachineConfig.Spec.Datastore].needGiBSpace += workerNeedGiB
+		} else {
+			usage[workerNodeGroupMachineConfig.Spec.Datastore] = &datastoreUsage{
+				availableSpace: workerAvailableSpace,
+				needGiBSpace:   workerNeedGiB,
+			}
 		}
 	}
 
@@ -142,7 +142,7 @@ type ProviderKubectlClient interface {
 	GetEksaVSphereDatacenterConfig(ctx context.Context, vsphereDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereDatacenterConfig, error)
 	GetEksaVSphereMachineConfig(ctx context.Context, vsphereMachineConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereMachineConfig, error)
 	GetKubeadmControlPlane(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*bootstrapv1.KubeadmControlPlane, error)
-	GetMachineDeployment(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error)
+	GetMachineDeployments(ctx context.Context, opts ...executables.KubectlOpt) ([]clusterv1.MachineDeployment, error)
 	GetEtcdadmCluster(ctx context.Context, cluster *types.Cluster, clusterName string, opts ...executables.KubectlOpt) (*etcdv1.EtcdadmCluster, error)
 	GetSecret(ctx context.Context, secretObjectName string, opts ...executables.KubectlOpt) (*corev1.Secret, error)
 	UpdateAnnotation(ctx context.Context, resourceType, objectName string, annotations map[string]string, opts ...executables.KubectlOpt) error
@@ -174,12 +174,15 @@ func NewProvider(datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConf
 }
 
 func NewProviderCustomNet(datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfigs map[string]*v1alpha1.VSphereMachineConfig, clusterConfig *v1alpha1.Cluster, providerGovcClient ProviderGovcClient, providerKubectlClient ProviderKubectlClient, writer filewriter.FileWriter, netClient networkutils.NetClient, now types.NowFunc, skipIpCheck bool, resourceSetManager ClusterResourceSetManager) *vsphereProvider {
-	var controlPlaneMachineSpec, workerNodeGroupMachineSpec, etcdMachineSpec *v1alpha1.VSphereMachineConfigSpec
+	var controlPlaneMachineSpec, etcdMachineSpec *v1alpha1.VSphereMachineConfigSpec
 	if clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef != nil && machineConfigs[clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name] != nil {
 		controlPlaneMachineSpec = &machineConfigs[clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name].Spec
 	}
-	if len(clusterConfig.Spec.WorkerNodeGroupConfigurations) > 0 && clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef != nil && machineConfigs[clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name] != nil {
-		workerNodeGroupMachineSpec = &machineConfigs[clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name].Spec
+	workerNodeGroupMachineSpecs := make(map[string]*v1alpha1.VSphereMachineConfigSpec, len(machineConfigs))
+	for _, workerNodeGroup := range clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		if workerNodeGroup.MachineGroupRef != nil && machineConfigs[workerNodeGroup.MachineGroupRef.Name] != nil {
+			workerNodeGroupMachineSpecs[workerNodeGroup.MachineGroupRef.Name] = &machineConfigs[workerNodeGroup.MachineGroupRef.Name].Spec
+		}
 	}
 	if clusterConfig.Spec.ExternalEtcdConfiguration != nil {
 		if clusterConfig.Spec.ExternalEtcdConfiguration.MachineGroupRef != nil && machineConfigs[clusterConfig.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name] != nil {
@@ -195,11 +198,11 @@ func NewProviderCustomNet(datacenterConfig *v1alpha1.VSphereDatacenterConfig, ma
 		providerKubectlClient: providerKubectlClient,
 		writer:                writer,
 		templateBuilder: &VsphereTemplateBuilder{
-			datacenterSpec:             &datacenterConfig.Spec,
-			controlPlaneMachineSpec:    controlPlaneMachineSpec,
-			workerNodeGroupMachineSpec: workerNodeGroupMachineSpec,
-			etcdMachineSpec:            etcdMachineSpec,
-			now:                        now,
+			datacenterSpec:              &datacenterConfig.Spec,
+			controlPlaneMachineSpec:     controlPlaneMachineSpec,
+			workerNodeGroupMachineSpecs: workerNodeGroupMachineSpecs,
+			etcdMachineSpec:             etcdMachineSpec,
+			now:                         now,
 		},
 		skipIpCheck:        skipIpCheck,
 		resourceSetManager: resourceSetManager,
@@ -257,22 +260,28 @@ func (p *vsphereProvider) setupSSHAuthKeysForCreate() error {
 		p.controlPlaneSshAuthKey = generatedKey
 		useKeyGeneratedForControlplane = true
 	}
-	workerUser := p.machineConfigs[p.clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name].Spec.Users[0]
-	p.workerSshAuthKey = workerUser.SshAuthorizedKeys[0]
-	if err := p.parseSSHAuthKey(&p.workerSshAuthKey); err != nil {
-		return err
-	}
-	if len(p.workerSshAuthKey) <= 0 {
-		if useKeyGeneratedForControlplane { // use the same key
-			p.workerSshAuthKey = p.controlPlaneSshAuthKey
-		} else {
-			generatedKey, err := p.generateSSHAuthKey(workerUser.Name)
-			if err != nil {
-				return err
+	for _, workerNodeGroup := range p.clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		workerUser := p.machineConfigs[workerNodeGroup.MachineGroupRef.Name].Spec.Users[0]
+		workerSshAuthKey := workerUser.SshAuthorizedKeys[0]
+		if err := p.parseSSHAuthKey(&workerSshAuthKey); err != nil {
+			return err
+		}
+		if len(workerSshAuthKey) <= 0 {
+			if useKeyGeneratedForControlplane { // use the same key
+				workerSshAuthKey = p.controlPlaneSshAuthKey
+			} else if useKeyGeneratedForWorker { // use the key generated for a previous worker node group
+				workerSshAuthKey = p.workerSshAuthKey
+			} else {
+				generatedKey, err := p.generateSSHAuthKey(workerUser.Name)
+				if err != nil {
+					return err
+				}
+				workerSshAuthKey = generatedKey
+				p.workerSshAuthKey = generatedKey
+				useKeyGeneratedForWorker = true
 			}
-			p.workerSshAuthKey = generatedKey
-			useKeyGeneratedForWorker = true
 		}
+		workerUser.SshAuthorizedKeys[0] = workerSshAuthKey
 	}
 	if p.clusterConfig.Spec.ExternalEtcdConfiguration != nil {
 		etcdUser := p.machineConfigs[p.clusterConfig.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name].Spec.Users[0]
@@ -296,7 +305,6 @@ func (p *vsphereProvider) setupSSHAuthKeysForCreate() error {
 		etcdUser.SshAuthorizedKeys[0] = p.etcdSshAuthKey
 	}
 	controlPlaneUser.SshAuthorizedKeys[0] = p.controlPlaneSshAuthKey
-	workerUser.SshAuthorizedKeys[0] = p.workerSshAuthKey
 	return nil
 }
 
@@ -307,12 +315,12 @@ func (p *vsphereProvider) setupSSHAuthKeysForUpgrade() error {
 		return err
 	}
 	controlPlaneUser.SshAuthorizedKeys[0] = p.controlPlaneSshAuthKey
-	workerUser := p.machineConfigs[p.clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name].Spec.Users[0]
-	p.workerSshAuthKey = workerUser.SshAuthorizedKeys[0]
-	if err := p.parseSSHAuthKey(&p.workerSshAuthKey); err != nil {
-		return err
+	for _, workerNodeGroup := range p.clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		workerUser := p.machineConfigs[workerNodeGroup.MachineGroupRef.Name].Spec.Users[0]
+		if err := p.parseSSHAuthKey(&workerUser.SshAuthorizedKeys[0]); err != nil {
+			return err
+		}
 	}
-	workerUser.SshAuthorizedKeys[0] = p.workerSshAuthKey
 	if p.clusterConfig.Spec.ExternalEtcdConfiguration != nil {
 		etcdUser := p.machineConfigs[p.clusterConfig.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name].Spec.Users[0]
 		p.etcdSshAuthKey = etcdUser.SshAuthorizedKeys[0]
@@ -479,8 +487,15 @@ func (p *vsphereProvider) validateMachineConfigsNameUniqueness(ctx context.Conte
 		}
 	}
 
-	workerMachineConfigName := clusterSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name
-	if prevSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name != workerMachineConfigName {
+	prevMachineConfigNames := make(map[string]struct{}, len(prevSpec.Spec.WorkerNodeGroupConfigurations))
+	for _, ref := range prevSpec.MachineConfigRefs() {
+		prevMachineConfigNames[ref.Name] = struct{}{}
+	}
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		workerMachineConfigName := workerNodeGroup.MachineGroupRef.Name
+		if _, ok := prevMachineConfigNames[workerMachineConfigName]; ok {
+			continue
+		}
 		em, err := p.providerKubectlClient.SearchVsphereMachineConfig(ctx, workerMachineConfigName, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.GetNamespace())
 		if err != nil {
 			return err
@@ -594,27 +609,34 @@ func AnyImmutableFieldChanged(oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig,
 	return false
 }
 
-func NewVsphereTemplateBuilder(datacenterSpec *v1alpha1.VSphereDatacenterConfigSpec, controlPlaneMachineSpec, workerNodeGroupMachineSpec, etcdMachineSpec *v1alpha1.VSphereMachineConfigSpec, now types.NowFunc) providers.TemplateBuilder {
+// NewVsphereTemplateBuilder returns a builder for the CAPI specs of a cluster.
+// workerNodeGroupMachineSpecs holds the machine spec of each worker node group keyed by the VSphereMachineConfig name.
+func NewVsphereTemplateBuilder(datacenterSpec *v1alpha1.VSphereDatacenterConfigSpec, controlPlaneMachineSpec *v1alpha1.VSphereMachineConfigSpec, workerNodeGroupMachineSpecs map[string]*v1alpha1.VSphereMachineConfigSpec, etcdMachineSpec *v1alpha1.VSphereMachineConfigSpec, now types.NowFunc) providers.TemplateBuilder {
 	return &VsphereTemplateBuilder{
-		datacenterSpec:             datacenterSpec,
-		controlPlaneMachineSpec:    controlPlaneMachineSpec,
-		workerNodeGroupMachineSpec: workerNodeGroupMachineSpec,
-		etcdMachineSpec:            etcdMachineSpec,
-		now:                        now,
+		datacenterSpec:              datacenterSpec,
+		controlPlaneMachineSpec:     controlPlaneMachineSpec,
+		workerNodeGroupMachineSpecs: workerNodeGroupMachineSpecs,
+		etcdMachineSpec:             etcdMachineSpec,
+		now:                         now,
 	}
 }
 
 type VsphereTemplateBuilder struct {
-	datacenterSpec             *v1alpha1.VSphereDatacenterConfigSpec
-	controlPlaneMachineSpec    *v1alpha1.VSphereMachineConfigSpec
-	workerNodeGroupMachineSpec *v1alpha1.VSphereMachineConfigSpec
-	etcdMachineSpec            *v1alpha1.VSphereMachineConfigSpec
-	now                        types.NowFunc
+	datacenterSpec              *v1alpha1.VSphereDatacenterConfigSpec
+	controlPlaneMachineSpec     *v1alpha1.VSphereMachineConfigSpec
+	workerNodeGroupMachineSpecs map[string]*v1alpha1.VSphereMachineConfigSpec
+	etcdMachineSpec             *v1alpha1.VSphereMachineConfigSpec
+	now                         types.NowFunc
+}
+
+func (vs *VsphereTemplateBuilder) WorkerMachineTemplateName(clusterName, workerNodeGroupName string) string {
+	t := vs.now().UnixNano() / int64(time.Millisecond)
+	return fmt.Sprintf("%s-%s-%d", clusterName, workerNodeGroupName, t)
 }
 
-func (vs *VsphereTemplateBuilder) WorkerMachineTemplateName(clusterName string) string {
+func (vs *VsphereTemplateBuilder) KubeadmConfigTemplateName(clusterName, workerNodeGroupName string) string {
 	t := vs.now().UnixNano() / int64(time.Millisecond)
-	return fmt.Sprintf("%s-worker-node-template-%d", clusterName, t)
+	return fmt.Sprintf("%s-%s-template-%d", clusterName, workerNodeGroupName, t)
 }
 
 func (vs *VsphereTemplateBuilder) CPMachineTemplateName(clusterName string) string {
@@ -647,18 +669,21 @@ func (vs *VsphereTemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *clus
 }
 
 func (vs *VsphereTemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, buildOptions ...providers.BuildMapOption) (content []byte, err error) {
-	values := buildTemplateMapMD(clusterSpec, *vs.datacenterSpec, *vs.workerNodeGroupMachineSpec)
-
-	for _, buildOption := range buildOptions {
-		buildOption(values)
-	}
+	workerSpecs := make([][]byte, 0, len(clusterSpec.Spec.WorkerNodeGroupConfigurations))
+	for _, workerNodeGroup := range clusterSpec.Spec.WorkerNodeGroupConfigurations {
+		values := buildTemplateMapMD(clusterSpec, *vs.datacenterSpec, *vs.workerNodeGroupMachineSpecs[workerNodeGroup.MachineGroupRef.Name], workerNodeGroup)
+		for _, buildOption := range buildOptions {
+			buildOption(values)
+		}
 
-	bytes, err := templater.Execute(defaultClusterConfigMD, values)
-	if err != nil {
-		return nil, err
+		bytes, err := templater.Execute(defaultClusterConfigMD, values)
+		if err != nil {
+			return nil, err
+		}
+		workerSpecs = append(workerSpecs, bytes)
 	}
 
-	return bytes, nil
+	return templater.JoinYamlResources(workerSpecs...), nil
 }
 
 func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphereDatacenterConfigSpec, controlPlaneMachineSpec, etcdMachineSpec v1alpha1.VSphereMachineConfigSpec) map[string]interface{} {
@@ -666,10 +691,14 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 	format := "cloud-config"
 	etcdExtraArgs := clusterapi.SecureEtcdTlsCipherSuitesExtraArgs()
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
-	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
+	apiServerExtraArgs := clusterapi.ApiServerExtraArgs(clusterSpec.Spec.ControlPlaneConfiguration).
+		Append(clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig)).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Spec.PodIAMConfig)).
 		Append(sharedExtraArgs)
+	kubeletExtraArgs := clusterapi.ControlPlaneKubeletExtraArgs(clusterSpec.Spec.ControlPlaneConfiguration).
+		Append(sharedExtraArgs)
+	auditLogConfig := common.GetAuditLogConfig(clusterSpec.Cluster)
 
 	values := map[string]interface{}{
 		"clusterName":                          clusterSpec.ObjectMeta.Name,
@@ -710,12 +739,15 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		"apiserverExtraArgs":                   apiServerExtraArgs.ToPartialYaml(),
 		"controllermanagerExtraArgs":           sharedExtraArgs.ToPartialYaml(),
 		"schedulerExtraArgs":                   sharedExtraArgs.ToPartialYaml(),
-		"kubeletExtraArgs":                     sharedExtraArgs.ToPartialYaml(),
+		"kubeletExtraArgs":                     kubeletExtraArgs.ToPartialYaml(),
 		"format":                               format,
 		"externalEtcdVersion":                  bundle.KubeDistro.EtcdVersion,
 		"etcdImage":                            bundle.KubeDistro.EtcdImage.VersionedImage(),
 		"eksaSystemNamespace":                  constants.EksaSystemNamespace,
-		"auditPolicy":                          common.GetAuditPolicy(),
+		"auditPolicy":                          auditLogConfig.Policy,
+		"auditLogMaxAge":                       auditLogConfig.MaxAge,
+		"auditLogMaxBackup":                    auditLogConfig.MaxBackup,
+		"auditLogMaxSize":                      auditLogConfig.MaxSize,
 		"resourceSetName":                      resourceSetName(clusterSpec),
 		"eksaVsphereUsername":                  os.Getenv(EksavSphereUsernameKey),
 		"eksaVspherePassword":                  os.Getenv(EksavSpherePasswordKey),
@@ -783,16 +815,23 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		values["deployCPI"] = true
 	}
 
+	if clusterSpec.Spec.ControlPlaneConfiguration.NodeDrainTimeout != nil {
+		values["nodeDrainTimeout"] = clusterSpec.Spec.ControlPlaneConfiguration.NodeDrainTimeout.Duration.String()
+	}
+
 	return values
 }
 
-func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphereDatacenterConfigSpec, workerNodeGroupMachineSpec v1alpha1.VSphereMachineConfigSpec) map[string]interface{} {
+func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphereDatacenterConfigSpec, workerNodeGroupMachineSpec v1alpha1.VSphereMachineConfigSpec, workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration) map[string]interface{} {
 	bundle := clusterSpec.VersionsBundle
 	format := "cloud-config"
-	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
+	kubeletExtraArgs := clusterapi.WorkerNodeGroupKubeletExtraArgs(workerNodeGroup).
+		Append(clusterapi.SecureTlsCipherSuitesExtraArgs()).
+		Append(clusterapi.NodeLabelsExtraArgs(workerNodeGroup))
 
 	values := map[string]interface{}{
 		"clusterName":                    clusterSpec.ObjectMeta.Name,
+		providers.WorkerNodeGroupNameKey: workerNodeGroup.Name,
 		"kubernetesVersion":              bundle.KubeDistro.Kubernetes.Tag,
 		"thumbprint":                     datacenterSpec.Thumbprint,
 		"vsphereDatacenter":              datacenterSpec.Datacenter,
@@ -803,14 +842,17 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		"vsphereServer":                  datacenterSpec.Server,
 		"workerVsphereStoragePolicyName": workerNodeGroupMachineSpec.StoragePolicyName,
 		"vsphereTemplate":                workerNodeGroupMachineSpec.Template,
-		"workerReplicas":                 clusterSpec.Spec.WorkerNodeGroupConfigurations[0].Count,
+		"workerReplicas":                 clusterapi.WorkerReplicas(workerNodeGroup, nil),
 		"workloadVMsMemoryMiB":           workerNodeGroupMachineSpec.MemoryMiB,
 		"workloadVMsNumCPUs":             workerNodeGroupMachineSpec.NumCPUs,
 		"workloadDiskGiB":                workerNodeGroupMachineSpec.DiskGiB,
 		"workerSshUsername":              workerNodeGroupMachineSpec.Users[0].Name,
+		"vsphereWorkerSshAuthorizedKey":  sshAuthorizedKey(workerNodeGroupMachineSpec.Users),
 		"format":                         format,
 		"eksaSystemNamespace":            constants.EksaSystemNamespace,
 		"kubeletExtraArgs":               kubeletExtraArgs.ToPartialYaml(),
+		// upgrades override it with the existing template, or a new one if the kubelet configuration changes
+		"workloadkubeadmconfigTemplateName": clusterapi.MachineDeploymentName(clusterSpec.Name, workerNodeGroup),
 	}
 
 	if clusterSpec.Spec.RegistryMirrorConfiguration != nil {
@@ -850,12 +892,35 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		values["bottlerocketBootstrapVersion"] = bundle.BottleRocketBootstrap.Bootstrap.Tag()
 	}
 
+	if workerNodeGroup.AutoScalingConfiguration != nil {
+		values["autoscalingConfig"] = workerNodeGroup.AutoScalingConfiguration
+	}
+
+	if len(workerNodeGroup.Taints) > 0 {
+		values["workerNodeGroupTaints"] = workerNodeGroup.Taints
+	}
+
+	if workerNodeGroup.UpgradeRolloutStrategy != nil {
+		values["upgradeRolloutStrategy"] = workerNodeGroup.UpgradeRolloutStrategy
+	}
+
+	if workerNodeGroup.NodeDrainTimeout != nil {
+		values["nodeDrainTimeout"] = workerNodeGroup.NodeDrainTimeout.Duration.String()
+	}
+
 	return values
 }
 
+func sshAuthorizedKey(users []v1alpha1.UserConfiguration) string {
+	if len(users) <= 0 || len(users[0].SshAuthorizedKeys) <= 0 {
+		return ""
+	}
+	return users[0].SshAuthorizedKeys[0]
+}
+
 func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	clusterName := newClusterSpec.ObjectMeta.Name
-	var controlPlaneTemplateName, workloadTemplateName, etcdTemplateName string
+	var controlPlaneTemplateName, etcdTemplateName string
 	var needsNewEtcdTemplate bool
 
 	c, err := p.providerKubectlClient.GetEksaCluster(ctx, workloadCluster, newClusterSpec.Name)
@@ -871,12 +936,6 @@ func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootst
 	if err != nil {
 		return nil, nil, err
 	}
-	workerMachineConfig := p.machineConfigs[newClusterSpec.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name]
-	workerVmc, err := p.providerKubectlClient.GetEksaVSphereMachineConfig(ctx, c.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Namespace)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	needsNewControlPlaneTemplate := NeedsNewControlPlaneTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, controlPlaneVmc, controlPlaneMachineConfig)
 	if !needsNewControlPlaneTemplate {
 		cp, err := p.providerKubectlClient.GetKubeadmControlPlane(ctx, workloadCluster, c.Name, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
@@ -888,15 +947,35 @@ func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootst
 		controlPlaneTemplateName = p.templateBuilder.CPMachineTemplateName(clusterName)
 	}
 
-	needsNewWorkloadTemplate := NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, workerVmc, workerMachineConfig)
-	if !needsNewWorkloadTemplate {
-		md, err := p.providerKubectlClient.GetMachineDeployment(ctx, workloadCluster, clusterName, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
-		if err != nil {
-			return nil, nil, err
+	workloadTemplateNames := make(map[string]string, len(newClusterSpec.Spec.WorkerNodeGroupConfigurations))
+	kubeadmconfigTemplateNames := make(map[string]string, len(newClusterSpec.Spec.WorkerNodeGroupConfigurations))
+	workerReplicas := make(map[string]int, len(newClusterSpec.Spec.WorkerNodeGroupConfigurations))
+	mds, err := p.providerKubectlClient.GetMachineDeployments(ctx, executables.WithCluster(bootstrapCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		return nil, nil, err
+	}
+	existingMDs := clusterapi.MachineDeploymentsByWorkerNodeGroup(clusterName, newClusterSpec.Spec.WorkerNodeGroupConfigurations, mds)
+	for _, workerNodeGroup := range newClusterSpec.Spec.WorkerNodeGroupConfigurations {
+		// new worker node groups don't have a MachineDeployment yet
+		md, ok := existingMDs[workerNodeGroup.Name]
+		needsNewWorkloadTemplate := true
+		if ok {
+			needsNewWorkloadTemplate, err = p.needsNewWorkloadTemplate(ctx, workloadCluster, c, currentSpec, newClusterSpec, vdc, workerNodeGroup)
+			if err != nil {
+				return nil, nil, err
+			}
 		}
-		workloadTemplateName = md.Spec.Template.Spec.InfrastructureRef.Name
-	} else {
-		workloadTemplateName = p.templateBuilder.WorkerMachineTemplateName(clusterName)
+		if !needsNewWorkloadTemplate {
+			workloadTemplateNames[workerNodeGroup.Name] = md.Spec.Template.Spec.InfrastructureRef.Name
+		} else {
+			workloadTemplateNames[workerNodeGroup.Name] = p.templateBuilder.WorkerMachineTemplateName(clusterName, workerNodeGroup.Name)
+		}
+		kubeadmconfigTemplateNames[workerNodeGroup.Name] = clusterapi.KubeadmConfigTemplateName(clusterName, workerNodeGroup, md)
+		if ok && clusterapi.NeedsNewKubeadmConfigTemplate(c, workerNodeGroup) {
+			kubeadmconfigTemplateNames[workerNodeGroup.Name] = p.templateBuilder.KubeadmConfigTemplateName(clusterName, workerNodeGroup.Name)
+		}
+		// keep the replicas set by the cluster autoscaler instead of resetting them to the spec count
+		workerReplicas[workerNodeGroup.Name] = clusterapi.WorkerReplicas(workerNodeGroup, md)
 	}
 
 	if newClusterSpec.Spec.ExternalEtcdConfiguration != nil {
@@ -939,10 +1018,11 @@ func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootst
 		return nil, nil, err
 	}
 
-	workersOpt := func(values map[string]interface{}) {
-		values["workloadTemplateName"] = workloadTemplateName
-		values["vsphereWorkerSshAuthorizedKey"] = p.workerSshAuthKey
-	}
+	workersOpt := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		values["workloadTemplateName"] = workloadTemplateNames[workerNodeGroupName]
+		values["workloadkubeadmconfigTemplateName"] = kubeadmconfigTemplateNames[workerNodeGroupName]
+		values["workerReplicas"] = workerReplicas[workerNodeGroupName]
+	})
 	workersSpec, err = p.templateBuilder.GenerateCAPISpecWorkers(newClusterSpec, workersOpt)
 	if err != nil {
 		return nil, nil, err
@@ -950,6 +1030,21 @@ func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootst
 	return controlPlaneSpec, workersSpec, nil
 }
 
+// needsNewWorkloadTemplate compares a worker node group with the one of the same name in the existing cluster.
+// Groups that don't exist in the cluster yet always need a new template.
+func (p *vsphereProvider) needsNewWorkloadTemplate(ctx context.Context, workloadCluster *types.Cluster, existingCluster *v1alpha1.Cluster, currentSpec, newClusterSpec *cluster.Spec, vdc *v1alpha1.VSphereDatacenterConfig, workerNodeGroup v1alpha1.WorkerNodeGroupConfiguration) (bool, error) {
+	existingWorkerNodeGroup, ok := clusterapi.ExistingWorkerNodeGroup(existingCluster, workerNodeGroup.Name)
+	if !ok {
+		return true, nil
+	}
+	workerVmc, err := p.providerKubectlClient.GetEksaVSphereMachineConfig(ctx, existingWorkerNodeGroup.MachineGroupRef.Name, workloadCluster.KubeconfigFile, newClusterSpec.Namespace)
+	if err != nil {
+		return false, err
+	}
+	workerMachineConfig := p.machineConfigs[workerNodeGroup.MachineGroupRef.Name]
+	return NeedsNewWorkloadTemplate(currentSpec, newClusterSpec, vdc, p.datacenterConfig, workerVmc, workerMachineConfig), nil
+}
+
 func (p *vsphereProvider) generateCAPISpecForCreate(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	clusterName := clusterSpec.ObjectMeta.Name
 
@@ -963,10 +1058,9 @@ func (p *vsphereProvider) generateCAPISpecForCreate(ctx context.Context, cluster
 	if err != nil {
 		return nil, nil, err
 	}
-	workersOpt := func(values map[string]interface{}) {
-		values["workloadTemplateName"] = p.templateBuilder.WorkerMachineTemplateName(clusterName)
-		values["vsphereWorkerSshAuthorizedKey"] = p.workerSshAuthKey
-	}
+	workersOpt := providers.WorkerNodeGroupOption(func(workerNodeGroupName string, values map[string]interface{}) {
+		values["workloadTemplateName"] = p.templateBuilder.WorkerMachineTemplateName(clusterName, workerNodeGroupName)
+	})
 	workersSpec, err = p.templateBuilder.GenerateCAPISpecWorkers(clusterSpec, workersOpt)
 	if err != nil {
 		return nil, nil, err
@@ -1102,14 +1196,19 @@ func (p *vsphereProvider) DatacenterConfig() providers.DatacenterConfig {
 func (p *vsphereProvider) MachineConfigs() []providers.MachineConfig {
 	var configs []providers.MachineConfig
 	controlPlaneMachineName := p.clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
-	workerMachineName := p.clusterConfig.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name
 	p.machineConfigs[controlPlaneMachineName].Annotations = map[string]string{p.clusterConfig.ControlPlaneAnnotation(): "true"}
 	if p.clusterConfig.IsManaged() {
 		p.machineConfigs[controlPlaneMachineName].SetManagement(p.clusterConfig.ManagedBy())
 	}
 
 	configs = append(configs, p.machineConfigs[controlPlaneMachineName])
-	if workerMachineName != controlPlaneMachineName {
+	added := map[string]bool{controlPlaneMachineName: true}
+	for _, workerNodeGroup := range p.clusterConfig.Spec.WorkerNodeGroupConfigurations {
+		workerMachineName := workerNodeGroup.MachineGroupRef.Name
+		if added[workerMachineName] {
+			continue
+		}
+		added[workerMachineName] = true
 		configs = append(configs, p.machineConfigs[workerMachineName])
 		if p.clusterConfig.IsManaged() {
 			p.machineConfigs[workerMachineName].SetManagement(p.clusterConfig.ManagedBy())
@@ -1118,7 +1217,7 @@ func (p *vsphereProvider) MachineConfigs() []providers.MachineConfig {
 	if p.clusterConfig.Spec.ExternalEtcdConfiguration != nil {
 		etcdMachineName := p.clusterConfig.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name
 		p.machineConfigs[etcdMachineName].Annotations = map[string]string{p.clusterConfig.EtcdAnnotation(): "true"}
-		if etcdMachineName != controlPlaneMachineName && etcdMachineName != workerMachineName {
+		if !added[etcdMachineName] {
 			configs = append(configs, p.machineConfigs[etcdMachineName])
 			p.machineConfigs[etcdMachineName].SetManagement(p.clusterConfig.ManagedBy())
 		}
@@ -1142,7 +1241,16 @@ func (p *vsphereProvider) ValidateNewSpec(ctx context.Context, cluster *types.Cl
 	oSpec := prevDatacenter.Spec
 	nSpec := datacenter.Spec
 
+	prevMachineConfigRefs := make(map[string]struct{})
+	for _, ref := range prevSpec.MachineConfigRefs() {
+		prevMachineConfigRefs[ref.Name] = struct{}{}
+	}
+
 	for _, machineConfigRef := range clusterSpec.MachineConfigRefs() {
+		// machine configs of new worker node groups don't exist in the cluster yet
+		if _, ok := prevMachineConfigRefs[machineConfigRef.Name]; !ok {
+			continue
+		}
 		machineConfig, ok := p.machineConfigs[machineConfigRef.Name]
 		if !ok {
 			return fmt.Errorf("cannot find machine config %s in vsphere provider machine configs", machineConfigRef.Name)
@@ -1295,6 +1403,48 @@ func (p *vsphereProvider) UpgradeNeeded(_ context.Context, newSpec, currentSpec
 		newV.KubeVip.ImageDigest != oldV.KubeVip.ImageDigest, nil
 }
 
+func (p *vsphereProvider) MachineGroupRollouts(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) ([]types.MachineGroupRollout, error) {
+	vdc, err := p.providerKubectlClient.GetEksaVSphereDatacenterConfig(ctx, currentSpec.Spec.DatacenterRef.Name, cluster.KubeconfigFile, newSpec.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlaneVmc, err := p.providerKubectlClient.GetEksaVSphereMachineConfig(ctx, currentSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name, cluster.KubeconfigFile, newSpec.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	controlPlaneMachineConfig := p.machineConfigs[newSpec.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+
+	rollouts := []types.MachineGroupRollout{
+		{Name: types.ControlPlaneMachineGroup, Replaced: NeedsNewControlPlaneTemplate(currentSpec, newSpec, vdc, p.datacenterConfig, controlPlaneVmc, controlPlaneMachineConfig)},
+	}
+
+	for _, workerNodeGroup := range newSpec.Spec.WorkerNodeGroupConfigurations {
+		replaced, err := p.needsNewWorkloadTemplate(ctx, cluster, currentSpec.Cluster, currentSpec, newSpec, vdc, workerNodeGroup)
+		if err != nil {
+			return nil, err
+		}
+		rollouts = append(rollouts, types.MachineGroupRollout{
+			Name:     types.WorkerNodeGroupMachineGroup(workerNodeGroup.Name),
+			Replaced: replaced || clusterapi.NeedsNewKubeadmConfigTemplate(currentSpec.Cluster, workerNodeGroup),
+		})
+	}
+
+	if newSpec.Spec.ExternalEtcdConfiguration != nil && currentSpec.Spec.ExternalEtcdConfiguration != nil {
+		etcdVmc, err := p.providerKubectlClient.GetEksaVSphereMachineConfig(ctx, currentSpec.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name, cluster.KubeconfigFile, newSpec.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		etcdMachineConfig := p.machineConfigs[newSpec.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name]
+		rollouts = append(rollouts, types.MachineGroupRollout{
+			Name:     types.EtcdMachineGroup,
+			Replaced: NeedsNewEtcdTemplate(currentSpec, newSpec, vdc, p.datacenterConfig, etcdVmc, etcdMachineConfig),
+		})
+	}
+
+	return rollouts, nil
+}
+
 func (p *vsphereProvider) RunPostControlPlaneCreation(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error {
 	// Use retrier so that cluster creation does not fail due to any intermittent failure while connecting to kube-api server
 	err := p.Retrier.Retry(
@@ -1304,3 +1454,8 @@ func (p *vsphereProvider) RunPostControlPlaneCreation(ctx context.Context, clust
 	)
 	return err
 }
+
+// EnableDryRun stops the setup from importing the missing default templates, it fails instead
+func (p *vsphereProvider) EnableDryRun() {
+	p.defaulter.dryRun = true
+}
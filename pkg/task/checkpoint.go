@@ -0,0 +1,165 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const checkpointFileNameSuffix = "checkpoint.yaml"
+
+// ResumableTask is a Task whose completion can be checkpointed, so a failed workflow can be resumed after it.
+// Restore is called instead of Run when resuming a workflow in which the task already completed: it must
+// re-apply any in-memory change Run makes to the CommandContext and return the Task Run would have returned.
+type ResumableTask interface {
+	Task
+	Restore(ctx context.Context, commandContext *CommandContext) (Task, error)
+}
+
+// Checkpoint is the progress of a workflow, persisted in the cluster folder after each ResumableTask completes
+type Checkpoint struct {
+	CompletedTasks     []string               `json:"completedTasks"`
+	BootstrapCluster   *types.Cluster         `json:"bootstrapCluster,omitempty"`
+	WorkloadCluster    *types.Cluster         `json:"workloadCluster,omitempty"`
+	CurrentClusterSpec *ClusterSpecCheckpoint `json:"currentClusterSpec,omitempty"`
+	UpgradeChangeDiff  *types.ChangeDiff      `json:"upgradeChangeDiff,omitempty"`
+}
+
+// ClusterSpecCheckpoint holds the objects needed to rebuild a cluster.Spec
+type ClusterSpecCheckpoint struct {
+	Cluster      *v1alpha1.Cluster        `json:"cluster"`
+	Bundles      *releasev1alpha1.Bundles `json:"bundles"`
+	GitOpsConfig *v1alpha1.GitOpsConfig   `json:"gitOpsConfig,omitempty"`
+}
+
+func (c *Checkpoint) completed(taskName string) bool {
+	for _, t := range c.CompletedTasks {
+		if t == taskName {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Checkpoint) markCompleted(taskName string) {
+	if !c.completed(taskName) {
+		c.CompletedTasks = append(c.CompletedTasks, taskName)
+	}
+}
+
+func (c *Checkpoint) unmarkCompleted(taskName string) {
+	tasks := make([]string, 0, len(c.CompletedTasks))
+	for _, t := range c.CompletedTasks {
+		if t != taskName {
+			tasks = append(tasks, t)
+		}
+	}
+	c.CompletedTasks = tasks
+}
+
+// snapshot copies the CommandContext state needed by the remaining tasks into the checkpoint
+func (c *Checkpoint) snapshot(commandContext *CommandContext) {
+	c.BootstrapCluster = commandContext.BootstrapCluster
+	c.WorkloadCluster = commandContext.WorkloadCluster
+	c.UpgradeChangeDiff = commandContext.UpgradeChangeDiff
+	c.CurrentClusterSpec = nil
+	if spec := commandContext.CurrentClusterSpec; spec != nil {
+		c.CurrentClusterSpec = &ClusterSpecCheckpoint{
+			Cluster:      spec.Cluster,
+			Bundles:      spec.Bundles,
+			GitOpsConfig: spec.GitOpsConfig,
+		}
+	}
+}
+
+// restore sets the CommandContext state saved in the checkpoint
+func (c *Checkpoint) restore(commandContext *CommandContext) error {
+	if c.BootstrapCluster != nil {
+		commandContext.BootstrapCluster = c.BootstrapCluster
+	}
+	if c.WorkloadCluster != nil {
+		commandContext.WorkloadCluster = c.WorkloadCluster
+	}
+	if c.UpgradeChangeDiff != nil {
+		commandContext.UpgradeChangeDiff = c.UpgradeChangeDiff
+	}
+	if c.CurrentClusterSpec != nil {
+		spec, err := cluster.BuildSpecFromBundles(c.CurrentClusterSpec.Cluster, c.CurrentClusterSpec.Bundles, cluster.WithGitOpsConfig(c.CurrentClusterSpec.GitOpsConfig))
+		if err != nil {
+			return fmt.Errorf("failed restoring current cluster spec from checkpoint: %v", err)
+		}
+		commandContext.CurrentClusterSpec = spec
+	}
+	return nil
+}
+
+// checkpointer persists a Checkpoint as a temporary file in the cluster folder,
+// so it's removed together with the rest of the generated files once the workflow succeeds
+type checkpointer struct {
+	writer     filewriter.FileWriter
+	fileName   string
+	checkpoint *Checkpoint
+}
+
+func newCheckpointer(commandContext *CommandContext) *checkpointer {
+	return &checkpointer{
+		writer:     commandContext.Writer,
+		fileName:   fmt.Sprintf("%s-%s", commandContext.ClusterSpec.Name, checkpointFileNameSuffix),
+		checkpoint: &Checkpoint{},
+	}
+}
+
+func (c *checkpointer) path() string {
+	return filepath.Join(c.writer.Dir(), filewriter.DefaultTmpFolder, c.fileName)
+}
+
+func (c *checkpointer) load() error {
+	content, err := ioutil.ReadFile(c.path())
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no checkpoint found to resume from at %s", c.path())
+	}
+	if err != nil {
+		return fmt.Errorf("failed reading checkpoint: %v", err)
+	}
+
+	checkpoint := &Checkpoint{}
+	if err = yaml.Unmarshal(content, checkpoint); err != nil {
+		return fmt.Errorf("failed parsing checkpoint %s: %v", c.path(), err)
+	}
+	c.checkpoint = checkpoint
+	return nil
+}
+
+func (c *checkpointer) save(commandContext *CommandContext) {
+	c.checkpoint.snapshot(commandContext)
+	content, err := yaml.Marshal(c.checkpoint)
+	if err != nil {
+		logger.Info("Warning: failed marshalling checkpoint", "error", err)
+		return
+	}
+	if _, err = c.writer.Write(c.fileName, content); err != nil {
+		logger.Info("Warning: failed writing checkpoint", "error", err)
+	}
+}
+
+// InvalidateCompletedTask removes a task from the workflow checkpoint so it's run again if the workflow is resumed.
+// Tasks that revert the work of a previously completed task must call this.
+func (c *CommandContext) InvalidateCompletedTask(taskName string) {
+	if c.checkpointer == nil || !c.checkpointer.checkpoint.completed(taskName) {
+		return
+	}
+	c.checkpointer.checkpoint.unmarkCompleted(taskName)
+	c.checkpointer.save(c)
+}
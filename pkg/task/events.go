@@ -0,0 +1,115 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+type EventType string
+
+const (
+	TaskStarted  EventType = "TaskStarted"
+	TaskFinished EventType = "TaskFinished"
+	TaskFailed   EventType = "TaskFailed"
+)
+
+// Event is emitted by the task runner when a task starts and when it finishes or fails
+type Event struct {
+	Type EventType `json:"type"`
+	Task string    `json:"task"`
+	Time time.Time `json:"time"`
+	// DurationSeconds is only set for finished and failed tasks
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+	// Subtasks holds the duration in seconds of the sub tasks profiled during the task
+	Subtasks map[string]float64 `json:"subtasks,omitempty"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// EventSink receives the events of a task runner
+type EventSink interface {
+	Emit(event Event) error
+}
+
+// JSONLinesFileSink appends each event as a JSON object in its own line to a file
+type JSONLinesFileSink struct {
+	path string
+}
+
+func NewJSONLinesFileSink(path string) *JSONLinesFileSink {
+	return &JSONLinesFileSink{path: path}
+}
+
+func (s *JSONLinesFileSink) Path() string {
+	return s.path
+}
+
+func (s *JSONLinesFileSink) Emit(event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed marshalling task event: %v", err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed opening task events file: %v", err)
+	}
+	defer f.Close()
+
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed writing task event: %v", err)
+	}
+	return nil
+}
+
+// TimingSummary is an EventSink that keeps the outcome and duration of every task to print them as a table
+type TimingSummary struct {
+	mu    sync.Mutex
+	tasks []Event
+}
+
+func NewTimingSummary() *TimingSummary {
+	return &TimingSummary{}
+}
+
+func (s *TimingSummary) Emit(event Event) error {
+	if event.Type == TaskStarted {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks = append(s.tasks, event)
+	return nil
+}
+
+// Print writes a table with the status and duration of each finished task, followed by the total
+func (s *TimingSummary) Print(w io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.tasks) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "TASK\tSTATUS\tDURATION")
+	var total time.Duration
+	for _, t := range s.tasks {
+		status := "Succeeded"
+		if t.Type == TaskFailed {
+			status = "Failed"
+		}
+		duration := secondsToDuration(t.DurationSeconds)
+		total += duration
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", t.Task, status, duration)
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t%s\n", total)
+	return tw.Flush()
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return (time.Duration(s * float64(time.Second))).Round(time.Millisecond)
+}
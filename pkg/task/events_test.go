@@ -0,0 +1,50 @@
+package task_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/task"
+)
+
+func TestJSONLinesFileSinkEmit(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink := task.NewJSONLinesFileSink(path)
+	now := time.Now().UTC()
+
+	g.Expect(sink.Emit(task.Event{Type: task.TaskStarted, Task: "taskA", Time: now})).To(Succeed())
+	g.Expect(sink.Emit(task.Event{Type: task.TaskFailed, Task: "taskA", Time: now, DurationSeconds: 1.5, Error: "failed"})).To(Succeed())
+
+	content, err := ioutil.ReadFile(path)
+	g.Expect(err).To(Succeed())
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	g.Expect(lines).To(HaveLen(2))
+
+	got := task.Event{}
+	g.Expect(json.Unmarshal([]byte(lines[1]), &got)).To(Succeed())
+	g.Expect(got).To(Equal(task.Event{Type: task.TaskFailed, Task: "taskA", Time: now, DurationSeconds: 1.5, Error: "failed"}))
+}
+
+func TestTimingSummaryPrint(t *testing.T) {
+	g := NewWithT(t)
+	summary := task.NewTimingSummary()
+	g.Expect(summary.Emit(task.Event{Type: task.TaskStarted, Task: "taskA"})).To(Succeed())
+	g.Expect(summary.Emit(task.Event{Type: task.TaskFinished, Task: "taskA", DurationSeconds: 2})).To(Succeed())
+	g.Expect(summary.Emit(task.Event{Type: task.TaskFailed, Task: "taskB", DurationSeconds: 0.5})).To(Succeed())
+
+	out := &bytes.Buffer{}
+	g.Expect(summary.Print(out)).To(Succeed())
+	g.Expect(out.String()).To(Equal(`TASK      STATUS      DURATION
+taskA     Succeeded   2s
+taskB     Failed      500ms
+TOTAL                 2.5s
+`))
+}
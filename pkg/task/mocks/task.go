@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/task (interfaces: Task)
+// Source: github.com/aws/eks-anywhere/pkg/task (interfaces: Task,ResumableTask)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -62,3 +62,69 @@ func (mr *MockTaskMockRecorder) Run(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockTask)(nil).Run), arg0, arg1)
 }
+
+// MockResumableTask is a mock of ResumableTask interface.
+type MockResumableTask struct {
+	ctrl     *gomock.Controller
+	recorder *MockResumableTaskMockRecorder
+}
+
+// MockResumableTaskMockRecorder is the mock recorder for MockResumableTask.
+type MockResumableTaskMockRecorder struct {
+	mock *MockResumableTask
+}
+
+// NewMockResumableTask creates a new mock instance.
+func NewMockResumableTask(ctrl *gomock.Controller) *MockResumableTask {
+	mock := &MockResumableTask{ctrl: ctrl}
+	mock.recorder = &MockResumableTaskMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResumableTask) EXPECT() *MockResumableTaskMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockResumableTask) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockResumableTaskMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockResumableTask)(nil).Name))
+}
+
+// Restore mocks base method.
+func (m *MockResumableTask) Restore(arg0 context.Context, arg1 *task.CommandContext) (task.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(task.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockResumableTaskMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockResumableTask)(nil).Restore), arg0, arg1)
+}
+
+// Run mocks base method.
+func (m *MockResumableTask) Run(arg0 context.Context, arg1 *task.CommandContext) task.Task {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", arg0, arg1)
+	ret0, _ := ret[0].(task.Task)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockResumableTaskMockRecorder) Run(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockResumableTask)(nil).Run), arg0, arg1)
+}
@@ -2,6 +2,7 @@ package task
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -20,20 +21,23 @@ type Task interface {
 
 // Command context maintains the mutable and shared entities
 type CommandContext struct {
-	Bootstrapper       interfaces.Bootstrapper
-	Provider           providers.Provider
-	ClusterManager     interfaces.ClusterManager
-	AddonManager       interfaces.AddonManager
-	Validations        interfaces.Validator
-	Writer             filewriter.FileWriter
-	CAPIManager        interfaces.CAPIManager
-	ClusterSpec        *cluster.Spec
-	CurrentClusterSpec *cluster.Spec
-	UpgradeChangeDiff  *types.ChangeDiff
-	BootstrapCluster   *types.Cluster
-	WorkloadCluster    *types.Cluster
-	Profiler           *Profiler
-	OriginalError      error
+	Bootstrapper            interfaces.Bootstrapper
+	Provider                providers.Provider
+	ClusterManager          interfaces.ClusterManager
+	AddonManager            interfaces.AddonManager
+	TargetAddonManager      interfaces.AddonManager
+	Validations             interfaces.Validator
+	Writer                  filewriter.FileWriter
+	CAPIManager             interfaces.CAPIManager
+	ClusterSpec             *cluster.Spec
+	CurrentClusterSpec      *cluster.Spec
+	UpgradeChangeDiff       *types.ChangeDiff
+	BootstrapCluster        *types.Cluster
+	WorkloadCluster         *types.Cluster
+	TargetManagementCluster *types.Cluster
+	Profiler                *Profiler
+	OriginalError           error
+	checkpointer            *checkpointer
 }
 
 func (c *CommandContext) SetError(err error) {
@@ -97,7 +101,35 @@ func (pp *Profiler) logProfileSummary(taskName string) {
 
 // Manages Task execution
 type taskRunner struct {
-	task Task
+	task        Task
+	checkpoints bool
+	resume      bool
+	sinks       []EventSink
+}
+
+type TaskRunnerOpt func(*taskRunner)
+
+// WithCheckpoints makes the runner persist a Checkpoint in the cluster folder after each ResumableTask completes
+func WithCheckpoints() TaskRunnerOpt {
+	return func(r *taskRunner) {
+		r.checkpoints = true
+	}
+}
+
+// WithResume makes the runner load the Checkpoint of a previous run and skip the ResumableTasks it completed.
+// Tasks that are not resumable, like validations, are always run.
+func WithResume() TaskRunnerOpt {
+	return func(r *taskRunner) {
+		r.checkpoints = true
+		r.resume = true
+	}
+}
+
+// WithEventSinks makes the runner emit an Event to each sink when a task starts, finishes or fails
+func WithEventSinks(sinks ...EventSink) TaskRunnerOpt {
+	return func(r *taskRunner) {
+		r.sinks = append(r.sinks, sinks...)
+	}
 }
 
 // executes Task
@@ -106,26 +138,132 @@ func (pr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 		metrics: make(map[string]map[string]time.Duration),
 		starts:  make(map[string]map[string]time.Time),
 	}
+	if err := pr.setupCheckpoints(commandContext); err != nil {
+		return err
+	}
 	task := pr.task
 	start := time.Now()
 	defer taskRunnerFinalBlock(start)
 	for task != nil {
+		nextTask, restored, err := pr.restoreTask(ctx, task, commandContext)
+		if err != nil {
+			return err
+		}
+		if restored {
+			task = nextTask
+			continue
+		}
 		logger.V(4).Info("Task start", "task_name", task.Name())
 		commandContext.Profiler.SetStartTask(task.Name())
-		nextTask := task.Run(ctx, commandContext)
+		pr.emitStarted(task)
+		previousErr := commandContext.OriginalError
+		nextTask = task.Run(ctx, commandContext)
 		commandContext.Profiler.MarkDoneTask(task.Name())
 		commandContext.Profiler.logProfileSummary(task.Name())
+		pr.emitDone(task, commandContext, previousErr == nil && commandContext.OriginalError != nil)
+		pr.checkpointTask(task, commandContext)
 		task = nextTask
 	}
 	return commandContext.OriginalError
 }
 
+func (pr *taskRunner) setupCheckpoints(commandContext *CommandContext) error {
+	if !pr.checkpoints {
+		return nil
+	}
+	commandContext.checkpointer = newCheckpointer(commandContext)
+	if !pr.resume {
+		return nil
+	}
+	if err := commandContext.checkpointer.load(); err != nil {
+		return err
+	}
+	logger.Info("Resuming from checkpoint", "completed_tasks", len(commandContext.checkpointer.checkpoint.CompletedTasks))
+	return commandContext.checkpointer.checkpoint.restore(commandContext)
+}
+
+// restoreTask skips a task completed in the run being resumed. Once a resumable task needs to run,
+// no more tasks are skipped.
+func (pr *taskRunner) restoreTask(ctx context.Context, task Task, commandContext *CommandContext) (next Task, restored bool, err error) {
+	if !pr.resume {
+		return nil, false, nil
+	}
+	resumable, ok := task.(ResumableTask)
+	if !ok {
+		return nil, false, nil
+	}
+	if !commandContext.checkpointer.checkpoint.completed(task.Name()) {
+		pr.resume = false
+		return nil, false, nil
+	}
+
+	logger.V(4).Info("Task completed in previous run, skipping", "task_name", task.Name())
+	next, err = resumable.Restore(ctx, commandContext)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed restoring task %s: %v", task.Name(), err)
+	}
+	return next, true, nil
+}
+
+func (pr *taskRunner) checkpointTask(task Task, commandContext *CommandContext) {
+	if commandContext.checkpointer == nil || commandContext.OriginalError != nil {
+		return
+	}
+	if _, ok := task.(ResumableTask); !ok {
+		return
+	}
+	commandContext.checkpointer.checkpoint.markCompleted(task.Name())
+	commandContext.checkpointer.save(commandContext)
+}
+
+func (pr *taskRunner) emitStarted(task Task) {
+	if len(pr.sinks) == 0 {
+		return
+	}
+	pr.emit(Event{Type: TaskStarted, Task: task.Name(), Time: time.Now()})
+}
+
+func (pr *taskRunner) emitDone(task Task, commandContext *CommandContext, failed bool) {
+	if len(pr.sinks) == 0 {
+		return
+	}
+	taskName := task.Name()
+	event := Event{Type: TaskFinished, Task: taskName, Time: time.Now()}
+	for name, duration := range commandContext.Profiler.Metrics()[taskName] {
+		if name == taskName {
+			event.DurationSeconds = duration.Seconds()
+			continue
+		}
+		if event.Subtasks == nil {
+			event.Subtasks = map[string]float64{}
+		}
+		event.Subtasks[name] = duration.Seconds()
+	}
+	if failed {
+		event.Type = TaskFailed
+		event.Error = commandContext.OriginalError.Error()
+	}
+	pr.emit(event)
+}
+
+func (pr *taskRunner) emit(event Event) {
+	for _, sink := range pr.sinks {
+		if err := sink.Emit(event); err != nil {
+			logger.V(4).Info("Failed emitting task event", "task_name", event.Task, "error", err)
+		}
+	}
+}
+
 func taskRunnerFinalBlock(startTime time.Time) {
 	logger.V(4).Info("Tasks completed", "duration", time.Since(startTime))
 }
 
-func NewTaskRunner(task Task) *taskRunner {
-	return &taskRunner{
+func NewTaskRunner(task Task, opts ...TaskRunnerOpt) *taskRunner {
+	r := &taskRunner{
 		task: task,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
@@ -2,12 +2,22 @@ package task_test
 
 import (
 	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/task"
 	mocktasks "github.com/aws/eks-anywhere/pkg/task/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
 )
 
 func TestTaskRunnerRunTask(t *testing.T) {
@@ -55,3 +65,157 @@ func TestTaskRunnerRunTask(t *testing.T) {
 		}
 	}
 }
+
+func newCheckpointCommandContext(t *testing.T) *task.CommandContext {
+	writer, err := filewriter.NewWriter(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed creating writer: %v", err)
+	}
+	return &task.CommandContext{
+		Writer: writer,
+		ClusterSpec: &cluster.Spec{
+			Cluster: &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+		},
+	}
+}
+
+func readCheckpoint(t *testing.T, cmdContext *task.CommandContext) *task.Checkpoint {
+	content, err := ioutil.ReadFile(filepath.Join(cmdContext.Writer.Dir(), filewriter.DefaultTmpFolder, "test-cluster-checkpoint.yaml"))
+	if err != nil {
+		t.Fatalf("failed reading checkpoint: %v", err)
+	}
+	checkpoint := &task.Checkpoint{}
+	if err = yaml.Unmarshal(content, checkpoint); err != nil {
+		t.Fatalf("failed parsing checkpoint: %v", err)
+	}
+	return checkpoint
+}
+
+func TestTaskRunnerRunTaskWithCheckpoints(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	cmdContext := newCheckpointCommandContext(t)
+	bootstrapCluster := &types.Cluster{Name: "bootstrap", KubeconfigFile: "bootstrap.kubeconfig"}
+
+	validateTask := mocktasks.NewMockTask(ctrl)
+	resumableTaskA := mocktasks.NewMockResumableTask(ctrl)
+	resumableTaskB := mocktasks.NewMockResumableTask(ctrl)
+
+	validateTask.EXPECT().Name().Return("validate").AnyTimes()
+	validateTask.EXPECT().Run(ctx, cmdContext).Return(resumableTaskA)
+	resumableTaskA.EXPECT().Name().Return("taskA").AnyTimes()
+	resumableTaskA.EXPECT().Run(ctx, cmdContext).DoAndReturn(func(_ context.Context, c *task.CommandContext) task.Task {
+		c.BootstrapCluster = bootstrapCluster
+		return resumableTaskB
+	})
+	resumableTaskB.EXPECT().Name().Return("taskB").AnyTimes()
+	resumableTaskB.EXPECT().Run(ctx, cmdContext).DoAndReturn(func(_ context.Context, c *task.CommandContext) task.Task {
+		c.SetError(errors.New("task failed"))
+		return nil
+	})
+
+	err := task.NewTaskRunner(validateTask, task.WithCheckpoints()).RunTask(ctx, cmdContext)
+	g.Expect(err).To(MatchError("task failed"))
+
+	checkpoint := readCheckpoint(t, cmdContext)
+	g.Expect(checkpoint.CompletedTasks).To(Equal([]string{"taskA"}))
+	g.Expect(checkpoint.BootstrapCluster).To(Equal(bootstrapCluster))
+}
+
+func TestTaskRunnerRunTaskResume(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	cmdContext := newCheckpointCommandContext(t)
+	bootstrapCluster := &types.Cluster{Name: "bootstrap", KubeconfigFile: "bootstrap.kubeconfig"}
+	_, err := cmdContext.Writer.Write("test-cluster-checkpoint.yaml", []byte(`completedTasks:
+- taskA
+bootstrapCluster:
+  Name: bootstrap
+  KubeconfigFile: bootstrap.kubeconfig
+`))
+	g.Expect(err).To(Succeed())
+
+	validateTask := mocktasks.NewMockTask(ctrl)
+	resumableTaskA := mocktasks.NewMockResumableTask(ctrl)
+	resumableTaskB := mocktasks.NewMockResumableTask(ctrl)
+
+	validateTask.EXPECT().Name().Return("validate").AnyTimes()
+	validateTask.EXPECT().Run(ctx, cmdContext).Return(resumableTaskA)
+	resumableTaskA.EXPECT().Name().Return("taskA").AnyTimes()
+	resumableTaskA.EXPECT().Run(ctx, cmdContext).Times(0)
+	resumableTaskA.EXPECT().Restore(ctx, cmdContext).Return(resumableTaskB, nil)
+	resumableTaskB.EXPECT().Name().Return("taskB").AnyTimes()
+	resumableTaskB.EXPECT().Run(ctx, cmdContext).Return(nil)
+
+	err = task.NewTaskRunner(validateTask, task.WithResume()).RunTask(ctx, cmdContext)
+	g.Expect(err).To(Succeed())
+	g.Expect(cmdContext.BootstrapCluster).To(Equal(bootstrapCluster))
+	g.Expect(readCheckpoint(t, cmdContext).CompletedTasks).To(Equal([]string{"taskA", "taskB"}))
+}
+
+func TestTaskRunnerRunTaskResumeNoCheckpoint(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	cmdContext := newCheckpointCommandContext(t)
+	firstTask := mocktasks.NewMockTask(ctrl)
+
+	err := task.NewTaskRunner(firstTask, task.WithResume()).RunTask(context.Background(), cmdContext)
+	g.Expect(err).To(MatchError(ContainSubstring("no checkpoint found to resume from")))
+}
+
+func TestTaskRunnerRunTaskWithEventSinks(t *testing.T) {
+	g := NewWithT(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	cmdContext := &task.CommandContext{}
+	sink := &recordingSink{}
+
+	taskA := mocktasks.NewMockTask(ctrl)
+	taskB := mocktasks.NewMockTask(ctrl)
+	diagnosticsTask := mocktasks.NewMockTask(ctrl)
+
+	taskA.EXPECT().Name().Return("taskA").AnyTimes()
+	taskA.EXPECT().Run(ctx, cmdContext).DoAndReturn(func(_ context.Context, c *task.CommandContext) task.Task {
+		c.Profiler.SetStart("taskA", "subtask")
+		c.Profiler.MarkDone("taskA", "subtask")
+		return taskB
+	})
+	taskB.EXPECT().Name().Return("taskB").AnyTimes()
+	taskB.EXPECT().Run(ctx, cmdContext).DoAndReturn(func(_ context.Context, c *task.CommandContext) task.Task {
+		c.SetError(errors.New("task failed"))
+		return diagnosticsTask
+	})
+	diagnosticsTask.EXPECT().Name().Return("diagnostics").AnyTimes()
+	diagnosticsTask.EXPECT().Run(ctx, cmdContext).Return(nil)
+
+	err := task.NewTaskRunner(taskA, task.WithEventSinks(sink)).RunTask(ctx, cmdContext)
+	g.Expect(err).To(MatchError("task failed"))
+
+	g.Expect(sink.types()).To(Equal([]string{
+		"TaskStarted/taskA", "TaskFinished/taskA",
+		"TaskStarted/taskB", "TaskFailed/taskB",
+		"TaskStarted/diagnostics", "TaskFinished/diagnostics",
+	}))
+	g.Expect(sink.events[1].Subtasks).To(HaveKey("subtask"))
+	g.Expect(sink.events[3].Error).To(Equal("task failed"))
+	g.Expect(sink.events[5].Error).To(BeEmpty())
+}
+
+type recordingSink struct {
+	events []task.Event
+}
+
+func (s *recordingSink) Emit(event task.Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingSink) types() []string {
+	types := make([]string, 0, len(s.events))
+	for _, e := range s.events {
+		types = append(types, string(e.Type)+"/"+e.Task)
+	}
+	return types
+}
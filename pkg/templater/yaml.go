@@ -1,5 +1,7 @@
 package templater
 
+import "bytes"
+
 const objectSeparator string = "\n---\n"
 
 func AppendYamlResources(resources ...[]byte) []byte {
@@ -18,3 +20,8 @@ func AppendYamlResources(resources ...[]byte) []byte {
 
 	return b
 }
+
+// JoinYamlResources concatenates the resources adding the yaml object separator only between them
+func JoinYamlResources(resources ...[]byte) []byte {
+	return bytes.Join(resources, []byte(objectSeparator))
+}
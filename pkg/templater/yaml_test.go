@@ -0,0 +1,15 @@
+package templater_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+func TestJoinYamlResources(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(string(templater.JoinYamlResources([]byte("a: 1")))).To(Equal("a: 1"))
+	g.Expect(string(templater.JoinYamlResources([]byte("a: 1"), []byte("b: 2")))).To(Equal("a: 1\n---\nb: 2"))
+}
@@ -10,6 +10,7 @@ type Deployment struct {
 
 type Machine struct {
 	Metadata MachineMetadata `json:"metadata"`
+	Spec     MachineSpec     `json:"spec"`
 	Status   MachineStatus   `json:"status"`
 }
 
@@ -22,13 +23,36 @@ func (m *Machine) HasAnyLabel(labels []string) bool {
 	return false
 }
 
+// Address returns the first IP address reported for the machine, or an empty string if it doesn't have any
+func (m *Machine) Address() string {
+	for _, a := range m.Status.Addresses {
+		if a.Type == "InternalIP" || a.Type == "ExternalIP" {
+			return a.Address
+		}
+	}
+	return ""
+}
+
+type MachineSpec struct {
+	InfrastructureRef ResourceRef `json:"infrastructureRef"`
+}
+
 type MachineStatus struct {
-	NodeRef    *ResourceRef `json:"nodeRef,omitempty"`
+	NodeRef    *ResourceRef     `json:"nodeRef,omitempty"`
+	Addresses  []MachineAddress `json:"addresses,omitempty"`
 	Conditions Conditions
 }
 
+type MachineAddress struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
 type MachineMetadata struct {
-	Labels map[string]string `json:"labels,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	CreationTimestamp time.Time         `json:"creationTimestamp,omitempty"`
+	DeletionTimestamp *time.Time        `json:"deletionTimestamp,omitempty"`
 }
 
 type ResourceRef struct {
@@ -44,15 +68,23 @@ type ConditionType string
 type ConditionStatus string
 
 type Condition struct {
-	Type   ConditionType   `json:"type"`
-	Status ConditionStatus `json:"status"`
+	Type               ConditionType   `json:"type"`
+	Status             ConditionStatus `json:"status"`
+	Reason             string          `json:"reason,omitempty"`
+	Message            string          `json:"message,omitempty"`
+	LastTransitionTime time.Time       `json:"lastTransitionTime,omitempty"`
 }
 
 type CAPICluster struct {
 	Metadata Metadata
+	Spec     CAPIClusterSpec
 	Status   ClusterStatus
 }
 
+type CAPIClusterSpec struct {
+	Paused bool
+}
+
 type ClusterStatus struct {
 	Phase string
 }
@@ -1,15 +1,27 @@
 package types
 
 type ChangeDiff struct {
-	ComponentReports []ComponentChangeDiff
+	ComponentReports []ComponentChangeDiff `json:"componentReports"`
 }
 
 type ComponentChangeDiff struct {
-	ComponentName string
-	OldVersion    string
-	NewVersion    string
+	ComponentName string `json:"componentName"`
+	OldVersion    string `json:"oldVersion"`
+	NewVersion    string `json:"newVersion"`
 }
 
+// MachineGroupRollout tells if the machines of a group (control plane, workers or etcd) are replaced during an upgrade
+type MachineGroupRollout struct {
+	Name     string `json:"name"`
+	Replaced bool   `json:"replaced"`
+}
+
+const (
+	ControlPlaneMachineGroup = "control-plane"
+	WorkersMachineGroup      = "workers"
+	EtcdMachineGroup         = "etcd"
+)
+
 func NewChangeDiff(componentReports ...*ComponentChangeDiff) *ChangeDiff {
 	reports := make([]ComponentChangeDiff, 0, len(componentReports))
 	for _, r := range componentReports {
@@ -34,3 +46,8 @@ func (c *ChangeDiff) Append(changeDiffs ...*ChangeDiff) {
 func (c *ChangeDiff) Changed() bool {
 	return len(c.ComponentReports) > 0
 }
+
+// WorkerNodeGroupMachineGroup returns the machine group name of a worker node group
+func WorkerNodeGroupMachineGroup(workerNodeGroupName string) string {
+	return WorkersMachineGroup + "/" + workerNodeGroupName
+}
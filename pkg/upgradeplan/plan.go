@@ -0,0 +1,152 @@
+package upgradeplan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/addonmanager/addonclients"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	TableOutput = "table"
+	JSONOutput  = "json"
+	YAMLOutput  = "yaml"
+)
+
+// Plan describes what an upgrade to a new cluster spec would change
+type Plan struct {
+	Cluster           string                      `json:"cluster"`
+	UpgradeAvailable  bool                        `json:"upgradeAvailable"`
+	KubernetesVersion *types.ComponentChangeDiff  `json:"kubernetesVersion,omitempty"`
+	EksdRelease       *types.ComponentChangeDiff  `json:"eksdRelease,omitempty"`
+	Components        []types.ComponentChangeDiff `json:"components"`
+	NodeRollouts      []types.MachineGroupRollout `json:"nodeRollouts"`
+}
+
+// Build compares the spec running in the cluster with the new one, aggregating the component changes
+// of CAPI, the provider, Flux and EKS-A, and asks the provider which machine groups would be replaced
+func Build(ctx context.Context, cluster *types.Cluster, provider providers.Provider, currentSpec, newSpec *cluster.Spec) (*Plan, error) {
+	components := types.NewChangeDiff()
+	components.Append(
+		clustermanager.EksaChangeDiff(currentSpec, newSpec),
+		addonclients.FluxChangeDiff(currentSpec, newSpec),
+		clusterapi.CapiChangeDiff(currentSpec, newSpec, provider),
+	)
+
+	rollouts, err := provider.MachineGroupRollouts(ctx, cluster, currentSpec, newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed calculating node rollouts: %v", err)
+	}
+
+	plan := &Plan{
+		Cluster:      newSpec.Name,
+		Components:   components.ComponentReports,
+		NodeRollouts: rollouts,
+	}
+
+	if currentSpec.Spec.KubernetesVersion != newSpec.Spec.KubernetesVersion {
+		plan.KubernetesVersion = &types.ComponentChangeDiff{
+			ComponentName: "kubernetes",
+			OldVersion:    string(currentSpec.Spec.KubernetesVersion),
+			NewVersion:    string(newSpec.Spec.KubernetesVersion),
+		}
+	}
+
+	if currentSpec.VersionsBundle.EksD.Name != newSpec.VersionsBundle.EksD.Name {
+		plan.EksdRelease = &types.ComponentChangeDiff{
+			ComponentName: "eks-d",
+			OldVersion:    currentSpec.VersionsBundle.EksD.Name,
+			NewVersion:    newSpec.VersionsBundle.EksD.Name,
+		}
+	}
+
+	plan.UpgradeAvailable = len(plan.Components) > 0 || plan.KubernetesVersion != nil || plan.EksdRelease != nil || plan.replacesNodes()
+
+	return plan, nil
+}
+
+func (p *Plan) replacesNodes() bool {
+	for _, r := range p.NodeRollouts {
+		if r.Replaced {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateOutputFormat checks the format is one Print supports
+func ValidateOutputFormat(format string) error {
+	switch format {
+	case "", TableOutput, JSONOutput, YAMLOutput:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %s, supported formats are %s, %s and %s", format, TableOutput, JSONOutput, YAMLOutput)
+	}
+}
+
+// Print writes the plan in the given format: table, json or yaml
+func (p *Plan) Print(w io.Writer, format string) error {
+	if err := ValidateOutputFormat(format); err != nil {
+		return err
+	}
+
+	switch format {
+	case JSONOutput:
+		content, err := json.MarshalIndent(p, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed marshalling upgrade plan: %v", err)
+		}
+		_, err = fmt.Fprintln(w, string(content))
+		return err
+	case YAMLOutput:
+		content, err := yaml.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("failed marshalling upgrade plan: %v", err)
+		}
+		_, err = w.Write(content)
+		return err
+	default:
+		return p.printTable(w)
+	}
+}
+
+func (p *Plan) printTable(w io.Writer) error {
+	if !p.UpgradeAvailable {
+		_, err := fmt.Fprintln(w, "All the components are up to date with the latest versions")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tCURRENT VERSION\tNEXT VERSION")
+	versions := make([]types.ComponentChangeDiff, 0, len(p.Components)+2)
+	if p.KubernetesVersion != nil {
+		versions = append(versions, *p.KubernetesVersion)
+	}
+	if p.EksdRelease != nil {
+		versions = append(versions, *p.EksdRelease)
+	}
+	versions = append(versions, p.Components...)
+	for _, c := range versions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.ComponentName, c.OldVersion, c.NewVersion)
+	}
+
+	if len(p.NodeRollouts) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "MACHINE GROUP\tNODES REPLACED")
+		for _, r := range p.NodeRollouts {
+			fmt.Fprintf(tw, "%s\t%t\n", r.Name, r.Replaced)
+		}
+	}
+
+	return tw.Flush()
+}
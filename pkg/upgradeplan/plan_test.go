@@ -0,0 +1,150 @@
+package upgradeplan_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/upgradeplan"
+)
+
+type planTest struct {
+	*WithT
+	ctx         context.Context
+	provider    *providermocks.MockProvider
+	cluster     *types.Cluster
+	currentSpec *cluster.Spec
+	newSpec     *cluster.Spec
+}
+
+func newPlanTest(t *testing.T) *planTest {
+	spec := func(s *cluster.Spec) {
+		s.Name = "test-cluster"
+		s.Spec.KubernetesVersion = v1alpha1.Kube120
+		s.VersionsBundle.EksD.Name = "kubernetes-1-20-eks-7"
+		s.VersionsBundle.Eksa.Version = "v0.6.0"
+	}
+	return &planTest{
+		WithT:       NewWithT(t),
+		ctx:         context.Background(),
+		provider:    providermocks.NewMockProvider(gomock.NewController(t)),
+		cluster:     &types.Cluster{Name: "test-cluster", KubeconfigFile: "test-cluster.kubeconfig"},
+		currentSpec: test.NewClusterSpec(spec),
+		newSpec:     test.NewClusterSpec(spec),
+	}
+}
+
+func (tt *planTest) expectRollouts(replaced bool) {
+	tt.provider.EXPECT().ChangeDiff(tt.currentSpec, tt.newSpec).Return(nil).AnyTimes()
+	tt.provider.EXPECT().MachineGroupRollouts(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec).Return([]types.MachineGroupRollout{
+		{Name: types.ControlPlaneMachineGroup, Replaced: replaced},
+		{Name: types.WorkersMachineGroup, Replaced: replaced},
+	}, nil)
+}
+
+func TestBuildNoUpgrade(t *testing.T) {
+	tt := newPlanTest(t)
+	tt.expectRollouts(false)
+
+	plan, err := upgradeplan.Build(tt.ctx, tt.cluster, tt.provider, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(plan.UpgradeAvailable).To(BeFalse())
+	tt.Expect(plan.Components).To(BeEmpty())
+	tt.Expect(plan.KubernetesVersion).To(BeNil())
+
+	out := &bytes.Buffer{}
+	tt.Expect(plan.Print(out, upgradeplan.TableOutput)).To(Succeed())
+	tt.Expect(out.String()).To(Equal("All the components are up to date with the latest versions\n"))
+}
+
+func TestBuildKubernetesUpgrade(t *testing.T) {
+	tt := newPlanTest(t)
+	tt.newSpec.Spec.KubernetesVersion = v1alpha1.Kube121
+	tt.newSpec.VersionsBundle.EksD.Name = "kubernetes-1-21-eks-4"
+	tt.newSpec.VersionsBundle.Eksa.Version = "v0.7.0"
+	tt.expectRollouts(true)
+
+	plan, err := upgradeplan.Build(tt.ctx, tt.cluster, tt.provider, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(plan.UpgradeAvailable).To(BeTrue())
+	tt.Expect(plan.KubernetesVersion).To(Equal(&types.ComponentChangeDiff{ComponentName: "kubernetes", OldVersion: "1.20", NewVersion: "1.21"}))
+	tt.Expect(plan.EksdRelease).To(Equal(&types.ComponentChangeDiff{ComponentName: "eks-d", OldVersion: "kubernetes-1-20-eks-7", NewVersion: "kubernetes-1-21-eks-4"}))
+	tt.Expect(plan.Components).To(ConsistOf(types.ComponentChangeDiff{ComponentName: "EKS-A", OldVersion: "v0.6.0", NewVersion: "v0.7.0"}))
+
+	out := &bytes.Buffer{}
+	tt.Expect(plan.Print(out, upgradeplan.TableOutput)).To(Succeed())
+	tt.Expect(out.String()).To(Equal(`NAME         CURRENT VERSION         NEXT VERSION
+kubernetes   1.20                    1.21
+eks-d        kubernetes-1-20-eks-7   kubernetes-1-21-eks-4
+EKS-A        v0.6.0                  v0.7.0
+
+MACHINE GROUP   NODES REPLACED
+control-plane   true
+workers         true
+`))
+
+	out.Reset()
+	tt.Expect(plan.Print(out, upgradeplan.YAMLOutput)).To(Succeed())
+	tt.Expect(out.String()).To(Equal(`cluster: test-cluster
+components:
+- componentName: EKS-A
+  newVersion: v0.7.0
+  oldVersion: v0.6.0
+eksdRelease:
+  componentName: eks-d
+  newVersion: kubernetes-1-21-eks-4
+  oldVersion: kubernetes-1-20-eks-7
+kubernetesVersion:
+  componentName: kubernetes
+  newVersion: "1.21"
+  oldVersion: "1.20"
+nodeRollouts:
+- name: control-plane
+  replaced: true
+- name: workers
+  replaced: true
+upgradeAvailable: true
+`))
+}
+
+func TestBuildRolloutsError(t *testing.T) {
+	tt := newPlanTest(t)
+	tt.provider.EXPECT().ChangeDiff(tt.currentSpec, tt.newSpec).Return(nil).AnyTimes()
+	tt.provider.EXPECT().MachineGroupRollouts(tt.ctx, tt.cluster, tt.currentSpec, tt.newSpec).Return(nil, errors.New("error getting machine configs"))
+
+	_, err := upgradeplan.Build(tt.ctx, tt.cluster, tt.provider, tt.currentSpec, tt.newSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("error getting machine configs")))
+}
+
+func TestPrintJSON(t *testing.T) {
+	g := NewWithT(t)
+	plan := &upgradeplan.Plan{
+		Cluster:          "test-cluster",
+		UpgradeAvailable: true,
+		Components:       []types.ComponentChangeDiff{{ComponentName: "Flux", OldVersion: "v0.1.0", NewVersion: "v0.2.0"}},
+		NodeRollouts:     []types.MachineGroupRollout{{Name: types.WorkersMachineGroup}},
+	}
+
+	out := &bytes.Buffer{}
+	g.Expect(plan.Print(out, upgradeplan.JSONOutput)).To(Succeed())
+	g.Expect(out.String()).To(MatchJSON(`{
+		"cluster": "test-cluster",
+		"upgradeAvailable": true,
+		"components": [{"componentName": "Flux", "oldVersion": "v0.1.0", "newVersion": "v0.2.0"}],
+		"nodeRollouts": [{"name": "workers", "replaced": false}]
+	}`))
+}
+
+func TestPrintInvalidFormat(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect((&upgradeplan.Plan{}).Print(&bytes.Buffer{}, "xml")).To(MatchError(ContainSubstring("invalid output format xml")))
+}
@@ -12,6 +12,9 @@ import (
 	executables "github.com/aws/eks-anywhere/pkg/executables"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/apps/v1"
+	v10 "k8s.io/api/core/v1"
+	v1beta1 "k8s.io/api/policy/v1beta1"
 )
 
 // MockKubectlClient is a mock of KubectlClient interface.
@@ -52,6 +55,26 @@ func (mr *MockKubectlClientMockRecorder) GetClusters(ctx, cluster interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusters", reflect.TypeOf((*MockKubectlClient)(nil).GetClusters), ctx, cluster)
 }
 
+// GetDeployments mocks base method.
+func (m *MockKubectlClient) GetDeployments(ctx context.Context, opts ...executables.KubectlOpt) ([]v1.Deployment, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDeployments", varargs...)
+	ret0, _ := ret[0].([]v1.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeployments indicates an expected call of GetDeployments.
+func (mr *MockKubectlClientMockRecorder) GetDeployments(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployments", reflect.TypeOf((*MockKubectlClient)(nil).GetDeployments), varargs...)
+}
+
 // GetEksaAWSIamConfig mocks base method.
 func (m *MockKubectlClient) GetEksaAWSIamConfig(ctx context.Context, awsIamConfigName, kubeconfigFile, namespace string) (*v1alpha1.AWSIamConfig, error) {
 	m.ctrl.T.Helper()
@@ -127,6 +150,61 @@ func (mr *MockKubectlClientMockRecorder) GetEksaVSphereDatacenterConfig(ctx, vsp
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaVSphereDatacenterConfig", reflect.TypeOf((*MockKubectlClient)(nil).GetEksaVSphereDatacenterConfig), ctx, vsphereDatacenterConfigName, kubeconfigFile, namespace)
 }
 
+// GetNodes mocks base method.
+func (m *MockKubectlClient) GetNodes(ctx context.Context, kubeconfig string) ([]v10.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodes", ctx, kubeconfig)
+	ret0, _ := ret[0].([]v10.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodes indicates an expected call of GetNodes.
+func (mr *MockKubectlClientMockRecorder) GetNodes(ctx, kubeconfig interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockKubectlClient)(nil).GetNodes), ctx, kubeconfig)
+}
+
+// GetPodDisruptionBudgets mocks base method.
+func (m *MockKubectlClient) GetPodDisruptionBudgets(ctx context.Context, opts ...executables.KubectlOpt) ([]v1beta1.PodDisruptionBudget, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPodDisruptionBudgets", varargs...)
+	ret0, _ := ret[0].([]v1beta1.PodDisruptionBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPodDisruptionBudgets indicates an expected call of GetPodDisruptionBudgets.
+func (mr *MockKubectlClientMockRecorder) GetPodDisruptionBudgets(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodDisruptionBudgets", reflect.TypeOf((*MockKubectlClient)(nil).GetPodDisruptionBudgets), varargs...)
+}
+
+// GetPods mocks base method.
+func (m *MockKubectlClient) GetPods(ctx context.Context, opts ...executables.KubectlOpt) ([]v10.Pod, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPods", varargs...)
+	ret0, _ := ret[0].([]v10.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPods indicates an expected call of GetPods.
+func (mr *MockKubectlClientMockRecorder) GetPods(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPods", reflect.TypeOf((*MockKubectlClient)(nil).GetPods), varargs...)
+}
+
 // SearchEksaGitOpsConfig mocks base method.
 func (m *MockKubectlClient) SearchEksaGitOpsConfig(ctx context.Context, gitOpsConfigName, kubeconfigFile, namespace string) ([]*v1alpha1.GitOpsConfig, error) {
 	m.ctrl.T.Helper()
@@ -23,7 +23,7 @@ func (r *Runner) Run() error {
 	for _, v := range r.validations {
 		result := v()
 		result.Report()
-		if result.Err != nil {
+		if result.Err != nil && !result.Warning {
 			failed = true
 		}
 	}
@@ -44,3 +44,16 @@ func TestRunnerRunSuccess(t *testing.T) {
 
 	g.Expect(r.Run()).To(Succeed())
 }
+
+func TestRunnerRunWarning(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	r.Register(func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Err:     errors.New("risky"),
+			Warning: true,
+		}
+	})
+
+	g.Expect(r.Run()).To(Succeed())
+}
@@ -0,0 +1,234 @@
+package upgradevalidations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// systemNamespaces hold the components installed and upgraded by EKS Anywhere, their workloads are not checked
+var systemNamespaces = map[string]struct{}{
+	constants.EksaSystemNamespace:                     {},
+	constants.EksaDiagnosticsNamespace:                {},
+	constants.CapdSystemNamespace:                     {},
+	constants.CapiKubeadmBootstrapSystemNamespace:     {},
+	constants.CapiKubeadmControlPlaneSystemNamespace:  {},
+	constants.CapiSystemNamespace:                     {},
+	constants.CapiWebhookSystemNamespace:              {},
+	constants.CapvSystemNamespace:                     {},
+	constants.CapaSystemNamespace:                     {},
+	constants.CertManagerNamespace:                    {},
+	constants.EtcdAdmBootstrapProviderSystemNamespace: {},
+	constants.EtcdAdmControllerSystemNamespace:        {},
+	constants.KubeSystemNamespace:                     {},
+	constants.LocalPathStorageNamespace:               {},
+	cluster.FluxDefaultNamespace:                      {},
+}
+
+type workloadResources struct {
+	nodes       []corev1.Node
+	pods        []corev1.Pod
+	deployments []appsv1.Deployment
+	pdbs        []policyv1beta1.PodDisruptionBudget
+}
+
+// WorkloadDisruptionValidations returns the validations that look for workloads that block or are disrupted
+// when the nodes of the cluster are drained during the upgrade. Unless failOnRisks is set, the issues are reported as warnings
+func WorkloadDisruptionValidations(ctx context.Context, k validations.KubectlClient, cluster *types.Cluster, failOnRisks bool) []validations.Validation {
+	resources, err := getWorkloadResources(ctx, k, cluster)
+	if err != nil {
+		return []validations.Validation{
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "workload disruption checks",
+					Remediation: fmt.Sprintf("ensure the API server of cluster %s is reachable", cluster.Name),
+					Err:         err,
+					Warning:     !failOnRisks,
+				}
+			},
+		}
+	}
+
+	return []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "pod disruption budgets allow evictions",
+				Remediation: "scale up the workloads or relax their pod disruption budgets, otherwise the drain of their nodes is blocked",
+				Err:         validateBlockingPodDisruptionBudgets(resources.pdbs),
+				Warning:     !failOnRisks,
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "single replica deployments",
+				Remediation: "scale up the deployments and add pod disruption budgets to avoid downtime while their nodes are drained",
+				Err:         validateSingleReplicaDeployments(resources.deployments, resources.pdbs),
+				Warning:     !failOnRisks,
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "pods with local storage",
+				Remediation: "move the data of the pods to persistent volumes, the emptyDir volumes are deleted when their nodes are drained",
+				Err:         validatePodsLocalStorage(resources.pods),
+				Warning:     !failOnRisks,
+			}
+		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "nodes schedulable",
+				Remediation: "uncordon the nodes with kubectl uncordon, or wait until the operation that cordoned them is finished",
+				Err:         validateCordonedNodes(resources.nodes),
+				Warning:     !failOnRisks,
+			}
+		},
+	}
+}
+
+func getWorkloadResources(ctx context.Context, k validations.KubectlClient, cluster *types.Cluster) (*workloadResources, error) {
+	var err error
+	r := &workloadResources{}
+	if r.nodes, err = k.GetNodes(ctx, cluster.KubeconfigFile); err != nil {
+		return nil, err
+	}
+	if r.pods, err = k.GetPods(ctx, executables.WithCluster(cluster), executables.WithAllNamespaces()); err != nil {
+		return nil, err
+	}
+	if r.deployments, err = k.GetDeployments(ctx, executables.WithCluster(cluster), executables.WithAllNamespaces()); err != nil {
+		return nil, err
+	}
+	if r.pdbs, err = k.GetPodDisruptionBudgets(ctx, executables.WithCluster(cluster), executables.WithAllNamespaces()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func validateBlockingPodDisruptionBudgets(pdbs []policyv1beta1.PodDisruptionBudget) error {
+	var blocking []string
+	for _, pdb := range pdbs {
+		if isSystemNamespace(pdb.Namespace) {
+			continue
+		}
+		if pdb.Status.ExpectedPods > 0 && pdb.Status.DisruptionsAllowed == 0 {
+			blocking = append(blocking, namespacedName(pdb.ObjectMeta))
+		}
+	}
+	if len(blocking) > 0 {
+		return fmt.Errorf("pod disruption budgets don't allow any eviction: %s", joinSorted(blocking))
+	}
+	return nil
+}
+
+func validateSingleReplicaDeployments(deployments []appsv1.Deployment, pdbs []policyv1beta1.PodDisruptionBudget) error {
+	var unprotected []string
+	for _, d := range deployments {
+		if isSystemNamespace(d.Namespace) {
+			continue
+		}
+		// replicas defaults to 1
+		if d.Spec.Replicas != nil && *d.Spec.Replicas != 1 {
+			continue
+		}
+		if !hasPodDisruptionBudget(d, pdbs) {
+			unprotected = append(unprotected, namespacedName(d.ObjectMeta))
+		}
+	}
+	if len(unprotected) > 0 {
+		return fmt.Errorf("deployments with one replica and no pod disruption budget: %s", joinSorted(unprotected))
+	}
+	return nil
+}
+
+func hasPodDisruptionBudget(d appsv1.Deployment, pdbs []policyv1beta1.PodDisruptionBudget) bool {
+	podLabels := labels.Set(d.Spec.Template.Labels)
+	for _, pdb := range pdbs {
+		if pdb.Namespace != d.Namespace || pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if !selector.Empty() && selector.Matches(podLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePodsLocalStorage(pods []corev1.Pod) error {
+	var withLocalStorage []string
+	for _, p := range pods {
+		if isSystemNamespace(p.Namespace) || isEvictionExempt(p) {
+			continue
+		}
+		for _, v := range p.Spec.Volumes {
+			if v.EmptyDir != nil {
+				withLocalStorage = append(withLocalStorage, namespacedName(p.ObjectMeta))
+				break
+			}
+		}
+	}
+	if len(withLocalStorage) > 0 {
+		return fmt.Errorf("pods with local storage: %s", joinSorted(withLocalStorage))
+	}
+	return nil
+}
+
+// isEvictionExempt returns true for the pods that are not evicted by a drain: mirror pods, daemon set pods and finished pods
+func isEvictionExempt(p corev1.Pod) bool {
+	if _, ok := p.Annotations[mirrorPodAnnotation]; ok {
+		return true
+	}
+	if p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed {
+		return true
+	}
+	for _, o := range p.OwnerReferences {
+		if o.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
+
+func validateCordonedNodes(nodes []corev1.Node) error {
+	var cordoned []string
+	for _, n := range nodes {
+		if n.Spec.Unschedulable {
+			cordoned = append(cordoned, n.Name)
+		}
+	}
+	if len(cordoned) > 0 {
+		return fmt.Errorf("nodes are cordoned: %s", joinSorted(cordoned))
+	}
+	return nil
+}
+
+func isSystemNamespace(namespace string) bool {
+	_, ok := systemNamespaces[namespace]
+	return ok
+}
+
+func namespacedName(m metav1.ObjectMeta) string {
+	return m.Namespace + "/" + m.Name
+}
+
+func joinSorted(s []string) string {
+	sort.Strings(s)
+	return strings.Join(s, ", ")
+}
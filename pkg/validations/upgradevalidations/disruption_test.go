@@ -0,0 +1,152 @@
+package upgradevalidations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/mocks"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+)
+
+type disruptionTest struct {
+	*WithT
+	ctx         context.Context
+	k           *mocks.MockKubectlClient
+	cluster     *types.Cluster
+	nodes       []corev1.Node
+	pods        []corev1.Pod
+	deployments []appsv1.Deployment
+	pdbs        []policyv1beta1.PodDisruptionBudget
+}
+
+func newDisruptionTest(t *testing.T) *disruptionTest {
+	return &disruptionTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		k:       mocks.NewMockKubectlClient(gomock.NewController(t)),
+		cluster: &types.Cluster{Name: testclustername, KubeconfigFile: kubeconfigFilePath},
+	}
+}
+
+func (d *disruptionTest) run(failOnRisks bool) []*validations.ValidationResult {
+	d.k.EXPECT().GetNodes(d.ctx, kubeconfigFilePath).Return(d.nodes, nil)
+	d.k.EXPECT().GetPods(d.ctx, gomock.Any()).Return(d.pods, nil)
+	d.k.EXPECT().GetDeployments(d.ctx, gomock.Any()).Return(d.deployments, nil)
+	d.k.EXPECT().GetPodDisruptionBudgets(d.ctx, gomock.Any()).Return(d.pdbs, nil)
+
+	var results []*validations.ValidationResult
+	for _, v := range upgradevalidations.WorkloadDisruptionValidations(d.ctx, d.k, d.cluster, failOnRisks) {
+		results = append(results, v())
+	}
+	return results
+}
+
+func (d *disruptionTest) resultErrors(results []*validations.ValidationResult) map[string]string {
+	errs := map[string]string{}
+	for _, r := range results {
+		if r.Err != nil {
+			errs[r.Name] = r.Err.Error()
+		}
+	}
+	return errs
+}
+
+func deployment(namespace, name string, replicas int32, podLabels map[string]string) appsv1.Deployment {
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: podLabels}},
+		},
+	}
+}
+
+func pdb(namespace, name string, podLabels map[string]string, expectedPods, disruptionsAllowed int32) policyv1beta1.PodDisruptionBudget {
+	return policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       policyv1beta1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: podLabels}},
+		Status:     policyv1beta1.PodDisruptionBudgetStatus{ExpectedPods: expectedPods, DisruptionsAllowed: disruptionsAllowed},
+	}
+}
+
+func TestWorkloadDisruptionValidationsNoRisks(t *testing.T) {
+	tt := newDisruptionTest(t)
+	tt.nodes = []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}}
+	tt.deployments = []appsv1.Deployment{
+		deployment("default", "web", 3, map[string]string{"app": "web"}),
+		deployment("default", "db", 1, map[string]string{"app": "db"}),
+		deployment("kube-system", "coredns", 1, map[string]string{"k8s-app": "kube-dns"}),
+	}
+	tt.pdbs = []policyv1beta1.PodDisruptionBudget{
+		pdb("default", "web", map[string]string{"app": "web"}, 3, 1),
+		pdb("default", "db", map[string]string{"app": "db"}, 0, 0),
+		pdb("kube-system", "coredns", map[string]string{"k8s-app": "kube-dns"}, 2, 0),
+	}
+	tt.pods = []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "default",
+				Name:            "agent-abc",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent"}},
+			},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}},
+		},
+	}
+
+	results := tt.run(true)
+	tt.Expect(results).To(HaveLen(4))
+	tt.Expect(tt.resultErrors(results)).To(BeEmpty())
+}
+
+func TestWorkloadDisruptionValidationsRisks(t *testing.T) {
+	tt := newDisruptionTest(t)
+	tt.nodes = []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Spec: corev1.NodeSpec{Unschedulable: true}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Spec: corev1.NodeSpec{Unschedulable: true}},
+	}
+	tt.deployments = []appsv1.Deployment{
+		deployment("default", "web", 1, map[string]string{"app": "web"}),
+		deployment("default", "db", 1, map[string]string{"app": "db"}),
+	}
+	tt.pdbs = []policyv1beta1.PodDisruptionBudget{
+		pdb("default", "db", map[string]string{"app": "db"}, 1, 0),
+	}
+	tt.pods = []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-abc"},
+			Spec:       corev1.PodSpec{Volumes: []corev1.Volume{{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}},
+		},
+	}
+
+	results := tt.run(false)
+	tt.Expect(tt.resultErrors(results)).To(Equal(map[string]string{
+		"pod disruption budgets allow evictions": "pod disruption budgets don't allow any eviction: default/db",
+		"single replica deployments":             "deployments with one replica and no pod disruption budget: default/web",
+		"pods with local storage":                "pods with local storage: default/web-abc",
+		"nodes schedulable":                      "nodes are cordoned: node-1, node-2",
+	}))
+	for _, r := range results {
+		tt.Expect(r.Warning).To(BeTrue())
+	}
+}
+
+func TestWorkloadDisruptionValidationsGetResourcesError(t *testing.T) {
+	tt := newDisruptionTest(t)
+	tt.k.EXPECT().GetNodes(tt.ctx, kubeconfigFilePath).Return(nil, errors.New("error getting nodes"))
+
+	validationFuncs := upgradevalidations.WorkloadDisruptionValidations(tt.ctx, tt.k, tt.cluster, true)
+	tt.Expect(validationFuncs).To(HaveLen(1))
+	result := validationFuncs[0]()
+	tt.Expect(result.Err).To(MatchError("error getting nodes"))
+	tt.Expect(result.Warning).To(BeFalse())
+}
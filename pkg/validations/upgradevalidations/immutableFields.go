@@ -44,23 +44,20 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 			return err
 		}
 
-		if prevGitOps.Spec.Flux.Github.Owner != spec.GitOpsConfig.Spec.Flux.Github.Owner {
-			return fmt.Errorf("gitOps spec.flux.github.owner is immutable")
+		prevFlux, newFlux := prevGitOps.Spec.Flux, spec.GitOpsConfig.Spec.Flux
+		if prevFlux.IsGit() != newFlux.IsGit() || prevFlux.IsGitlab() != newFlux.IsGitlab() {
+			return fmt.Errorf("gitOps spec.flux git provider is immutable")
 		}
-		if prevGitOps.Spec.Flux.Github.Repository != spec.GitOpsConfig.Spec.Flux.Github.Repository {
-			return fmt.Errorf("gitOps spec.flux.github.repository is immutable")
+		switch {
+		case newFlux.IsGit():
+			err = validateImmutableGitProviderFields(prevFlux.Git, newFlux.Git)
+		case newFlux.IsGitlab():
+			err = validateImmutableGitlabFields(prevFlux.Gitlab, newFlux.Gitlab)
+		default:
+			err = validateImmutableGithubFields(prevFlux.Github, newFlux.Github)
 		}
-		if prevGitOps.Spec.Flux.Github.Personal != spec.GitOpsConfig.Spec.Flux.Github.Personal {
-			return fmt.Errorf("gitOps spec.flux.github.personal is immutable")
-		}
-		if spec.GitOpsConfig.Spec.Flux.Github.FluxSystemNamespace != "" && prevGitOps.Spec.Flux.Github.FluxSystemNamespace != spec.GitOpsConfig.Spec.Flux.Github.FluxSystemNamespace {
-			return fmt.Errorf("gitOps spec.flux.github.fluxSystemNamespace is immutable")
-		}
-		if spec.GitOpsConfig.Spec.Flux.Github.Branch != "" && prevGitOps.Spec.Flux.Github.Branch != spec.GitOpsConfig.Spec.Flux.Github.Branch {
-			return fmt.Errorf("gitOps spec.flux.github.branch is immutable")
-		}
-		if spec.GitOpsConfig.Spec.Flux.Github.ClusterConfigPath != "" && prevGitOps.Spec.Flux.Github.ClusterConfigPath != spec.GitOpsConfig.Spec.Flux.Github.ClusterConfigPath {
-			return fmt.Errorf("gitOps spec.flux.github.clusterConfigPath is immutable")
+		if err != nil {
+			return err
 		}
 
 		spec.SetDefaultGitOps()
@@ -120,3 +117,66 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 
 	return provider.ValidateNewSpec(ctx, cluster, spec)
 }
+
+func validateImmutableGithubFields(prev, new v1alpha1.Github) error {
+	if prev.Owner != new.Owner {
+		return fmt.Errorf("gitOps spec.flux.github.owner is immutable")
+	}
+	if prev.Repository != new.Repository {
+		return fmt.Errorf("gitOps spec.flux.github.repository is immutable")
+	}
+	if prev.Personal != new.Personal {
+		return fmt.Errorf("gitOps spec.flux.github.personal is immutable")
+	}
+	if new.FluxSystemNamespace != "" && prev.FluxSystemNamespace != new.FluxSystemNamespace {
+		return fmt.Errorf("gitOps spec.flux.github.fluxSystemNamespace is immutable")
+	}
+	if new.Branch != "" && prev.Branch != new.Branch {
+		return fmt.Errorf("gitOps spec.flux.github.branch is immutable")
+	}
+	if new.ClusterConfigPath != "" && prev.ClusterConfigPath != new.ClusterConfigPath {
+		return fmt.Errorf("gitOps spec.flux.github.clusterConfigPath is immutable")
+	}
+	return nil
+}
+
+func validateImmutableGitProviderFields(prev, new *v1alpha1.GitProviderConfig) error {
+	if prev.RepositoryUrl != new.RepositoryUrl {
+		return fmt.Errorf("gitOps spec.flux.git.repositoryUrl is immutable")
+	}
+	if new.FluxSystemNamespace != "" && prev.FluxSystemNamespace != new.FluxSystemNamespace {
+		return fmt.Errorf("gitOps spec.flux.git.fluxSystemNamespace is immutable")
+	}
+	if new.Branch != "" && prev.Branch != new.Branch {
+		return fmt.Errorf("gitOps spec.flux.git.branch is immutable")
+	}
+	if new.ClusterConfigPath != "" && prev.ClusterConfigPath != new.ClusterConfigPath {
+		return fmt.Errorf("gitOps spec.flux.git.clusterConfigPath is immutable")
+	}
+	return nil
+}
+
+func validateImmutableGitlabFields(prev, new *v1alpha1.Gitlab) error {
+	if prev.Hostname != new.Hostname {
+		return fmt.Errorf("gitOps spec.flux.gitlab.hostname is immutable")
+	}
+	if prev.Owner != new.Owner {
+		return fmt.Errorf("gitOps spec.flux.gitlab.owner is immutable")
+	}
+	if prev.Repository != new.Repository {
+		return fmt.Errorf("gitOps spec.flux.gitlab.repository is immutable")
+	}
+	if prev.Personal != new.Personal {
+		return fmt.Errorf("gitOps spec.flux.gitlab.personal is immutable")
+	}
+	if new.FluxSystemNamespace != "" && prev.FluxSystemNamespace != new.FluxSystemNamespace {
+		return fmt.Errorf("gitOps spec.flux.gitlab.fluxSystemNamespace is immutable")
+	}
+	if new.Branch != "" && prev.Branch != new.Branch {
+		return fmt.Errorf("gitOps spec.flux.gitlab.branch is immutable")
+	}
+	if new.ClusterConfigPath != "" && prev.ClusterConfigPath != new.ClusterConfigPath {
+		return fmt.Errorf("gitOps spec.flux.gitlab.clusterConfigPath is immutable")
+	}
+	return nil
+}
@@ -71,6 +71,12 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) (err erro
 		}
 	}
 
+	runner := validations.NewRunner()
+	runner.Register(WorkloadDisruptionValidations(ctx, k, u.Opts.WorkloadCluster, u.Opts.FailOnWorkloadDisruptionRisks)...)
+	if err = runner.Run(); err != nil {
+		errs = append(errs, fmt.Sprintf("workload disruption %v", err))
+	}
+
 	if len(errs) > 0 {
 		return &validations.ValidationError{Errs: errs}
 	}
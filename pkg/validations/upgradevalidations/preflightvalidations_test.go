@@ -281,6 +281,34 @@ func TestPreflightValidations(t *testing.T) {
 				s.GitOpsConfig.Spec.Flux.Github.Personal = !s.GitOpsConfig.Spec.Flux.Github.Personal
 			},
 		},
+		{
+			name:               "ValidationGitOpsGitProviderImmutable",
+			clusterVersion:     "v1.19.16-eks-1-19-4",
+			upgradeVersion:     "1.19",
+			getClusterResponse: goodClusterResponse,
+			cpResponse:         nil,
+			workerResponse:     nil,
+			nodeResponse:       nil,
+			crdResponse:        nil,
+			wantErr:            composeError("gitOps spec.flux git provider is immutable"),
+			modifyFunc: func(s *cluster.Spec) {
+				s.GitOpsConfig.Spec.Flux.Git = &v1alpha1.GitProviderConfig{RepositoryUrl: "ssh://git@git.example.com/owner/repo.git"}
+			},
+		},
+		{
+			name:               "ValidationGitOpsGitlabProviderImmutable",
+			clusterVersion:     "v1.19.16-eks-1-19-4",
+			upgradeVersion:     "1.19",
+			getClusterResponse: goodClusterResponse,
+			cpResponse:         nil,
+			workerResponse:     nil,
+			nodeResponse:       nil,
+			crdResponse:        nil,
+			wantErr:            composeError("gitOps spec.flux git provider is immutable"),
+			modifyFunc: func(s *cluster.Spec) {
+				s.GitOpsConfig.Spec.Flux.Gitlab = &v1alpha1.Gitlab{Owner: "owner", Repository: "repo"}
+			},
+		},
 		{
 			name:               "ValidationOIDCClientIdImmutable",
 			clusterVersion:     "v1.19.16-eks-1-19-4",
@@ -609,6 +637,10 @@ func TestPreflightValidations(t *testing.T) {
 			k.EXPECT().GetEksaGitOpsConfig(ctx, clusterSpec.Spec.GitOpsRef.Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.GitOpsConfig, nil).MaxTimes(1)
 			k.EXPECT().GetEksaOIDCConfig(ctx, clusterSpec.Spec.IdentityProviderRefs[0].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.OIDCConfig, nil).MaxTimes(1)
 			k.EXPECT().Version(ctx, workloadCluster).Return(versionResponse, nil)
+			k.EXPECT().GetNodes(ctx, kubeconfigFilePath).Return(nil, nil)
+			k.EXPECT().GetPods(ctx, gomock.Any()).Return(nil, nil)
+			k.EXPECT().GetDeployments(ctx, gomock.Any()).Return(nil, nil)
+			k.EXPECT().GetPodDisruptionBudgets(ctx, gomock.Any()).Return(nil, nil)
 			upgradeValidations := upgradevalidations.New(opts)
 			err := upgradeValidations.PreflightValidations(ctx)
 			if !reflect.DeepEqual(err, tc.wantErr) {
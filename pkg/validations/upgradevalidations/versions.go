@@ -11,6 +11,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 const supportedMinorVersionIncrement = 1
@@ -43,3 +44,37 @@ func ValidateServerVersionSkew(ctx context.Context, compareVersion v1alpha1.Kube
 	}
 	return nil
 }
+
+// UpgradePath returns the kubernetes versions a cluster has to be upgraded to, in order, to go from currentVersion
+// to targetVersion one minor version at a time. Every intermediate version must be supported by the bundles manifest
+func UpgradePath(bundles *releasev1alpha1.Bundles, currentVersion, targetVersion v1alpha1.KubernetesVersion) ([]v1alpha1.KubernetesVersion, error) {
+	current, err := version.ParseGeneric(string(currentVersion))
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing current version: %v", err)
+	}
+
+	target, err := version.ParseGeneric(string(targetVersion))
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing target version: %v", err)
+	}
+
+	if current.Major() != target.Major() || target.Minor() < current.Minor() {
+		return nil, fmt.Errorf("can't upgrade from kubernetes version %s to %s", currentVersion, targetVersion)
+	}
+
+	supported := make(map[string]bool, len(bundles.Spec.VersionsBundles))
+	for _, versionsBundle := range bundles.Spec.VersionsBundles {
+		supported[versionsBundle.KubeVersion] = true
+	}
+
+	var path []v1alpha1.KubernetesVersion
+	for minor := current.Minor() + supportedMinorVersionIncrement; minor <= target.Minor(); minor += supportedMinorVersionIncrement {
+		hop := v1alpha1.KubernetesVersion(fmt.Sprintf("%d.%d", target.Major(), minor))
+		if !supported[string(hop)] {
+			return nil, fmt.Errorf("can't upgrade from kubernetes version %s to %s: version %s is not supported by bundles manifest %d", currentVersion, targetVersion, hop, bundles.Spec.Number)
+		}
+		path = append(path, hop)
+	}
+
+	return path, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 func TestValidateVersionSkew(t *testing.T) {
@@ -57,3 +58,71 @@ func TestValidateVersionSkew(t *testing.T) {
 		})
 	}
 }
+
+func TestUpgradePath(t *testing.T) {
+	bundles := &releasev1alpha1.Bundles{
+		Spec: releasev1alpha1.BundlesSpec{
+			Number: 1,
+			VersionsBundles: []releasev1alpha1.VersionsBundle{
+				{KubeVersion: "1.19"},
+				{KubeVersion: "1.20"},
+				{KubeVersion: "1.21"},
+			},
+		},
+	}
+	tests := []struct {
+		name           string
+		currentVersion v1alpha1.KubernetesVersion
+		targetVersion  v1alpha1.KubernetesVersion
+		wantPath       []v1alpha1.KubernetesVersion
+		wantErr        string
+	}{
+		{
+			name:           "SeveralMinorVersions",
+			currentVersion: v1alpha1.Kube119,
+			targetVersion:  v1alpha1.Kube121,
+			wantPath:       []v1alpha1.KubernetesVersion{v1alpha1.Kube120, v1alpha1.Kube121},
+		},
+		{
+			name:           "OneMinorVersion",
+			currentVersion: v1alpha1.Kube120,
+			targetVersion:  v1alpha1.Kube121,
+			wantPath:       []v1alpha1.KubernetesVersion{v1alpha1.Kube121},
+		},
+		{
+			name:           "SameVersion",
+			currentVersion: v1alpha1.Kube121,
+			targetVersion:  v1alpha1.Kube121,
+		},
+		{
+			name:           "Downgrade",
+			currentVersion: v1alpha1.Kube121,
+			targetVersion:  v1alpha1.Kube119,
+			wantErr:        "can't upgrade from kubernetes version 1.21 to 1.19",
+		},
+		{
+			name:           "IntermediateVersionNotSupported",
+			currentVersion: "1.17",
+			targetVersion:  v1alpha1.Kube120,
+			wantErr:        "can't upgrade from kubernetes version 1.17 to 1.20: version 1.18 is not supported by bundles manifest 1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := upgradevalidations.UpgradePath(bundles, tc.currentVersion, tc.targetVersion)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("UpgradePath() error = %v, want %s", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpgradePath() error = %v, want nil", err)
+			}
+			if !reflect.DeepEqual(path, tc.wantPath) {
+				t.Errorf("UpgradePath() = %v, want %v", path, tc.wantPath)
+			}
+		})
+	}
+}
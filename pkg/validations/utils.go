@@ -10,9 +10,15 @@ type ValidationResult struct {
 	Name        string
 	Err         error
 	Remediation string
+	// Warning reports Err as a warning, it doesn't fail the validations
+	Warning bool
 }
 
 func (v *ValidationResult) Report() {
+	if v.Err != nil && v.Warning {
+		logger.MarkWarning("Validation warning", "validation", v.Name, "warning", v.Err, "remediation", v.Remediation)
+		return
+	}
 	if v.Err != nil {
 		logger.MarkFail("Validation failed", "validation", v.Name, "error", v.Err, "remediation", v.Remediation)
 		return
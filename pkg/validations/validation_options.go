@@ -12,4 +12,6 @@ type Opts struct {
 	WorkloadCluster   *types.Cluster
 	ManagementCluster *types.Cluster
 	Provider          providers.Provider
+	// FailOnWorkloadDisruptionRisks reports the workload disruption checks as errors instead of warnings
+	FailOnWorkloadDisruptionRisks bool
 }
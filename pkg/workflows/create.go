@@ -21,6 +21,7 @@ type Create struct {
 	clusterManager interfaces.ClusterManager
 	addonManager   interfaces.AddonManager
 	writer         filewriter.FileWriter
+	eventSinks     []task.EventSink
 }
 
 func NewCreate(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
@@ -34,7 +35,13 @@ func NewCreate(bootstrapper interfaces.Bootstrapper, provider providers.Provider
 	}
 }
 
-func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator, forceCleanup bool) error {
+// WithTaskEventSinks makes the workflow emit the start, finish and failure of each of its tasks to the given sinks
+func (c *Create) WithTaskEventSinks(sinks ...task.EventSink) *Create {
+	c.eventSinks = sinks
+	return c
+}
+
+func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator, forceCleanup, resume bool) error {
 	if forceCleanup {
 		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
 			Name: clusterSpec.Name,
@@ -56,7 +63,7 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&SetAndValidateTask{}).RunTask(ctx, commandContext)
+	return task.NewTaskRunner(&SetAndValidateTask{}, runnerOpts(resume, c.eventSinks)...).RunTask(ctx, commandContext)
 }
 
 // task related entities
@@ -130,6 +137,10 @@ func (s *CreateBootStrapClusterTask) Name() string {
 	return "bootstrap-cluster-init"
 }
 
+func (s *CreateBootStrapClusterTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &CreateWorkloadClusterTask{}, nil
+}
+
 // SetAndValidateTask implementation
 
 func (s *SetAndValidateTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -230,6 +241,10 @@ func (s *CreateWorkloadClusterTask) Name() string {
 	return "workload-cluster-init"
 }
 
+func (s *CreateWorkloadClusterTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &MoveClusterManagementTask{}, nil
+}
+
 // MoveClusterManagementTask implementation
 
 func (s *MoveClusterManagementTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -250,6 +265,10 @@ func (s *MoveClusterManagementTask) Name() string {
 	return "capi-management-move"
 }
 
+func (s *MoveClusterManagementTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &InstallEksaComponentsTask{}, nil
+}
+
 // InstallEksaComponentsTask implementation
 
 func (s *InstallEksaComponentsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -274,7 +293,13 @@ func (s *InstallEksaComponentsTask) Run(ctx context.Context, commandContext *tas
 	if commandContext.BootstrapCluster.ExistingManagement {
 		targetCluster = commandContext.BootstrapCluster
 	}
-	err := commandContext.ClusterManager.CreateEKSAResources(ctx, targetCluster, commandContext.ClusterSpec, datacenterConfig, machineConfigs)
+	err := commandContext.ClusterManager.InstallClusterAutoscaler(ctx, targetCluster, commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
+	err = commandContext.ClusterManager.CreateEKSAResources(ctx, targetCluster, commandContext.ClusterSpec, datacenterConfig, machineConfigs)
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
@@ -291,6 +316,13 @@ func (s *InstallEksaComponentsTask) Name() string {
 	return "eksa-components-install"
 }
 
+func (s *InstallEksaComponentsTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	// the EKS-A objects were created paused, later tasks rely on the spec having the same annotations
+	commandContext.ClusterSpec.PauseReconcile()
+	commandContext.Provider.DatacenterConfig().PauseReconcile()
+	return &InstallAddonManagerTask{}, nil
+}
+
 // InstallAddonManagerTask implementation
 
 func (s *InstallAddonManagerTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -308,6 +340,10 @@ func (s *InstallAddonManagerTask) Name() string {
 	return "addon-manager-install"
 }
 
+func (s *InstallAddonManagerTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &WriteClusterConfigTask{}, nil
+}
+
 func (s *WriteClusterConfigTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Writing cluster config file")
 	err := clustermarshaller.WriteClusterConfig(commandContext.ClusterSpec, commandContext.Provider.DatacenterConfig(), commandContext.Provider.MachineConfigs(), commandContext.Writer)
@@ -322,6 +358,10 @@ func (s *WriteClusterConfigTask) Name() string {
 	return "write-cluster-config"
 }
 
+func (s *WriteClusterConfigTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &DeleteBootstrapClusterTask{}, nil
+}
+
 // DeleteBootstrapClusterTask implementation
 
 func (s *DeleteBootstrapClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
@@ -342,6 +382,15 @@ func (s *DeleteBootstrapClusterTask) Name() string {
 	return "delete-kind-cluster"
 }
 
+// runnerOpts configures the task runner to checkpoint the workflow progress and, if resuming, to start from the last checkpoint
+func runnerOpts(resume bool, sinks []task.EventSink) []task.TaskRunnerOpt {
+	opts := []task.TaskRunnerOpt{task.WithEventSinks(sinks...)}
+	if resume {
+		return append(opts, task.WithResume())
+	}
+	return append(opts, task.WithCheckpoints())
+}
+
 func getManagementCluster(commandContext *task.CommandContext) *types.Cluster {
 	target := commandContext.WorkloadCluster
 	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
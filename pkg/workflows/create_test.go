@@ -32,6 +32,7 @@ type createTestSetup struct {
 	ctx              context.Context
 	clusterSpec      *cluster.Spec
 	forceCleanup     bool
+	resume           bool
 	bootstrapCluster *types.Cluster
 	workloadCluster  *types.Cluster
 }
@@ -43,6 +44,7 @@ func newCreateTest(t *testing.T) *createTestSetup {
 	addonManager := mocks.NewMockAddonManager(mockCtrl)
 	provider := providermocks.NewMockProvider(mockCtrl)
 	writer := writermocks.NewMockFileWriter(mockCtrl)
+	writer.EXPECT().Write("cluster-name-checkpoint.yaml", gomock.Any()).AnyTimes()
 	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
 	machineConfigs := []providers.MachineConfig{&v1alpha1.VSphereMachineConfig{}}
 	workflow := workflows.NewCreate(bootstrapper, provider, clusterManager, addonManager, writer)
@@ -147,6 +149,8 @@ func (c *createTestSetup) expectInstallEksaComponents() {
 
 		c.provider.EXPECT().MachineConfigs().Return(c.machineConfigs),
 
+		c.clusterManager.EXPECT().InstallClusterAutoscaler(c.ctx, c.workloadCluster, c.clusterSpec),
+
 		c.clusterManager.EXPECT().CreateEKSAResources(
 			c.ctx, c.workloadCluster, c.clusterSpec, c.datacenterConfig, c.machineConfigs,
 		),
@@ -164,6 +168,8 @@ func (c *createTestSetup) skipInstallEksaComponents() {
 
 		c.provider.EXPECT().MachineConfigs().Return(c.machineConfigs),
 
+		c.clusterManager.EXPECT().InstallClusterAutoscaler(c.ctx, c.bootstrapCluster, c.clusterSpec),
+
 		c.clusterManager.EXPECT().CreateEKSAResources(
 			c.ctx, c.bootstrapCluster, c.clusterSpec, c.datacenterConfig, c.machineConfigs,
 		),
@@ -207,7 +213,7 @@ func (c *createTestSetup) expectInstallMHC() {
 }
 
 func (c *createTestSetup) run() error {
-	return c.workflow.Run(c.ctx, c.clusterSpec, c.validator, c.forceCleanup)
+	return c.workflow.Run(c.ctx, c.clusterSpec, c.validator, c.forceCleanup, c.resume)
 }
 
 func (c *createTestSetup) expectPreflightValidationsToPass() {
@@ -16,6 +16,7 @@ type Delete struct {
 	provider       providers.Provider
 	clusterManager interfaces.ClusterManager
 	addonManager   interfaces.AddonManager
+	eventSinks     []task.EventSink
 }
 
 func NewDelete(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
@@ -28,6 +29,12 @@ func NewDelete(bootstrapper interfaces.Bootstrapper, provider providers.Provider
 	}
 }
 
+// WithTaskEventSinks sets the sinks that receive the events of the delete tasks
+func (c *Delete) WithTaskEventSinks(sinks ...task.EventSink) *Delete {
+	c.eventSinks = sinks
+	return c
+}
+
 func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, forceCleanup bool, kubeconfig string) error {
 	if forceCleanup {
 		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
@@ -50,7 +57,7 @@ func (c *Delete) Run(ctx context.Context, workloadCluster *types.Cluster, cluste
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&setupAndValidate{}).RunTask(ctx, commandContext)
+	return task.NewTaskRunner(&setupAndValidate{}, task.WithEventSinks(c.eventSinks...)).RunTask(ctx, commandContext)
 }
 
 type setupAndValidate struct{}
@@ -0,0 +1,208 @@
+package workflows
+
+import (
+	"context"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+const (
+	// dryRunDir is the folder, inside the cluster folder, where the dry runs write the generated manifests
+	dryRunDir = "dry-run"
+	// dryRunGitOpsDir is the folder, inside dryRunDir, with the files that are committed to the GitOps repository
+	dryRunGitOpsDir = "gitops"
+)
+
+// DryRun runs the setup and validations of a create or upgrade and writes all the manifests they would apply,
+// without creating a bootstrap cluster or changing any cluster or infrastructure
+type DryRun struct {
+	provider       providers.Provider
+	clusterManager interfaces.ClusterManager
+	addonManager   interfaces.AddonManager
+	writer         filewriter.FileWriter
+	eventSinks     []task.EventSink
+}
+
+func NewDryRun(provider providers.Provider, clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager, writer filewriter.FileWriter) *DryRun {
+	return &DryRun{
+		provider:       provider,
+		clusterManager: clusterManager,
+		addonManager:   addonManager,
+		writer:         writer,
+	}
+}
+
+// WithTaskEventSinks makes the workflow emit the start, finish and failure of each of its tasks to the given sinks
+func (d *DryRun) WithTaskEventSinks(sinks ...task.EventSink) *DryRun {
+	d.eventSinks = sinks
+	return d
+}
+
+// RunCreate validates the create of a cluster and writes the manifests it would apply
+func (d *DryRun) RunCreate(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator) error {
+	commandContext := d.commandContext(clusterSpec, validator)
+	return d.run(ctx, &dryRunCreateValidateTask{}, commandContext)
+}
+
+// RunUpgrade validates the upgrade of a cluster and writes the manifests it would apply
+func (d *DryRun) RunUpgrade(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, validator interfaces.Validator) error {
+	commandContext := d.commandContext(clusterSpec, validator)
+	commandContext.WorkloadCluster = workloadCluster
+	return d.run(ctx, &dryRunUpgradeValidateTask{}, commandContext)
+}
+
+func (d *DryRun) commandContext(clusterSpec *cluster.Spec, validator interfaces.Validator) *task.CommandContext {
+	commandContext := &task.CommandContext{
+		Provider:       d.provider,
+		ClusterManager: d.clusterManager,
+		AddonManager:   d.addonManager,
+		ClusterSpec:    clusterSpec,
+		Writer:         d.writer,
+		Validations:    validator,
+	}
+	if clusterSpec.ManagementCluster != nil {
+		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
+	}
+	return commandContext
+}
+
+// run doesn't checkpoint the tasks, so a dry run is never resumed by a real create or upgrade
+func (d *DryRun) run(ctx context.Context, start task.Task, commandContext *task.CommandContext) error {
+	d.provider.EnableDryRun()
+	return task.NewTaskRunner(start, task.WithEventSinks(d.eventSinks...)).RunTask(ctx, commandContext)
+}
+
+type dryRunCreateValidateTask struct{}
+
+type dryRunUpgradeValidateTask struct{}
+
+type writeCreateManifestsTask struct{}
+
+type writeUpgradeManifestsTask struct{}
+
+func (s *dryRunCreateValidateTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Performing setup and validations")
+	createValidations := &SetAndValidateTask{}
+	runner := validations.NewRunner()
+	runner.Register(createValidations.providerValidation(ctx, commandContext)...)
+	runner.Register(commandContext.AddonManager.Validations(ctx, commandContext.ClusterSpec)...)
+	runner.Register(createValidations.validations(ctx, commandContext)...)
+
+	if err := runner.Run(); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	return &writeCreateManifestsTask{}
+}
+
+func (s *dryRunCreateValidateTask) Name() string {
+	return "setup-validate"
+}
+
+func (s *dryRunUpgradeValidateTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Performing setup and validations")
+	runner := validations.NewRunner()
+	runner.Register((&setupAndValidateTasks{}).validations(ctx, commandContext)...)
+
+	if err := runner.Run(); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	return &writeUpgradeManifestsTask{}
+}
+
+func (s *dryRunUpgradeValidateTask) Name() string {
+	return "setup-and-validate"
+}
+
+func (s *writeCreateManifestsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	w, err := dryRunWriter(commandContext)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	logger.Info("Generating cluster manifests")
+	if err = commandContext.ClusterManager.WriteManifestsForCreate(ctx, w, commandContext.ClusterSpec, commandContext.Provider); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	if err = writeGitOpsManifests(ctx, commandContext, w); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	logger.Info("Dry run manifests written", "folder", w.Dir())
+	return nil
+}
+
+func (s *writeCreateManifestsTask) Name() string {
+	return "write-manifests"
+}
+
+func (s *writeUpgradeManifestsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	target := getManagementCluster(commandContext)
+	currentSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, target, commandContext.ClusterSpec.Name)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	commandContext.CurrentClusterSpec = currentSpec
+
+	w, err := dryRunWriter(commandContext)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	logger.Info("Generating cluster manifests")
+	err = commandContext.ClusterManager.WriteManifestsForUpgrade(ctx, w, target, commandContext.WorkloadCluster, currentSpec, commandContext.ClusterSpec, commandContext.Provider)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	if err = writeGitOpsManifests(ctx, commandContext, w); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	logger.Info("Dry run manifests written", "folder", w.Dir())
+	return nil
+}
+
+func (s *writeUpgradeManifestsTask) Name() string {
+	return "write-manifests"
+}
+
+func dryRunWriter(commandContext *task.CommandContext) (filewriter.FileWriter, error) {
+	w, err := commandContext.Writer.WithDir(dryRunDir)
+	if err != nil {
+		return nil, err
+	}
+	w.CleanUpTemp()
+	return w, nil
+}
+
+func writeGitOpsManifests(ctx context.Context, commandContext *task.CommandContext, w filewriter.FileWriter) error {
+	if commandContext.ClusterSpec.GitOpsConfig == nil {
+		return nil
+	}
+
+	logger.Info("Generating GitOps manifests")
+	gitOpsWriter, err := w.WithDir(dryRunGitOpsDir)
+	if err != nil {
+		return err
+	}
+	gitOpsWriter.CleanUpTemp()
+
+	return commandContext.AddonManager.WriteGitOpsManifests(ctx, gitOpsWriter, commandContext.ClusterSpec, commandContext.Provider.DatacenterConfig(), commandContext.Provider.MachineConfigs())
+}
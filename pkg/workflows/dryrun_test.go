@@ -0,0 +1,157 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
+)
+
+type dryRunTestSetup struct {
+	t                *testing.T
+	clusterManager   *mocks.MockClusterManager
+	addonManager     *mocks.MockAddonManager
+	provider         *providermocks.MockProvider
+	writer           *writermocks.MockFileWriter
+	dryRunWriter     *writermocks.MockFileWriter
+	validator        *mocks.MockValidator
+	datacenterConfig providers.DatacenterConfig
+	machineConfigs   []providers.MachineConfig
+	workflow         *workflows.DryRun
+	ctx              context.Context
+	clusterSpec      *cluster.Spec
+	workloadCluster  *types.Cluster
+}
+
+func newDryRunTest(t *testing.T) *dryRunTestSetup {
+	mockCtrl := gomock.NewController(t)
+	clusterManager := mocks.NewMockClusterManager(mockCtrl)
+	addonManager := mocks.NewMockAddonManager(mockCtrl)
+	provider := providermocks.NewMockProvider(mockCtrl)
+	writer := writermocks.NewMockFileWriter(mockCtrl)
+
+	return &dryRunTestSetup{
+		t:                t,
+		clusterManager:   clusterManager,
+		addonManager:     addonManager,
+		provider:         provider,
+		writer:           writer,
+		dryRunWriter:     writermocks.NewMockFileWriter(mockCtrl),
+		validator:        mocks.NewMockValidator(mockCtrl),
+		datacenterConfig: &v1alpha1.VSphereDatacenterConfig{},
+		machineConfigs:   []providers.MachineConfig{&v1alpha1.VSphereMachineConfig{}},
+		workflow:         workflows.NewDryRun(provider, clusterManager, addonManager, writer),
+		ctx:              context.Background(),
+		clusterSpec:      test.NewClusterSpec(func(s *cluster.Spec) { s.Name = "cluster-name" }),
+		workloadCluster:  &types.Cluster{Name: "cluster-name", KubeconfigFile: "cluster-name.kubeconfig"},
+	}
+}
+
+func (c *dryRunTestSetup) expectCreateSetup() {
+	gomock.InOrder(
+		c.provider.EXPECT().EnableDryRun(),
+		c.provider.EXPECT().SetupAndValidateCreateCluster(c.ctx, c.clusterSpec),
+	)
+	c.provider.EXPECT().Name()
+	c.addonManager.EXPECT().Validations(c.ctx, c.clusterSpec)
+	c.validator.EXPECT().PreflightValidations(c.ctx)
+}
+
+func (c *dryRunTestSetup) expectUpgradeSetup() {
+	gomock.InOrder(
+		c.provider.EXPECT().EnableDryRun(),
+		c.provider.EXPECT().SetupAndValidateUpgradeCluster(c.ctx, c.workloadCluster, c.clusterSpec),
+	)
+	c.provider.EXPECT().Name()
+	c.validator.EXPECT().PreflightValidations(c.ctx)
+}
+
+func (c *dryRunTestSetup) expectDryRunWriter() {
+	c.writer.EXPECT().WithDir("dry-run").Return(c.dryRunWriter, nil)
+	c.dryRunWriter.EXPECT().CleanUpTemp()
+	c.dryRunWriter.EXPECT().Dir().Return("cluster-name/dry-run")
+}
+
+func (c *dryRunTestSetup) expectWriteGitOpsManifests() {
+	gitOpsWriter := writermocks.NewMockFileWriter(gomock.NewController(c.t))
+	c.dryRunWriter.EXPECT().WithDir("gitops").Return(gitOpsWriter, nil)
+	gitOpsWriter.EXPECT().CleanUpTemp()
+	c.provider.EXPECT().DatacenterConfig().Return(c.datacenterConfig)
+	c.provider.EXPECT().MachineConfigs().Return(c.machineConfigs)
+	c.addonManager.EXPECT().WriteGitOpsManifests(c.ctx, gitOpsWriter, c.clusterSpec, c.datacenterConfig, c.machineConfigs)
+}
+
+func TestDryRunCreate(t *testing.T) {
+	test := newDryRunTest(t)
+	test.expectCreateSetup()
+	test.expectDryRunWriter()
+	test.clusterManager.EXPECT().WriteManifestsForCreate(test.ctx, test.dryRunWriter, test.clusterSpec, test.provider)
+
+	if err := test.workflow.RunCreate(test.ctx, test.clusterSpec, test.validator); err != nil {
+		t.Fatalf("DryRun.RunCreate() err = %v, want err = nil", err)
+	}
+}
+
+func TestDryRunCreateWithGitOps(t *testing.T) {
+	test := newDryRunTest(t)
+	test.clusterSpec.GitOpsConfig = &v1alpha1.GitOpsConfig{}
+	test.expectCreateSetup()
+	test.expectDryRunWriter()
+	test.clusterManager.EXPECT().WriteManifestsForCreate(test.ctx, test.dryRunWriter, test.clusterSpec, test.provider)
+	test.expectWriteGitOpsManifests()
+
+	if err := test.workflow.RunCreate(test.ctx, test.clusterSpec, test.validator); err != nil {
+		t.Fatalf("DryRun.RunCreate() err = %v, want err = nil", err)
+	}
+}
+
+func TestDryRunCreateValidationsError(t *testing.T) {
+	test := newDryRunTest(t)
+	test.provider.EXPECT().EnableDryRun()
+	test.provider.EXPECT().SetupAndValidateCreateCluster(test.ctx, test.clusterSpec).Return(errors.New("invalid setup"))
+	test.provider.EXPECT().Name()
+	test.addonManager.EXPECT().Validations(test.ctx, test.clusterSpec)
+	test.validator.EXPECT().PreflightValidations(test.ctx)
+
+	if err := test.workflow.RunCreate(test.ctx, test.clusterSpec, test.validator); err == nil {
+		t.Fatal("DryRun.RunCreate() err = nil, want err not nil")
+	}
+}
+
+func TestDryRunUpgrade(t *testing.T) {
+	test := newDryRunTest(t)
+	currentSpec := test.clusterSpec.DeepCopy()
+	test.expectUpgradeSetup()
+	test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, test.workloadCluster, test.clusterSpec.Name).Return(currentSpec, nil)
+	test.expectDryRunWriter()
+	test.clusterManager.EXPECT().WriteManifestsForUpgrade(test.ctx, test.dryRunWriter, test.workloadCluster, test.workloadCluster, currentSpec, test.clusterSpec, test.provider)
+
+	if err := test.workflow.RunUpgrade(test.ctx, test.clusterSpec, test.workloadCluster, test.validator); err != nil {
+		t.Fatalf("DryRun.RunUpgrade() err = %v, want err = nil", err)
+	}
+}
+
+func TestDryRunUpgradeWriteManifestsError(t *testing.T) {
+	test := newDryRunTest(t)
+	currentSpec := test.clusterSpec.DeepCopy()
+	test.expectUpgradeSetup()
+	test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, test.workloadCluster, test.clusterSpec.Name).Return(currentSpec, nil)
+	test.writer.EXPECT().WithDir("dry-run").Return(test.dryRunWriter, nil)
+	test.dryRunWriter.EXPECT().CleanUpTemp()
+	test.clusterManager.EXPECT().WriteManifestsForUpgrade(test.ctx, test.dryRunWriter, test.workloadCluster, test.workloadCluster, currentSpec, test.clusterSpec, test.provider).Return(errors.New("error writing"))
+
+	if err := test.workflow.RunUpgrade(test.ctx, test.clusterSpec, test.workloadCluster, test.validator); err == nil {
+		t.Fatal("DryRun.RunUpgrade() err = nil, want err not nil")
+	}
+}
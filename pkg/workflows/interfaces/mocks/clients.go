@@ -10,6 +10,7 @@ import (
 
 	bootstrapper "github.com/aws/eks-anywhere/pkg/bootstrapper"
 	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	filewriter "github.com/aws/eks-anywhere/pkg/filewriter"
 	providers "github.com/aws/eks-anywhere/pkg/providers"
 	types "github.com/aws/eks-anywhere/pkg/types"
 	validations "github.com/aws/eks-anywhere/pkg/validations"
@@ -167,6 +168,20 @@ func (mr *MockClusterManagerMockRecorder) DeleteCluster(arg0, arg1, arg2, arg3,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCluster", reflect.TypeOf((*MockClusterManager)(nil).DeleteCluster), arg0, arg1, arg2, arg3, arg4)
 }
 
+// DeleteEKSAResources mocks base method.
+func (m *MockClusterManager) DeleteEKSAResources(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEKSAResources", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEKSAResources indicates an expected call of DeleteEKSAResources.
+func (mr *MockClusterManagerMockRecorder) DeleteEKSAResources(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEKSAResources", reflect.TypeOf((*MockClusterManager)(nil).DeleteEKSAResources), arg0, arg1, arg2, arg3)
+}
+
 // EKSAClusterSpecChanged mocks base method.
 func (m *MockClusterManager) EKSAClusterSpecChanged(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.DatacenterConfig, arg4 []providers.MachineConfig) (bool, error) {
 	m.ctrl.T.Helper()
@@ -225,6 +240,20 @@ func (mr *MockClusterManagerMockRecorder) InstallCAPI(arg0, arg1, arg2, arg3 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCAPI", reflect.TypeOf((*MockClusterManager)(nil).InstallCAPI), arg0, arg1, arg2, arg3)
 }
 
+// InstallClusterAutoscaler mocks base method.
+func (m *MockClusterManager) InstallClusterAutoscaler(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallClusterAutoscaler", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallClusterAutoscaler indicates an expected call of InstallClusterAutoscaler.
+func (mr *MockClusterManagerMockRecorder) InstallClusterAutoscaler(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallClusterAutoscaler", reflect.TypeOf((*MockClusterManager)(nil).InstallClusterAutoscaler), arg0, arg1, arg2)
+}
+
 // InstallCustomComponents mocks base method.
 func (m *MockClusterManager) InstallCustomComponents(arg0 context.Context, arg1 *cluster.Spec, arg2 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -385,6 +414,34 @@ func (mr *MockClusterManagerMockRecorder) UpgradeCluster(arg0, arg1, arg2, arg3,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeCluster", reflect.TypeOf((*MockClusterManager)(nil).UpgradeCluster), arg0, arg1, arg2, arg3, arg4)
 }
 
+// WriteManifestsForCreate mocks base method.
+func (m *MockClusterManager) WriteManifestsForCreate(arg0 context.Context, arg1 filewriter.FileWriter, arg2 *cluster.Spec, arg3 providers.Provider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteManifestsForCreate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteManifestsForCreate indicates an expected call of WriteManifestsForCreate.
+func (mr *MockClusterManagerMockRecorder) WriteManifestsForCreate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteManifestsForCreate", reflect.TypeOf((*MockClusterManager)(nil).WriteManifestsForCreate), arg0, arg1, arg2, arg3)
+}
+
+// WriteManifestsForUpgrade mocks base method.
+func (m *MockClusterManager) WriteManifestsForUpgrade(arg0 context.Context, arg1 filewriter.FileWriter, arg2, arg3 *types.Cluster, arg4, arg5 *cluster.Spec, arg6 providers.Provider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteManifestsForUpgrade", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteManifestsForUpgrade indicates an expected call of WriteManifestsForUpgrade.
+func (mr *MockClusterManagerMockRecorder) WriteManifestsForUpgrade(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteManifestsForUpgrade", reflect.TypeOf((*MockClusterManager)(nil).WriteManifestsForUpgrade), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // MockAddonManager is a mock of AddonManager interface.
 type MockAddonManager struct {
 	ctrl     *gomock.Controller
@@ -535,6 +592,20 @@ func (mr *MockAddonManagerMockRecorder) Validations(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validations", reflect.TypeOf((*MockAddonManager)(nil).Validations), arg0, arg1)
 }
 
+// WriteGitOpsManifests mocks base method.
+func (m *MockAddonManager) WriteGitOpsManifests(arg0 context.Context, arg1 filewriter.FileWriter, arg2 *cluster.Spec, arg3 providers.DatacenterConfig, arg4 []providers.MachineConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteGitOpsManifests", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteGitOpsManifests indicates an expected call of WriteGitOpsManifests.
+func (mr *MockAddonManagerMockRecorder) WriteGitOpsManifests(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteGitOpsManifests", reflect.TypeOf((*MockAddonManager)(nil).WriteGitOpsManifests), arg0, arg1, arg2, arg3, arg4)
+}
+
 // MockValidator is a mock of Validator interface.
 type MockValidator struct {
 	ctrl     *gomock.Controller
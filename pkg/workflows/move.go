@@ -0,0 +1,272 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+// Move changes the management cluster of a workload cluster
+type Move struct {
+	provider           providers.Provider
+	clusterManager     interfaces.ClusterManager
+	addonManager       interfaces.AddonManager
+	targetAddonManager interfaces.AddonManager
+	eventSinks         []task.EventSink
+}
+
+func NewMove(provider providers.Provider, clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager) *Move {
+	return &Move{
+		provider:       provider,
+		clusterManager: clusterManager,
+		addonManager:   addonManager,
+	}
+}
+
+// WithTaskEventSinks sets the sinks that receive the events of the move tasks
+func (c *Move) WithTaskEventSinks(sinks ...task.EventSink) *Move {
+	c.eventSinks = sinks
+	return c
+}
+
+// WithTargetAddonManager sets the addon manager of the target management cluster, used to commit the config
+// of clusters managed with GitOps to its Git repository
+func (c *Move) WithTargetAddonManager(addonManager interfaces.AddonManager) *Move {
+	c.targetAddonManager = addonManager
+	return c
+}
+
+// Run moves the CAPI and EKS-A objects of the cluster in clusterSpec from the management cluster from to the management cluster to
+func (c *Move) Run(ctx context.Context, clusterSpec *cluster.Spec, from, to *types.Cluster) error {
+	commandContext := &task.CommandContext{
+		Provider:                c.provider,
+		ClusterManager:          c.clusterManager,
+		AddonManager:            c.addonManager,
+		TargetAddonManager:      c.targetAddonManager,
+		ClusterSpec:             clusterSpec,
+		BootstrapCluster:        from,
+		TargetManagementCluster: to,
+	}
+
+	return task.NewTaskRunner(&validateMoveTask{}, task.WithEventSinks(c.eventSinks...)).RunTask(ctx, commandContext)
+}
+
+type validateMoveTask struct{}
+
+type pauseReconcileForMoveTask struct{}
+
+type moveCAPIObjectsTask struct{}
+
+type moveEKSAObjectsTask struct{}
+
+type resumeReconcileInTargetTask struct{}
+
+type updateTargetGitRepoTask struct{}
+
+type cleanupSourceGitRepoTask struct{}
+
+func (s *validateMoveTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Performing setup and validations")
+	if err := s.validate(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	err := commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.BootstrapCluster, commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	return &pauseReconcileForMoveTask{}
+}
+
+func (s *validateMoveTask) validate(ctx context.Context, commandContext *task.CommandContext) error {
+	spec := commandContext.ClusterSpec
+	from := commandContext.BootstrapCluster
+	to := commandContext.TargetManagementCluster
+
+	if spec.IsSelfManaged() {
+		return fmt.Errorf("cluster %s is self-managed, only workload clusters can be moved", spec.Name)
+	}
+	if spec.ManagedBy() != from.Name {
+		return fmt.Errorf("cluster %s is managed by %s, not by %s", spec.Name, spec.ManagedBy(), from.Name)
+	}
+	if from.Name == to.Name {
+		return fmt.Errorf("cluster %s is already managed by %s", spec.Name, to.Name)
+	}
+
+	targetSpec, err := commandContext.ClusterManager.GetCurrentClusterSpec(ctx, to, to.Name)
+	if err != nil {
+		return fmt.Errorf("error getting target management cluster spec: %v", err)
+	}
+	if !targetSpec.IsSelfManaged() {
+		return fmt.Errorf("target cluster %s is not a management cluster", to.Name)
+	}
+	if targetSpec.Spec.DatacenterRef.Kind != spec.Spec.DatacenterRef.Kind {
+		return fmt.Errorf("target management cluster %s uses a %s, cluster %s uses a %s", to.Name, targetSpec.Spec.DatacenterRef.Kind, spec.Name, spec.Spec.DatacenterRef.Kind)
+	}
+
+	// The spec as it is in the source management cluster is kept to pause, delete and clean up the cluster there
+	current := *spec
+	current.Cluster = spec.Cluster.DeepCopy()
+	current.GitOpsConfig = spec.GitOpsConfig.DeepCopy()
+	commandContext.CurrentClusterSpec = &current
+
+	if spec.GitOpsConfig == nil {
+		return nil
+	}
+	if targetSpec.GitOpsConfig == nil || commandContext.TargetAddonManager == nil {
+		return fmt.Errorf("cluster %s is managed with GitOps, target management cluster %s must be managed with GitOps too", spec.Name, to.Name)
+	}
+	if targetSpec.GitOpsConfig.Spec.Equal(&spec.GitOpsConfig.Spec) {
+		return fmt.Errorf("target management cluster %s uses the same GitOps repository and path as %s", to.Name, from.Name)
+	}
+	// Workload clusters must use the same GitOps config as their management cluster
+	spec.GitOpsConfig.Spec = *targetSpec.GitOpsConfig.Spec.DeepCopy()
+
+	return nil
+}
+
+func (s *validateMoveTask) Name() string {
+	return "setup-and-validate"
+}
+
+func (s *pauseReconcileForMoveTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	from := commandContext.BootstrapCluster
+
+	logger.Info("Pausing Flux kustomization")
+	err := commandContext.AddonManager.PauseGitOpsKustomization(ctx, from, commandContext.CurrentClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	logger.Info("Pausing EKS-A cluster controller reconcile")
+	err = commandContext.ClusterManager.PauseEKSAControllerReconcile(ctx, from, commandContext.CurrentClusterSpec, commandContext.Provider)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	return &moveCAPIObjectsTask{}
+}
+
+func (s *pauseReconcileForMoveTask) Name() string {
+	return "pause-controllers-reconcile"
+}
+
+func (s *moveCAPIObjectsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Moving cluster management to target cluster")
+	err := commandContext.ClusterManager.MoveCAPI(ctx, commandContext.BootstrapCluster, commandContext.TargetManagementCluster, commandContext.ClusterSpec.Name, commandContext.ClusterSpec, types.WithNodeRef(), types.WithNodeHealthy())
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	return &moveEKSAObjectsTask{}
+}
+
+func (s *moveCAPIObjectsTask) Name() string {
+	return "capi-management-move"
+}
+
+func (s *moveEKSAObjectsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	from := commandContext.BootstrapCluster
+	to := commandContext.TargetManagementCluster
+
+	logger.Info("Creating EKS-A resources in target cluster")
+	datacenterConfig := commandContext.Provider.DatacenterConfig()
+	machineConfigs := commandContext.Provider.MachineConfigs()
+
+	commandContext.ClusterSpec.SetManagedBy(to.Name)
+	// this disables create-webhook validation, the objects are resumed once the source objects are gone
+	commandContext.ClusterSpec.PauseReconcile()
+	datacenterConfig.PauseReconcile()
+
+	err := commandContext.ClusterManager.CreateEKSAResources(ctx, to, commandContext.ClusterSpec, datacenterConfig, machineConfigs)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	logger.Info("Deleting EKS-A resources from source cluster")
+	// the source cluster object is paused, so the controller releases it without deleting the cluster
+	err = commandContext.ClusterManager.DeleteEKSAResources(ctx, from, commandContext.CurrentClusterSpec, commandContext.Provider)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectMgmtClusterDiagnosticsTask{}
+	}
+
+	return &resumeReconcileInTargetTask{}
+}
+
+func (s *moveEKSAObjectsTask) Name() string {
+	return "eksa-resources-move"
+}
+
+func (s *resumeReconcileInTargetTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Resuming EKS-A controller reconciliation in target cluster")
+	err := commandContext.ClusterManager.ResumeEKSAControllerReconcile(ctx, commandContext.TargetManagementCluster, commandContext.ClusterSpec, commandContext.Provider)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+	commandContext.ClusterSpec.ClearPauseAnnotation()
+	commandContext.Provider.DatacenterConfig().ClearPauseAnnotation()
+
+	return &updateTargetGitRepoTask{}
+}
+
+func (s *resumeReconcileInTargetTask) Name() string {
+	return "resume-eksa-reconcile"
+}
+
+func (s *updateTargetGitRepoTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if commandContext.ClusterSpec.GitOpsConfig == nil {
+		return &cleanupSourceGitRepoTask{}
+	}
+
+	// The config is added to the target repo before it's removed from the source one, so it's never lost
+	logger.Info("Adding cluster to target Git repo")
+	err := commandContext.TargetAddonManager.UpdateGitEksaSpec(ctx, commandContext.ClusterSpec, commandContext.Provider.DatacenterConfig(), commandContext.Provider.MachineConfigs())
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	return &cleanupSourceGitRepoTask{}
+}
+
+func (s *updateTargetGitRepoTask) Name() string {
+	return "update-target-git-repo"
+}
+
+func (s *cleanupSourceGitRepoTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Removing cluster from source Git repo")
+	err := commandContext.AddonManager.CleanupGitRepo(ctx, commandContext.CurrentClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	logger.Info("Resuming Flux kustomization")
+	err = commandContext.AddonManager.ResumeGitOpsKustomization(ctx, commandContext.BootstrapCluster, commandContext.CurrentClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	logger.MarkSuccess("Cluster moved!")
+	return nil
+}
+
+func (s *cleanupSourceGitRepoTask) Name() string {
+	return "clean-up-git-repo"
+}
@@ -0,0 +1,242 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
+)
+
+type moveTestSetup struct {
+	*WithT
+	clusterManager     *mocks.MockClusterManager
+	addonManager       *mocks.MockAddonManager
+	targetAddonManager *mocks.MockAddonManager
+	provider           *providermocks.MockProvider
+	workflow           *workflows.Move
+	ctx                context.Context
+	clusterSpec        *cluster.Spec
+	// currentSpec is the spec of the cluster as it is in the source management cluster
+	currentSpec      *cluster.Spec
+	targetSpec       *cluster.Spec
+	datacenterConfig providers.DatacenterConfig
+	machineConfigs   []providers.MachineConfig
+	from             *types.Cluster
+	to               *types.Cluster
+}
+
+func newMoveTest(t *testing.T) *moveTestSetup {
+	mockCtrl := gomock.NewController(t)
+	clusterManager := mocks.NewMockClusterManager(mockCtrl)
+	addonManager := mocks.NewMockAddonManager(mockCtrl)
+	targetAddonManager := mocks.NewMockAddonManager(mockCtrl)
+	provider := providermocks.NewMockProvider(mockCtrl)
+	workloadSpec := func(s *cluster.Spec) {
+		s.Name = "workload"
+		s.SetManagedBy("mgmt-1")
+		s.Spec.DatacenterRef.Kind = v1alpha1.VSphereDatacenterKind
+	}
+
+	return &moveTestSetup{
+		WithT:              NewWithT(t),
+		clusterManager:     clusterManager,
+		addonManager:       addonManager,
+		targetAddonManager: targetAddonManager,
+		provider:           provider,
+		workflow:           workflows.NewMove(provider, clusterManager, addonManager).WithTargetAddonManager(targetAddonManager),
+		ctx:                context.Background(),
+		clusterSpec:        test.NewClusterSpec(workloadSpec),
+		currentSpec:        test.NewClusterSpec(workloadSpec),
+		targetSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Name = "mgmt-2"
+			s.Spec.DatacenterRef.Kind = v1alpha1.VSphereDatacenterKind
+		}),
+		datacenterConfig: &v1alpha1.VSphereDatacenterConfig{},
+		machineConfigs:   []providers.MachineConfig{&v1alpha1.VSphereMachineConfig{}},
+		from:             &types.Cluster{Name: "mgmt-1", KubeconfigFile: "mgmt-1.kubeconfig"},
+		to:               &types.Cluster{Name: "mgmt-2", KubeconfigFile: "mgmt-2.kubeconfig"},
+	}
+}
+
+func (c *moveTestSetup) expectValidations() {
+	gomock.InOrder(
+		c.clusterManager.EXPECT().GetCurrentClusterSpec(c.ctx, c.to, c.to.Name).Return(c.targetSpec, nil),
+		c.provider.EXPECT().SetupAndValidateUpgradeCluster(c.ctx, c.from, c.clusterSpec),
+	)
+}
+
+func (c *moveTestSetup) expectPauseReconcile() {
+	gomock.InOrder(
+		c.addonManager.EXPECT().PauseGitOpsKustomization(c.ctx, c.from, c.currentSpec),
+		c.clusterManager.EXPECT().PauseEKSAControllerReconcile(c.ctx, c.from, c.currentSpec, c.provider),
+	)
+}
+
+func (c *moveTestSetup) expectMoveCAPI(err error) {
+	c.clusterManager.EXPECT().MoveCAPI(c.ctx, c.from, c.to, c.clusterSpec.Name, c.clusterSpec, gomock.Any(), gomock.Any()).Return(err)
+}
+
+func (c *moveTestSetup) expectMoveEKSAResources() {
+	gomock.InOrder(
+		c.provider.EXPECT().DatacenterConfig().Return(c.datacenterConfig),
+		c.provider.EXPECT().MachineConfigs().Return(c.machineConfigs),
+		c.clusterManager.EXPECT().CreateEKSAResources(c.ctx, c.to, c.clusterSpec, c.datacenterConfig, c.machineConfigs).DoAndReturn(
+			func(_ context.Context, _ *types.Cluster, spec *cluster.Spec, _ providers.DatacenterConfig, _ []providers.MachineConfig) error {
+				c.Expect(spec.ManagedBy()).To(Equal(c.to.Name))
+				c.Expect(spec.IsReconcilePaused()).To(BeTrue())
+				return nil
+			},
+		),
+		c.clusterManager.EXPECT().DeleteEKSAResources(c.ctx, c.from, c.currentSpec, c.provider),
+	)
+}
+
+func (c *moveTestSetup) expectResumeInTarget() {
+	gomock.InOrder(
+		c.clusterManager.EXPECT().ResumeEKSAControllerReconcile(c.ctx, c.to, c.clusterSpec, c.provider),
+		c.provider.EXPECT().DatacenterConfig().Return(c.datacenterConfig),
+	)
+}
+
+func (c *moveTestSetup) expectUpdateTargetGitRepo() {
+	gomock.InOrder(
+		c.provider.EXPECT().DatacenterConfig().Return(c.datacenterConfig),
+		c.provider.EXPECT().MachineConfigs().Return(c.machineConfigs),
+		c.targetAddonManager.EXPECT().UpdateGitEksaSpec(c.ctx, c.clusterSpec, c.datacenterConfig, c.machineConfigs).DoAndReturn(
+			func(_ context.Context, spec *cluster.Spec, _ providers.DatacenterConfig, _ []providers.MachineConfig) error {
+				c.Expect(spec.ManagedBy()).To(Equal(c.to.Name))
+				c.Expect(spec.IsReconcilePaused()).To(BeFalse())
+				c.Expect(spec.GitOpsConfig.Spec).To(Equal(c.targetSpec.GitOpsConfig.Spec))
+				return nil
+			},
+		),
+	)
+}
+
+func (c *moveTestSetup) expectCleanupGit() {
+	gomock.InOrder(
+		c.addonManager.EXPECT().CleanupGitRepo(c.ctx, c.currentSpec),
+		c.addonManager.EXPECT().ResumeGitOpsKustomization(c.ctx, c.from, c.currentSpec),
+	)
+}
+
+func (c *moveTestSetup) withGitOps() {
+	gitOpsConfig := func(path string) *v1alpha1.GitOpsConfig {
+		return &v1alpha1.GitOpsConfig{
+			Spec: v1alpha1.GitOpsConfigSpec{
+				Flux: v1alpha1.Flux{
+					Github: v1alpha1.Github{Owner: "owner", Repository: "repo", ClusterConfigPath: path},
+				},
+			},
+		}
+	}
+	c.clusterSpec.GitOpsConfig = gitOpsConfig("clusters/mgmt-1")
+	c.currentSpec.GitOpsConfig = gitOpsConfig("clusters/mgmt-1")
+	c.targetSpec.GitOpsConfig = gitOpsConfig("clusters/mgmt-2")
+}
+
+func (c *moveTestSetup) run() error {
+	return c.workflow.Run(c.ctx, c.clusterSpec, c.from, c.to)
+}
+
+func TestMoveRunSuccess(t *testing.T) {
+	test := newMoveTest(t)
+	test.expectValidations()
+	test.expectPauseReconcile()
+	test.expectMoveCAPI(nil)
+	test.expectMoveEKSAResources()
+	test.expectResumeInTarget()
+	test.expectCleanupGit()
+
+	test.Expect(test.run()).To(Succeed())
+}
+
+func TestMoveRunGitOpsSuccess(t *testing.T) {
+	test := newMoveTest(t)
+	test.withGitOps()
+	test.expectValidations()
+	test.expectPauseReconcile()
+	test.expectMoveCAPI(nil)
+	test.expectMoveEKSAResources()
+	test.expectResumeInTarget()
+	test.expectUpdateTargetGitRepo()
+	test.expectCleanupGit()
+
+	test.Expect(test.run()).To(Succeed())
+}
+
+func TestMoveRunGitOpsUpdateTargetGitRepoError(t *testing.T) {
+	test := newMoveTest(t)
+	test.withGitOps()
+	test.expectValidations()
+	test.expectPauseReconcile()
+	test.expectMoveCAPI(nil)
+	test.expectMoveEKSAResources()
+	test.expectResumeInTarget()
+	test.provider.EXPECT().DatacenterConfig().Return(test.datacenterConfig)
+	test.provider.EXPECT().MachineConfigs().Return(test.machineConfigs)
+	test.targetAddonManager.EXPECT().UpdateGitEksaSpec(test.ctx, test.clusterSpec, test.datacenterConfig, test.machineConfigs).Return(errors.New("error pushing"))
+
+	test.Expect(test.run()).To(MatchError("error pushing"))
+}
+
+func TestMoveRunGitOpsTargetWithoutGitOps(t *testing.T) {
+	test := newMoveTest(t)
+	test.withGitOps()
+	test.targetSpec.GitOpsConfig = nil
+	test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, test.to, test.to.Name).Return(test.targetSpec, nil)
+
+	test.Expect(test.run()).To(MatchError("cluster workload is managed with GitOps, target management cluster mgmt-2 must be managed with GitOps too"))
+}
+
+func TestMoveRunGitOpsSameRepositoryPath(t *testing.T) {
+	test := newMoveTest(t)
+	test.withGitOps()
+	test.targetSpec.GitOpsConfig = test.clusterSpec.GitOpsConfig.DeepCopy()
+	test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, test.to, test.to.Name).Return(test.targetSpec, nil)
+
+	test.Expect(test.run()).To(MatchError("target management cluster mgmt-2 uses the same GitOps repository and path as mgmt-1"))
+}
+
+func TestMoveRunSelfManagedCluster(t *testing.T) {
+	test := newMoveTest(t)
+	test.clusterSpec.SetSelfManaged()
+
+	test.Expect(test.run()).To(MatchError("cluster workload is self-managed, only workload clusters can be moved"))
+}
+
+func TestMoveRunSameManagementCluster(t *testing.T) {
+	test := newMoveTest(t)
+	test.to = test.from
+
+	test.Expect(test.run()).To(MatchError("cluster workload is already managed by mgmt-1"))
+}
+
+func TestMoveRunTargetNotManagementCluster(t *testing.T) {
+	test := newMoveTest(t)
+	test.targetSpec.SetManagedBy("mgmt-1")
+	test.clusterManager.EXPECT().GetCurrentClusterSpec(test.ctx, test.to, test.to.Name).Return(test.targetSpec, nil)
+
+	test.Expect(test.run()).To(MatchError("target cluster mgmt-2 is not a management cluster"))
+}
+
+func TestMoveRunMoveCAPIError(t *testing.T) {
+	test := newMoveTest(t)
+	test.expectValidations()
+	test.expectPauseReconcile()
+	test.expectMoveCAPI(errors.New("error moving"))
+	test.clusterManager.EXPECT().SaveLogsManagementCluster(test.ctx, test.from)
+
+	test.Expect(test.run()).To(MatchError("error moving"))
+}
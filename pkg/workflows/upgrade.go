@@ -23,6 +23,7 @@ type Upgrade struct {
 	writer            filewriter.FileWriter
 	capiManager       interfaces.CAPIManager
 	upgradeChangeDiff *types.ChangeDiff
+	eventSinks        []task.EventSink
 }
 
 func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
@@ -40,7 +41,13 @@ func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provide
 	}
 }
 
-func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, validator interfaces.Validator, forceCleanup bool) error {
+// WithTaskEventSinks sets the sinks that receive the events of the upgrade tasks
+func (c *Upgrade) WithTaskEventSinks(sinks ...task.EventSink) *Upgrade {
+	c.eventSinks = sinks
+	return c
+}
+
+func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, validator interfaces.Validator, forceCleanup, resume bool) error {
 	if forceCleanup {
 		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
 			Name: clusterSpec.Name,
@@ -66,7 +73,7 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, workloadCl
 		commandContext.BootstrapCluster = clusterSpec.ManagementCluster
 	}
 
-	return task.NewTaskRunner(&setupAndValidateTasks{}).RunTask(ctx, commandContext)
+	return task.NewTaskRunner(&setupAndValidateTasks{}, runnerOpts(resume, c.eventSinks)...).RunTask(ctx, commandContext)
 }
 
 type setupAndValidateTasks struct{}
@@ -156,6 +163,10 @@ func (s *updateSecrets) Name() string {
 	return "update-secrets"
 }
 
+func (s *updateSecrets) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &ensureEtcdCAPIComponentsExistTask{}, nil
+}
+
 func (s *ensureEtcdCAPIComponentsExistTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
@@ -177,6 +188,10 @@ func (s *ensureEtcdCAPIComponentsExistTask) Name() string {
 	return "ensure-etcd-capi-components-exist"
 }
 
+func (s *ensureEtcdCAPIComponentsExistTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &upgradeCoreComponents{}, nil
+}
+
 func (s *upgradeCoreComponents) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
@@ -215,6 +230,10 @@ func (s *upgradeCoreComponents) Name() string {
 	return "upgrade-core-components"
 }
 
+func (s *upgradeCoreComponents) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &upgradeNeeded{}, nil
+}
+
 func (s *upgradeNeeded) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if upgradeNeeded, err := commandContext.Provider.UpgradeNeeded(ctx, commandContext.ClusterSpec, commandContext.CurrentClusterSpec); err != nil {
 		commandContext.SetError(err)
@@ -247,6 +266,10 @@ func (s *upgradeNeeded) Name() string {
 	return "upgrade-needed"
 }
 
+func (s *upgradeNeeded) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &pauseEksaAndFluxReconcile{}, nil
+}
+
 func (s *pauseEksaAndFluxReconcile) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
@@ -270,6 +293,10 @@ func (s *pauseEksaAndFluxReconcile) Name() string {
 	return "pause-controllers-reconcile"
 }
 
+func (s *pauseEksaAndFluxReconcile) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &createBootstrapClusterTask{}, nil
+}
+
 func (s *createBootstrapClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
 		return &upgradeWorkloadClusterTask{}
@@ -295,6 +322,13 @@ func (s *createBootstrapClusterTask) Name() string {
 	return "bootstrap-cluster-init"
 }
 
+func (s *createBootstrapClusterTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	if commandContext.BootstrapCluster != nil && commandContext.BootstrapCluster.ExistingManagement {
+		return &upgradeWorkloadClusterTask{}, nil
+	}
+	return &installCAPITask{}, nil
+}
+
 func (s *installCAPITask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Installing cluster-api providers on bootstrap cluster")
 	err := commandContext.ClusterManager.InstallCAPI(ctx, commandContext.ClusterSpec, commandContext.BootstrapCluster, commandContext.Provider)
@@ -309,6 +343,10 @@ func (s *installCAPITask) Name() string {
 	return "install-capi"
 }
 
+func (s *installCAPITask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &moveManagementToBootstrapTask{}, nil
+}
+
 func (s *moveManagementToBootstrapTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Moving cluster management from workload to bootstrap cluster")
 	err := commandContext.ClusterManager.MoveCAPI(ctx, commandContext.WorkloadCluster, commandContext.BootstrapCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef(), types.WithNodeHealthy())
@@ -323,6 +361,10 @@ func (s *moveManagementToBootstrapTask) Name() string {
 	return "capi-management-move-to-bootstrap"
 }
 
+func (s *moveManagementToBootstrapTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &upgradeWorkloadClusterTask{}, nil
+}
+
 func (s *upgradeWorkloadClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
@@ -350,21 +392,36 @@ func (s *upgradeWorkloadClusterTask) Name() string {
 	return "upgrade-workload-cluster"
 }
 
+func (s *upgradeWorkloadClusterTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &moveManagementToWorkloadTask{}, nil
+}
+
 func (s *moveManagementToWorkloadTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.BootstrapCluster.ExistingManagement {
 		return &updateClusterAndGitResources{}
 	}
-	logger.Info("Moving cluster management from bootstrap to workload cluster")
-	err := commandContext.ClusterManager.MoveCAPI(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef(), types.WithNodeHealthy())
-	if err != nil {
+	if err := s.moveManagement(ctx, commandContext); err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
 	return &updateClusterAndGitResources{}
 }
 
+func (s *moveManagementToWorkloadTask) moveManagement(ctx context.Context, commandContext *task.CommandContext) error {
+	logger.Info("Moving cluster management from bootstrap to workload cluster")
+	return commandContext.ClusterManager.MoveCAPI(ctx, commandContext.BootstrapCluster, commandContext.WorkloadCluster, commandContext.WorkloadCluster.Name, commandContext.ClusterSpec, types.WithNodeRef(), types.WithNodeHealthy())
+}
+
 func (s *moveManagementToWorkloadTaskAndExit) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	_ = s.moveManagementToWorkloadTask.Run(ctx, commandContext)
+	if commandContext.BootstrapCluster.ExistingManagement {
+		return &CollectDiagnosticsTask{}
+	}
+	if err := s.moveManagement(ctx, commandContext); err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+	// cluster management is back in the workload cluster, a resumed upgrade needs to move it to the bootstrap cluster again
+	commandContext.InvalidateCompletedTask((&moveManagementToBootstrapTask{}).Name())
 	return &CollectDiagnosticsTask{}
 }
 
@@ -372,6 +429,10 @@ func (s *moveManagementToWorkloadTask) Name() string {
 	return "capi-management-move-to-workload"
 }
 
+func (s *moveManagementToWorkloadTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &updateClusterAndGitResources{}, nil
+}
+
 func (s *updateClusterAndGitResources) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
@@ -384,6 +445,12 @@ func (s *updateClusterAndGitResources) Run(ctx context.Context, commandContext *
 		return &CollectDiagnosticsTask{}
 	}
 
+	err = commandContext.ClusterManager.InstallClusterAutoscaler(ctx, target, commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+
 	logger.Info("Resuming EKS-A controller reconciliation")
 	err = commandContext.ClusterManager.ResumeEKSAControllerReconcile(ctx, target, commandContext.ClusterSpec, commandContext.Provider)
 	if err != nil {
@@ -404,6 +471,10 @@ func (s *updateClusterAndGitResources) Name() string {
 	return "update-resources"
 }
 
+func (s *updateClusterAndGitResources) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &resumeFluxReconcile{}, nil
+}
+
 func (s *resumeFluxReconcile) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	target := getManagementCluster(commandContext)
 
@@ -427,6 +498,10 @@ func (s *resumeFluxReconcile) Name() string {
 	return "resume-flux-kustomization"
 }
 
+func (s *resumeFluxReconcile) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &writeClusterConfigTask{}, nil
+}
+
 func (s *writeClusterConfigTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Writing cluster config file")
 	err := clustermarshaller.WriteClusterConfig(commandContext.ClusterSpec, commandContext.Provider.DatacenterConfig(), commandContext.Provider.MachineConfigs(), commandContext.Writer)
@@ -440,6 +515,10 @@ func (s *writeClusterConfigTask) Name() string {
 	return "write-cluster-config"
 }
 
+func (s *writeClusterConfigTask) Restore(ctx context.Context, commandContext *task.CommandContext) (task.Task, error) {
+	return &deleteBootstrapClusterTask{}, nil
+}
+
 func (s *deleteBootstrapClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.OriginalError != nil {
 		_ = s.CollectDiagnosticsTask.Run(ctx, commandContext)
@@ -3,6 +3,9 @@ package workflows_test
 import (
 	"context"
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -11,6 +14,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
@@ -35,6 +39,7 @@ type upgradeTestSetup struct {
 	newClusterSpec     *cluster.Spec
 	currentClusterSpec *cluster.Spec
 	forceCleanup       bool
+	resume             bool
 	bootstrapCluster   *types.Cluster
 	workloadCluster    *types.Cluster
 }
@@ -46,6 +51,7 @@ func newUpgradeTest(t *testing.T) *upgradeTestSetup {
 	addonManager := mocks.NewMockAddonManager(mockCtrl)
 	provider := providermocks.NewMockProvider(mockCtrl)
 	writer := writermocks.NewMockFileWriter(mockCtrl)
+	writer.EXPECT().Write("cluster-name-checkpoint.yaml", gomock.Any()).AnyTimes()
 	validator := mocks.NewMockValidator(mockCtrl)
 	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{}
 	capiUpgrader := mocks.NewMockCAPIManager(mockCtrl)
@@ -235,6 +241,7 @@ func (c *upgradeTestSetup) expectCreateEKSAResources(expectedCluster *types.Clus
 		c.clusterManager.EXPECT().CreateEKSAResources(
 			c.ctx, expectedCluster, c.newClusterSpec, c.datacenterConfig, c.machineConfigs,
 		),
+		c.clusterManager.EXPECT().InstallClusterAutoscaler(c.ctx, expectedCluster, c.newClusterSpec),
 	)
 }
 
@@ -280,7 +287,7 @@ func (c *upgradeTestSetup) expectSaveLogs(expectedWorkloadCluster *types.Cluster
 
 func (c *upgradeTestSetup) run() error {
 	// ctx context.Context, workloadCluster *types.Cluster, forceCleanup bool
-	return c.workflow.Run(c.ctx, c.newClusterSpec, c.workloadCluster, c.validator, c.forceCleanup)
+	return c.workflow.Run(c.ctx, c.newClusterSpec, c.workloadCluster, c.validator, c.forceCleanup, c.resume)
 }
 
 func (c *upgradeTestSetup) expectProviderNoUpgradeNeeded() {
@@ -464,3 +471,56 @@ func TestUpgradeWorkloadRunSuccess(t *testing.T) {
 		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
 	}
 }
+
+func (c *upgradeTestSetup) expectCheckpoint(checkpoint string) {
+	dir := c.t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, filewriter.DefaultTmpFolder), os.ModePerm); err != nil {
+		c.t.Fatalf("failed creating checkpoint folder: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, filewriter.DefaultTmpFolder, "cluster-name-checkpoint.yaml"), []byte(checkpoint), os.ModePerm); err != nil {
+		c.t.Fatalf("failed writing checkpoint: %v", err)
+	}
+	c.writer.EXPECT().Dir().Return(dir).AnyTimes()
+}
+
+func TestUpgradeRunResumeAfterMoveManagementToBootstrap(t *testing.T) {
+	test := newUpgradeTest(t)
+	test.resume = true
+	test.expectCheckpoint(`completedTasks:
+- update-secrets
+- ensure-etcd-capi-components-exist
+- upgrade-core-components
+- upgrade-needed
+- pause-controllers-reconcile
+- bootstrap-cluster-init
+- install-capi
+- capi-management-move-to-bootstrap
+bootstrapCluster:
+  Name: bootstrap
+upgradeChangeDiff:
+  componentReports:
+  - componentName: vsphere
+    oldVersion: v0.0.1
+    newVersion: v0.0.2
+`)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectNotToCreateBootstrap()
+	test.expectNotToMoveManagementToBootstrap()
+	test.expectUpgradeWorkload(test.workloadCluster)
+	test.expectMoveManagementToWorkload()
+	test.expectWriteClusterConfig()
+	test.expectDeleteBootstrap()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	test.expectCreateEKSAResources(test.workloadCluster)
+	test.expectResumeEKSAControllerReconcile(test.workloadCluster)
+	test.expectUpdateGitEksaSpec()
+	test.expectForceReconcileGitRepo(test.workloadCluster)
+	test.expectResumeGitOpsKustomization(test.workloadCluster)
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
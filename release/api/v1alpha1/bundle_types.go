@@ -76,6 +76,7 @@ type VersionsBundle struct {
 	ExternalEtcdBootstrap  EtcdadmBootstrapBundle      `json:"etcdadmBootstrap"`
 	ExternalEtcdController EtcdadmControllerBundle     `json:"etcdadmController"`
 	Tinkerbell             TinkerbellBundle            `json:"tinkerbell"`
+	ClusterAutoscaler      ClusterAutoscalerBundle     `json:"clusterAutoscaler,omitempty"`
 }
 
 type EksDRelease struct {
@@ -208,6 +209,11 @@ type FluxBundle struct {
 	NotificationController Image  `json:"notificationController"`
 }
 
+type ClusterAutoscalerBundle struct {
+	Version    string `json:"version,omitempty"`
+	Autoscaler Image  `json:"autoscaler"`
+}
+
 type EksaBundle struct {
 	Version             string   `json:"version,omitempty"`
 	CliTools            Image    `json:"cliTools"`
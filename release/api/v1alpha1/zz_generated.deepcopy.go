@@ -261,6 +261,22 @@ func (in *CiliumBundle) DeepCopy() *CiliumBundle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalerBundle) DeepCopyInto(out *ClusterAutoscalerBundle) {
+	*out = *in
+	in.Autoscaler.DeepCopyInto(&out.Autoscaler)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerBundle.
+func (in *ClusterAutoscalerBundle) DeepCopy() *ClusterAutoscalerBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscalerBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoreClusterAPI) DeepCopyInto(out *CoreClusterAPI) {
 	*out = *in
@@ -675,6 +691,7 @@ func (in *VersionsBundle) DeepCopyInto(out *VersionsBundle) {
 	in.ExternalEtcdBootstrap.DeepCopyInto(&out.ExternalEtcdBootstrap)
 	in.ExternalEtcdController.DeepCopyInto(&out.ExternalEtcdController)
 	in.Tinkerbell.DeepCopyInto(&out.Tinkerbell)
+	in.ClusterAutoscaler.DeepCopyInto(&out.ClusterAutoscaler)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionsBundle.
@@ -136,6 +136,11 @@ func (r *ReleaseConfig) GetVersionsBundles(imageDigests map[string]string) ([]an
 		return nil, errors.Wrapf(err, "Error getting bundle for external Etcdadm controller")
 	}
 
+	clusterAutoscalerBundle, err := r.GetClusterAutoscalerBundle(imageDigests)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting bundle for cluster-autoscaler")
+	}
+
 	bottlerocketAdminBundle, err := r.GetBottlerocketAdminBundle()
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error getting bundle for Bottlerocket admin container")
@@ -207,6 +212,7 @@ func (r *ReleaseConfig) GetVersionsBundles(imageDigests map[string]string) ([]an
 			BottleRocketBootstrap:  bottlerocketBootstrapBundle,
 			BottleRocketAdmin:      bottlerocketAdminBundle,
 			Tinkerbell:             tinkerbellBundle,
+			ClusterAutoscaler:      clusterAutoscalerBundle,
 		}
 		versionsBundles = append(versionsBundles, versionsBundle)
 	}
@@ -254,6 +260,7 @@ func (r *ReleaseConfig) GenerateBundleArtifactsTable() (map[string][]Artifact, e
 		"etcdadm":                      r.GetEtcdadmAssets,
 		"cri-tools":                    r.GetCriToolsAssets,
 		"diagnostic-collector":         r.GetDiagnosticCollectorAssets,
+		"cluster-autoscaler":           r.GetClusterAutoscalerAssets,
 	}
 
 	if r.DevRelease && r.BuildRepoBranchName == "main" {
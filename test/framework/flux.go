@@ -584,15 +584,15 @@ func (e *ClusterE2ETest) writeEKSASpec(c *v1alpha1.Cluster, datacenterConfig pro
 }
 
 func (e *ClusterE2ETest) gitRepoName() string {
-	return e.GitOpsConfig.Spec.Flux.Github.Repository
+	return e.GitOpsConfig.Spec.Flux.Repository()
 }
 
 func (e *ClusterE2ETest) gitBranch() string {
-	return e.GitOpsConfig.Spec.Flux.Github.Branch
+	return e.GitOpsConfig.Spec.Flux.Branch()
 }
 
 func (e *ClusterE2ETest) clusterConfGitPath() string {
-	p := e.GitOpsConfig.Spec.Flux.Github.ClusterConfigPath
+	p := e.GitOpsConfig.Spec.Flux.ClusterConfigPath()
 	if len(p) == 0 {
 		p = path.Join("clusters", e.ClusterName)
 	}
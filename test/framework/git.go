@@ -26,8 +26,8 @@ func (e *ClusterE2ETest) NewGitOptions(ctx context.Context, cluster *v1alpha1.Cl
 	var localGitRepoPath string
 	var localGitWriterPath string
 	if repoPath == "" {
-		localGitRepoPath = filepath.Join(cluster.Name, "git", gitOpsConfig.Spec.Flux.Github.Repository)
-		localGitWriterPath = filepath.Join("git", gitOpsConfig.Spec.Flux.Github.Repository)
+		localGitRepoPath = filepath.Join(cluster.Name, "git", gitOpsConfig.Spec.Flux.Repository())
+		localGitWriterPath = filepath.Join("git", gitOpsConfig.Spec.Flux.Repository())
 	} else {
 		localGitRepoPath = repoPath
 		localGitWriterPath = repoPath
@@ -38,7 +38,7 @@ func (e *ClusterE2ETest) NewGitOptions(ctx context.Context, cluster *v1alpha1.Cl
 	}
 	goGit := gogit.New(gogitOptions)
 
-	gitProviderFactoryOptions := gitFactory.Options{GithubGitClient: goGit}
+	gitProviderFactoryOptions := gitFactory.Options{GithubGitClient: goGit, GenericGitClient: goGit, GitlabGitClient: goGit}
 	gitProviderFactory := gitFactory.New(gitProviderFactoryOptions)
 	gitProvider, err := gitProviderFactory.BuildProvider(ctx, &gitOpsConfig.Spec)
 	if err != nil {